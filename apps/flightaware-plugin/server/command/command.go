@@ -1,6 +1,7 @@
 package command
 
 import (
+	"errors"
 	"fmt"
 	"slices"
 	"strconv"
@@ -34,18 +35,44 @@ type MessageServiceInterface interface {
 
 type DeparturesArgs struct {
 	Airport string
+	Airline string
+	Window  time.Duration
+}
+
+type ArrivalsArgs struct {
+	Airport string
+	Airline string
+	Window  time.Duration
 }
 
 type SubscribeArgs struct {
 	Airport         string
 	FrequencyStr    string
 	UpdateFrequency int64
+	Arrivals        bool
+	Airline         string
+	AircraftTypes   []string
+	Window          time.Duration
+	Lookahead       time.Duration
 }
 
 type UnsubscribeArgs struct {
 	SubscriptionID string
 }
 
+type ResetArgs struct {
+	SubscriptionID string
+}
+
+// Subscription update frequency limits, in seconds
+const (
+	MinUpdateFrequency     = 300
+	DefaultUpdateFrequency = 3600
+)
+
+// MaxLookbackWindow is the longest --window or --lookahead accepted
+const MaxLookbackWindow = 7 * 24 * time.Hour
+
 
 // TableFormatter helps build markdown tables for subscription listings
 type TableFormatter struct {
@@ -111,7 +138,7 @@ func NewCommandHandler(client *pluginapi.Client, flightService flight.FlightInte
 		Description:      "FlightAware Commands",
 		DisplayName:      "FlightAware",
 		AutoComplete:     true,
-		AutoCompleteDesc: "Get flight departures and manage subscriptions",
+		AutoCompleteDesc: "Get flight departures and arrivals and manage subscriptions",
 		AutoCompleteHint: "[command]",
 		AutocompleteData: &model.AutocompleteData{
 			Trigger:  "flights",
@@ -129,6 +156,42 @@ func NewCommandHandler(client *pluginapi.Client, flightService flight.FlightInte
 										Item:     "--airport",
 										HelpText: "Specify airport code (e.g., SFO, LAX, JFK, RDU)",
 									},
+									{
+										Item:     "--airline",
+										HelpText: "(optional) Only show airlines with these codes (e.g., UA or UA,DL)",
+									},
+									{
+										Item:     "--window",
+										HelpText: "(optional) How far back to look, e.g. 2h or 24h (default 6h, max 168h)",
+									},
+								},
+							},
+							Name:     "airport",
+							HelpText: "Airport code (e.g., SFO, LAX, JFK, RDU)",
+							Required: false,
+						},
+					},
+				},
+				{
+					Trigger:  "arrivals",
+					HelpText: "Get arrivals at an airport",
+					Arguments: []*model.AutocompleteArg{
+						{
+							Type: model.AutocompleteArgTypeStaticList,
+							Data: &model.AutocompleteStaticListArg{
+								PossibleArguments: []model.AutocompleteListItem{
+									{
+										Item:     "--airport",
+										HelpText: "Specify airport code (e.g., SFO, LAX, JFK, RDU)",
+									},
+									{
+										Item:     "--airline",
+										HelpText: "(optional) Only show airlines with these codes (e.g., UA or UA,DL)",
+									},
+									{
+										Item:     "--window",
+										HelpText: "(optional) How far back to look, e.g. 2h or 24h (default 6h, max 168h)",
+									},
 								},
 							},
 							Name:     "airport",
@@ -139,7 +202,7 @@ func NewCommandHandler(client *pluginapi.Client, flightService flight.FlightInte
 				},
 				{
 					Trigger:  "subscribe",
-					HelpText: "Subscribe to airport departure updates",
+					HelpText: "Subscribe to airport departure or arrival updates",
 					Arguments: []*model.AutocompleteArg{
 						{
 							Type: model.AutocompleteArgTypeStaticList,
@@ -153,6 +216,26 @@ func NewCommandHandler(client *pluginapi.Client, flightService flight.FlightInte
 										Item:     "--frequency",
 										HelpText: "Update frequency in seconds (minimum 300)",
 									},
+									{
+										Item:     "--arrivals",
+										HelpText: "(optional) Subscribe to arrivals instead of departures",
+									},
+									{
+										Item:     "--airline",
+										HelpText: "(optional) Only include airlines with these codes (e.g., UA or UA,DL)",
+									},
+									{
+										Item:     "--aircraft-type",
+										HelpText: "(optional) Only include these aircraft types (e.g., B737 or B737,A320)",
+									},
+									{
+										Item:     "--window",
+										HelpText: "(optional) How far back each update looks, e.g. 2h or 24h (default 12h, max 168h)",
+									},
+									{
+										Item:     "--lookahead",
+										HelpText: "(optional) How far ahead each update reports scheduled flights, e.g. 2h or 0 (default 12h, max 168h)",
+									},
 								},
 							},
 							Name:     "airport",
@@ -163,7 +246,21 @@ func NewCommandHandler(client *pluginapi.Client, flightService flight.FlightInte
 				},
 				{
 					Trigger:  "unsubscribe",
-					HelpText: "Unsubscribe from departure updates",
+					HelpText: "Unsubscribe from flight updates",
+					Arguments: []*model.AutocompleteArg{
+						{
+							Type: model.AutocompleteArgTypeText,
+							Data: &model.AutocompleteTextArg{
+								Hint:    "[subscription id]",
+								Pattern: "^[a-zA-Z0-9_-]+$",
+							},
+							Required: true,
+						},
+					},
+				},
+				{
+					Trigger:  "reset",
+					HelpText: "Post every flight again on a subscription's next update",
 					Arguments: []*model.AutocompleteArg{
 						{
 							Type: model.AutocompleteArgTypeText,
@@ -193,6 +290,10 @@ func NewCommandHandler(client *pluginapi.Client, flightService flight.FlightInte
 						},
 					},
 				},
+				{
+					Trigger:  "limits",
+					HelpText: "Show subscription limits and usage",
+				},
 				{
 					Trigger:  "help",
 					HelpText: "Show help information",
@@ -223,12 +324,18 @@ func (ch *CommandHandler) Handle(args *model.CommandArgs) (*model.CommandRespons
 	switch subcommand {
 	case "departures":
 		return ch.handleDeparturesCommand(args, cmdArgs)
+	case "arrivals":
+		return ch.handleArrivalsCommand(args, cmdArgs)
 	case "subscribe":
 		return ch.handleSubscribeCommand(args, cmdArgs)
 	case "unsubscribe":
 		return ch.handleUnsubscribeCommand(args, cmdArgs)
+	case "reset", "--reset":
+		return ch.handleResetCommand(args, cmdArgs)
 	case "list":
 		return ch.handleListCommand(args, cmdArgs)
+	case "limits", "--limits":
+		return ch.handleLimitsCommand(args)
 	case "help", "--help":
 		return ch.sendHelpResponse(args)
 	default:
@@ -269,12 +376,13 @@ func (ch *CommandHandler) handleDeparturesCommand(args *model.CommandArgs, cmdAr
 		return ch.sendErrorResponse(fmt.Sprintf("Invalid command: %v. Use `/flights help` for usage.", err)), nil
 	}
 
-	flights, err := ch.flightService.GetDepartureFlights(parsedArgs.Airport)
+	flights, err := ch.flightService.GetDepartureFlights(parsedArgs.Airport, flight.TimeWindow{Lookback: parsedArgs.Window})
 	if err != nil {
 		ch.client.Log.Error("Failed to fetch departure flights", "airport", parsedArgs.Airport, "error", err)
 		return ch.sendErrorResponse(fmt.Sprintf("Unable to retrieve flight departures for %s. Please try again later.", parsedArgs.Airport)), nil
 	}
 
+	flights.Flights = flight.FilterByAirline(flights.Flights, parsedArgs.Airline)
 	response := ch.flightService.FormatFlightResponse(flights, parsedArgs.Airport)
 
 	post := &model.Post{
@@ -285,6 +393,30 @@ func (ch *CommandHandler) handleDeparturesCommand(args *model.CommandArgs, cmdAr
 	return ch.messageService.SendPublicResponse(args, post)
 }
 
+func (ch *CommandHandler) handleArrivalsCommand(args *model.CommandArgs, cmdArgs []string) (*model.CommandResponse, error) {
+	commandFields := ch.buildCommandFields("arrivals", cmdArgs)
+	parsedArgs, err := ch.parser.ParseArrivalsCommand(commandFields)
+	if err != nil {
+		return ch.sendErrorResponse(fmt.Sprintf("Invalid command: %v. Use `/flights help` for usage.", err)), nil
+	}
+
+	flights, err := ch.flightService.GetArrivalFlights(parsedArgs.Airport, flight.TimeWindow{Lookback: parsedArgs.Window})
+	if err != nil {
+		ch.client.Log.Error("Failed to fetch arrival flights", "airport", parsedArgs.Airport, "error", err)
+		return ch.sendErrorResponse(fmt.Sprintf("Unable to retrieve flight arrivals for %s. Please try again later.", parsedArgs.Airport)), nil
+	}
+
+	flights.Flights = flight.FilterByAirline(flights.Flights, parsedArgs.Airline)
+	response := ch.flightService.FormatArrivalResponse(flights, parsedArgs.Airport)
+
+	post := &model.Post{
+		ChannelId: args.ChannelId,
+		Message:   response,
+	}
+
+	return ch.messageService.SendPublicResponse(args, post)
+}
+
 func (ch *CommandHandler) handleSubscribeCommand(args *model.CommandArgs, cmdArgs []string) (*model.CommandResponse, error) {
 	commandFields := ch.buildCommandFields("subscribe", cmdArgs)
 	parsedArgs, err := ch.parser.ParseSubscribeCommand(commandFields)
@@ -292,21 +424,47 @@ func (ch *CommandHandler) handleSubscribeCommand(args *model.CommandArgs, cmdArg
 		return ch.sendErrorResponse(fmt.Sprintf("Invalid command: %v. Use `/flights help` for usage.", err)), nil
 	}
 
+	mode := subscription.ModeDepartures
+	if parsedArgs.Arrivals {
+		mode = subscription.ModeArrivals
+	}
+
+	// New subscriptions save their lookback so they keep it if the default changes
+	window := parsedArgs.Window
+	if window == 0 {
+		window = flight.DefaultSubscriptionLookbackWindow
+	}
+
 	sub := &subscription.FlightSubscription{
-		ID:              fmt.Sprintf("%s-%s-%d", parsedArgs.Airport, args.ChannelId, time.Now().Unix()),
-		Airport:         parsedArgs.Airport,
-		ChannelID:       args.ChannelId,
-		UserID:          args.UserId,
-		UpdateFrequency: parsedArgs.UpdateFrequency,
-		LastUpdated:     time.Now(),
+		ID:                 fmt.Sprintf("%s-%s-%d", parsedArgs.Airport, args.ChannelId, time.Now().Unix()),
+		Airport:            parsedArgs.Airport,
+		ChannelID:          args.ChannelId,
+		UserID:             args.UserId,
+		UpdateFrequency:    parsedArgs.UpdateFrequency,
+		LastUpdated:        time.Now(),
+		Mode:               mode,
+		Airline:            parsedArgs.Airline,
+		AircraftTypeFilter: parsedArgs.AircraftTypes,
+		LookbackWindow:     int64(window / time.Second),
+		LookaheadWindow:    int64(parsedArgs.Lookahead / time.Second),
 	}
 
 	if err := ch.subscriptionMgr.AddSubscription(sub); err != nil {
+		var locationErr *subscription.LocationLimitError
+		if errors.As(err, &locationErr) {
+			return ch.sendErrorResponse(fmt.Sprintf("Unable to subscribe to %s: %v. "+
+				"Remove a subscription with `/flights unsubscribe`. See `/flights limits` for current usage.", parsedArgs.Airport, err)), nil
+		}
+		var limitErr *subscription.LimitExceededError
+		if errors.As(err, &limitErr) {
+			return ch.sendErrorResponse(fmt.Sprintf("Unable to subscribe to %s: %v. "+
+				"Use a lower frequency or remove a subscription with `/flights unsubscribe`. See `/flights limits` for current usage.", parsedArgs.Airport, err)), nil
+		}
 		ch.client.Log.Error("Failed to create subscription", "airport", parsedArgs.Airport, "frequency", parsedArgs.UpdateFrequency, "channel_id", args.ChannelId, "error", err)
 		return ch.sendErrorResponse(fmt.Sprintf("Unable to create subscription for %s. Please try again later.", parsedArgs.Airport)), nil
 	}
 
-	message := fmt.Sprintf("✅ Subscribed to departures from **%s**. Updates will be sent every %d seconds (ID: `%s`).", parsedArgs.Airport, parsedArgs.UpdateFrequency, sub.ID)
+	message := fmt.Sprintf("✅ Subscribed to %s **%s** for %s%s. Updates covering %s will be sent every %d seconds (ID: `%s`).", describeMode(mode), parsedArgs.Airport, describeAirline(sub.Airline), describeAircraftTypes(sub.AircraftTypeFilter), describeTimeWindow(sub.TimeWindow()), parsedArgs.UpdateFrequency, sub.ID)
 	post := &model.Post{
 		ChannelId: args.ChannelId,
 		Message:   message,
@@ -328,11 +486,14 @@ func (ch *CommandHandler) handleUnsubscribeCommand(args *model.CommandArgs, cmdA
 			return ch.sendErrorResponse("No active subscriptions found in this channel."), nil
 		}
 
-		table := NewTableFormatter("**Active Subscriptions in this Channel:**", "ID", "Airport", "Frequency", "Last Updated")
+		table := NewTableFormatter("**Active Subscriptions in this Channel:**", "ID", "Airport", "Type", "Airline", "Window", "Frequency", "Last Updated")
 		for _, sub := range subs {
 			table.AddRow(
 				fmt.Sprintf("`%s`", sub.ID),
 				sub.Airport,
+				sub.FlightMode(),
+				describeAirline(sub.Airline),
+				formatTimeWindow(sub.TimeWindow()),
 				fmt.Sprintf("%d seconds", sub.UpdateFrequency),
 				sub.LastUpdated.Format(time.RFC1123),
 			)
@@ -352,7 +513,7 @@ func (ch *CommandHandler) handleUnsubscribeCommand(args *model.CommandArgs, cmdA
 	}
 
 	if ch.subscriptionMgr.RemoveSubscription(parsedArgs.SubscriptionID) {
-		message := fmt.Sprintf("✅ Unsubscribed from departures from **%s**.", sub.Airport)
+		message := fmt.Sprintf("✅ Unsubscribed from %s **%s**.", describeMode(sub.FlightMode()), sub.Airport)
 		post := &model.Post{
 			ChannelId: args.ChannelId,
 			Message:   message,
@@ -363,6 +524,30 @@ func (ch *CommandHandler) handleUnsubscribeCommand(args *model.CommandArgs, cmdA
 	}
 }
 
+func (ch *CommandHandler) handleResetCommand(args *model.CommandArgs, cmdArgs []string) (*model.CommandResponse, error) {
+	commandFields := ch.buildCommandFields("reset", cmdArgs)
+	parsedArgs, err := ch.parser.ParseResetCommand(commandFields)
+	if err != nil {
+		return ch.sendErrorResponse(fmt.Sprintf("Invalid command: %v. Use `/flights help` for usage.", err)), nil
+	}
+
+	sub, exists := ch.subscriptionMgr.GetSubscription(parsedArgs.SubscriptionID)
+	if !exists {
+		return ch.sendErrorResponse(fmt.Sprintf("Subscription with ID `%s` not found.", parsedArgs.SubscriptionID)), nil
+	}
+
+	if sub.ChannelID != args.ChannelId {
+		return ch.sendErrorResponse("This subscription does not belong to this channel."), nil
+	}
+
+	if !ch.subscriptionMgr.ResetReportedFlights(parsedArgs.SubscriptionID) {
+		return ch.sendErrorResponse("Failed to reset subscription. Please try again."), nil
+	}
+
+	message := fmt.Sprintf("✅ The next update for %s **%s** will include every flight, not just new ones.", describeMode(sub.FlightMode()), sub.Airport)
+	return ch.messageService.SendEphemeralResponse(args, message)
+}
+
 func (ch *CommandHandler) handleListCommand(args *model.CommandArgs, cmdArgs []string) (*model.CommandResponse, error) {
 	// Check if --all flag is provided
 	showAll := slices.Contains(cmdArgs, "--all")
@@ -388,7 +573,7 @@ func (ch *CommandHandler) handleListCommand(args *model.CommandArgs, cmdArgs []s
 
 	var table *TableFormatter
 	if showAll {
-		table = NewTableFormatter(title, "ID", "Airport", "Channel", "Frequency", "Last Updated")
+		table = NewTableFormatter(title, "ID", "Airport", "Type", "Airline", "Window", "Channel", "Frequency", "Last Updated")
 		for _, sub := range subs {
 			channelName := "Unknown Channel"
 			channelData, err := ch.client.Channel.Get(sub.ChannelID)
@@ -400,17 +585,23 @@ func (ch *CommandHandler) handleListCommand(args *model.CommandArgs, cmdArgs []s
 			table.AddRow(
 				fmt.Sprintf("`%s`", sub.ID),
 				sub.Airport,
+				sub.FlightMode(),
+				describeAirline(sub.Airline),
+				formatTimeWindow(sub.TimeWindow()),
 				fmt.Sprintf("~%s", channelName),
 				fmt.Sprintf("%d seconds", sub.UpdateFrequency),
 				sub.LastUpdated.Format(time.RFC1123),
 			)
 		}
 	} else {
-		table = NewTableFormatter(title, "ID", "Airport", "Frequency", "Last Updated")
+		table = NewTableFormatter(title, "ID", "Airport", "Type", "Airline", "Window", "Frequency", "Last Updated")
 		for _, sub := range subs {
 			table.AddRow(
 				fmt.Sprintf("`%s`", sub.ID),
 				sub.Airport,
+				sub.FlightMode(),
+				describeAirline(sub.Airline),
+				formatTimeWindow(sub.TimeWindow()),
 				fmt.Sprintf("%d seconds", sub.UpdateFrequency),
 				sub.LastUpdated.Format(time.RFC1123),
 			)
@@ -424,6 +615,68 @@ func (ch *CommandHandler) handleListCommand(args *model.CommandArgs, cmdArgs []s
 	return ch.messageService.SendPublicResponse(args, post)
 }
 
+func (ch *CommandHandler) handleLimitsCommand(args *model.CommandArgs) (*model.CommandResponse, error) {
+	channelSubs := ch.subscriptionMgr.GetSubscriptionsForChannel(args.ChannelId)
+	usage := ch.subscriptionMgr.GetAPIUsage()
+
+	message := "**Flight Subscription Limits**\n\n" +
+		fmt.Sprintf("- Minimum update frequency: %d seconds (%d minutes)\n", MinUpdateFrequency, MinUpdateFrequency/60) +
+		fmt.Sprintf("- Default update frequency: %d seconds (%d minutes)\n", DefaultUpdateFrequency, DefaultUpdateFrequency/60) +
+		fmt.Sprintf("- Flights shown per update: up to %d\n", flight.MaxFlightsPerResponse) +
+		fmt.Sprintf("- Lookback window: %s by default for queries and %s for new subscriptions, up to %s\n", flight.DefaultLookbackWindow, flight.DefaultSubscriptionLookbackWindow, MaxLookbackWindow) +
+		fmt.Sprintf("- Subscription lookahead: %s by default, up to %s\n\n", flight.DefaultLookaheadWindow, MaxLookbackWindow) +
+		"**Usage**\n\n" +
+		fmt.Sprintf("- Subscriptions in this channel: %d\n", len(channelSubs)) +
+		fmt.Sprintf("- Subscriptions on this server: %d\n", usage.Subscriptions) +
+		fmt.Sprintf("- Projected API calls per hour: %s of %d\n", subscription.FormatCalls(usage.HourlyCalls), usage.HourlyLimit) +
+		fmt.Sprintf("- Projected API calls per day: %s of %d\n", subscription.FormatCalls(usage.DailyCalls), usage.DailyLimit) +
+		fmt.Sprintf("- Subscriptions per airport: up to %d\n\n", subscription.DefaultMaxSubscriptionsPerLocation) +
+		"New subscriptions that would exceed any limit are rejected. " +
+		"Use `/flights unsubscribe` to see this channel's subscriptions and remove one."
+
+	return ch.messageService.SendEphemeralResponse(args, message)
+}
+
+// describeMode returns the phrase used in confirmation messages for a subscription mode
+func describeMode(mode string) string {
+	if mode == subscription.ModeArrivals {
+		return "arrivals at"
+	}
+	return "departures from"
+}
+
+// describeTimeWindow returns the span of flights a subscription reports, as used in confirmation messages
+func describeTimeWindow(window flight.TimeWindow) string {
+	if window.Lookahead <= 0 {
+		return fmt.Sprintf("the last %s", window.Lookback)
+	}
+	return fmt.Sprintf("the last %s and the next %s", window.Lookback, window.Lookahead)
+}
+
+// formatTimeWindow returns the window shown in subscription tables, e.g. "6h0m0s" or "6h0m0s / +12h0m0s"
+func formatTimeWindow(window flight.TimeWindow) string {
+	if window.Lookahead <= 0 {
+		return window.Lookback.String()
+	}
+	return fmt.Sprintf("%s / +%s", window.Lookback, window.Lookahead)
+}
+
+// describeAirline returns the airline filter shown in listings, where an empty filter means all airlines
+func describeAirline(airline string) string {
+	if airline == "" {
+		return "all airlines"
+	}
+	return airline
+}
+
+// describeAircraftTypes returns the aircraft type filter as used in confirmation messages, or "" for all types
+func describeAircraftTypes(aircraftTypes []string) string {
+	if len(aircraftTypes) == 0 {
+		return ""
+	}
+	return fmt.Sprintf(" on %s aircraft", strings.Join(aircraftTypes, ", "))
+}
+
 func (ch *CommandHandler) sendErrorResponse(message string) *model.CommandResponse {
 	return &model.CommandResponse{
 		Text:         message,
@@ -434,19 +687,34 @@ func (ch *CommandHandler) sendErrorResponse(message string) *model.CommandRespon
 func (ch *CommandHandler) sendHelpResponse(args *model.CommandArgs) (*model.CommandResponse, error) {
 	helpText := "**Flight Departures Bot Commands**\n\n" +
 		"**One-time Queries:**\n" +
-		"- `/flights departures --airport [code]` - Get recent departures from an airport\n\n" +
+		"- `/flights departures --airport [code]` - Get recent departures from an airport\n" +
+		"- `/flights arrivals --airport [code]` - Get recent arrivals at an airport\n" +
+		"- Add `--airline [codes]` to either query to only show those airlines (e.g., `--airline UA,DL`)\n" +
+		"- Add `--window [duration]` to either query to change how far back to look (e.g., `--window 2h`, default 6h, max 168h)\n\n" +
 		"**Subscription Commands:**\n" +
 		"- `/flights subscribe --airport [code] --frequency [seconds]` - Subscribe to airport departures\n" +
-		"- `/flights unsubscribe --id [subscription_id]` - Unsubscribe from airport departures\n" +
+		"- `/flights subscribe --airport [code] --frequency [seconds] --arrivals` - Subscribe to airport arrivals\n" +
+		"- `/flights subscribe --airport [code] --frequency [seconds] --airline [codes]` - Subscribe to specific airlines only\n" +
+		"- `/flights subscribe --airport [code] --frequency [seconds] --aircraft-type [types]` - Subscribe to specific aircraft types only (e.g., `--aircraft-type B737,A320`)\n" +
+		"- `/flights subscribe --airport [code] --frequency [seconds] --window [duration]` - Change how far back each update looks (default 12h, `--lookback` also works)\n" +
+		"- `/flights subscribe --airport [code] --frequency [seconds] --lookahead [duration]` - Change how far ahead each update reports scheduled flights (default 12h, `0` for none)\n" +
+		"- `/flights unsubscribe --id [subscription_id]` - Unsubscribe from airport updates\n" +
+		"- `/flights reset --id [subscription_id]` - Post every flight again on the next update, not just new ones\n" +
 		"- `/flights list` - List all subscriptions in this channel\n" +
 		"- `/flights list --all` - List all subscriptions on the server\n" +
+		"- `/flights limits` - Show subscription limits and projected API usage\n" +
 		"- `/flights help` - Show this help message\n\n" +
 		"**Examples:**\n" +
 		"- `/flights departures --airport SFO` - Get departures from San Francisco International\n" +
 		"- `/flights departures --airport RDU` - Get departures from Raleigh-Durham International\n" +
-		"- `/flights subscribe --airport EGLL --frequency 3600` - Subscribe to hourly updates for London Heathrow\n\n" +
-		"**Note:** 3-letter airport codes (like SFO, LAX, JFK, RDU) are automatically converted to 4-letter ICAO codes (KSFO, KLAX, KJFK, KRDU).\n" +
-		"Information includes flight callsign, airline, departure time, destination, and flight duration when available."
+		"- `/flights arrivals --airport JFK` - Get arrivals at John F. Kennedy International\n" +
+		"- `/flights subscribe --airport EGLL --frequency 3600` - Subscribe to hourly updates for London Heathrow\n" +
+		"- `/flights subscribe LAX 1800 --arrivals` - Subscribe to arrivals at Los Angeles International every 30 minutes\n" +
+		"- `/flights subscribe SFO 3600 --airline UA,AA` - Subscribe to United and American departures from San Francisco\n\n" +
+		"**Note:** Subscriptions only post flights they have not posted before, and post a notice when a flight they track is delayed or cancelled. " +
+		"Airline codes match the start of the flight callsign. Without `--airline`, all airlines are included.\n" +
+		"3-letter airport codes (like SFO, LAX, JFK, RDU) are automatically converted to 4-letter ICAO codes (KSFO, KLAX, KJFK, KRDU).\n" +
+		"Information includes flight callsign, airline, departure or arrival time, destination or origin, status, and flight duration when available."
 
 	return ch.messageService.SendEphemeralResponse(args, helpText)
 }
@@ -459,7 +727,16 @@ func (cp *CommandParser) ParseDeparturesCommand(commandFields []string) (*Depart
 		return nil, fmt.Errorf("insufficient arguments")
 	}
 
-	args := &DeparturesArgs{}
+	commandFields, airline, err := extractAirlineFlag(commandFields)
+	if err != nil {
+		return nil, err
+	}
+	commandFields, window, err := extractWindowFlag(commandFields)
+	if err != nil {
+		return nil, err
+	}
+
+	args := &DeparturesArgs{Airline: airline, Window: window}
 
 	// Check if using flag syntax or simple syntax
 	if len(commandFields) >= 3 && !strings.HasPrefix(commandFields[2], "--") {
@@ -480,13 +757,52 @@ func (cp *CommandParser) ParseDeparturesCommand(commandFields []string) (*Depart
 	return args, nil
 }
 
-func (cp *CommandParser) ParseSubscribeCommand(commandFields []string) (*SubscribeArgs, error) {
-	if len(commandFields) < 3 {
-		return nil, fmt.Errorf("insufficient arguments")
+// ParseArrivalsCommand parses /flights arrivals, which takes the same arguments as departures
+func (cp *CommandParser) ParseArrivalsCommand(commandFields []string) (*ArrivalsArgs, error) {
+	departuresArgs, err := cp.ParseDeparturesCommand(commandFields)
+	if err != nil {
+		return nil, err
 	}
 
+	return &ArrivalsArgs{Airport: departuresArgs.Airport, Airline: departuresArgs.Airline, Window: departuresArgs.Window}, nil
+}
+
+func (cp *CommandParser) ParseSubscribeCommand(commandFields []string) (*SubscribeArgs, error) {
 	args := &SubscribeArgs{
-		FrequencyStr: "3600", // Default to 1 hour
+		FrequencyStr: strconv.Itoa(DefaultUpdateFrequency),
+	}
+
+	// --arrivals takes no value, so remove it before parsing positional and value flags
+	if slices.Contains(commandFields, "--arrivals") {
+		args.Arrivals = true
+		commandFields = slices.DeleteFunc(slices.Clone(commandFields), func(field string) bool {
+			return field == "--arrivals"
+		})
+	}
+
+	commandFields, airline, err := extractAirlineFlag(commandFields)
+	if err != nil {
+		return nil, err
+	}
+	args.Airline = airline
+
+	commandFields, args.AircraftTypes, err = extractAircraftTypeFlag(commandFields)
+	if err != nil {
+		return nil, err
+	}
+
+	commandFields, args.Window, err = extractWindowFlag(commandFields)
+	if err != nil {
+		return nil, err
+	}
+
+	commandFields, args.Lookahead, err = extractLookaheadFlag(commandFields)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(commandFields) < 3 {
+		return nil, fmt.Errorf("insufficient arguments")
 	}
 
 	// Check if using flag syntax or simple syntax
@@ -509,19 +825,33 @@ func (cp *CommandParser) ParseSubscribeCommand(commandFields []string) (*Subscri
 	}
 
 	// Parse frequency
-	err := cp.parseFrequency(args)
+	err = cp.parseFrequency(args)
 	if err != nil {
 		return nil, err
 	}
 
-	// Validate minimum frequency (5 minutes = 300 seconds)
-	if args.UpdateFrequency < 300 {
-		return nil, fmt.Errorf("update frequency must be at least 300 seconds (5 minutes)")
+	// Validate minimum frequency
+	if args.UpdateFrequency < MinUpdateFrequency {
+		return nil, fmt.Errorf("update frequency must be at least %d seconds (%d minutes)", MinUpdateFrequency, MinUpdateFrequency/60)
 	}
 
 	return args, nil
 }
 
+func (cp *CommandParser) ParseResetCommand(commandFields []string) (*ResetArgs, error) {
+	// Reset takes the same arguments as unsubscribe, but the ID is required
+	unsubscribeArgs, err := cp.ParseUnsubscribeCommand(commandFields)
+	if err != nil {
+		return nil, err
+	}
+
+	if unsubscribeArgs.SubscriptionID == "" {
+		return nil, fmt.Errorf("missing required parameter: subscription id")
+	}
+
+	return &ResetArgs{SubscriptionID: unsubscribeArgs.SubscriptionID}, nil
+}
+
 func (cp *CommandParser) ParseUnsubscribeCommand(commandFields []string) (*UnsubscribeArgs, error) {
 	args := &UnsubscribeArgs{}
 
@@ -545,6 +875,123 @@ func (cp *CommandParser) ParseUnsubscribeCommand(commandFields []string) (*Unsub
 	return args, nil
 }
 
+// extractFlagValue removes a flag and its value from the command fields so both the simple
+// and flag syntax can be parsed as before. An empty value means the flag was not given.
+func extractFlagValue(commandFields []string, flag string) ([]string, string, error) {
+	index := slices.Index(commandFields, flag)
+	if index == -1 {
+		return commandFields, "", nil
+	}
+	if index+1 >= len(commandFields) || strings.HasPrefix(commandFields[index+1], "--") {
+		return nil, "", fmt.Errorf("missing value for %s", flag)
+	}
+
+	remaining := slices.Delete(slices.Clone(commandFields), index, index+2)
+	return remaining, commandFields[index+1], nil
+}
+
+// extractAirlineFlag removes --airline and its value from the command fields.
+// The codes are returned upper-cased and comma-separated.
+func extractAirlineFlag(commandFields []string) ([]string, string, error) {
+	remaining, value, err := extractFlagValue(commandFields, "--airline")
+	if err != nil || value == "" {
+		return remaining, "", err
+	}
+
+	var codes []string
+	for _, code := range strings.Split(value, ",") {
+		if code = strings.ToUpper(strings.TrimSpace(code)); code != "" {
+			codes = append(codes, code)
+		}
+	}
+	if len(codes) == 0 {
+		return nil, "", fmt.Errorf("missing value for --airline")
+	}
+
+	return remaining, strings.Join(codes, ","), nil
+}
+
+// extractAircraftTypeFlag removes --aircraft-type and its comma-separated value from the command fields.
+// The types are returned upper-cased; nil means the flag was not given.
+func extractAircraftTypeFlag(commandFields []string) ([]string, []string, error) {
+	remaining, value, err := extractFlagValue(commandFields, "--aircraft-type")
+	if err != nil || value == "" {
+		return remaining, nil, err
+	}
+
+	var aircraftTypes []string
+	for _, aircraftType := range strings.Split(value, ",") {
+		if aircraftType = strings.ToUpper(strings.TrimSpace(aircraftType)); aircraftType != "" {
+			aircraftTypes = append(aircraftTypes, aircraftType)
+		}
+	}
+	if len(aircraftTypes) == 0 {
+		return nil, nil, fmt.Errorf("missing value for --aircraft-type")
+	}
+
+	return remaining, aircraftTypes, nil
+}
+
+// extractWindowFlag removes --window, or its alias --lookback, and its duration from the command fields.
+// A zero window means the flag was not given and the default window applies.
+func extractWindowFlag(commandFields []string) ([]string, time.Duration, error) {
+	remaining, value, err := extractFlagValue(commandFields, "--window")
+	if err != nil {
+		return nil, 0, err
+	}
+	remaining, lookback, err := extractFlagValue(remaining, "--lookback")
+	if err != nil {
+		return nil, 0, err
+	}
+	if value != "" && lookback != "" {
+		return nil, 0, fmt.Errorf("use either --window or --lookback, not both")
+	}
+	if value == "" {
+		value = lookback
+	}
+	if value == "" {
+		return remaining, 0, nil
+	}
+
+	window, err := time.ParseDuration(value)
+	if err != nil {
+		return nil, 0, fmt.Errorf("invalid window: %s. Please use a duration like 90m, 6h or 24h", value)
+	}
+	if window < time.Minute {
+		return nil, 0, fmt.Errorf("window must be at least 1 minute")
+	}
+	if window > MaxLookbackWindow {
+		return nil, 0, fmt.Errorf("window must be at most %s (7 days)", MaxLookbackWindow)
+	}
+
+	return remaining, window, nil
+}
+
+// extractLookaheadFlag removes --lookahead and its duration from the command fields.
+// Without the flag, flight.DefaultLookaheadWindow applies; --lookahead 0 reports no scheduled flights.
+func extractLookaheadFlag(commandFields []string) ([]string, time.Duration, error) {
+	remaining, value, err := extractFlagValue(commandFields, "--lookahead")
+	if err != nil {
+		return nil, 0, err
+	}
+	if value == "" {
+		return remaining, flight.DefaultLookaheadWindow, nil
+	}
+
+	lookahead, err := time.ParseDuration(value)
+	if err != nil {
+		return nil, 0, fmt.Errorf("invalid lookahead: %s. Please use a duration like 90m, 6h or 24h", value)
+	}
+	if lookahead < 0 {
+		return nil, 0, fmt.Errorf("lookahead must not be negative")
+	}
+	if lookahead > MaxLookbackWindow {
+		return nil, 0, fmt.Errorf("lookahead must be at most %s (7 days)", MaxLookbackWindow)
+	}
+
+	return remaining, lookahead, nil
+}
+
 // parseFlags is a generic flag parser that maps flag names to target string pointers
 func (cp *CommandParser) parseFlags(fields []string, flagMap map[string]*string) {
 	for i := 0; i < len(fields); i++ {
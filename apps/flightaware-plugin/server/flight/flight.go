@@ -3,11 +3,13 @@ package flight
 import (
 	"encoding/json"
 	"fmt"
+	"hash/fnv"
 	"math/rand"
 	"os"
 	"path/filepath"
 	"strings"
 	"time"
+	"unicode"
 )
 
 type Flight struct {
@@ -17,12 +19,33 @@ type Flight struct {
 	LastSeen                      int64  `json:"lastSeen"`
 	EstArrivalAirport             string `json:"estArrivalAirport"`
 	Callsign                      string `json:"callsign"`
+	AircraftType                  string `json:"aircraftType,omitempty"`
 	EstDepartureAirportHorizDistance int  `json:"estDepartureAirportHorizDistance"`
 	EstDepartureAirportVertDistance   int  `json:"estDepartureAirportVertDistance"`
 	EstArrivalAirportHorizDistance   int  `json:"estArrivalAirportHorizDistance"`
 	EstArrivalAirportVertDistance     int  `json:"estArrivalAirportVertDistance"`
 	DepartureAirportCandidatesCount  int  `json:"departureAirportCandidatesCount"`
 	ArrivalAirportCandidatesCount    int  `json:"arrivalAirportCandidatesCount"`
+	Status                        string `json:"status,omitempty"`
+}
+
+// Flight statuses reported for generated flights
+const (
+	StatusScheduled = "scheduled"
+	StatusDelayed   = "delayed"
+	StatusCancelled = "cancelled"
+	StatusDeparted  = "departed"
+	StatusArrived   = "arrived"
+)
+
+// ID identifies a flight for status tracking by its callsign
+func (f Flight) ID() string {
+	return strings.TrimSpace(f.Callsign)
+}
+
+// Key identifies a flight across fetches by its callsign and departure time
+func (f Flight) Key() string {
+	return fmt.Sprintf("%s@%d", strings.TrimSpace(f.Callsign), f.FirstSeen)
 }
 
 type DepartureFlights struct {
@@ -32,9 +55,45 @@ type DepartureFlights struct {
 	Flights []Flight `json:"flights"`
 }
 
+type ArrivalFlights struct {
+	Airport string   `json:"airport"`
+	Start   int64    `json:"start"`
+	End     int64    `json:"end"`
+	Flights []Flight `json:"flights"`
+}
+
+// MaxFlightsPerResponse is the most flights listed in a single departures or arrivals response
+const MaxFlightsPerResponse = 20
+
+// DefaultLookbackWindow is how far back flights are reported when no window is requested
+const DefaultLookbackWindow = 6 * time.Hour
+
+// DefaultSubscriptionLookbackWindow is how far back new subscriptions report flights when no window is requested
+const DefaultSubscriptionLookbackWindow = 12 * time.Hour
+
+// DefaultLookaheadWindow is how far ahead new subscriptions report scheduled flights
+const DefaultLookaheadWindow = 12 * time.Hour
+
+// TimeWindow is the span of flights reported around the current time
+type TimeWindow struct {
+	Lookback  time.Duration // How far before now to report; zero means DefaultLookbackWindow
+	Lookahead time.Duration // How far after now to report scheduled flights
+}
+
+// Range returns the start and end unix times of the window around now
+func (w TimeWindow) Range(now time.Time) (int64, int64) {
+	lookback := w.Lookback
+	if lookback <= 0 {
+		lookback = DefaultLookbackWindow
+	}
+	return now.Add(-lookback).Unix(), now.Add(max(w.Lookahead, 0)).Unix()
+}
+
 type FlightInterface interface {
-	GetDepartureFlights(airport string) (*DepartureFlights, error)
+	GetDepartureFlights(airport string, window TimeWindow) (*DepartureFlights, error)
+	GetArrivalFlights(airport string, window TimeWindow) (*ArrivalFlights, error)
 	FormatFlightResponse(flights *DepartureFlights, airport string) string
+	FormatArrivalResponse(flights *ArrivalFlights, airport string) string
 }
 
 type FlightService struct {
@@ -66,16 +125,18 @@ func (fs *FlightService) loadFlights() error {
 	return nil
 }
 
-func (fs *FlightService) GetDepartureFlights(airport string) (*DepartureFlights, error) {
+// GetDepartureFlights returns flights that departed the airport within the window's lookback,
+// or are scheduled to depart within its lookahead
+func (fs *FlightService) GetDepartureFlights(airport string, window TimeWindow) (*DepartureFlights, error) {
 	// Convert airport code to ICAO format if needed
 	icaoAirport := fs.getICAOCode(airport)
 	
-	// Use current time and 6 hours ago as default time range for realistic timestamps
-	end := time.Now().Unix()
-	start := time.Now().Add(-6 * time.Hour).Unix()
+	// Use the window around the current time as the time range for realistic timestamps
+	now := time.Now()
+	start, end := window.Range(now)
 	
 	// Generate random flights for any airport
-	randomFlights := fs.generateRandomFlights(icaoAirport, start, end)
+	randomFlights := fs.generateRandomFlights(icaoAirport, start, end, now.Unix())
 
 	result := &DepartureFlights{
 		Airport: icaoAirport,
@@ -87,51 +148,200 @@ func (fs *FlightService) GetDepartureFlights(airport string) (*DepartureFlights,
 	return result, nil
 }
 
-func (fs *FlightService) generateRandomFlights(airport string, start, end int64) []Flight {
-	if len(fs.flights) == 0 {
-		return []Flight{}
+// GetArrivalFlights returns flights that arrived at the airport within the window's lookback,
+// or are scheduled to arrive within its lookahead
+func (fs *FlightService) GetArrivalFlights(airport string, window TimeWindow) (*ArrivalFlights, error) {
+	// Convert airport code to ICAO format if needed
+	icaoAirport := fs.getICAOCode(airport)
+
+	// Use the same window handling as departures
+	now := time.Now()
+	start, end := window.Range(now)
+
+	// Generate random arriving flights for any airport
+	randomFlights := fs.generateRandomArrivals(icaoAirport, start, end, now.Unix())
+
+	result := &ArrivalFlights{
+		Airport: icaoAirport,
+		Start:   start,
+		End:     end,
+		Flights: randomFlights,
 	}
 
-	// Generate random number of flights (between 3 and 8)
-	numFlights := rand.Intn(6) + 3
-	if numFlights > len(fs.flights) {
-		numFlights = len(fs.flights)
+	return result, nil
+}
+
+// scheduleSlot is the length of the slots flights are generated in. Each airport gets the same flights
+// in a slot on every fetch, so a flight keeps its callsign, times and status as the window moves.
+const scheduleSlot = time.Hour
+
+// scheduledFlight is a generated flight: the loaded flight it is based on, with a callsign and the time
+// of the event (departure or arrival) in the requested window
+type scheduledFlight struct {
+	flight   Flight
+	at       int64
+	duration int64
+}
+
+// scheduleFlights returns the flights departing or arriving (kind) at the airport between start and
+// end, in time order. The flights of each slot are generated from a random source seeded with the
+// airport, kind and slot, so fetching the same slot again returns the same flights.
+func (fs *FlightService) scheduleFlights(airport, kind string, start, end int64) []scheduledFlight {
+	if len(fs.flights) == 0 || end <= start {
+		return nil
 	}
 
-	// Create a copy of flights and shuffle them
-	availableFlights := make([]Flight, len(fs.flights))
-	copy(availableFlights, fs.flights)
-	
-	// Shuffle the flights
-	for i := range availableFlights {
-		j := rand.Intn(i + 1)
-		availableFlights[i], availableFlights[j] = availableFlights[j], availableFlights[i]
+	slotSeconds := int64(scheduleSlot / time.Second)
+	slotsPerDay := int64(24 * time.Hour / scheduleSlot)
+
+	var scheduled []scheduledFlight
+	for slot := start / slotSeconds; slot*slotSeconds < end; slot++ {
+		r := rand.New(rand.NewSource(int64(hashString(fmt.Sprintf("%s/%s/%d", kind, airport, slot)))))
+
+		// Between 0 and 2 flights an hour, each at a whole minute
+		for i := range r.Intn(3) {
+			template := fs.flights[r.Intn(len(fs.flights))]
+			at := slot*slotSeconds + r.Int63n(slotSeconds/60)*60
+			duration := r.Int63n(7*3600) + 3600 // 1-8 hours in seconds
+			if at < start || at >= end {
+				continue
+			}
+
+			// Number the flights by their slot of the day, so callsigns do not repeat within a day
+			number := 100 + (((slot%slotsPerDay)*3+int64(i))*37+int64(hashString(airport)%900))%900
+			template.Callsign = fmt.Sprintf("%s%d", airlinePrefix(template.Callsign), number)
+
+			scheduled = append(scheduled, scheduledFlight{flight: template, at: at, duration: duration})
+		}
 	}
 
-	// Select random flights and modify them for the requested airport
-	var randomFlights []Flight
-	timeRange := end - start
-	
-	for i := 0; i < numFlights; i++ {
-		flight := availableFlights[i]
-		
+	return scheduled
+}
+
+func (fs *FlightService) generateRandomFlights(airport string, start, end, now int64) []Flight {
+	randomFlights := []Flight{}
+	for _, scheduled := range fs.scheduleFlights(airport, "departures", start, end) {
 		// Modify the flight to appear as if it's departing from the requested airport
+		flight := scheduled.flight
 		flight.EstDepartureAirport = airport
-		
-		// Generate random departure time within the requested time range
-		randomOffset := rand.Int63n(timeRange)
-		flight.FirstSeen = start + randomOffset
-		
-		// Set arrival time (flight duration between 1-8 hours)
-		flightDuration := rand.Int63n(7*3600) + 3600 // 1-8 hours in seconds
-		flight.LastSeen = flight.FirstSeen + flightDuration
-		
+		flight.FirstSeen = scheduled.at
+		flight.LastSeen = flight.FirstSeen + scheduled.duration
+		flight.Status = flightStatus(flight, flight.FirstSeen, now, StatusDeparted)
+
+		randomFlights = append(randomFlights, flight)
+	}
+
+	return randomFlights
+}
+
+func (fs *FlightService) generateRandomArrivals(airport string, start, end, now int64) []Flight {
+	randomFlights := []Flight{}
+	for _, scheduled := range fs.scheduleFlights(airport, "arrivals", start, end) {
+		// Modify the flight to appear as if it's arriving at the requested airport
+		flight := scheduled.flight
+		flight.EstArrivalAirport = airport
+		flight.LastSeen = scheduled.at
+		flight.FirstSeen = flight.LastSeen - scheduled.duration
+		flight.Status = flightStatus(flight, flight.LastSeen, now, StatusArrived)
+
 		randomFlights = append(randomFlights, flight)
 	}
 
 	return randomFlights
 }
 
+// completedStatus returns the given status for flights whose time has passed and scheduled otherwise
+func completedStatus(at, now int64, completed string) string {
+	if at > now {
+		return StatusScheduled
+	}
+	return completed
+}
+
+// flightStatus returns the status of a flight due at the given time. Most flights are scheduled until
+// then and completed after; some are cancelled, and some are delayed until 15 to 120 minutes after
+// their time. Which flights are disrupted depends only on the flight's key, so the status only
+// changes when now passes the flight's time or the end of its delay.
+func flightStatus(f Flight, at, now int64, completed string) string {
+	h := hashString(f.Key())
+	switch n := h % 100; {
+	case n < 5:
+		return StatusCancelled
+	case n < 20:
+		delay := int64(15+(h/100)%106) * 60
+		if now < at+delay {
+			return StatusDelayed
+		}
+		return completed
+	default:
+		return completedStatus(at, now, completed)
+	}
+}
+
+// hashString returns a stable hash of s, for deriving generated flight details
+func hashString(s string) uint64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(s))
+	return h.Sum64()
+}
+
+// airlinePrefix returns the airline code at the start of a callsign, e.g. "UAL" for "UAL123" and "B6"
+// for "B6303"
+func airlinePrefix(callsign string) string {
+	prefix := strings.TrimRightFunc(strings.TrimSpace(callsign), unicode.IsDigit)
+	if len(prefix) < 2 && len(callsign) >= 2 {
+		return callsign[:2]
+	}
+	return prefix
+}
+
+// FilterByAirline returns the flights whose callsign starts with one of the comma-separated
+// airline codes (e.g. "UA,DL"). An empty value matches all airlines.
+func FilterByAirline(flights []Flight, airlines string) []Flight {
+	if strings.TrimSpace(airlines) == "" {
+		return flights
+	}
+
+	var codes []string
+	for _, code := range strings.Split(airlines, ",") {
+		if code = strings.ToUpper(strings.TrimSpace(code)); code != "" {
+			codes = append(codes, code)
+		}
+	}
+
+	filtered := []Flight{}
+	for _, flight := range flights {
+		callsign := strings.ToUpper(strings.TrimSpace(flight.Callsign))
+		for _, code := range codes {
+			if strings.HasPrefix(callsign, code) {
+				filtered = append(filtered, flight)
+				break
+			}
+		}
+	}
+	return filtered
+}
+
+// FilterByAircraftType returns the flights whose aircraft type matches one of the given types,
+// ignoring case (e.g. "B737", "A320"). An empty list matches all aircraft types.
+func FilterByAircraftType(flights []Flight, aircraftTypes []string) []Flight {
+	if len(aircraftTypes) == 0 {
+		return flights
+	}
+
+	filtered := []Flight{}
+	for _, flight := range flights {
+		aircraftType := strings.TrimSpace(flight.AircraftType)
+		for _, t := range aircraftTypes {
+			if strings.EqualFold(aircraftType, strings.TrimSpace(t)) {
+				filtered = append(filtered, flight)
+				break
+			}
+		}
+	}
+	return filtered
+}
+
 func (fs *FlightService) FormatFlightResponse(flights *DepartureFlights, airport string) string {
 	if len(flights.Flights) == 0 {
 		return fmt.Sprintf("No departures found from %s.", airport)
@@ -140,10 +350,10 @@ func (fs *FlightService) FormatFlightResponse(flights *DepartureFlights, airport
 	var sb strings.Builder
 	sb.WriteString(fmt.Sprintf("**Recent Departures from %s**\n\n", airport))
 
-	sb.WriteString("| Flight | Airline | Departure Time | Destination | Duration |\n")
-	sb.WriteString("|--------|---------|---------------|-------------|----------|\n")
+	sb.WriteString("| Flight | Airline | Departure Time | Destination | Duration | Status |\n")
+	sb.WriteString("|--------|---------|---------------|-------------|----------|--------|\n")
 
-	maxFlights := 20
+	maxFlights := MaxFlightsPerResponse
 	if len(flights.Flights) < maxFlights {
 		maxFlights = len(flights.Flights)
 	}
@@ -170,8 +380,57 @@ func (fs *FlightService) FormatFlightResponse(flights *DepartureFlights, airport
 			airlineName = "Unknown"
 		}
 
-		sb.WriteString(fmt.Sprintf("| **%s** | %s | %s | %s | %s |\n",
-			callsign, airlineName, departureTime, destination, duration))
+		sb.WriteString(fmt.Sprintf("| **%s** | %s | %s | %s | %s | %s |\n",
+			callsign, airlineName, departureTime, destination, duration, displayStatus(flight.Status)))
+	}
+
+	if len(flights.Flights) > maxFlights {
+		sb.WriteString(fmt.Sprintf("\n_Showing %d of %d total flights_", maxFlights, len(flights.Flights)))
+	}
+
+	return sb.String()
+}
+
+func (fs *FlightService) FormatArrivalResponse(flights *ArrivalFlights, airport string) string {
+	if len(flights.Flights) == 0 {
+		return fmt.Sprintf("No arrivals found at %s.", airport)
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("**Recent Arrivals at %s**\n\n", airport))
+
+	sb.WriteString("| Flight | Airline | Arrival Time | Origin | Duration | Status |\n")
+	sb.WriteString("|--------|---------|-------------|--------|----------|--------|\n")
+
+	maxFlights := MaxFlightsPerResponse
+	if len(flights.Flights) < maxFlights {
+		maxFlights = len(flights.Flights)
+	}
+
+	for i := 0; i < maxFlights; i++ {
+		flight := flights.Flights[i]
+		arrivalTime := time.Unix(flight.LastSeen, 0).Format("15:04 MST")
+
+		callsign := strings.TrimSpace(flight.Callsign)
+
+		origin := "-"
+		if flight.EstDepartureAirport != "" {
+			origin = flight.EstDepartureAirport
+		}
+
+		duration := "-"
+		if flight.LastSeen > flight.FirstSeen {
+			durationMinutes := (flight.LastSeen - flight.FirstSeen) / 60
+			duration = fmt.Sprintf("%d min", durationMinutes)
+		}
+
+		airlineName := fs.getAirlineInfo(callsign)
+		if airlineName == "" {
+			airlineName = "Unknown"
+		}
+
+		sb.WriteString(fmt.Sprintf("| **%s** | %s | %s | %s | %s | %s |\n",
+			callsign, airlineName, arrivalTime, origin, duration, displayStatus(flight.Status)))
 	}
 
 	if len(flights.Flights) > maxFlights {
@@ -181,6 +440,14 @@ func (fs *FlightService) FormatFlightResponse(flights *DepartureFlights, airport
 	return sb.String()
 }
 
+// displayStatus returns the status shown in flight tables
+func displayStatus(status string) string {
+	if status == "" {
+		return "-"
+	}
+	return strings.ToUpper(status[:1]) + status[1:]
+}
+
 func (fs *FlightService) getICAOCode(airport string) string {
 	// Simple mapping for common airports
 	airportMap := map[string]string{
@@ -0,0 +1,217 @@
+package flight
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTimeWindowRange(t *testing.T) {
+	now := time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name          string
+		window        TimeWindow
+		expectedStart time.Time
+		expectedEnd   time.Time
+	}{
+		{
+			name:          "zero window uses the default lookback",
+			window:        TimeWindow{},
+			expectedStart: now.Add(-DefaultLookbackWindow),
+			expectedEnd:   now,
+		},
+		{
+			name:          "lookback only",
+			window:        TimeWindow{Lookback: 2 * time.Hour},
+			expectedStart: time.Date(2025, 1, 15, 10, 0, 0, 0, time.UTC),
+			expectedEnd:   now,
+		},
+		{
+			name:          "lookback and lookahead",
+			window:        TimeWindow{Lookback: 12 * time.Hour, Lookahead: 12 * time.Hour},
+			expectedStart: time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC),
+			expectedEnd:   time.Date(2025, 1, 16, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:          "negative lookahead is ignored",
+			window:        TimeWindow{Lookback: time.Hour, Lookahead: -time.Hour},
+			expectedStart: time.Date(2025, 1, 15, 11, 0, 0, 0, time.UTC),
+			expectedEnd:   now,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			start, end := tc.window.Range(now)
+			if start != tc.expectedStart.Unix() {
+				t.Errorf("Expected start %s, got %s", tc.expectedStart, time.Unix(start, 0).UTC())
+			}
+			if end != tc.expectedEnd.Unix() {
+				t.Errorf("Expected end %s, got %s", tc.expectedEnd, time.Unix(end, 0).UTC())
+			}
+		})
+	}
+}
+
+// testFlightService returns a flight service with a few loaded flights to generate from
+func testFlightService() *FlightService {
+	return &FlightService{flights: []Flight{
+		{Callsign: "UAL123", AircraftType: "B737", EstArrivalAirport: "KLAX"},
+		{Callsign: "DL456", AircraftType: "A321", EstArrivalAirport: "KATL"},
+		{Callsign: "B6303", AircraftType: "A320", EstArrivalAirport: "KBOS"},
+	}}
+}
+
+// flightKeys returns the keys of the flights in the order given
+func flightKeys(flights []Flight) []string {
+	keys := make([]string, 0, len(flights))
+	for _, f := range flights {
+		keys = append(keys, f.Key())
+	}
+	return keys
+}
+
+func TestGeneratedFlightsAreStable(t *testing.T) {
+	fs := testFlightService()
+	now := time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC)
+	start, end := TimeWindow{Lookback: 6 * time.Hour}.Range(now)
+
+	for _, kind := range []string{"departures", "arrivals"} {
+		t.Run(kind, func(t *testing.T) {
+			generate := fs.generateRandomFlights
+			if kind == "arrivals" {
+				generate = fs.generateRandomArrivals
+			}
+
+			first := generate("KSFO", start, end, now.Unix())
+			if len(first) == 0 {
+				t.Fatal("Expected flights in a 6 hour window")
+			}
+			if again := generate("KSFO", start, end, now.Unix()); strings.Join(flightKeys(again), ",") != strings.Join(flightKeys(first), ",") {
+				t.Errorf("Expected the same flights on a second fetch, got %v and %v", flightKeys(first), flightKeys(again))
+			}
+
+			// Ten minutes later the window has moved, but the flights still in it are the same
+			later := generate("KSFO", start+600, end+600, now.Unix()+600)
+			var overlap []string
+			for _, f := range first {
+				if at := eventTime(f, kind); at >= start+600 {
+					overlap = append(overlap, f.Key())
+				}
+			}
+			var laterOverlap []string
+			for _, f := range later {
+				if at := eventTime(f, kind); at < end {
+					laterOverlap = append(laterOverlap, f.Key())
+				}
+			}
+			if strings.Join(laterOverlap, ",") != strings.Join(overlap, ",") {
+				t.Errorf("Expected the flights in both windows to match, got %v and %v", overlap, laterOverlap)
+			}
+
+			// Callsigns keep the airline code and do not repeat within a day
+			seen := map[string]bool{}
+			for _, f := range generate("KSFO", start, start+24*3600, now.Unix()) {
+				if seen[f.Callsign] {
+					t.Errorf("Callsign %s is generated twice in a day", f.Callsign)
+				}
+				seen[f.Callsign] = true
+				if !strings.HasPrefix(f.Callsign, "UAL") && !strings.HasPrefix(f.Callsign, "DL") && !strings.HasPrefix(f.Callsign, "B6") {
+					t.Errorf("Unexpected airline in callsign %s", f.Callsign)
+				}
+			}
+		})
+	}
+}
+
+// eventTime returns the departure time of departures and the arrival time of arrivals
+func eventTime(f Flight, kind string) int64 {
+	if kind == "arrivals" {
+		return f.LastSeen
+	}
+	return f.FirstSeen
+}
+
+func TestCompletedStatus(t *testing.T) {
+	if status := completedStatus(100, 200, StatusDeparted); status != StatusDeparted {
+		t.Errorf("Expected a past flight to be %s, got %s", StatusDeparted, status)
+	}
+	if status := completedStatus(300, 200, StatusDeparted); status != StatusScheduled {
+		t.Errorf("Expected a future flight to be %s, got %s", StatusScheduled, status)
+	}
+}
+
+func TestFlightStatus(t *testing.T) {
+	const at = int64(10000)
+
+	// Find a flight of each kind, since which flights are disrupted depends on their key
+	byStatus := map[string]Flight{}
+	for i := range 200 {
+		f := Flight{Callsign: fmt.Sprintf("UAL%d", i), FirstSeen: at}
+		byStatus[flightStatus(f, at, at+24*3600, StatusDeparted)] = f
+	}
+	for _, status := range []string{StatusCancelled, StatusDeparted} {
+		if _, ok := byStatus[status]; !ok {
+			t.Fatalf("Expected some flights to end up %s, got %v", status, byStatus)
+		}
+	}
+
+	// The same flight at the same time always has the same status
+	for _, f := range byStatus {
+		if first, second := flightStatus(f, at, at-60, StatusDeparted), flightStatus(f, at, at-60, StatusDeparted); first != second {
+			t.Errorf("Expected %s to keep its status, got %s then %s", f.Key(), first, second)
+		}
+	}
+
+	cancelled := byStatus[StatusCancelled]
+	if status := flightStatus(cancelled, at, at-3600, StatusDeparted); status != StatusCancelled {
+		t.Errorf("Expected a cancelled flight to be cancelled before its time, got %s", status)
+	}
+
+	// A flight that is on time is scheduled until it departs
+	for _, f := range byStatus {
+		if flightStatus(f, at, at-3600, StatusDeparted) != StatusScheduled {
+			continue
+		}
+		if status := flightStatus(f, at, at+60, StatusDeparted); status != StatusDeparted {
+			t.Errorf("Expected %s to depart after its time, got %s", f.Key(), status)
+		}
+		return
+	}
+	t.Error("Expected some flights to be on time")
+}
+
+func TestFilterByAircraftType(t *testing.T) {
+	flights := []Flight{
+		{Callsign: "UAL123", AircraftType: "B737"},
+		{Callsign: "DL456", AircraftType: "A321"},
+		{Callsign: "JB202", AircraftType: "a320"},
+		{Callsign: "BA707"},
+	}
+
+	testCases := []struct {
+		name     string
+		filter   []string
+		expected []string
+	}{
+		{name: "empty filter keeps every flight", filter: nil, expected: []string{"UAL123", "DL456", "JB202", "BA707"}},
+		{name: "single type", filter: []string{"B737"}, expected: []string{"UAL123"}},
+		{name: "matching ignores case", filter: []string{"b737", "A320"}, expected: []string{"UAL123", "JB202"}},
+		{name: "no matches", filter: []string{"B747"}, expected: []string{}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			filtered := FilterByAircraftType(flights, tc.filter)
+			callsigns := []string{}
+			for _, f := range filtered {
+				callsigns = append(callsigns, f.Callsign)
+			}
+			if strings.Join(callsigns, ",") != strings.Join(tc.expected, ",") {
+				t.Errorf("Expected %v, got %v", tc.expected, callsigns)
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"encoding/json"
 	"net/http"
 	"sync"
 
@@ -101,9 +102,36 @@ func (p *Plugin) ExecuteCommand(c *plugin.Context, args *model.CommandArgs) (*mo
 
 func (p *Plugin) ServeHTTP(c *plugin.Context, w http.ResponseWriter, r *http.Request) {
 	router := mux.NewRouter()
+	router.HandleFunc("/api-usage", p.handleAPIUsage).Methods(http.MethodGet)
 	router.ServeHTTP(w, r)
 }
 
+// handleAPIUsage returns the projected flight data calls of all subscriptions, the limits and
+// subscription counts as JSON. The stats include channel and user IDs, so only system admins can read it.
+func (p *Plugin) handleAPIUsage(w http.ResponseWriter, r *http.Request) {
+	userID := r.Header.Get("Mattermost-User-ID")
+	if userID == "" {
+		http.Error(w, "not authorized", http.StatusUnauthorized)
+		return
+	}
+	if !p.client.User.HasPermissionTo(userID, model.PermissionManageSystem) {
+		http.Error(w, "forbidden", http.StatusForbidden)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	response := struct {
+		subscription.APIUsage
+		Stats subscription.SubscriptionStats `json:"stats"`
+	}{
+		APIUsage: p.subscriptionMgr.GetAPIUsage(),
+		Stats:    p.subscriptionMgr.GetSubscriptionStats(),
+	}
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		p.client.Log.Error("Failed to write API usage", "error", err.Error())
+	}
+}
+
 func main() {
 	plugin.ClientMain(&Plugin{})
 }
\ No newline at end of file
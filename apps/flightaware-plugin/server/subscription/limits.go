@@ -0,0 +1,151 @@
+package subscription
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// Default API-usage limits; each subscription tick makes one flight data call
+const (
+	DefaultHourlyLimit = 50
+	DefaultDailyLimit  = 1000
+)
+
+// DefaultMaxSubscriptionsPerLocation is how many subscriptions can watch the same airport
+const DefaultMaxSubscriptionsPerLocation = 5
+
+// APIUsage is the projected number of flight data calls made by all subscriptions
+type APIUsage struct {
+	Subscriptions int     `json:"subscriptions"`
+	HourlyCalls   float64 `json:"hourly_calls"`
+	HourlyLimit   int     `json:"hourly_limit"`
+	DailyCalls    float64 `json:"daily_calls"`
+	DailyLimit    int     `json:"daily_limit"`
+}
+
+// LimitExceededError is returned when a new subscription would push projected usage over a limit
+type LimitExceededError struct {
+	Period    string
+	Projected float64
+	Limit     int
+}
+
+func (e *LimitExceededError) Error() string {
+	return fmt.Sprintf("this subscription would bring projected usage to %s calls per %s, over the limit of %d",
+		FormatCalls(e.Projected), e.Period, e.Limit)
+}
+
+// LocationLimitError is returned when a new subscription would exceed the subscriptions allowed for one airport
+type LocationLimitError struct {
+	Location string
+	Limit    int
+}
+
+func (e *LocationLimitError) Error() string {
+	return fmt.Sprintf("%s already has the maximum of %d subscriptions", e.Location, e.Limit)
+}
+
+// FormatCalls rounds a projected call count for display
+func FormatCalls(calls float64) string {
+	return fmt.Sprintf("%.1f", calls)
+}
+
+// callsPer returns how many calls a subscription updating every frequency seconds makes in period
+func callsPer(period time.Duration, frequency int64) float64 {
+	if frequency <= 0 {
+		return 0
+	}
+	return period.Seconds() / float64(frequency)
+}
+
+// GetAPIUsage returns the projected calls per hour and per day of all subscriptions
+func (sm *SubscriptionManager) GetAPIUsage() APIUsage {
+	sm.mutex.RLock()
+	defer sm.mutex.RUnlock()
+
+	return sm.apiUsage()
+}
+
+// apiUsage computes GetAPIUsage. Callers must hold the mutex.
+func (sm *SubscriptionManager) apiUsage() APIUsage {
+	usage := APIUsage{
+		Subscriptions: len(sm.subscriptions),
+		HourlyLimit:   sm.hourlyLimit,
+		DailyLimit:    sm.dailyLimit,
+	}
+	for _, sub := range sm.subscriptions {
+		usage.HourlyCalls += callsPer(time.Hour, sub.UpdateFrequency)
+		usage.DailyCalls += callsPer(24*time.Hour, sub.UpdateFrequency)
+	}
+	return usage
+}
+
+// CountSubscriptionsForLocation returns how many subscriptions watch the airport, ignoring case
+func (sm *SubscriptionManager) CountSubscriptionsForLocation(location string) int {
+	sm.mutex.RLock()
+	defer sm.mutex.RUnlock()
+
+	return sm.countSubscriptionsForLocation(location)
+}
+
+// countSubscriptionsForLocation computes CountSubscriptionsForLocation. Callers must hold the mutex.
+func (sm *SubscriptionManager) countSubscriptionsForLocation(location string) int {
+	count := 0
+	for _, sub := range sm.subscriptions {
+		if strings.EqualFold(sub.Airport, location) {
+			count++
+		}
+	}
+	return count
+}
+
+// maxSubscriptionsPerLocation returns the per-airport cap, treating zero as the default
+func (sm *SubscriptionManager) maxSubscriptionsPerLocation() int {
+	if sm.MaxSubscriptionsPerLocation <= 0 {
+		return DefaultMaxSubscriptionsPerLocation
+	}
+	return sm.MaxSubscriptionsPerLocation
+}
+
+// CheckSubscriptionLimits returns a LocationLimitError if the airport already has the maximum number of
+// subscriptions, or a LimitExceededError if adding a subscription that updates every frequency seconds
+// would bring projected usage over the hourly or daily limit
+func (sm *SubscriptionManager) CheckSubscriptionLimits(location string, frequency int64) error {
+	sm.mutex.RLock()
+	defer sm.mutex.RUnlock()
+
+	if err := sm.checkLocationLimit(location); err != nil {
+		return err
+	}
+	return sm.checkUsageLimits(frequency)
+}
+
+// checkLocationLimit returns a LocationLimitError if the airport already has the maximum number of
+// subscriptions. Callers must hold the mutex.
+func (sm *SubscriptionManager) checkLocationLimit(location string) error {
+	if limit := sm.maxSubscriptionsPerLocation(); sm.countSubscriptionsForLocation(location)+1 > limit {
+		return &LocationLimitError{Location: location, Limit: limit}
+	}
+	return nil
+}
+
+// checkUsageLimits returns a LimitExceededError if adding a subscription that updates every frequency
+// seconds would bring projected usage over the hourly or daily limit. Callers must hold the mutex.
+func (sm *SubscriptionManager) checkUsageLimits(frequency int64) error {
+	usage := sm.apiUsage()
+
+	// Round away floating point noise so a subscription landing exactly on the limit is allowed
+	hourly := math.Round((usage.HourlyCalls+callsPer(time.Hour, frequency))*1000) / 1000
+	if hourly > float64(usage.HourlyLimit) {
+		return &LimitExceededError{Period: "hour", Projected: hourly, Limit: usage.HourlyLimit}
+	}
+
+	daily := math.Round((usage.DailyCalls+callsPer(24*time.Hour, frequency))*1000) / 1000
+	if daily > float64(usage.DailyLimit) {
+		return &LimitExceededError{Period: "day", Projected: daily, Limit: usage.DailyLimit}
+	}
+
+	return nil
+}
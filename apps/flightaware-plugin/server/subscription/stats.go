@@ -0,0 +1,38 @@
+package subscription
+
+import "strings"
+
+// SubscriptionStats counts subscriptions by airport, channel and the user who created them.
+// Airports are counted in upper case, since airport codes are matched ignoring case.
+// Active subscriptions have a running update job; paused ones are stored but not updating.
+type SubscriptionStats struct {
+	ByLocation  map[string]int `json:"by_location"`
+	ByChannel   map[string]int `json:"by_channel"`
+	ByUser      map[string]int `json:"by_user"`
+	TotalActive int            `json:"total_active"`
+	TotalPaused int            `json:"total_paused"`
+}
+
+// GetSubscriptionStats returns subscription counts by airport, channel and user
+func (sm *SubscriptionManager) GetSubscriptionStats() SubscriptionStats {
+	sm.mutex.RLock()
+	defer sm.mutex.RUnlock()
+
+	stats := SubscriptionStats{
+		ByLocation: make(map[string]int),
+		ByChannel:  make(map[string]int),
+		ByUser:     make(map[string]int),
+	}
+	for id, sub := range sm.subscriptions {
+		stats.ByLocation[strings.ToUpper(sub.Airport)]++
+		stats.ByChannel[sub.ChannelID]++
+		stats.ByUser[sub.UserID]++
+
+		if _, running := sm.jobs[id]; running {
+			stats.TotalActive++
+		} else {
+			stats.TotalPaused++
+		}
+	}
+	return stats
+}
@@ -2,7 +2,11 @@ package subscription
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"maps"
+	"slices"
+	"strings"
 	"sync"
 	"time"
 
@@ -11,6 +15,12 @@ import (
 	"github.com/mattermost/mattermost/server/public/pluginapi"
 )
 
+// Subscription modes; stored subscriptions without a mode are departures
+const (
+	ModeDepartures = "departures"
+	ModeArrivals   = "arrivals"
+)
+
 type FlightSubscription struct {
 	ID              string        `json:"id"`
 	Airport         string        `json:"airport"`
@@ -18,6 +28,53 @@ type FlightSubscription struct {
 	UserID          string        `json:"user_id"`
 	UpdateFrequency int64         `json:"update_frequency"`
 	LastUpdated     time.Time     `json:"last_updated"`
+	Mode            string        `json:"mode,omitempty"`
+	// Airline is a comma-separated list of airline codes to include; empty means all airlines
+	Airline         string        `json:"airline,omitempty"`
+	// AircraftTypeFilter holds the aircraft types to include (e.g. B737, A320); empty means all types
+	AircraftTypeFilter []string   `json:"aircraft_type_filter,omitempty"`
+	// LookbackWindow is how far back to report flights in seconds; zero means flight.DefaultLookbackWindow
+	LookbackWindow  int64         `json:"lookback_window,omitempty"`
+	// LookaheadWindow is how far ahead to report scheduled flights in seconds
+	LookaheadWindow int64         `json:"lookahead_window,omitempty"`
+	// ReportedFlights holds the keys of flights in the last fetch that were already posted
+	ReportedFlights []string      `json:"reported_flights,omitempty"`
+	// FlightStatuses holds the status of each flight in the last fetch, by flight key
+	FlightStatuses  map[string]string `json:"flight_statuses,omitempty"`
+}
+
+// Window returns the lookback window, treating subscriptions saved without one as the default window
+func (sub *FlightSubscription) Window() time.Duration {
+	if sub.LookbackWindow <= 0 {
+		return flight.DefaultLookbackWindow
+	}
+	return time.Duration(sub.LookbackWindow) * time.Second
+}
+
+// clone returns a copy of the subscription. Jobs update stored subscriptions under the manager's
+// mutex, so the getters copy them while holding it instead of handing out the stored pointers.
+func (sub *FlightSubscription) clone() *FlightSubscription {
+	copied := *sub
+	copied.AircraftTypeFilter = slices.Clone(sub.AircraftTypeFilter)
+	copied.ReportedFlights = slices.Clone(sub.ReportedFlights)
+	copied.FlightStatuses = maps.Clone(sub.FlightStatuses)
+	return &copied
+}
+
+// TimeWindow returns the span of flights each update reports
+func (sub *FlightSubscription) TimeWindow() flight.TimeWindow {
+	return flight.TimeWindow{
+		Lookback:  sub.Window(),
+		Lookahead: time.Duration(sub.LookaheadWindow) * time.Second,
+	}
+}
+
+// FlightMode returns the subscription mode, treating subscriptions saved before modes existed as departures
+func (sub *FlightSubscription) FlightMode() string {
+	if sub.Mode == ModeArrivals {
+		return ModeArrivals
+	}
+	return ModeDepartures
 }
 
 type SubscriptionInterface interface {
@@ -26,6 +83,11 @@ type SubscriptionInterface interface {
 	GetSubscription(id string) (*FlightSubscription, bool)
 	GetSubscriptionsForChannel(channelID string) []*FlightSubscription
 	GetAllSubscriptions() []*FlightSubscription
+	ResetReportedFlights(id string) bool
+	CheckSubscriptionLimits(location string, frequency int64) error
+	CountSubscriptionsForLocation(location string) int
+	GetAPIUsage() APIUsage
+	GetSubscriptionStats() SubscriptionStats
 	StopAll()
 }
 
@@ -35,7 +97,14 @@ type SubscriptionManager struct {
 	messageService MessageServiceInterface
 	subscriptions  map[string]*FlightSubscription
 	jobs           map[string]chan struct{} // Track running subscription jobs
+	jobsWG         sync.WaitGroup           // Counts running subscription loops so StopAll can wait for them
+	hourlyLimit    int                      // Maximum projected flight data calls per hour
+	dailyLimit     int                      // Maximum projected flight data calls per day
 	mutex          sync.RWMutex
+
+	// MaxSubscriptionsPerLocation caps the subscriptions watching one airport, since each polls
+	// the same flight data; zero uses DefaultMaxSubscriptionsPerLocation
+	MaxSubscriptionsPerLocation int
 }
 
 type MessageServiceInterface interface {
@@ -50,6 +119,10 @@ func NewSubscriptionManager(client *pluginapi.Client, flightService flight.Fligh
 		messageService: messageService,
 		subscriptions:  make(map[string]*FlightSubscription),
 		jobs:           make(map[string]chan struct{}),
+		hourlyLimit:    DefaultHourlyLimit,
+		dailyLimit:     DefaultDailyLimit,
+
+		MaxSubscriptionsPerLocation: DefaultMaxSubscriptionsPerLocation,
 	}
 	if err := sm.loadSubscriptions(); err != nil {
 		return nil, fmt.Errorf("failed to initialize subscription manager: %w", err)
@@ -57,13 +130,33 @@ func NewSubscriptionManager(client *pluginapi.Client, flightService flight.Fligh
 	return sm, nil
 }
 
+// ErrSubscriptionExists is returned by AddSubscription when the ID is already in use
+var ErrSubscriptionExists = errors.New("a subscription with this ID already exists")
+
+// AddSubscription stores a subscription and starts its updates. It returns ErrSubscriptionExists if the
+// ID is in use, and the error from CheckSubscriptionLimits if the subscription would exceed a limit.
+// The checks run under the same lock as the add, so concurrent adds cannot both pass them.
 func (sm *SubscriptionManager) AddSubscription(sub *FlightSubscription) error {
 	sm.mutex.Lock()
 	defer sm.mutex.Unlock()
 
+	// Replacing a subscription would leave its running job without a stop channel
+	if _, exists := sm.subscriptions[sub.ID]; exists {
+		return ErrSubscriptionExists
+	}
+	if _, running := sm.jobs[sub.ID]; running {
+		return ErrSubscriptionExists
+	}
+	if err := sm.checkLocationLimit(sub.Airport); err != nil {
+		return err
+	}
+	if err := sm.checkUsageLimits(sub.UpdateFrequency); err != nil {
+		return err
+	}
+
 	sm.subscriptions[sub.ID] = sub
 
-	go sm.startSubscription(sub)
+	go sm.startSubscription(sub, sm.registerJob(sub.ID))
 	sm.saveSubscriptions()
 
 	return nil
@@ -84,6 +177,16 @@ func (sm *SubscriptionManager) RemoveSubscription(id string) bool {
 	return false
 }
 
+// registerJob creates the stop channel for a subscription job before its goroutine starts,
+// so a subscription removed right after being added is still stopped, and counts the job for StopAll.
+// Callers must hold the mutex, make sure no job is registered for id, and run the job with startSubscription.
+func (sm *SubscriptionManager) registerJob(id string) chan struct{} {
+	stopChan := make(chan struct{})
+	sm.jobs[id] = stopChan
+	sm.jobsWG.Add(1)
+	return stopChan
+}
+
 // stopSubscriptionJob stops a subscription job if it's running
 func (sm *SubscriptionManager) stopSubscriptionJob(id string) {
 	// Check if job exists
@@ -97,13 +200,18 @@ func (sm *SubscriptionManager) stopSubscriptionJob(id string) {
 	delete(sm.jobs, id)
 }
 
+// GetSubscription returns a copy of the subscription, which stays safe to read while its job runs
 func (sm *SubscriptionManager) GetSubscription(id string) (*FlightSubscription, bool) {
 	sm.mutex.RLock()
 	defer sm.mutex.RUnlock()
 	sub, exists := sm.subscriptions[id]
-	return sub, exists
+	if !exists {
+		return nil, false
+	}
+	return sub.clone(), true
 }
 
+// GetSubscriptionsForChannel returns copies of the channel's subscriptions
 func (sm *SubscriptionManager) GetSubscriptionsForChannel(channelID string) []*FlightSubscription {
 	sm.mutex.RLock()
 	defer sm.mutex.RUnlock()
@@ -111,56 +219,86 @@ func (sm *SubscriptionManager) GetSubscriptionsForChannel(channelID string) []*F
 	var subs []*FlightSubscription
 	for _, sub := range sm.subscriptions {
 		if sub.ChannelID == channelID {
-			subs = append(subs, sub)
+			subs = append(subs, sub.clone())
 		}
 	}
 	return subs
 }
 
+// GetAllSubscriptions returns copies of all subscriptions
 func (sm *SubscriptionManager) GetAllSubscriptions() []*FlightSubscription {
 	sm.mutex.RLock()
 	defer sm.mutex.RUnlock()
 
 	var subs []*FlightSubscription
 	for _, sub := range sm.subscriptions {
-		subs = append(subs, sub)
+		subs = append(subs, sub.clone())
 	}
 	return subs
 }
 
-func (sm *SubscriptionManager) StopAll() {
+// ResetReportedFlights forgets which flights a subscription has posted, so the next update posts every flight
+func (sm *SubscriptionManager) ResetReportedFlights(id string) bool {
 	sm.mutex.Lock()
 	defer sm.mutex.Unlock()
 
-	for id := range sm.jobs {
-		sm.stopSubscriptionJob(id)
+	sub, exists := sm.subscriptions[id]
+	if !exists {
+		return false
 	}
+	sub.ReportedFlights = nil
+	sm.saveSubscriptions()
+	return true
 }
 
-func (sm *SubscriptionManager) startSubscription(sub *FlightSubscription) {
-	// Create and store the stop channel for this job
+// StopAll stops every subscription job and waits for their loops to return
+func (sm *SubscriptionManager) StopAll() {
 	sm.mutex.Lock()
-	stopChan := make(chan struct{})
-	sm.jobs[sub.ID] = stopChan
+	for id := range sm.jobs {
+		sm.stopSubscriptionJob(id)
+	}
 	sm.mutex.Unlock()
 
+	// Wait without the mutex, since an update in progress takes it to save
+	sm.jobsWG.Wait()
+}
+
+// startSubscription runs a job registered with registerJob until its stop channel is closed
+func (sm *SubscriptionManager) startSubscription(sub *FlightSubscription, stopChan chan struct{}) {
+	defer sm.jobsWG.Done()
+
 	ticker := time.NewTicker(time.Duration(sub.UpdateFrequency) * time.Second)
 	defer ticker.Stop()
 
 	fetchAndSendFlights := func() {
 		now := time.Now()
 
-		flights, err := sm.flightService.GetDepartureFlights(sub.Airport)
+		update, err := sm.nextFlightUpdate(sub)
 		if err != nil {
 			sm.client.Log.Error("Failed to fetch flight data for subscription", 
 				"subscription_id", sub.ID, 
 				"airport", sub.Airport, 
+				"mode", sub.FlightMode(),
 				"channel_id", sub.ChannelID, 
 				"error", err.Error())
 			return
 		}
 
-		response := sm.flightService.FormatFlightResponse(flights, sub.Airport)
+		messages := slices.DeleteFunc([]string{update.StatusMessage, update.Response}, func(message string) bool {
+			return message == ""
+		})
+		if len(messages) == 0 {
+			sm.client.Log.Debug("No new flights or status changes for subscription", "subscription_id", sub.ID, "airport", sub.Airport)
+			// Still drop flights that have left the window
+			sm.mutex.Lock()
+			if !slices.Equal(sub.ReportedFlights, update.ReportedFlights) || !maps.Equal(sub.FlightStatuses, update.FlightStatuses) {
+				sub.ReportedFlights = update.ReportedFlights
+				sub.FlightStatuses = update.FlightStatuses
+				sm.saveSubscriptions()
+			}
+			sm.mutex.Unlock()
+			return
+		}
 
 		// Check if channel still exists before sending update
 		if !sm.isChannelValid(sub.ChannelID) {
@@ -169,17 +307,30 @@ func (sm *SubscriptionManager) startSubscription(sub *FlightSubscription) {
 			return
 		}
 
-		if err := sm.messageService.SendPublicMessage(sub.ChannelID, response); err != nil {
-			sm.client.Log.Error("Failed to send flight update to channel", 
-				"subscription_id", sub.ID, 
-				"airport", sub.Airport, 
-				"channel_id", sub.ChannelID, 
-				"error", err.Error())
-			return
+		for _, message := range messages {
+			if err := sm.messageService.SendPublicMessage(sub.ChannelID, message); err != nil {
+				sm.client.Log.Error("Failed to send flight update to channel", 
+					"subscription_id", sub.ID, 
+					"airport", sub.Airport, 
+					"channel_id", sub.ChannelID, 
+					"error", err.Error())
+				return
+			}
 		}
 
+		sm.mutex.Lock()
 		sub.LastUpdated = now
+		sub.ReportedFlights = update.ReportedFlights
+		sub.FlightStatuses = update.FlightStatuses
 		sm.saveSubscriptions()
+		sm.mutex.Unlock()
+	}
+
+	// The subscription may have been removed before this job got to run
+	select {
+	case <-stopChan:
+		return
+	default:
 	}
 
 	fetchAndSendFlights()
@@ -194,6 +345,135 @@ func (sm *SubscriptionManager) startSubscription(sub *FlightSubscription) {
 	}
 }
 
+// flightUpdate is what a subscription tick posts and the state it saves once posted
+type flightUpdate struct {
+	Response        string            // Formatted flights not posted before, "" when there are none
+	StatusMessage   string            // Formatted status changes since the last tick, "" when there are none
+	ReportedFlights []string          // Keys of every flight fetched
+	FlightStatuses  map[string]string // Status of every flight fetched, by flight key
+}
+
+// nextFlightUpdate fetches departures or arrivals depending on the subscription mode, keeping only
+// flights from the subscribed airlines and aircraft types. New flights are formatted into the response, and flights
+// whose status changed since the last tick into the status message.
+func (sm *SubscriptionManager) nextFlightUpdate(sub *FlightSubscription) (*flightUpdate, error) {
+	sm.mutex.RLock()
+	reported := sub.ReportedFlights
+	previousStatuses := sub.FlightStatuses
+	sm.mutex.RUnlock()
+
+	update := &flightUpdate{}
+
+	if sub.FlightMode() == ModeArrivals {
+		flights, err := sm.flightService.GetArrivalFlights(sub.Airport, sub.TimeWindow())
+		if err != nil {
+			return nil, err
+		}
+		fetched := flight.FilterByAircraftType(flight.FilterByAirline(flights.Flights, sub.Airline), sub.AircraftTypeFilter)
+		update.setStatusChanges(sub.Airport, previousStatuses, fetched)
+		flights.Flights, update.ReportedFlights = unreportedFlights(fetched, reported)
+		if len(flights.Flights) > 0 {
+			update.Response = sm.flightService.FormatArrivalResponse(flights, sub.Airport)
+		}
+		return update, nil
+	}
+
+	flights, err := sm.flightService.GetDepartureFlights(sub.Airport, sub.TimeWindow())
+	if err != nil {
+		return nil, err
+	}
+	fetched := flight.FilterByAircraftType(flight.FilterByAirline(flights.Flights, sub.Airline), sub.AircraftTypeFilter)
+	update.setStatusChanges(sub.Airport, previousStatuses, fetched)
+	flights.Flights, update.ReportedFlights = unreportedFlights(fetched, reported)
+	if len(flights.Flights) > 0 {
+		update.Response = sm.flightService.FormatFlightResponse(flights, sub.Airport)
+	}
+	return update, nil
+}
+
+// setStatusChanges records the fetched flight statuses and formats any changes from previous
+func (u *flightUpdate) setStatusChanges(airport string, previous map[string]string, fetched []flight.Flight) {
+	var changes []StatusChange
+	u.FlightStatuses, changes = diffFlightStatuses(previous, fetched)
+	if len(changes) > 0 {
+		u.StatusMessage = formatStatusChanges(airport, changes)
+	}
+}
+
+// unreportedFlights returns the flights whose keys are not in reported, along with the keys of all
+// the given flights. Only keys from the latest fetch are kept, so flights that have left the
+// lookback window are forgotten instead of accumulating.
+func unreportedFlights(flights []flight.Flight, reported []string) ([]flight.Flight, []string) {
+	newFlights := []flight.Flight{}
+	keys := make([]string, 0, len(flights))
+	for _, f := range flights {
+		key := f.Key()
+		keys = append(keys, key)
+		if !slices.Contains(reported, key) {
+			newFlights = append(newFlights, f)
+		}
+	}
+	return newFlights, keys
+}
+
+// StatusChange is a tracked flight whose status differs from the previous fetch
+type StatusChange struct {
+	FlightID string
+	From     string
+	To       string
+}
+
+// diffFlightStatuses returns the status of every fetched flight, by key, and the flights whose status
+// changed since previous, ordered by flight ID. Flights are tracked by key rather than ID because a
+// window longer than a day can hold two flights with the same callsign. Flights seen for the first
+// time are tracked but not reported, and flights that are no longer fetched are forgotten.
+func diffFlightStatuses(previous map[string]string, flights []flight.Flight) (map[string]string, []StatusChange) {
+	statuses := make(map[string]string, len(flights))
+	var changes []StatusChange
+	for _, f := range flights {
+		if f.Status == "" {
+			continue
+		}
+		key := f.Key()
+		statuses[key] = f.Status
+		if from, tracked := previous[key]; tracked && from != f.Status {
+			changes = append(changes, StatusChange{FlightID: f.ID(), From: from, To: f.Status})
+		}
+	}
+
+	slices.SortFunc(changes, func(a, b StatusChange) int {
+		return strings.Compare(a.FlightID, b.FlightID)
+	})
+	return statuses, changes
+}
+
+// formatStatusChanges builds the message posted when tracked flights change status
+func formatStatusChanges(airport string, changes []StatusChange) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("**Flight Status Changes at %s**\n\n", airport))
+	for _, change := range changes {
+		sb.WriteString(fmt.Sprintf("- %s **%s** now **%s** (was %s)\n",
+			statusEmoji(change.To), change.FlightID, strings.ToUpper(change.To), change.From))
+	}
+	return sb.String()
+}
+
+// statusEmoji returns the marker shown next to a status change
+func statusEmoji(status string) string {
+	switch status {
+	case flight.StatusDelayed:
+		return "⚠️"
+	case flight.StatusCancelled:
+		return "❌"
+	case flight.StatusDeparted:
+		return "🛫"
+	case flight.StatusArrived:
+		return "🛬"
+	default:
+		return "ℹ️"
+	}
+}
+
 func (sm *SubscriptionManager) loadSubscriptions() error {
 	var data []byte
 	if appErr := sm.client.KV.Get("flight_subscriptions", &data); appErr != nil {
@@ -214,12 +494,11 @@ func (sm *SubscriptionManager) loadSubscriptions() error {
 
 	sm.mutex.Lock()
 	sm.subscriptions = subs
-	sm.mutex.Unlock()
-
 	// Start subscriptions for all loaded subscriptions
 	for _, sub := range subs {
-		go sm.startSubscription(sub)
+		go sm.startSubscription(sub, sm.registerJob(sub.ID))
 	}
+	sm.mutex.Unlock()
 	
 	sm.client.Log.Info("Successfully loaded subscriptions", "count", len(subs))
 	return nil
@@ -0,0 +1,659 @@
+package subscription
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"runtime"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/coltoneshaw/demokit/flightaware-plugin/server/flight"
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/plugin/plugintest"
+	"github.com/mattermost/mattermost/server/public/pluginapi"
+	"github.com/stretchr/testify/mock"
+)
+
+// fakeFlightService returns the next batch of flights on each fetch and records what was requested and
+// formatted. It is safe for the concurrent fetches of several subscriptions.
+type fakeFlightService struct {
+	mu        sync.Mutex
+	batches   [][]flight.Flight
+	formatted [][]flight.Flight
+	windows   []flight.TimeWindow
+}
+
+func (f *fakeFlightService) nextBatch() []flight.Flight {
+	if len(f.batches) == 0 {
+		return nil
+	}
+	batch := f.batches[0]
+	f.batches = f.batches[1:]
+	return batch
+}
+
+func (f *fakeFlightService) GetDepartureFlights(airport string, window flight.TimeWindow) (*flight.DepartureFlights, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.windows = append(f.windows, window)
+	return &flight.DepartureFlights{Airport: airport, Flights: f.nextBatch()}, nil
+}
+
+func (f *fakeFlightService) GetArrivalFlights(airport string, window flight.TimeWindow) (*flight.ArrivalFlights, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.windows = append(f.windows, window)
+	return &flight.ArrivalFlights{Airport: airport, Flights: f.nextBatch()}, nil
+}
+
+func (f *fakeFlightService) FormatFlightResponse(flights *flight.DepartureFlights, airport string) string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.formatted = append(f.formatted, flights.Flights)
+	return "departures"
+}
+
+func (f *fakeFlightService) FormatArrivalResponse(flights *flight.ArrivalFlights, airport string) string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.formatted = append(f.formatted, flights.Flights)
+	return "arrivals"
+}
+
+func TestNextFlightUpdatePostsEachFlightOnce(t *testing.T) {
+	united := flight.Flight{Callsign: "UAL123  ", FirstSeen: 1000}
+	delta := flight.Flight{Callsign: "DL456", FirstSeen: 2000}
+	american := flight.Flight{Callsign: "AA789", FirstSeen: 3000}
+
+	for _, mode := range []string{ModeDepartures, ModeArrivals} {
+		t.Run(mode, func(t *testing.T) {
+			flightService := &fakeFlightService{batches: [][]flight.Flight{
+				{united, delta},
+				{united, delta},
+				{delta, american},
+			}}
+			sm := &SubscriptionManager{flightService: flightService}
+			sub := &FlightSubscription{ID: "sub1", Airport: "KSFO", Mode: mode}
+
+			// First fetch posts everything
+			update, err := sm.nextFlightUpdate(sub)
+			if err != nil || update.Response == "" {
+				t.Fatalf("Expected first fetch to post, got update %+v and error %v", update, err)
+			}
+			sub.ReportedFlights = update.ReportedFlights
+
+			// The same flights again are not posted
+			update, err = sm.nextFlightUpdate(sub)
+			if err != nil || update.Response != "" {
+				t.Fatalf("Expected repeated flights not to be posted, got update %+v and error %v", update, err)
+			}
+			sub.ReportedFlights = update.ReportedFlights
+
+			// Only the newly seen flight is posted
+			update, err = sm.nextFlightUpdate(sub)
+			if err != nil || update.Response == "" {
+				t.Fatalf("Expected new flight to be posted, got update %+v and error %v", update, err)
+			}
+			keys := update.ReportedFlights
+
+			if len(flightService.formatted) != 2 {
+				t.Fatalf("Expected 2 formatted updates, got %d", len(flightService.formatted))
+			}
+			if posted := flightService.formatted[1]; len(posted) != 1 || posted[0].Callsign != american.Callsign {
+				t.Errorf("Expected only %s in the second update, got %+v", american.Callsign, posted)
+			}
+			if len(keys) != 2 || keys[0] != delta.Key() || keys[1] != american.Key() {
+				t.Errorf("Expected reported keys to cover only the latest fetch, got %v", keys)
+			}
+		})
+	}
+}
+
+func TestNextFlightUpdateDeduplicatesGeneratedFlights(t *testing.T) {
+	flightService, err := flight.NewFlightService("../..")
+	if err != nil {
+		t.Fatalf("Failed to create flight service: %v", err)
+	}
+
+	for _, mode := range []string{ModeDepartures, ModeArrivals} {
+		t.Run(mode, func(t *testing.T) {
+			sm := &SubscriptionManager{flightService: flightService}
+			sub := &FlightSubscription{ID: "sub1", Airport: "KSFO", Mode: mode, LookbackWindow: 24 * 3600}
+
+			update, err := sm.nextFlightUpdate(sub)
+			if err != nil || update.Response == "" {
+				t.Fatalf("Expected first fetch to post, got update %+v and error %v", update, err)
+			}
+			sub.ReportedFlights = update.ReportedFlights
+
+			update, err = sm.nextFlightUpdate(sub)
+			if err != nil {
+				t.Fatalf("nextFlightUpdate returned error: %v", err)
+			}
+			if update.Response != "" {
+				t.Errorf("Expected the second fetch to post nothing, got %q", update.Response)
+			}
+		})
+	}
+}
+
+func TestNextFlightUpdateReportsNoStatusChangesForGeneratedFlights(t *testing.T) {
+	flightService, err := flight.NewFlightService("../..")
+	if err != nil {
+		t.Fatalf("Failed to create flight service: %v", err)
+	}
+
+	sm := &SubscriptionManager{flightService: flightService}
+	sub := &FlightSubscription{ID: "sub1", Airport: "KSFO", LookbackWindow: 24 * 3600, LookaheadWindow: 12 * 3600}
+
+	update, err := sm.nextFlightUpdate(sub)
+	if err != nil || len(update.FlightStatuses) == 0 {
+		t.Fatalf("Expected first fetch to track statuses, got update %+v and error %v", update, err)
+	}
+	sub.FlightStatuses = update.FlightStatuses
+
+	update, err = sm.nextFlightUpdate(sub)
+	if err != nil {
+		t.Fatalf("nextFlightUpdate returned error: %v", err)
+	}
+	if update.StatusMessage != "" {
+		t.Errorf("Expected no status changes between back-to-back fetches, got %q", update.StatusMessage)
+	}
+}
+
+func TestDiffFlightStatuses(t *testing.T) {
+	testCases := []struct {
+		name             string
+		previous         map[string]string
+		flights          []flight.Flight
+		expectedStatuses map[string]string
+		expectedChanges  []StatusChange
+	}{
+		{
+			name:             "first fetch tracks without reporting",
+			previous:         nil,
+			flights:          []flight.Flight{{Callsign: "UAL123  ", Status: flight.StatusDeparted}},
+			expectedStatuses: map[string]string{"UAL123@0": flight.StatusDeparted},
+		},
+		{
+			name:             "unchanged status is not reported",
+			previous:         map[string]string{"UAL123@0": flight.StatusDeparted},
+			flights:          []flight.Flight{{Callsign: "UAL123", Status: flight.StatusDeparted}},
+			expectedStatuses: map[string]string{"UAL123@0": flight.StatusDeparted},
+		},
+		{
+			name:     "changes are reported in flight order",
+			previous: map[string]string{"UAL123@0": flight.StatusScheduled, "DL456@0": flight.StatusScheduled},
+			flights: []flight.Flight{
+				{Callsign: "UAL123", Status: flight.StatusCancelled},
+				{Callsign: "DL456", Status: flight.StatusDelayed},
+			},
+			expectedStatuses: map[string]string{"UAL123@0": flight.StatusCancelled, "DL456@0": flight.StatusDelayed},
+			expectedChanges: []StatusChange{
+				{FlightID: "DL456", From: flight.StatusScheduled, To: flight.StatusDelayed},
+				{FlightID: "UAL123", From: flight.StatusScheduled, To: flight.StatusCancelled},
+			},
+		},
+		{
+			name:             "flights no longer fetched are forgotten",
+			previous:         map[string]string{"UAL123@0": flight.StatusDeparted, "AA789@0": flight.StatusDelayed},
+			flights:          []flight.Flight{{Callsign: "AA789", Status: flight.StatusDelayed}, {Callsign: "N12345"}},
+			expectedStatuses: map[string]string{"AA789@0": flight.StatusDelayed},
+		},
+		{
+			name:     "flights sharing a callsign on different days are tracked apart",
+			previous: map[string]string{"UAL123@1000": flight.StatusDeparted, "UAL123@87400": flight.StatusScheduled},
+			flights: []flight.Flight{
+				{Callsign: "UAL123", FirstSeen: 1000, Status: flight.StatusDeparted},
+				{Callsign: "UAL123", FirstSeen: 87400, Status: flight.StatusScheduled},
+			},
+			expectedStatuses: map[string]string{"UAL123@1000": flight.StatusDeparted, "UAL123@87400": flight.StatusScheduled},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			statuses, changes := diffFlightStatuses(tc.previous, tc.flights)
+			if !maps.Equal(statuses, tc.expectedStatuses) {
+				t.Errorf("Expected statuses %v, got %v", tc.expectedStatuses, statuses)
+			}
+			if !slices.Equal(changes, tc.expectedChanges) {
+				t.Errorf("Expected changes %+v, got %+v", tc.expectedChanges, changes)
+			}
+		})
+	}
+}
+
+func TestNextFlightUpdateReportsStatusChanges(t *testing.T) {
+	flightService := &fakeFlightService{batches: [][]flight.Flight{
+		{{Callsign: "UAL123", FirstSeen: 1000, Status: flight.StatusDeparted}},
+		{{Callsign: "UAL123", FirstSeen: 1000, Status: flight.StatusDelayed}},
+	}}
+	sm := &SubscriptionManager{flightService: flightService}
+	sub := &FlightSubscription{ID: "sub1", Airport: "KSFO", Mode: ModeDepartures}
+
+	update, err := sm.nextFlightUpdate(sub)
+	if err != nil || update.StatusMessage != "" {
+		t.Fatalf("Expected no status changes on the first fetch, got update %+v and error %v", update, err)
+	}
+	sub.ReportedFlights = update.ReportedFlights
+	sub.FlightStatuses = update.FlightStatuses
+
+	update, err = sm.nextFlightUpdate(sub)
+	if err != nil {
+		t.Fatalf("nextFlightUpdate returned error: %v", err)
+	}
+	if update.Response != "" {
+		t.Errorf("Expected the already posted flight not to be posted again, got %q", update.Response)
+	}
+	if !strings.Contains(update.StatusMessage, "**UAL123** now **DELAYED** (was departed)") {
+		t.Errorf("Expected a delay notice for UAL123, got %q", update.StatusMessage)
+	}
+}
+
+func TestNextFlightUpdateFiltersAircraftTypes(t *testing.T) {
+	flightService := &fakeFlightService{batches: [][]flight.Flight{{
+		{Callsign: "UAL123", FirstSeen: 1000, AircraftType: "B737"},
+		{Callsign: "DL456", FirstSeen: 2000, AircraftType: "A321"},
+		{Callsign: "JB202", FirstSeen: 3000, AircraftType: "A320"},
+	}}}
+	sm := &SubscriptionManager{flightService: flightService}
+	sub := &FlightSubscription{ID: "sub1", Airport: "KSFO", Mode: ModeDepartures, AircraftTypeFilter: []string{"b737", "A320"}}
+
+	update, err := sm.nextFlightUpdate(sub)
+	if err != nil {
+		t.Fatalf("nextFlightUpdate returned error: %v", err)
+	}
+	if len(flightService.formatted) != 1 || len(flightService.formatted[0]) != 2 {
+		t.Fatalf("Expected 2 flights to be posted, got %+v", flightService.formatted)
+	}
+	if len(update.ReportedFlights) != 2 {
+		t.Errorf("Expected only the matching flights to be tracked, got %v", update.ReportedFlights)
+	}
+}
+
+func TestCheckSubscriptionLimits(t *testing.T) {
+	testCases := []struct {
+		name           string
+		frequencies    []int64
+		frequency      int64
+		dailyLimit     int
+		expectedPeriod string
+	}{
+		{
+			name:       "no existing subscriptions",
+			frequency:  300,
+			dailyLimit: DefaultDailyLimit,
+		},
+		{
+			name:        "exactly at the hourly limit",
+			frequencies: []int64{300, 300},
+			frequency:   300,
+			dailyLimit:  864,
+		},
+		{
+			name:           "over the hourly limit",
+			frequencies:    []int64{300, 300, 300},
+			frequency:      300,
+			dailyLimit:     DefaultDailyLimit,
+			expectedPeriod: "hour",
+		},
+		{
+			name:           "over the daily limit",
+			frequencies:    []int64{300, 300},
+			frequency:      300,
+			dailyLimit:     800,
+			expectedPeriod: "day",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sm := &SubscriptionManager{
+				subscriptions: make(map[string]*FlightSubscription),
+				hourlyLimit:   36,
+				dailyLimit:    tc.dailyLimit,
+			}
+			for i, frequency := range tc.frequencies {
+				id := fmt.Sprintf("sub%d", i)
+				sm.subscriptions[id] = &FlightSubscription{ID: id, UpdateFrequency: frequency}
+			}
+
+			err := sm.CheckSubscriptionLimits("KJFK", tc.frequency)
+			if tc.expectedPeriod == "" {
+				if err != nil {
+					t.Fatalf("Expected subscription to be allowed, got %v", err)
+				}
+				return
+			}
+
+			var limitErr *LimitExceededError
+			if !errors.As(err, &limitErr) {
+				t.Fatalf("Expected a LimitExceededError, got %v", err)
+			}
+			if limitErr.Period != tc.expectedPeriod {
+				t.Errorf("Expected the %s limit to be exceeded, got %s", tc.expectedPeriod, limitErr.Period)
+			}
+		})
+	}
+}
+
+func TestCountSubscriptionsForLocation(t *testing.T) {
+	sm := &SubscriptionManager{
+		subscriptions: map[string]*FlightSubscription{
+			"sub1": {ID: "sub1", Airport: "KJFK", ChannelID: "channel1"},
+			"sub2": {ID: "sub2", Airport: "KJFK", ChannelID: "channel2"},
+			"sub3": {ID: "sub3", Airport: "kjfk", ChannelID: "channel3"},
+			"sub4": {ID: "sub4", Airport: "KLAX", ChannelID: "channel1"},
+		},
+	}
+
+	testCases := map[string]int{
+		"KJFK": 3,
+		"KLAX": 1,
+		"KSFO": 0,
+	}
+	for location, expected := range testCases {
+		if count := sm.CountSubscriptionsForLocation(location); count != expected {
+			t.Errorf("Expected %d subscriptions for %s, got %d", expected, location, count)
+		}
+	}
+}
+
+func TestCheckSubscriptionLimitsPerLocation(t *testing.T) {
+	sm := &SubscriptionManager{
+		subscriptions: map[string]*FlightSubscription{
+			"sub1": {ID: "sub1", Airport: "KJFK", UpdateFrequency: 3600},
+			"sub2": {ID: "sub2", Airport: "KJFK", UpdateFrequency: 3600},
+			"sub3": {ID: "sub3", Airport: "KLAX", UpdateFrequency: 3600},
+		},
+		hourlyLimit:                 DefaultHourlyLimit,
+		dailyLimit:                  DefaultDailyLimit,
+		MaxSubscriptionsPerLocation: 2,
+	}
+
+	var locationErr *LocationLimitError
+	if err := sm.CheckSubscriptionLimits("KJFK", 3600); !errors.As(err, &locationErr) || locationErr.Limit != 2 {
+		t.Errorf("Expected a LocationLimitError with limit 2 for KJFK, got %v", err)
+	}
+	if err := sm.CheckSubscriptionLimits("KLAX", 3600); err != nil {
+		t.Errorf("Expected a second KLAX subscription to be allowed, got %v", err)
+	}
+
+	// Zero uses the default cap
+	sm.MaxSubscriptionsPerLocation = 0
+	if err := sm.CheckSubscriptionLimits("KJFK", 3600); err != nil {
+		t.Errorf("Expected the default cap of %d to allow a third KJFK subscription, got %v", DefaultMaxSubscriptionsPerLocation, err)
+	}
+}
+
+func TestGetSubscriptionStats(t *testing.T) {
+	sm := &SubscriptionManager{
+		subscriptions: map[string]*FlightSubscription{
+			"sub1": {ID: "sub1", Airport: "KJFK", ChannelID: "channel1", UserID: "user1"},
+			"sub2": {ID: "sub2", Airport: "KJFK", ChannelID: "channel2", UserID: "user1"},
+			"sub3": {ID: "sub3", Airport: "KLAX", ChannelID: "channel1", UserID: "user2"},
+			"sub4": {ID: "sub4", Airport: "kjfk", ChannelID: "channel1", UserID: "user3"},
+		},
+		jobs: map[string]chan struct{}{
+			"sub1": make(chan struct{}),
+			"sub2": make(chan struct{}),
+			"sub3": make(chan struct{}),
+		},
+	}
+
+	stats := sm.GetSubscriptionStats()
+
+	expected := SubscriptionStats{
+		ByLocation:  map[string]int{"KJFK": 3, "KLAX": 1},
+		ByChannel:   map[string]int{"channel1": 3, "channel2": 1},
+		ByUser:      map[string]int{"user1": 2, "user2": 1, "user3": 1},
+		TotalActive: 3,
+		TotalPaused: 1,
+	}
+	if !maps.Equal(stats.ByLocation, expected.ByLocation) {
+		t.Errorf("Expected counts by location %v, got %v", expected.ByLocation, stats.ByLocation)
+	}
+	if !maps.Equal(stats.ByChannel, expected.ByChannel) {
+		t.Errorf("Expected counts by channel %v, got %v", expected.ByChannel, stats.ByChannel)
+	}
+	if !maps.Equal(stats.ByUser, expected.ByUser) {
+		t.Errorf("Expected counts by user %v, got %v", expected.ByUser, stats.ByUser)
+	}
+	if stats.TotalActive != expected.TotalActive || stats.TotalPaused != expected.TotalPaused {
+		t.Errorf("Expected %d active and %d paused, got %d active and %d paused",
+			expected.TotalActive, expected.TotalPaused, stats.TotalActive, stats.TotalPaused)
+	}
+}
+
+func TestNextFlightUpdateUsesSubscriptionWindow(t *testing.T) {
+	testCases := []struct {
+		name     string
+		sub      *FlightSubscription
+		expected flight.TimeWindow
+	}{
+		{
+			name:     "saved before windows existed",
+			sub:      &FlightSubscription{ID: "sub1", Airport: "KSFO"},
+			expected: flight.TimeWindow{Lookback: flight.DefaultLookbackWindow},
+		},
+		{
+			name:     "lookback and lookahead",
+			sub:      &FlightSubscription{ID: "sub2", Airport: "KSFO", Mode: ModeArrivals, LookbackWindow: 7200, LookaheadWindow: 43200},
+			expected: flight.TimeWindow{Lookback: 2 * time.Hour, Lookahead: 12 * time.Hour},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			flightService := &fakeFlightService{batches: [][]flight.Flight{{}}}
+			sm := &SubscriptionManager{flightService: flightService}
+
+			if _, err := sm.nextFlightUpdate(tc.sub); err != nil {
+				t.Fatalf("nextFlightUpdate returned error: %v", err)
+			}
+			if len(flightService.windows) != 1 || flightService.windows[0] != tc.expected {
+				t.Errorf("Expected fetch window %+v, got %+v", tc.expected, flightService.windows)
+			}
+		})
+	}
+}
+
+// testAPI wraps the plugin API mock and ignores log calls
+type testAPI struct {
+	*plugintest.API
+}
+
+func (a *testAPI) LogDebug(msg string, keyValuePairs ...any) {}
+func (a *testAPI) LogInfo(msg string, keyValuePairs ...any)  {}
+func (a *testAPI) LogWarn(msg string, keyValuePairs ...any)  {}
+func (a *testAPI) LogError(msg string, keyValuePairs ...any) {}
+
+// fakeMessageService accepts every message
+type fakeMessageService struct{}
+
+func (fakeMessageService) SendPublicMessage(channelID, message string) error { return nil }
+func (fakeMessageService) GetBotUserID() string                              { return "bot1" }
+
+func TestGetSubscriptionsDoNotRaceWithUpdates(t *testing.T) {
+	api := &plugintest.API{}
+	api.On("KVSetWithOptions", "flight_subscriptions", mock.Anything, mock.Anything).Return(true, nil)
+	api.On("GetChannel", mock.Anything).Return(&model.Channel{Id: "channel1"}, nil)
+
+	now := time.Now().Unix()
+	sm := &SubscriptionManager{
+		client:         pluginapi.NewClient(&testAPI{API: api}, nil),
+		flightService:  &fakeFlightService{batches: [][]flight.Flight{{{Callsign: "UAL1", FirstSeen: now - 60}}}},
+		messageService: fakeMessageService{},
+		subscriptions:  make(map[string]*FlightSubscription),
+		jobs:           make(map[string]chan struct{}),
+		hourlyLimit:    DefaultHourlyLimit,
+		dailyLimit:     DefaultDailyLimit,
+	}
+
+	if err := sm.AddSubscription(&FlightSubscription{ID: "sub1", Airport: "KSFO", ChannelID: "channel1", UpdateFrequency: 300}); err != nil {
+		t.Fatalf("AddSubscription returned error: %v", err)
+	}
+
+	// Read the subscriptions the way the list commands do while the first update records its time
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		subs := sm.GetAllSubscriptions()
+		if len(subs) == 1 && !subs[0].LastUpdated.IsZero() {
+			break
+		}
+		if sub, exists := sm.GetSubscription("sub1"); exists {
+			_ = sub.LastUpdated.String()
+		}
+		time.Sleep(time.Millisecond)
+	}
+	sm.StopAll()
+
+	if sub, _ := sm.GetSubscription("sub1"); sub.LastUpdated.IsZero() {
+		t.Error("Expected the first update to record its time")
+	}
+}
+
+func TestStopAllWaitsForSubscriptionJobs(t *testing.T) {
+	api := &plugintest.API{}
+	api.On("KVSetWithOptions", "flight_subscriptions", mock.Anything, mock.Anything).Return(true, nil)
+
+	sm := &SubscriptionManager{
+		client:        pluginapi.NewClient(&testAPI{API: api}, nil),
+		flightService: &fakeFlightService{},
+		subscriptions: make(map[string]*FlightSubscription),
+		jobs:          make(map[string]chan struct{}),
+		hourlyLimit:   DefaultHourlyLimit,
+		dailyLimit:    DefaultDailyLimit,
+	}
+
+	before := runtime.NumGoroutine()
+	for i := range 5 {
+		sub := &FlightSubscription{ID: fmt.Sprintf("sub%d", i), Airport: "KSFO", UpdateFrequency: 3600}
+		if err := sm.AddSubscription(sub); err != nil {
+			t.Fatalf("AddSubscription returned error: %v", err)
+		}
+	}
+
+	sm.StopAll()
+
+	if len(sm.jobs) != 0 {
+		t.Errorf("Expected no jobs after StopAll, got %d", len(sm.jobs))
+	}
+
+	// Loops have returned once StopAll does; allow a moment for the goroutines themselves to exit
+	deadline := time.Now().Add(time.Second)
+	for runtime.NumGoroutine() > before && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if after := runtime.NumGoroutine(); after > before {
+		t.Errorf("Expected no lingering subscription goroutines, had %d before and %d after StopAll", before, after)
+	}
+}
+
+func TestAddSubscriptionRejectsExistingID(t *testing.T) {
+	api := &plugintest.API{}
+	api.On("KVSetWithOptions", "flight_subscriptions", mock.Anything, mock.Anything).Return(true, nil)
+
+	sm := &SubscriptionManager{
+		client:        pluginapi.NewClient(&testAPI{API: api}, nil),
+		flightService: &fakeFlightService{},
+		subscriptions: make(map[string]*FlightSubscription),
+		jobs:          make(map[string]chan struct{}),
+		hourlyLimit:   DefaultHourlyLimit,
+		dailyLimit:    DefaultDailyLimit,
+	}
+	t.Cleanup(sm.StopAll)
+
+	if err := sm.AddSubscription(&FlightSubscription{ID: "sub1", Airport: "KSFO", UpdateFrequency: 300}); err != nil {
+		t.Fatalf("AddSubscription returned error: %v", err)
+	}
+	if err := sm.AddSubscription(&FlightSubscription{ID: "sub1", Airport: "KLAX", UpdateFrequency: 300}); !errors.Is(err, ErrSubscriptionExists) {
+		t.Fatalf("Expected ErrSubscriptionExists, got %v", err)
+	}
+	if sub, _ := sm.GetSubscription("sub1"); sub.Airport != "KSFO" {
+		t.Errorf("Expected the original subscription to be kept, got %+v", sub)
+	}
+}
+
+func TestAddSubscriptionEnforcesLocationLimitConcurrently(t *testing.T) {
+	api := &plugintest.API{}
+	api.On("KVSetWithOptions", "flight_subscriptions", mock.Anything, mock.Anything).Return(true, nil)
+
+	sm := &SubscriptionManager{
+		client:                      pluginapi.NewClient(&testAPI{API: api}, nil),
+		flightService:               &fakeFlightService{},
+		subscriptions:               make(map[string]*FlightSubscription),
+		jobs:                        make(map[string]chan struct{}),
+		hourlyLimit:                 DefaultHourlyLimit,
+		dailyLimit:                  DefaultDailyLimit,
+		MaxSubscriptionsPerLocation: 2,
+	}
+	t.Cleanup(sm.StopAll)
+
+	var added atomic.Int32
+	var wg sync.WaitGroup
+	for i := range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := sm.AddSubscription(&FlightSubscription{ID: fmt.Sprintf("sub%d", i), Airport: "KSFO", UpdateFrequency: 300})
+			var locationErr *LocationLimitError
+			switch {
+			case err == nil:
+				added.Add(1)
+			case !errors.As(err, &locationErr):
+				t.Errorf("Expected a LocationLimitError, got %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := added.Load(); got != 2 {
+		t.Errorf("Expected 2 subscriptions to be added, got %d", got)
+	}
+}
+
+func TestAddSubscriptionEnforcesUsageLimitConcurrently(t *testing.T) {
+	api := &plugintest.API{}
+	api.On("KVSetWithOptions", "flight_subscriptions", mock.Anything, mock.Anything).Return(true, nil)
+
+	// Each subscription makes 12 calls an hour, so three fit under the hourly limit
+	sm := &SubscriptionManager{
+		client:                      pluginapi.NewClient(&testAPI{API: api}, nil),
+		flightService:               &fakeFlightService{},
+		subscriptions:               make(map[string]*FlightSubscription),
+		jobs:                        make(map[string]chan struct{}),
+		hourlyLimit:                 36,
+		dailyLimit:                  DefaultDailyLimit,
+		MaxSubscriptionsPerLocation: 100,
+	}
+	t.Cleanup(sm.StopAll)
+
+	var added atomic.Int32
+	var wg sync.WaitGroup
+	for i := range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := sm.AddSubscription(&FlightSubscription{ID: fmt.Sprintf("sub%d", i), Airport: fmt.Sprintf("K%03d", i), UpdateFrequency: 300})
+			var limitErr *LimitExceededError
+			switch {
+			case err == nil:
+				added.Add(1)
+			case !errors.As(err, &limitErr):
+				t.Errorf("Expected a LimitExceededError, got %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := added.Load(); got != 3 {
+		t.Errorf("Expected 3 subscriptions to be added, got %d", got)
+	}
+}
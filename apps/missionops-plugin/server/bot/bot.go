@@ -1,6 +1,7 @@
 package bot
 
 import (
+	"bytes"
 	"fmt"
 
 	"github.com/mattermost/mattermost/server/public/model"
@@ -21,6 +22,8 @@ type MissionBot struct {
 type BotInterface interface {
 	// SendBotDM sends a direct message from the bot to a user
 	PostMessageFromBot(channelID, message string) (*model.Post, error)
+	// SendPostWithAttachment uploads a file to a channel and posts it from the bot with a message
+	SendPostWithAttachment(channelID, message, fileName string, data []byte) (*model.Post, error)
 	// GetBotToken returns the bot token
 	GetBotToken() string
 	// GetBotUserID returns the bot's user ID
@@ -91,6 +94,26 @@ func (b *MissionBot) PostMessageFromBot(channelID, message string) (*model.Post,
 	return post, nil
 }
 
+// SendPostWithAttachment uploads data as fileName to a channel and posts it from the bot
+func (b *MissionBot) SendPostWithAttachment(channelID, message, fileName string, data []byte) (*model.Post, error) {
+	fileInfo, err := b.client.File.Upload(bytes.NewReader(data), fileName, channelID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to upload file")
+	}
+
+	post := &model.Post{
+		UserId:    b.Bot.UserId,
+		ChannelId: channelID,
+		Message:   message,
+		FileIds:   model.StringArray{fileInfo.Id},
+	}
+	if err := b.client.Post.CreatePost(post); err != nil {
+		return nil, errors.Wrap(err, "failed to create post")
+	}
+
+	return post, nil
+}
+
 // GetBotUserInfo returns the bot's user ID
 func (b *MissionBot) GetBotUserInfo() *model.Bot {
 	return b.Bot
@@ -8,6 +8,7 @@ import (
 	"github.com/coltoneshaw/demokit/missionops-plugin/server/bot"
 	"github.com/coltoneshaw/demokit/missionops-plugin/server/mission"
 	"github.com/coltoneshaw/demokit/missionops-plugin/server/subscription"
+	"github.com/coltoneshaw/demokit/missionops-plugin/server/template"
 
 	"github.com/mattermost/mattermost/server/public/model"
 	"github.com/mattermost/mattermost/server/public/pluginapi"
@@ -18,6 +19,7 @@ type Handler struct {
 	mission      mission.MissionInterface
 	bot          bot.BotInterface
 	subscription subscription.SubscriptionInterface
+	template     template.TemplateInterface
 }
 
 type Command interface {
@@ -27,22 +29,29 @@ type Command interface {
 	executeMissionListCommand(args *model.CommandArgs) (*model.CommandResponse, error)
 	executeMissionStatusCommand(args *model.CommandArgs) (*model.CommandResponse, error)
 	executeMissionCompleteCommand(args *model.CommandArgs) (*model.CommandResponse, error)
+	executeMissionTimelineCommand(args *model.CommandArgs) (*model.CommandResponse, error)
+	executeMissionArchiveCommand(args *model.CommandArgs) (*model.CommandResponse, error)
+	executeMissionReportCommand(args *model.CommandArgs) (*model.CommandResponse, error)
+	executeMissionReopenCommand(args *model.CommandArgs) (*model.CommandResponse, error)
+	executeMissionCrewCommand(args *model.CommandArgs) (*model.CommandResponse, error)
+	executeMissionTemplateCommand(args *model.CommandArgs) (*model.CommandResponse, error)
 	executeMissionSubscribeCommand(args *model.CommandArgs) (*model.CommandResponse, error)
 	executeMissionUnsubscribeCommand(args *model.CommandArgs) (*model.CommandResponse, error)
 	executeMissionSubscriptionsCommand(args *model.CommandArgs) (*model.CommandResponse, error)
 	HandleMissionComplete(w http.ResponseWriter, r *http.Request)
+	HandleMissionStart(w http.ResponseWriter, r *http.Request)
 }
 
 const helloCommandTrigger = "hello"
 
 // Register all your slash commands in the NewCommandHandler function.
-func NewCommandHandler(client *pluginapi.Client, mission mission.MissionInterface, bot bot.BotInterface, subscription subscription.SubscriptionInterface) Command {
+func NewCommandHandler(client *pluginapi.Client, mission mission.MissionInterface, bot bot.BotInterface, subscription subscription.SubscriptionInterface, template template.TemplateInterface) Command {
 	err := client.SlashCommand.Register(&model.Command{
 		Trigger:          "mission",
 		Description:      "Mission Operations Commands",
 		DisplayName:      "Mission Ops",
 		AutoComplete:     true,
-		AutoCompleteDesc: "Available commands: start, list, status, complete, subscribe, unsubscribe, subscriptions",
+		AutoCompleteDesc: "Available commands: start, list, status, crew, complete, timeline, archive, reopen, report, template, subscribe, unsubscribe, subscriptions",
 		AutoCompleteHint: "[command]",
 		AutocompleteData: &model.AutocompleteData{
 			Trigger:  "mission",
@@ -101,11 +110,73 @@ func NewCommandHandler(client *pluginapi.Client, mission mission.MissionInterfac
 							HelpText: "Crew members (space-separated usernames)",
 							Required: false,
 						},
+						{
+							Type: model.AutocompleteArgTypeText,
+							Data: &model.AutocompleteTextArg{
+								Hint: "[template]",
+							},
+							Name:     "template",
+							HelpText: "Mission template to fill in the callsign and airports not given",
+							Required: false,
+						},
+						{
+							Type: model.AutocompleteArgTypeStaticList,
+							Data: &model.AutocompleteStaticListArg{
+								PossibleArguments: []model.AutocompleteListItem{
+									{
+										Item:     "high",
+										HelpText: "🔴 Listed first",
+									},
+									{
+										Item:     "medium",
+										HelpText: "🟡 The default",
+									},
+									{
+										Item:     "low",
+										HelpText: "🟢 Listed last",
+									},
+								},
+							},
+							Name:     "priority",
+							HelpText: "Mission priority, which orders /mission list",
+							Required: false,
+						},
+						{
+							Type: model.AutocompleteArgTypeText,
+							Data: &model.AutocompleteTextArg{
+								Hint: "[tag]",
+							},
+							Name:     "tag",
+							HelpText: "Label for filtering /mission list; repeat the flag or separate tags with commas",
+							Required: false,
+						},
 					},
 				},
 				{
 					Trigger:  "list",
 					HelpText: "List all missions",
+					Arguments: []*model.AutocompleteArg{
+						{
+							Type: model.AutocompleteArgTypeStaticList,
+							Data: &model.AutocompleteStaticListArg{
+								PossibleArguments: []model.AutocompleteListItem{
+									{
+										Item:     "--status",
+										HelpText: "(optional) Only list missions with this status (stalled, in-air, completed, cancelled)",
+									},
+									{
+										Item:     "--tag",
+										HelpText: "(optional) Only list missions with this tag",
+									},
+									{
+										Item:     "--all",
+										HelpText: "(optional) Include archived missions",
+									},
+								},
+							},
+							Required: false,
+						},
+					},
 				},
 				{
 					Trigger:  "status",
@@ -147,6 +218,39 @@ func NewCommandHandler(client *pluginapi.Client, mission mission.MissionInterfac
 						},
 					},
 				},
+				{
+					Trigger:  "crew",
+					HelpText: "Add or remove mission crew members",
+					Arguments: []*model.AutocompleteArg{
+						{
+							Type: model.AutocompleteArgTypeText,
+							Data: &model.AutocompleteTextArg{
+								Hint: "@user1 @user2 ...",
+							},
+							Name:     "add",
+							HelpText: "Crew members to add to the mission and its channel",
+							Required: false,
+						},
+						{
+							Type: model.AutocompleteArgTypeText,
+							Data: &model.AutocompleteTextArg{
+								Hint: "@user1 @user2 ...",
+							},
+							Name:     "remove",
+							HelpText: "Crew members to remove from the mission and its channel",
+							Required: false,
+						},
+						{
+							Type: model.AutocompleteArgTypeText,
+							Data: &model.AutocompleteTextArg{
+								Hint: "[mission-id]",
+							},
+							Name:     "id",
+							HelpText: "Mission ID (required if not in a mission channel)",
+							Required: false,
+						},
+					},
+				},
 				{
 					Trigger:  "complete",
 					HelpText: "Fill out a post-mission report",
@@ -162,6 +266,157 @@ func NewCommandHandler(client *pluginapi.Client, mission mission.MissionInterfac
 						},
 					},
 				},
+				{
+					Trigger:  "timeline",
+					HelpText: "Show a mission's status change history",
+					Arguments: []*model.AutocompleteArg{
+						{
+							Type: model.AutocompleteArgTypeText,
+							Data: &model.AutocompleteTextArg{
+								Hint: "[mission-id]",
+							},
+							Name:     "id",
+							HelpText: "Mission ID (required if not in a mission channel)",
+							Required: false,
+						},
+					},
+				},
+				{
+					Trigger:  "archive",
+					HelpText: "Hide a completed or cancelled mission from the mission list",
+					Arguments: []*model.AutocompleteArg{
+						{
+							Type: model.AutocompleteArgTypeText,
+							Data: &model.AutocompleteTextArg{
+								Hint: "[mission-id]",
+							},
+							Name:     "id",
+							HelpText: "Mission ID (required if not in a mission channel)",
+							Required: false,
+						},
+					},
+				},
+				{
+					Trigger:  "reopen",
+					HelpText: "Move a completed or cancelled mission back to an active status",
+					Arguments: []*model.AutocompleteArg{
+						{
+							Type: model.AutocompleteArgTypeStaticList,
+							Data: &model.AutocompleteStaticListArg{
+								PossibleArguments: []model.AutocompleteListItem{
+									{
+										Item:     "stalled",
+										HelpText: "Mission is not active",
+									},
+									{
+										Item:     "in-air",
+										HelpText: "Mission is in progress",
+									},
+								},
+							},
+							Name:     "status",
+							HelpText: "Status to reopen the mission with (default: stalled)",
+							Required: false,
+						},
+						{
+							Type: model.AutocompleteArgTypeText,
+							Data: &model.AutocompleteTextArg{
+								Hint: "[mission-id]",
+							},
+							Name:     "id",
+							HelpText: "Mission ID (required if not in a mission channel)",
+							Required: false,
+						},
+					},
+				},
+				{
+					Trigger:  "report",
+					HelpText: "Export a completed mission's post-mission report as a file",
+					Arguments: []*model.AutocompleteArg{
+						{
+							Type: model.AutocompleteArgTypeStaticList,
+							Data: &model.AutocompleteStaticListArg{
+								PossibleArguments: []model.AutocompleteListItem{
+									{
+										Item:     "pdf",
+										HelpText: "PDF document",
+									},
+									{
+										Item:     "csv",
+										HelpText: "Spreadsheet-friendly CSV",
+									},
+								},
+							},
+							Name:     "format",
+							HelpText: "File format (default: pdf)",
+							Required: false,
+						},
+						{
+							Type: model.AutocompleteArgTypeText,
+							Data: &model.AutocompleteTextArg{
+								Hint: "[mission-id]",
+							},
+							Name:     "id",
+							HelpText: "Mission ID (required if not in a mission channel)",
+							Required: false,
+						},
+					},
+				},
+				{
+					Trigger:  "template",
+					HelpText: "Save or list mission templates for repeated sortie types",
+					SubCommands: []*model.AutocompleteData{
+						{
+							Trigger:  "save",
+							HelpText: "Save a mission template, replacing one with the same name",
+							Arguments: []*model.AutocompleteArg{
+								{
+									Type: model.AutocompleteArgTypeText,
+									Data: &model.AutocompleteTextArg{
+										Hint: "[name]",
+									},
+									Name:     "name",
+									HelpText: "Template name",
+									Required: true,
+								},
+								{
+									Type: model.AutocompleteArgTypeText,
+									Data: &model.AutocompleteTextArg{
+										Hint:    "[prefix]",
+										Pattern: "^[a-zA-Z0-9-_]+$",
+									},
+									Name:     "callsign-prefix",
+									HelpText: "Callsign prefix, numbered for each mission started from the template",
+									Required: false,
+								},
+								{
+									Type: model.AutocompleteArgTypeText,
+									Data: &model.AutocompleteTextArg{
+										Hint:    "[airport]",
+										Pattern: "^[A-Z]{3,4}$",
+									},
+									Name:     "departureAirport",
+									HelpText: "Departure airport code",
+									Required: false,
+								},
+								{
+									Type: model.AutocompleteArgTypeText,
+									Data: &model.AutocompleteTextArg{
+										Hint:    "[airport]",
+										Pattern: "^[A-Z]{3,4}$",
+									},
+									Name:     "arrivalAirport",
+									HelpText: "Arrival airport code",
+									Required: false,
+								},
+							},
+						},
+						{
+							Trigger:  "list",
+							HelpText: "List saved mission templates",
+						},
+					},
+				},
 				{
 					Trigger:  "subscribe",
 					HelpText: "Subscribe to mission status updates",
@@ -178,13 +433,24 @@ func NewCommandHandler(client *pluginapi.Client, mission mission.MissionInterfac
 						{
 							Type: model.AutocompleteArgTypeText,
 							Data: &model.AutocompleteTextArg{
-								Hint:    "[seconds]",
-								Pattern: "^[0-9]+$",
+								Hint: "[duration]",
 							},
-							Name:     "frequency",
-							HelpText: "Update frequency in seconds (minimum 300)",
+							Name:     "update-frequency",
+							HelpText: "Update frequency as a duration like 30m or 1h, or in seconds (minimum 5 minutes)",
 							Required: true,
 						},
+						{
+							Type: model.AutocompleteArgTypeStaticList,
+							Data: &model.AutocompleteStaticListArg{
+								PossibleArguments: []model.AutocompleteListItem{
+									{
+										Item:     "--digest",
+										HelpText: "(optional) Summarize status changes at the update frequency instead of posting each one",
+									},
+								},
+							},
+							Required: false,
+						},
 					},
 				},
 				{
@@ -217,6 +483,7 @@ func NewCommandHandler(client *pluginapi.Client, mission mission.MissionInterfac
 		mission:      mission,
 		bot:          bot,
 		subscription: subscription,
+		template:     template,
 	}
 }
 
@@ -255,8 +522,20 @@ func (c *Handler) Handle(args *model.CommandArgs) (*model.CommandResponse, error
 		return c.executeMissionListCommand(args)
 	case "status":
 		return c.executeMissionStatusCommand(args)
+	case "crew":
+		return c.executeMissionCrewCommand(args)
 	case "complete":
 		return c.executeMissionCompleteCommand(args)
+	case "timeline":
+		return c.executeMissionTimelineCommand(args)
+	case "archive":
+		return c.executeMissionArchiveCommand(args)
+	case "reopen":
+		return c.executeMissionReopenCommand(args)
+	case "report":
+		return c.executeMissionReportCommand(args)
+	case "template":
+		return c.executeMissionTemplateCommand(args)
 	case "subscribe":
 		return c.executeMissionSubscribeCommand(args)
 	case "unsubscribe":
@@ -0,0 +1,47 @@
+package command
+
+import (
+	"fmt"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+// executeMissionArchiveCommand handles the /mission archive command
+func (c *Handler) executeMissionArchiveCommand(args *model.CommandArgs) (*model.CommandResponse, error) {
+	// Parse arguments
+	commandArgs := parseArgs(args.Command)
+	missionID := commandArgs["id"]
+
+	// If no mission ID provided, try to find the mission based on channel ID
+	if missionID == "" {
+		mission, err := c.mission.GetMissionByChannelID(args.ChannelId)
+		if err != nil {
+			return &model.CommandResponse{
+				ResponseType: model.CommandResponseTypeEphemeral,
+				Text:         "This command must be run in a mission channel, or provide --id [mission_id]",
+			}, nil
+		}
+		missionID = mission.ID
+	}
+
+	mission, err := c.mission.GetMission(missionID)
+	if err != nil {
+		return &model.CommandResponse{
+			ResponseType: model.CommandResponseTypeEphemeral,
+			Text:         "Mission not found with the provided ID.",
+		}, nil
+	}
+
+	if err := c.mission.ArchiveMission(missionID); err != nil {
+		c.client.Log.Warn("Error archiving mission", "id", missionID, "error", err.Error())
+		return &model.CommandResponse{
+			ResponseType: model.CommandResponseTypeEphemeral,
+			Text:         fmt.Sprintf("Unable to archive mission: %v", err),
+		}, nil
+	}
+
+	return &model.CommandResponse{
+		ResponseType: model.CommandResponseTypeEphemeral,
+		Text:         fmt.Sprintf("📦 Mission **%s** archived. It is hidden from `/mission list`; use `/mission list --all` to see it.", mission.Name),
+	}, nil
+}
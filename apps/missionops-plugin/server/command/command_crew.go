@@ -0,0 +1,130 @@
+package command
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+// executeMissionCrewCommand handles the /mission crew command
+func (c *Handler) executeMissionCrewCommand(args *model.CommandArgs) (*model.CommandResponse, error) {
+	// Parse arguments
+	commandArgs := parseArgs(args.Command)
+	missionID := commandArgs["id"]
+
+	addUsernames := strings.Fields(commandArgs["add"])
+	removeUsernames := strings.Fields(commandArgs["remove"])
+	if len(addUsernames) == 0 && len(removeUsernames) == 0 {
+		return &model.CommandResponse{
+			ResponseType: model.CommandResponseTypeEphemeral,
+			Text:         "Crew changes are required. Use `--add @user1 @user2 ...` and/or `--remove @user1 @user2 ...`",
+		}, nil
+	}
+
+	// If no mission ID provided, try to find the mission based on channel ID
+	if missionID == "" {
+		m, err := c.mission.GetMissionByChannelID(args.ChannelId)
+		if err != nil {
+			return &model.CommandResponse{
+				ResponseType: model.CommandResponseTypeEphemeral,
+				Text:         "This command must be run in a mission channel, or provide --id [mission_id]",
+			}, nil
+		}
+		missionID = m.ID
+	}
+
+	m, err := c.mission.GetMission(missionID)
+	if err != nil {
+		return &model.CommandResponse{
+			ResponseType: model.CommandResponseTypeEphemeral,
+			Text:         "Mission not found with the provided ID.",
+		}, nil
+	}
+
+	// Resolve every username before changing anything, so a typo doesn't leave a partial update
+	addUsers, err := c.resolveUsers(addUsernames)
+	if err != nil {
+		return &model.CommandResponse{
+			ResponseType: model.CommandResponseTypeEphemeral,
+			Text:         err.Error(),
+		}, nil
+	}
+	removeUsers, err := c.resolveUsers(removeUsernames)
+	if err != nil {
+		return &model.CommandResponse{
+			ResponseType: model.CommandResponseTypeEphemeral,
+			Text:         err.Error(),
+		}, nil
+	}
+
+	var added, removed []string
+	var addIDs, removeIDs []string
+	for _, user := range addUsers {
+		if slices.Contains(m.Crew, user.Id) || slices.Contains(addIDs, user.Id) {
+			continue
+		}
+		if _, err := c.client.Channel.AddUser(m.ChannelID, user.Id, c.bot.GetBotUserInfo().UserId); err != nil {
+			return c.logCommandError(fmt.Sprintf("Error adding user to channel: userId=%s, error=%s", user.Id, err.Error())), nil
+		}
+		addIDs = append(addIDs, user.Id)
+		added = append(added, "@"+user.Username)
+	}
+	for _, user := range removeUsers {
+		if !slices.Contains(m.Crew, user.Id) || slices.Contains(removeIDs, user.Id) {
+			continue
+		}
+		if err := c.client.Channel.DeleteMember(m.ChannelID, user.Id); err != nil {
+			c.client.Log.Warn("Error removing user from mission channel", "userId", user.Id, "error", err.Error())
+		}
+		removeIDs = append(removeIDs, user.Id)
+		removed = append(removed, "@"+user.Username)
+	}
+
+	if len(addIDs) == 0 && len(removeIDs) == 0 {
+		return &model.CommandResponse{
+			ResponseType: model.CommandResponseTypeEphemeral,
+			Text:         "No crew changes: users to add are already on the crew and users to remove are not.",
+		}, nil
+	}
+
+	if err := c.mission.UpdateCrew(missionID, addIDs, removeIDs); err != nil {
+		return c.logCommandError(fmt.Sprintf("Error saving the mission crew: %v", err)), nil
+	}
+
+	var changes []string
+	if len(added) > 0 {
+		changes = append(changes, "added "+strings.Join(added, ", "))
+	}
+	if len(removed) > 0 {
+		changes = append(changes, "removed "+strings.Join(removed, ", "))
+	}
+	note := fmt.Sprintf("👥 Crew updated by %s: %s", c.describeUser(args.UserId), strings.Join(changes, "; "))
+
+	if _, err := c.bot.PostMessageFromBot(m.ChannelID, note); err != nil {
+		c.client.Log.Error("Error sending crew update message", "error", err.Error())
+		return &model.CommandResponse{
+			ResponseType: model.CommandResponseTypeEphemeral,
+			Text:         "Error sending crew update message. Please check your permissions.",
+		}, nil
+	}
+
+	return &model.CommandResponse{
+		ResponseType: model.CommandResponseTypeEphemeral,
+		Text:         "",
+	}, nil
+}
+
+// resolveUsers looks up users by username, with or without a leading @
+func (c *Handler) resolveUsers(usernames []string) ([]*model.User, error) {
+	users := make([]*model.User, 0, len(usernames))
+	for _, username := range usernames {
+		user, err := c.client.User.GetByUsername(strings.TrimPrefix(username, "@"))
+		if err != nil {
+			return nil, fmt.Errorf("User not found: %s", username)
+		}
+		users = append(users, user)
+	}
+	return users, nil
+}
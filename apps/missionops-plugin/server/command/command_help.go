@@ -6,14 +6,28 @@ import "github.com/mattermost/mattermost/server/public/model"
 func (p *Handler) executeMissionHelpCommand(args *model.CommandArgs) (*model.CommandResponse, error) {
 	helpText := "**Mission Operations Commands**\n\n" +
 		"**Mission Commands:**\n" +
-		"- `/mission start --name [name] --callsign [callsign] --departureAirport [code] --arrivalAirport [code] --crew @user1 @user2 ...` - Create a new mission\n" +
-		"- `/mission list` - List all missions\n" +
+		"- `/mission start --name [name] --callsign [callsign] --departureAirport [code] --arrivalAirport [code] --crew @user1 @user2 ... [--priority low|medium|high] [--tag tag1 --tag tag2]` - Create a new mission\n" +
+		"- `/mission start` - Create a new mission by filling in a form\n" +
+		"- `/mission list` - List all missions, highest priority first\n" +
+		"- `/mission list --status [status]` - List only missions with a status\n" +
+		"- `/mission list --tag [tag]` - List only missions with a tag\n" +
+		"- `/mission list --all` - List all missions, including archived ones\n" +
 		"- `/mission status [status]` - Update mission status (run in mission channel to skip --id)\n" +
+		"- `/mission crew --add @user1 --remove @user2` - Change the mission crew and channel members (run in mission channel to skip --id)\n" +
 		"- `/mission complete` - Fill out and submit a post-mission report form\n" +
+		"- `/mission timeline` - Show who changed the mission status and when (run in mission channel to skip --id)\n" +
+		"- `/mission archive` - Hide a completed or cancelled mission from `/mission list` (run in mission channel to skip --id)\n" +
+		"- `/mission reopen` - Move a completed or cancelled mission back to stalled, or `--status in-air` (run in mission channel to skip --id)\n" +
+		"- `/mission report --format pdf|csv` - Post a completed mission's report as a file (run in mission channel to skip --id)\n" +
 		"- `/mission help` - Show this help message\n\n" +
+		"**Template Commands:**\n" +
+		"- `/mission template save --name [name] --callsign-prefix [prefix] --departureAirport [code] --arrivalAirport [code]` - Save defaults for a repeated sortie type\n" +
+		"- `/mission template list` - List saved templates\n" +
+		"- `/mission start --template [name] --name [name] --crew @user1 ...` - Start a mission from a template; explicit flags override its values\n\n" +
 		"**Subscription Commands:**\n" +
-		"- `/mission subscribe --type [status1,status2] --frequency [seconds]` - Subscribe to mission status updates\n" +
-		"- `/mission subscribe --type all --frequency [seconds]` - Subscribe to all mission status updates\n" +
+		"- `/mission subscribe --type [status1,status2] --update-frequency [duration]` - Subscribe to mission status updates\n" +
+		"- `/mission subscribe --type all --update-frequency [duration]` - Subscribe to all mission status updates\n" +
+		"- `/mission subscribe --type [status1,status2] --update-frequency [duration] --digest` - Get one summary of status changes per update instead of a post for each change\n" +
 		"- `/mission unsubscribe --id [subscription_id]` - Unsubscribe from updates\n" +
 		"- `/mission subscriptions` - List all subscriptions in this channel\n\n" +
 		"**Valid Statuses:**\n" +
@@ -23,12 +37,17 @@ func (p *Handler) executeMissionHelpCommand(args *model.CommandArgs) (*model.Com
 		"- `cancelled` - Mission has been cancelled\n\n" +
 		"**Examples:**\n" +
 		"- `/mission start --name Alpha --callsign Eagle1 --departureAirport JFK --arrivalAirport LAX --crew @john @sarah`\n" +
+		"- `/mission template save --name training --callsign-prefix TRN --departureAirport JFK --arrivalAirport LAX`\n" +
+		"- `/mission start --template training --name Alpha --crew @john` (callsign TRN1, JFK to LAX)\n" +
+		"- `/mission list --status in-air`\n" +
 		"- `/mission status in-air`\n" +
 		"- `/mission status completed`\n" +
 		"- `/mission status cancelled --id [mission_id]` (when not in mission channel)\n" +
 		"- `/mission complete` (in a mission channel)\n" +
-		"- `/mission subscribe --type stalled,in-air --frequency 3600` (updates hourly)\n" +
-		"- `/mission subscribe --type all --frequency 1800` (updates every 30 minutes)"
+		"- `/mission timeline --id [mission_id]` (when not in mission channel)\n" +
+		"- `/mission report --format csv` (in a completed mission's channel)\n" +
+		"- `/mission subscribe --type stalled,in-air --update-frequency 1h` (updates hourly)\n" +
+		"- `/mission subscribe --type all --update-frequency 30m` (updates every 30 minutes)"
 
 	return &model.CommandResponse{
 		ResponseType: model.CommandResponseTypeEphemeral,
@@ -2,15 +2,40 @@ package command
 
 import (
 	"fmt"
+	"slices"
 	"strings"
+	"time"
 
+	"github.com/coltoneshaw/demokit/missionops-plugin/server/mission"
 	"github.com/mattermost/mattermost/server/public/model"
 )
 
 // executeMissionListCommand handles the /mission list command
 func (c *Handler) executeMissionListCommand(args *model.CommandArgs) (*model.CommandResponse, error) {
-	// Get all missions
-	missions, err := c.mission.GetAllMissions()
+	// Parse arguments
+	commandArgs := parseArgs(args.Command)
+	status := commandArgs["status"]
+	tag := strings.ToLower(commandArgs["tag"])
+	includeArchived := slices.Contains(strings.Fields(args.Command), "--all")
+
+	if status != "" && !mission.IsValidStatus(status) {
+		return &model.CommandResponse{
+			ResponseType: model.CommandResponseTypeEphemeral,
+			Text:         fmt.Sprintf("Invalid status: %s. Valid statuses: %s", status, strings.Join(mission.ValidStatuses, ", ")),
+		}, nil
+	}
+
+	// Get all missions, or only those with the requested status or tag
+	var missions []*mission.Mission
+	var err error
+	switch {
+	case status != "":
+		missions, err = c.mission.GetMissionsByStatus(status)
+	case tag != "":
+		missions, err = c.mission.GetMissionsByTag(tag)
+	default:
+		missions, err = c.mission.GetAllMissions()
+	}
 	if err != nil {
 		c.client.Log.Error("Error getting missions", "error", err.Error())
 		return &model.CommandResponse{
@@ -19,23 +44,72 @@ func (c *Handler) executeMissionListCommand(args *model.CommandArgs) (*model.Com
 		}, nil
 	}
 
+	// With both filters, narrow the missions with the status down to the tag
+	if status != "" && tag != "" {
+		missions = slices.DeleteFunc(missions, func(m *mission.Mission) bool { return !m.HasTag(tag) })
+	}
+
+	// Archived missions are only listed with --all
+	if !includeArchived {
+		missions = c.mission.ExcludeArchived(missions)
+	}
+
 	if len(missions) == 0 {
+		text := "No missions found."
+		switch {
+		case status != "" && tag != "":
+			text = fmt.Sprintf("No missions found with status %s and tag %s.", status, tag)
+		case status != "":
+			text = fmt.Sprintf("No missions found with status %s.", status)
+		case tag != "":
+			text = fmt.Sprintf("No missions found with tag %s.", tag)
+		}
+		if !includeArchived {
+			text += " Use `/mission list --all` to include archived missions."
+		}
 		return &model.CommandResponse{
 			ResponseType: model.CommandResponseTypeEphemeral,
-			Text:         "No missions found.",
+			Text:         text,
 		}, nil
 	}
 
+	// Most urgent first, and oldest first within a priority
+	mission.SortByPriority(missions)
+
 	// Format as a table
+	title := "Current Missions"
+	if includeArchived {
+		title = "All Missions"
+	}
+
+	var filters []string
+	if status != "" {
+		filters = append(filters, status)
+	}
+	if tag != "" {
+		filters = append(filters, "tag: "+tag)
+	}
+
 	var sb strings.Builder
-	sb.WriteString("# Current Missions\n\n")
-	sb.WriteString("| Name | Callsign | Departure | Arrival | Status | Channel |\n")
-	sb.WriteString("|------|----------|-----------|---------|--------|--------|\n")
+	if len(filters) > 0 {
+		sb.WriteString(fmt.Sprintf("# %s (%s)\n\n", title, strings.Join(filters, ", ")))
+	} else {
+		sb.WriteString(fmt.Sprintf("# %s\n\n", title))
+	}
+	sb.WriteString("| Name | Callsign | Departure | Arrival | Status | Channel | Created At | Duration |\n")
+	sb.WriteString("|------|----------|-----------|---------|--------|--------|------------|----------|\n")
 
-	for _, mission := range missions {
-		sb.WriteString(fmt.Sprintf("| %s | %s | %s | %s | %s | ~%s |\n",
-			mission.Name, mission.Callsign, mission.DepartureAirport, mission.ArrivalAirport,
-			mission.Status, mission.ChannelName))
+	now := time.Now()
+	for _, m := range missions {
+		missionStatus := m.Status
+		if c.mission.IsArchived(m) {
+			missionStatus += " (archived)"
+		}
+
+		sb.WriteString(fmt.Sprintf("| %s %s | %s | %s | %s | %s | ~%s | %s | %s |\n",
+			m.GetPriorityEmoji(), m.Name, m.Callsign, m.DepartureAirport, m.ArrivalAirport,
+			missionStatus, m.ChannelName, m.CreatedAt.Format(time.RFC1123),
+			formatMissionDuration(m, now)))
 	}
 
 	_, err = c.bot.PostMessageFromBot(args.ChannelId, sb.String())
@@ -46,3 +120,30 @@ func (c *Handler) executeMissionListCommand(args *model.CommandArgs) (*model.Com
 		Text:         "",
 	}, nil
 }
+
+// formatMissionDuration returns how long a mission has run since creation, stopping at
+// completion or cancellation, formatted like "3d 4h" or "2h 15m"
+func formatMissionDuration(m *mission.Mission, now time.Time) string {
+	end := now
+	if !m.CompletedAt.IsZero() {
+		end = m.CompletedAt
+	}
+
+	duration := end.Sub(m.CreatedAt)
+	if duration < 0 {
+		duration = 0
+	}
+
+	days := int(duration.Hours()) / 24
+	hours := int(duration.Hours()) % 24
+	minutes := int(duration.Minutes()) % 60
+
+	switch {
+	case days > 0:
+		return fmt.Sprintf("%dd %dh", days, hours)
+	case hours > 0:
+		return fmt.Sprintf("%dh %dm", hours, minutes)
+	default:
+		return fmt.Sprintf("%dm", minutes)
+	}
+}
@@ -0,0 +1,84 @@
+package command
+
+import (
+	"fmt"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+// executeMissionReopenCommand handles the /mission reopen command
+func (c *Handler) executeMissionReopenCommand(args *model.CommandArgs) (*model.CommandResponse, error) {
+	// Parse arguments
+	commandArgs := parseArgs(args.Command)
+	missionID := commandArgs["id"]
+
+	status := commandArgs["status"]
+	if status == "" {
+		status = "stalled"
+	}
+	if status != "stalled" && status != "in-air" {
+		return &model.CommandResponse{
+			ResponseType: model.CommandResponseTypeEphemeral,
+			Text:         "Invalid status. A mission can be reopened as stalled or in-air.",
+		}, nil
+	}
+
+	// If no mission ID provided, try to find the mission based on channel ID
+	if missionID == "" {
+		m, err := c.mission.GetMissionByChannelID(args.ChannelId)
+		if err != nil {
+			return &model.CommandResponse{
+				ResponseType: model.CommandResponseTypeEphemeral,
+				Text:         "This command must be run in a mission channel, or provide --id [mission_id]",
+			}, nil
+		}
+		missionID = m.ID
+	}
+
+	m, err := c.mission.GetMission(missionID)
+	if err != nil {
+		return &model.CommandResponse{
+			ResponseType: model.CommandResponseTypeEphemeral,
+			Text:         "Mission not found with the provided ID.",
+		}, nil
+	}
+	oldStatus := m.Status
+
+	if err := c.mission.ReopenMission(missionID, status, args.UserId); err != nil {
+		c.client.Log.Warn("Error reopening mission", "id", missionID, "error", err.Error())
+		return &model.CommandResponse{
+			ResponseType: model.CommandResponseTypeEphemeral,
+			Text:         fmt.Sprintf("Unable to reopen mission: %v", err),
+		}, nil
+	}
+
+	// Get the updated mission
+	m, err = c.mission.GetMission(missionID)
+	if err != nil {
+		return &model.CommandResponse{
+			ResponseType: model.CommandResponseTypeEphemeral,
+			Text:         "Mission not found after update.",
+		}, nil
+	}
+
+	if err := c.mission.UpdateChannelDisplayName(m); err != nil {
+		c.client.Log.Error("Error updating channel display name", "error", err.Error())
+	}
+
+	// Notify subscribed channels of the status change
+	go c.subscription.NotifySubscribersOfStatusChange(m, oldStatus)
+
+	_, err = c.bot.PostMessageFromBot(m.ChannelID, fmt.Sprintf("🔄 Mission **%s** reopened by %s. Status is now %s **%s**", m.Name, c.describeUser(args.UserId), c.mission.GetStatusEmoji(status), status))
+	if err != nil {
+		c.client.Log.Error("Error sending reopen message", "error", err.Error())
+		return &model.CommandResponse{
+			ResponseType: model.CommandResponseTypeEphemeral,
+			Text:         "Error sending reopen message. Please check your permissions.",
+		}, nil
+	}
+
+	return &model.CommandResponse{
+		ResponseType: model.CommandResponseTypeEphemeral,
+		Text:         "",
+	}, nil
+}
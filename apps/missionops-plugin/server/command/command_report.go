@@ -0,0 +1,70 @@
+package command
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/coltoneshaw/demokit/missionops-plugin/server/mission"
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+// executeMissionReportCommand handles the /mission report command
+func (c *Handler) executeMissionReportCommand(args *model.CommandArgs) (*model.CommandResponse, error) {
+	// Parse arguments
+	commandArgs := parseArgs(args.Command)
+	missionID := commandArgs["id"]
+
+	format := strings.ToLower(commandArgs["format"])
+	if format == "" {
+		format = mission.ReportFormatPDF
+	}
+	if !slices.Contains(mission.ReportFormats, format) {
+		return &model.CommandResponse{
+			ResponseType: model.CommandResponseTypeEphemeral,
+			Text:         fmt.Sprintf("Invalid format: %s. Use --format %s", format, strings.Join(mission.ReportFormats, "|")),
+		}, nil
+	}
+
+	// If no mission ID provided, try to find the mission based on channel ID
+	if missionID == "" {
+		m, err := c.mission.GetMissionByChannelID(args.ChannelId)
+		if err != nil {
+			return &model.CommandResponse{
+				ResponseType: model.CommandResponseTypeEphemeral,
+				Text:         "This command must be run in a mission channel, or provide --id [mission_id]",
+			}, nil
+		}
+		missionID = m.ID
+	}
+
+	m, err := c.mission.GetMission(missionID)
+	if err != nil {
+		return &model.CommandResponse{
+			ResponseType: model.CommandResponseTypeEphemeral,
+			Text:         "Mission not found with the provided ID.",
+		}, nil
+	}
+
+	if m.Report == nil {
+		return &model.CommandResponse{
+			ResponseType: model.CommandResponseTypeEphemeral,
+			Text:         fmt.Sprintf("Mission **%s** has no post-mission report yet. Submit one with `/mission complete`.", m.Name),
+		}, nil
+	}
+
+	data, err := mission.RenderReport(m, format, c.describeUser(m.Report.SubmittedBy))
+	if err != nil {
+		return c.logCommandError(fmt.Sprintf("Error rendering mission report: %v", err)), nil
+	}
+
+	message := fmt.Sprintf("📄 Post-mission report for **%s** (%s)", m.Name, strings.ToUpper(format))
+	if _, err := c.bot.SendPostWithAttachment(args.ChannelId, message, mission.ReportFileName(m, format), data); err != nil {
+		return c.logCommandError(fmt.Sprintf("Error posting mission report: %v", err)), nil
+	}
+
+	return &model.CommandResponse{
+		ResponseType: model.CommandResponseTypeEphemeral,
+		Text:         "",
+	}, nil
+}
@@ -2,7 +2,9 @@ package command
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
+	"net/http"
 	"os"
 	"path/filepath"
 	"strings"
@@ -14,9 +16,7 @@ import (
 	"github.com/pkg/errors"
 )
 
-func parseMissionStartArgs(command string, pluginAPI *pluginapi.Client) (*mission.MissionInfo, error) {
-	commandArgs := parseArgs(command)
-
+func parseMissionStartArgs(commandArgs map[string]string, pluginAPI *pluginapi.Client) (*mission.MissionInfo, error) {
 	name := commandArgs["name"]
 	callsign := commandArgs["callsign"]
 	departureAirport := strings.ToUpper(commandArgs["departureAirport"])
@@ -32,20 +32,23 @@ func parseMissionStartArgs(command string, pluginAPI *pluginapi.Client) (*missio
 		return nil, errors.New("At least one crew member is required. Use `--crew @user1 @user2 ...`")
 	}
 
+	// Resolve every crew member up front, so a typo is reported before anything is created
 	crewUserData := []model.User{}
+	var unknownUsernames []string
 	for _, username := range crewUsernames {
-		// TODO - may need to clean the username.
-		// Remove the @ symbol if it exists at the beginning of the username
-		cleanUsername := username
-		if strings.HasPrefix(username, "@") {
-			cleanUsername = username[1:]
-		}
-		user, err := pluginAPI.User.GetByUsername(cleanUsername)
+		user, err := pluginAPI.User.GetByUsername(strings.TrimPrefix(username, "@"))
 		if err != nil {
-			return nil, errors.New(fmt.Sprintf("User not found: %s", username))
+			unknownUsernames = append(unknownUsernames, "@"+strings.TrimPrefix(username, "@"))
+			continue
 		}
 		crewUserData = append(crewUserData, *user)
 	}
+	if len(unknownUsernames) == 1 {
+		return nil, errors.New(fmt.Sprintf("User not found: %s", unknownUsernames[0]))
+	}
+	if len(unknownUsernames) > 1 {
+		return nil, errors.New(fmt.Sprintf("Users not found: %s", strings.Join(unknownUsernames, ", ")))
+	}
 
 	// Validate required parameters
 	if name == "" {
@@ -64,26 +67,77 @@ func parseMissionStartArgs(command string, pluginAPI *pluginapi.Client) (*missio
 		return nil, errors.New("Arrival airport is required. Use `--arrivalAirport [code]`")
 	}
 
+	priority := mission.PriorityMedium
+	if priorityName := commandArgs["priority"]; priorityName != "" {
+		var ok bool
+		if priority, ok = mission.ParsePriority(priorityName); !ok {
+			return nil, errors.New(fmt.Sprintf("Invalid priority: %s. Use `--priority %s`", priorityName, strings.Join(mission.PriorityNames, "|")))
+		}
+	}
+
+	// Reject typos before the channel is created and weather is looked up for them
+	for _, code := range []string{departureAirport, arrivalAirport} {
+		if !mission.IsValidAirportCode(code) {
+			return nil, errors.New(fmt.Sprintf("Unknown airport code: %s. Use a 3-letter IATA (e.g. JFK) or 4-letter ICAO (e.g. KJFK) code", code))
+		}
+	}
+
 	return &mission.MissionInfo{
 		Name:             name,
 		Callsign:         callsign,
 		DepartureAirport: departureAirport,
 		ArrivalAirport:   arrivalAirport,
+		Priority:         priority,
+		Tags:             mission.ParseTags(commandArgs["tag"]),
 		Crew:             crewUserData,
 	}, nil
 }
 
 // executeMissionStartCommand handles the /mission start command
 func (c *Handler) executeMissionStartCommand(args *model.CommandArgs) (*model.CommandResponse, error) {
+	// Without any arguments, ask for them in a dialog instead
+	if len(strings.Fields(args.Command)) <= 2 {
+		return c.openMissionStartDialog(args)
+	}
+
+	// --tag may be repeated, so collect every value instead of only the last
+	commandArgs := parseArgs(args.Command)
+	if tags := parseRepeatedArg(args.Command, "tag"); len(tags) > 0 {
+		commandArgs["tag"] = strings.Join(tags, ",")
+	}
+
+	return c.startMission(args.UserId, args.TeamId, args.ChannelId, commandArgs)
+}
+
+// startMission creates a mission and its channel from start arguments, for the user running /mission start
+// in channelID, or submitting the start dialog there
+func (c *Handler) startMission(userID, teamID, channelID string, commandArgs map[string]string) (*model.CommandResponse, error) {
 	// First, ensure the bot is a member of the team where the command is being executed
-	if err := c.bot.EnsureTeamMember(args.TeamId); err != nil {
+	if err := c.bot.EnsureTeamMember(teamID); err != nil {
 		return &model.CommandResponse{
 			ResponseType: model.CommandResponseTypeEphemeral,
 			Text:         "❌ Error: The Mission Ops Bot cannot be added to this team. This is required to create mission channels. Please contact your system administrator.",
 		}, fmt.Errorf("failed to ensure bot is a team member: %v", err)
 	}
 
-	parsedMissionInfo, err := parseMissionStartArgs(args.Command, c.client)
+	// Fill in the arguments that weren't given from the template, if one was named
+	if templateName := commandArgs["template"]; templateName != "" {
+		t, err := c.template.GetTemplate(templateName)
+		if err != nil {
+			return &model.CommandResponse{
+				ResponseType: model.CommandResponseTypeEphemeral,
+				Text:         fmt.Sprintf("Mission template not found: %s. Use `/mission template list` to see saved templates.", templateName),
+			}, nil
+		}
+
+		missions, err := c.mission.GetAllMissions()
+		if err != nil {
+			return c.logCommandError(fmt.Sprintf("Error getting missions: %v", err)), err
+		}
+		applyTemplate(commandArgs, t, missions)
+	}
+
+	parsedMissionInfo, err := parseMissionStartArgs(commandArgs, c.client)
 	if err != nil {
 		return c.logCommandError(fmt.Sprintf("Error parsing mission start arguments %v", err)), err
 	}
@@ -98,32 +152,35 @@ func (c *Handler) executeMissionStartCommand(args *model.CommandArgs) (*model.Co
 
 	// Create the channel with status emoji in display name
 	channel := &model.Channel{
-		TeamId:      args.TeamId,
+		TeamId:      teamID,
 		Name:        channelName,
 		DisplayName: fmt.Sprintf("%s %s: %s", initialStatusEmoji, parsedMissionInfo.Callsign, parsedMissionInfo.Name),
 		Type:        model.ChannelTypeOpen,
 	}
 
-	err = c.client.Channel.Create(channel)
+	channel, existingMission, created, err := c.createMissionChannel(channel, parsedMissionInfo)
 	if err != nil {
-		// Provide a clearer error message for channel creation failures
-		if strings.Contains(err.Error(), "already exists") {
-			return c.logCommandError(fmt.Sprintf("A channel with this name already exists. Please use a different callsign or mission name.")), err
-		}
 		return c.logCommandError(fmt.Sprintf("Error creating mission channel: %v", err)), err
 	}
 
+	// Starting a mission that already exists only adds the crew to it
+	if existingMission != nil {
+		return c.addCrewToExistingMission(existingMission, parsedMissionInfo.Crew)
+	}
+
 	// Categorize the mission channel into "Active Missions" category using Playbooks API
 	if err := c.mission.CategorizeMissionChannel(channel.Id, channel.TeamId); err != nil {
-		return c.logCommandError(fmt.Sprintf("Error categorizing mission channel", "error", err.Error())), err
+		c.rollbackMissionStart(channel, created, "")
+		return c.logCommandError(fmt.Sprintf("Error categorizing mission channel: %v", err)), err
 	}
 
-	crewIds := make([]string, len(parsedMissionInfo.Crew))
-	crewUsernames := make([]string, len(parsedMissionInfo.Crew))
+	crewIds := make([]string, 0, len(parsedMissionInfo.Crew))
+	crewUsernames := make([]string, 0, len(parsedMissionInfo.Crew))
 	// Add all users to the channel
 	for _, user := range parsedMissionInfo.Crew {
 		if _, err := c.client.Channel.AddUser(channel.Id, user.Id, c.bot.GetBotUserInfo().UserId); err != nil {
-			return c.logCommandError(fmt.Sprintf("Error adding user to channel: userId=%s, error=%s", user.Id, err.Error())), err
+			c.rollbackMissionStart(channel, created, "")
+			return c.logCommandError(fmt.Sprintf("Error adding @%s to the mission channel, so the mission was not created: %v", user.Username, err)), err
 		}
 		crewIds = append(crewIds, user.Id)
 		crewUsernames = append(crewUsernames, user.Username)
@@ -135,18 +192,21 @@ func (c *Handler) executeMissionStartCommand(args *model.CommandArgs) (*model.Co
 		Callsign:         parsedMissionInfo.Callsign,
 		DepartureAirport: parsedMissionInfo.DepartureAirport,
 		ArrivalAirport:   parsedMissionInfo.ArrivalAirport,
-		CreatedBy:        args.UserId,
+		CreatedBy:        userID,
 		CreatedAt:        time.Now(),
 		Crew:             crewIds,
 		ChannelID:        channel.Id,
 		TeamID:           channel.TeamId,
 		ChannelName:      channelName,
 		Status:           "stalled",
+		Priority:         parsedMissionInfo.Priority,
+		Tags:             parsedMissionInfo.Tags,
 	}
 
 	// Add the mission to the KV store
 	if err := c.mission.AddMission(mission); err != nil {
-		return c.logCommandError(fmt.Sprintf("Error saving the mission", "error", err.Error())), err
+		c.rollbackMissionStart(channel, created, mission.ID)
+		return c.logCommandError(fmt.Sprintf("Error saving the mission: %v", err)), err
 	}
 
 	usernames := make([]string, len(crewUsernames))
@@ -160,18 +220,24 @@ func (c *Handler) executeMissionStartCommand(args *model.CommandArgs) (*model.Co
 		"**Departure:** %s\n"+
 		"**Arrival:** %s\n"+
 		"**Status:** %s\n"+
-		"**Crew:** %s\n\n",
-		parsedMissionInfo.Name, parsedMissionInfo.Callsign, parsedMissionInfo.DepartureAirport, parsedMissionInfo.ArrivalAirport, mission.Status, strings.Join(usernames, ", "))
+		"**Priority:** %s %s\n"+
+		"**Crew:** %s\n",
+		parsedMissionInfo.Name, parsedMissionInfo.Callsign, parsedMissionInfo.DepartureAirport, parsedMissionInfo.ArrivalAirport, mission.Status,
+		mission.GetPriorityEmoji(), mission.GetPriorityName(), strings.Join(usernames, ", "))
+	if len(mission.Tags) > 0 {
+		missionDetails += fmt.Sprintf("**Tags:** %s\n", strings.Join(mission.Tags, ", "))
+	}
+	missionDetails += "\n"
 
 	_, err = c.bot.PostMessageFromBot(channel.Id, missionDetails)
 	if err != nil {
-		return c.logCommandError(fmt.Sprintf("Error sending message to channel", "error", err.Error())), err
+		return c.logCommandError(fmt.Sprintf("Error sending message to channel: %v", err)), err
 	}
 
 	// Upload the flight plan PDF to the channel
 	err = c.UploadFlightPlanPDF(channel.Id)
 	if err != nil {
-		return c.logCommandError(fmt.Sprintf("Error uploading flight plan PDF", "error", err.Error())), err
+		return c.logCommandError(fmt.Sprintf("Error uploading flight plan PDF: %v", err)), err
 	}
 
 	time.Sleep(1 * time.Second)
@@ -187,7 +253,7 @@ func (c *Handler) executeMissionStartCommand(args *model.CommandArgs) (*model.Co
 
 	// Have the bot post the success message directly to the channel instead of returning it
 	successMsg := fmt.Sprintf("✅ Mission **%s** created with callsign **%s**. Channel: ~%s", mission.Name, mission.Callsign, channelName)
-	_, err = c.bot.PostMessageFromBot(args.ChannelId, successMsg)
+	_, err = c.bot.PostMessageFromBot(channelID, successMsg)
 	if err != nil {
 		c.client.Log.Error("Error sending success message", "error", err.Error())
 	}
@@ -199,6 +265,220 @@ func (c *Handler) executeMissionStartCommand(args *model.CommandArgs) (*model.Co
 	}, nil
 }
 
+// openMissionStartDialog opens an interactive dialog asking for the /mission start arguments
+func (c *Handler) openMissionStartDialog(args *model.CommandArgs) (*model.CommandResponse, error) {
+	dialog := model.OpenDialogRequest{
+		TriggerId: args.TriggerId,
+		URL:       "/plugins/com.coltoneshaw.missionops/api/v1/missions/start",
+		Dialog: model.Dialog{
+			CallbackId:       "mission_start_dialog",
+			Title:            "Start Mission",
+			IntroductionText: "Create a mission channel, add the crew and check the weather at both airports.",
+			SubmitLabel:      "Start Mission",
+			NotifyOnCancel:   false,
+			Elements: []model.DialogElement{
+				{
+					DisplayName: "Mission Name",
+					Name:        "name",
+					Type:        "text",
+					Placeholder: "Alpha",
+				},
+				{
+					DisplayName: "Callsign",
+					Name:        "callsign",
+					Type:        "text",
+					Placeholder: "Eagle1",
+				},
+				{
+					DisplayName: "Departure Airport",
+					Name:        "departureAirport",
+					Type:        "text",
+					Placeholder: "JFK",
+					HelpText:    "3-letter IATA or 4-letter ICAO code",
+					MinLength:   3,
+					MaxLength:   4,
+				},
+				{
+					DisplayName: "Arrival Airport",
+					Name:        "arrivalAirport",
+					Type:        "text",
+					Placeholder: "LAX",
+					HelpText:    "3-letter IATA or 4-letter ICAO code",
+					MinLength:   3,
+					MaxLength:   4,
+				},
+				{
+					DisplayName: "Crew",
+					Name:        "crew",
+					Type:        "text",
+					Placeholder: "@user1 @user2",
+					HelpText:    "Space-separated usernames",
+				},
+				{
+					DisplayName: "Priority",
+					Name:        "priority",
+					Type:        "select",
+					Default:     "medium",
+					Optional:    true,
+					Options: []*model.PostActionOptions{
+						{Text: "🔴 High", Value: "high"},
+						{Text: "🟡 Medium", Value: "medium"},
+						{Text: "🟢 Low", Value: "low"},
+					},
+				},
+				{
+					DisplayName: "Tags",
+					Name:        "tag",
+					Type:        "text",
+					Placeholder: "training, night",
+					HelpText:    "Comma-separated labels to filter /mission list by",
+					Optional:    true,
+				},
+			},
+		},
+	}
+
+	if err := c.client.Frontend.OpenInteractiveDialog(dialog); err != nil {
+		c.client.Log.Error("Error opening interactive dialog", "error", err.Error())
+		return &model.CommandResponse{
+			ResponseType: model.CommandResponseTypeEphemeral,
+			Text:         "Error opening mission start dialog. Please try again, or use `/mission start --name [name] --callsign [callsign] ...`",
+		}, nil
+	}
+
+	// Return an empty response, as the dialog will handle the interaction
+	return &model.CommandResponse{
+		ResponseType: model.CommandResponseTypeEphemeral,
+		Text:         "",
+	}, nil
+}
+
+// HandleMissionStart handles the mission start dialog submission
+func (c *Handler) HandleMissionStart(w http.ResponseWriter, r *http.Request) {
+	var request model.SubmitDialogRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		c.client.Log.Error("Error decoding dialog submission", "error", err.Error())
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	commandArgs := map[string]string{}
+	for _, field := range []string{"name", "callsign", "departureAirport", "arrivalAirport", "crew", "priority", "tag"} {
+		if value, ok := request.Submission[field].(string); ok {
+			commandArgs[field] = strings.TrimSpace(value)
+		}
+	}
+	commandArgs["departureAirport"] = strings.ToUpper(commandArgs["departureAirport"])
+	commandArgs["arrivalAirport"] = strings.ToUpper(commandArgs["arrivalAirport"])
+
+	var response model.SubmitDialogResponse
+	result, err := c.startMission(request.UserId, request.TeamId, request.ChannelId, commandArgs)
+	if err != nil {
+		// Keep the dialog open with the error so the user can fix their input
+		response.Error = err.Error()
+	} else if result != nil && result.Text != "" {
+		c.client.Post.SendEphemeralPost(request.UserId, &model.Post{
+			UserId:    c.bot.GetBotUserInfo().UserId,
+			ChannelId: request.ChannelId,
+			Message:   result.Text,
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		c.client.Log.Error("Error writing dialog response", "error", err.Error())
+	}
+}
+
+// createMissionChannel creates the mission channel, reporting whether this start created it. If the name is
+// already taken it returns the existing channel instead, along with the mission already using it, if any.
+// An archived channel without a mission, e.g. one archived by rollbackMissionStart, is restored and counts
+// as created, so a failed retry archives it again. A channel that belongs to a mission with a different
+// callsign or name is an error.
+func (c *Handler) createMissionChannel(channel *model.Channel, info *mission.MissionInfo) (*model.Channel, *mission.Mission, bool, error) {
+	err := c.client.Channel.Create(channel)
+	if err == nil {
+		return channel, nil, true, nil
+	}
+
+	// The server rejects a duplicate channel name with a 400, including the name of an archived channel
+	var appErr *model.AppError
+	if !errors.As(err, &appErr) || appErr.StatusCode != http.StatusBadRequest {
+		return nil, nil, false, err
+	}
+
+	existing, getErr := c.client.Channel.GetByName(channel.TeamId, channel.Name, true)
+	if getErr != nil {
+		c.client.Log.Warn("Error getting existing mission channel", "name", channel.Name, "error", getErr.Error())
+		return nil, nil, false, err
+	}
+
+	existingMission, missionErr := c.mission.GetMissionByChannelID(existing.Id)
+	if missionErr != nil {
+		// The channel was left behind without a mission, e.g. by an interrupted start, so it can be reused
+		if existing.DeleteAt == 0 {
+			return existing, nil, false, nil
+		}
+		if restoreErr := c.restoreMissionChannel(existing, channel.DisplayName); restoreErr != nil {
+			return nil, nil, false, fmt.Errorf("failed to restore archived channel ~%s: %w", existing.Name, restoreErr)
+		}
+		return existing, nil, true, nil
+	}
+
+	if existingMission.Callsign != info.Callsign || existingMission.Name != info.Name {
+		return nil, nil, false, fmt.Errorf("channel ~%s already belongs to mission %s (callsign %s). Please use a different callsign or mission name", existing.Name, existingMission.Name, existingMission.Callsign)
+	}
+
+	return existing, existingMission, false, nil
+}
+
+// restoreMissionChannel unarchives channel and gives it displayName. The plugin API has no restore
+// call, so the channel is saved with its DeleteAt cleared.
+func (c *Handler) restoreMissionChannel(channel *model.Channel, displayName string) error {
+	channel.DeleteAt = 0
+	channel.DisplayName = displayName
+	return c.client.Channel.Update(channel)
+}
+
+// rollbackMissionStart undoes a mission start that failed partway: it deletes the mission record, when
+// missionID is set, and archives the mission channel if this start created it. createMissionChannel restores
+// the archived channel when the start is retried. Failures are only logged, so the
+// original error is the one reported to the user.
+func (c *Handler) rollbackMissionStart(channel *model.Channel, channelCreated bool, missionID string) {
+	if missionID != "" {
+		if err := c.mission.DeleteMission(missionID); err != nil {
+			c.client.Log.Error("Error removing mission after a failed start", "id", missionID, "error", err.Error())
+		}
+	}
+
+	if !channelCreated {
+		return
+	}
+	if err := c.client.Channel.Delete(channel.Id); err != nil {
+		c.client.Log.Error("Error archiving mission channel after a failed start", "channelId", channel.Id, "error", err.Error())
+	}
+}
+
+// addCrewToExistingMission adds the crew from a repeated /mission start to the mission that already exists
+func (c *Handler) addCrewToExistingMission(existing *mission.Mission, crew []model.User) (*model.CommandResponse, error) {
+	crewIds := make([]string, 0, len(crew))
+	for _, user := range crew {
+		if _, err := c.client.Channel.AddUser(existing.ChannelID, user.Id, c.bot.GetBotUserInfo().UserId); err != nil {
+			return c.logCommandError(fmt.Sprintf("Error adding user to channel: userId=%s, error=%s", user.Id, err.Error())), err
+		}
+		crewIds = append(crewIds, user.Id)
+	}
+
+	if err := c.mission.UpdateCrew(existing.ID, crewIds, nil); err != nil {
+		return c.logCommandError(fmt.Sprintf("Error saving the mission crew: %v", err)), err
+	}
+
+	return &model.CommandResponse{
+		ResponseType: model.CommandResponseTypeEphemeral,
+		Text:         fmt.Sprintf("Mission **%s** with callsign **%s** already exists in ~%s. The crew has been added to it.", existing.Name, existing.Callsign, existing.ChannelName),
+	}, nil
+}
+
 // UploadFlightPlanPDF uploads the embedded flight plan PDF to a channel
 func (c *Handler) UploadFlightPlanPDF(channelID string) error {
 
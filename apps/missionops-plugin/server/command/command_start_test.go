@@ -0,0 +1,342 @@
+package command
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/coltoneshaw/demokit/missionops-plugin/server/bot"
+	"github.com/coltoneshaw/demokit/missionops-plugin/server/mission"
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/plugin/plugintest"
+	"github.com/mattermost/mattermost/server/public/pluginapi"
+	"github.com/stretchr/testify/mock"
+)
+
+// testAPI wraps the plugin API mock and ignores log calls
+type testAPI struct {
+	*plugintest.API
+}
+
+func (a *testAPI) LogDebug(msg string, keyValuePairs ...any) {}
+func (a *testAPI) LogInfo(msg string, keyValuePairs ...any)  {}
+func (a *testAPI) LogWarn(msg string, keyValuePairs ...any)  {}
+func (a *testAPI) LogError(msg string, keyValuePairs ...any) {}
+
+// fakeMissions looks up missions by channel ID and records added and deleted missions; other
+// MissionInterface methods are not implemented
+type fakeMissions struct {
+	mission.MissionInterface
+	byChannel map[string]*mission.Mission
+	added     []string
+	deleted   []string
+}
+
+func (f *fakeMissions) GetMissionByChannelID(channelID string) (*mission.Mission, error) {
+	if m, ok := f.byChannel[channelID]; ok {
+		return m, nil
+	}
+	return nil, errors.New("no mission found")
+}
+
+func (f *fakeMissions) AddMission(m *mission.Mission) error {
+	f.added = append(f.added, m.ID)
+	return nil
+}
+
+func (f *fakeMissions) DeleteMission(id string) error {
+	f.deleted = append(f.deleted, id)
+	return nil
+}
+
+func (f *fakeMissions) CategorizeMissionChannel(channelID, teamID string) error {
+	return nil
+}
+
+func (f *fakeMissions) GetStatusEmoji(status string) string {
+	return ""
+}
+
+// fakeBot is a bot that is always a team member; other BotInterface methods are not implemented
+type fakeBot struct {
+	bot.BotInterface
+}
+
+func (f *fakeBot) EnsureTeamMember(teamID string) error {
+	return nil
+}
+
+func (f *fakeBot) GetBotUserInfo() *model.Bot {
+	return &model.Bot{UserId: "bot1"}
+}
+
+func (f *fakeBot) PostMessageFromBot(channelID, message string) (*model.Post, error) {
+	return nil, errors.New("posting is not available in tests")
+}
+
+func TestMissionStartWithoutArgsOpensDialog(t *testing.T) {
+	api := &plugintest.API{}
+	var dialog model.OpenDialogRequest
+	api.On("OpenInteractiveDialog", mock.Anything).Run(func(args mock.Arguments) {
+		dialog = args.Get(0).(model.OpenDialogRequest)
+	}).Return(nil)
+
+	c := &Handler{client: pluginapi.NewClient(&testAPI{API: api}, nil)}
+
+	response, err := c.executeMissionStartCommand(&model.CommandArgs{Command: "/mission start", TriggerId: "trigger1"})
+	if err != nil {
+		t.Fatalf("executeMissionStartCommand returned error: %v", err)
+	}
+	if response.Text != "" {
+		t.Errorf("Expected an empty response, got %q", response.Text)
+	}
+	if dialog.TriggerId != "trigger1" || !strings.HasSuffix(dialog.URL, "/api/v1/missions/start") {
+		t.Errorf("Unexpected dialog request: %+v", dialog)
+	}
+
+	names := make([]string, 0, len(dialog.Dialog.Elements))
+	for _, element := range dialog.Dialog.Elements {
+		names = append(names, element.Name)
+	}
+	if strings.Join(names, ",") != "name,callsign,departureAirport,arrivalAirport,crew,priority,tag" {
+		t.Errorf("Unexpected dialog fields: %v", names)
+	}
+}
+
+func TestHandleMissionStartReturnsValidationErrors(t *testing.T) {
+	api := &plugintest.API{}
+	api.On("GetUserByUsername", "maverick").Return(&model.User{Id: "user1", Username: "maverick"}, nil)
+
+	c := &Handler{
+		client: pluginapi.NewClient(&testAPI{API: api}, nil),
+		bot:    &fakeBot{},
+	}
+
+	body, err := json.Marshal(model.SubmitDialogRequest{
+		UserId:    "user1",
+		TeamId:    "team1",
+		ChannelId: "channel1",
+		Submission: map[string]any{
+			"name":             "Alpha",
+			"callsign":         "Eagle1",
+			"departureAirport": "jfk",
+			"arrivalAirport":   "xyz",
+			"crew":             "@maverick",
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to marshal dialog submission: %v", err)
+	}
+
+	recorder := httptest.NewRecorder()
+	c.HandleMissionStart(recorder, httptest.NewRequest(http.MethodPost, "/api/v1/missions/start", strings.NewReader(string(body))))
+
+	var response model.SubmitDialogResponse
+	if err := json.NewDecoder(recorder.Body).Decode(&response); err != nil {
+		t.Fatalf("Failed to decode dialog response: %v", err)
+	}
+	if !strings.Contains(response.Error, "Unknown airport code: XYZ") {
+		t.Errorf("Expected an unknown airport error, got %q", response.Error)
+	}
+	api.AssertNotCalled(t, "CreateChannel", mock.Anything)
+}
+
+func TestStartMissionListsUnknownCrew(t *testing.T) {
+	api := &plugintest.API{}
+	api.On("GetUserByUsername", "maverick").Return(&model.User{Id: "user1", Username: "maverick"}, nil)
+	api.On("GetUserByUsername", mock.Anything).Return(nil, model.NewAppError("GetUserByUsername", "app.user.get_by_username.app_error", nil, "", http.StatusNotFound))
+
+	c := &Handler{
+		client: pluginapi.NewClient(&testAPI{API: api}, nil),
+		bot:    &fakeBot{},
+	}
+
+	response, err := c.startMission("user1", "team1", "channel1", map[string]string{
+		"name":             "Alpha",
+		"callsign":         "Eagle1",
+		"departureAirport": "JFK",
+		"arrivalAirport":   "LAX",
+		"crew":             "@maverick @goose iceman",
+	})
+	if err == nil {
+		t.Fatal("Expected an error for unknown crew members")
+	}
+	if !strings.Contains(response.Text, "Users not found: @goose, @iceman") {
+		t.Errorf("Expected every unknown username in the error, got %q", response.Text)
+	}
+	api.AssertNotCalled(t, "CreateChannel", mock.Anything)
+}
+
+func TestStartMissionRollsBackWhenCrewCannotBeAdded(t *testing.T) {
+	api := &plugintest.API{}
+	api.On("GetUserByUsername", "maverick").Return(&model.User{Id: "user1", Username: "maverick"}, nil)
+	api.On("GetUserByUsername", "goose").Return(&model.User{Id: "user2", Username: "goose"}, nil)
+	api.On("CreateChannel", mock.Anything).Return(&model.Channel{Id: "channel2", TeamId: "team1", Name: "eagle1-alpha"}, nil)
+	api.On("GetConfig").Return(&model.Config{})
+	api.On("AddUserToChannel", "channel2", "user1", "bot1").Return(&model.ChannelMember{}, nil)
+	api.On("AddUserToChannel", "channel2", "user2", "bot1").Return(nil, model.NewAppError("AddUserToChannel", "api.channel.add_user.to.channel.failed.app_error", nil, "", http.StatusForbidden))
+	api.On("DeleteChannel", "channel2").Return(nil)
+
+	missions := &fakeMissions{}
+	c := &Handler{
+		client:  pluginapi.NewClient(&testAPI{API: api}, nil),
+		bot:     &fakeBot{},
+		mission: missions,
+	}
+
+	response, err := c.startMission("user1", "team1", "channel1", map[string]string{
+		"name":             "Alpha",
+		"callsign":         "Eagle1",
+		"departureAirport": "JFK",
+		"arrivalAirport":   "LAX",
+		"crew":             "@maverick @goose",
+	})
+	if err == nil {
+		t.Fatal("Expected an error when a crew member cannot be added")
+	}
+	if !strings.Contains(response.Text, "@goose") {
+		t.Errorf("Expected the error to name the crew member, got %q", response.Text)
+	}
+
+	api.AssertCalled(t, "DeleteChannel", "channel2")
+	if len(missions.added) != 0 {
+		t.Errorf("Expected no mission to be saved, got %v", missions.added)
+	}
+}
+
+func TestStartMissionRetryRestoresRolledBackChannel(t *testing.T) {
+	api := &plugintest.API{}
+	api.On("GetUserByUsername", "maverick").Return(&model.User{Id: "user1", Username: "maverick"}, nil)
+	api.On("GetUserByUsername", "goose").Return(&model.User{Id: "user2", Username: "goose"}, nil)
+	api.On("GetConfig").Return(&model.Config{})
+	api.On("AddUserToChannel", "channel2", "user1", "bot1").Return(&model.ChannelMember{}, nil)
+	api.On("AddUserToChannel", "channel2", "user2", "bot1").Return(nil, model.NewAppError("AddUserToChannel", "api.channel.add_user.to.channel.failed.app_error", nil, "", http.StatusForbidden)).Once()
+	api.On("AddUserToChannel", "channel2", "user2", "bot1").Return(&model.ChannelMember{}, nil)
+
+	// The channel name stays taken once the channel is archived, so the retry has to find and restore it
+	var archivedAt int64
+	api.On("CreateChannel", mock.Anything).Return(&model.Channel{Id: "channel2", TeamId: "team1", Name: "eagle1-alpha"}, nil).Once()
+	api.On("CreateChannel", mock.Anything).Return(nil, model.NewAppError("CreateChannel", "store.sql_channel.save_channel.exists.app_error", nil, "", http.StatusBadRequest))
+	api.On("DeleteChannel", "channel2").Run(func(mock.Arguments) { archivedAt = model.GetMillis() }).Return(nil)
+	api.On("GetChannelByName", "team1", "eagle1-alpha", true).Return(func(string, string, bool) *model.Channel {
+		return &model.Channel{Id: "channel2", TeamId: "team1", Name: "eagle1-alpha", DeleteAt: archivedAt}
+	}, nil)
+	var restored *model.Channel
+	api.On("UpdateChannel", mock.Anything).Run(func(args mock.Arguments) {
+		restored = args.Get(0).(*model.Channel)
+	}).Return(func(channel *model.Channel) *model.Channel { return channel }, nil)
+
+	missions := &fakeMissions{}
+	c := &Handler{
+		client:  pluginapi.NewClient(&testAPI{API: api}, nil),
+		bot:     &fakeBot{},
+		mission: missions,
+	}
+	args := map[string]string{
+		"name":             "Alpha",
+		"callsign":         "Eagle1",
+		"departureAirport": "JFK",
+		"arrivalAirport":   "LAX",
+		"crew":             "@maverick @goose",
+	}
+
+	if _, err := c.startMission("user1", "team1", "channel1", args); err == nil {
+		t.Fatal("Expected the first start to fail")
+	}
+	api.AssertCalled(t, "DeleteChannel", "channel2")
+
+	// The retry fails only when posting the mission details, after the mission is saved
+	if _, err := c.startMission("user1", "team1", "channel1", args); err == nil || !strings.Contains(err.Error(), "posting") {
+		t.Fatalf("Expected the retry to get as far as posting, got %v", err)
+	}
+	if restored == nil || restored.Id != "channel2" || restored.DeleteAt != 0 {
+		t.Fatalf("Expected the archived channel to be restored, got %+v", restored)
+	}
+	if len(missions.added) != 1 {
+		t.Errorf("Expected the retry to save the mission, got %v", missions.added)
+	}
+}
+
+func TestCreateMissionChannelReusesExistingChannel(t *testing.T) {
+	existingChannel := &model.Channel{Id: "channel1", TeamId: "team1", Name: "eagle1-alpha"}
+	info := &mission.MissionInfo{Name: "Alpha", Callsign: "Eagle1"}
+
+	testCases := []struct {
+		name            string
+		createStatus    int
+		missions        map[string]*mission.Mission
+		expectLookup    bool
+		expectError     bool
+		expectedMission string
+	}{
+		{
+			name:         "channel left behind without a mission",
+			createStatus: http.StatusBadRequest,
+			expectLookup: true,
+		},
+		{
+			name:            "same mission started again",
+			createStatus:    http.StatusBadRequest,
+			missions:        map[string]*mission.Mission{"channel1": {ID: "m1", Name: "Alpha", Callsign: "Eagle1"}},
+			expectLookup:    true,
+			expectedMission: "m1",
+		},
+		{
+			name:         "channel belongs to a different mission",
+			createStatus: http.StatusBadRequest,
+			missions:     map[string]*mission.Mission{"channel1": {ID: "m2", Name: "alpha", Callsign: "eagle1"}},
+			expectLookup: true,
+			expectError:  true,
+		},
+		{
+			name:         "other creation errors are returned",
+			createStatus: http.StatusInternalServerError,
+			expectError:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			api := &plugintest.API{}
+			api.On("CreateChannel", mock.Anything).Return(nil, model.NewAppError("CreateChannel", "store.sql_channel.save_channel.exists.app_error", nil, "", tc.createStatus))
+			api.On("GetChannelByName", "team1", "eagle1-alpha", true).Return(existingChannel, nil)
+
+			c := &Handler{
+				client:  pluginapi.NewClient(&testAPI{API: api}, nil),
+				mission: &fakeMissions{byChannel: tc.missions},
+			}
+
+			channel, existingMission, created, err := c.createMissionChannel(&model.Channel{TeamId: "team1", Name: "eagle1-alpha"}, info)
+
+			if tc.expectLookup {
+				api.AssertCalled(t, "GetChannelByName", "team1", "eagle1-alpha", true)
+			} else {
+				api.AssertNotCalled(t, "GetChannelByName", mock.Anything, mock.Anything, mock.Anything)
+			}
+
+			if tc.expectError {
+				if err == nil {
+					t.Fatal("Expected an error creating the mission channel")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("createMissionChannel returned error: %v", err)
+			}
+			if channel.Id != existingChannel.Id || created {
+				t.Errorf("Expected the existing channel to be reused, got %+v (created %v)", channel, created)
+			}
+
+			missionID := ""
+			if existingMission != nil {
+				missionID = existingMission.ID
+			}
+			if missionID != tc.expectedMission {
+				t.Errorf("Expected existing mission %q, got %q", tc.expectedMission, missionID)
+			}
+		})
+	}
+}
@@ -76,7 +76,7 @@ func (c *Handler) executeMissionStatusCommand(args *model.CommandArgs) (*model.C
 	oldStatus := mission.Status
 
 	// Update the mission status
-	if err := c.mission.UpdateMissionStatus(missionID, status); err != nil {
+	if err := c.mission.UpdateMissionStatus(missionID, status, args.UserId); err != nil {
 		c.client.Log.Error("Error updating mission status", "error", err.Error())
 		return &model.CommandResponse{
 			ResponseType: model.CommandResponseTypeEphemeral,
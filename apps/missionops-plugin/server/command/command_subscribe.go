@@ -2,7 +2,7 @@ package command
 
 import (
 	"fmt"
-	"strconv"
+	"slices"
 	"strings"
 	"time"
 
@@ -16,7 +16,17 @@ func (c *Handler) executeMissionSubscribeCommand(args *model.CommandArgs) (*mode
 	commandArgs := parseArgs(args.Command)
 
 	typesStr := commandArgs["type"]
-	frequencyStr := commandArgs["frequency"]
+	digest := slices.Contains(strings.Fields(args.Command), "--digest")
+	frequencyStr := commandArgs["update-frequency"]
+	if legacyFrequency := commandArgs["frequency"]; legacyFrequency != "" {
+		if frequencyStr != "" {
+			return &model.CommandResponse{
+				ResponseType: model.CommandResponseTypeEphemeral,
+				Text:         "Use either `--update-frequency` or `--frequency`, not both.",
+			}, nil
+		}
+		frequencyStr = legacyFrequency
+	}
 
 	// Check for help request
 	if commandArgs["help"] != "" || commandArgs["--help"] != "" {
@@ -33,7 +43,7 @@ func (c *Handler) executeMissionSubscribeCommand(args *model.CommandArgs) (*mode
 	if frequencyStr == "" {
 		return &model.CommandResponse{
 			ResponseType: model.CommandResponseTypeEphemeral,
-			Text:         "Update frequency is required. Use `--frequency [seconds]`",
+			Text:         "Update frequency is required. Use `--update-frequency [duration]`, e.g. `--update-frequency 1h`",
 		}, nil
 	}
 
@@ -62,19 +72,11 @@ func (c *Handler) executeMissionSubscribeCommand(args *model.CommandArgs) (*mode
 	}
 
 	// Parse frequency
-	frequency, err := strconv.ParseInt(frequencyStr, 10, 64)
+	frequency, err := parseUpdateFrequency(frequencyStr)
 	if err != nil {
 		return &model.CommandResponse{
 			ResponseType: model.CommandResponseTypeEphemeral,
-			Text:         "Invalid frequency format. Please use seconds (e.g., 3600 for hourly).",
-		}, nil
-	}
-
-	// Validate minimum frequency (5 minutes = 300 seconds)
-	if frequency < 300 {
-		return &model.CommandResponse{
-			ResponseType: model.CommandResponseTypeEphemeral,
-			Text:         "Frequency must be at least 300 seconds (5 minutes).",
+			Text:         fmt.Sprintf("Unable to subscribe: %v", err),
 		}, nil
 	}
 
@@ -86,6 +88,9 @@ func (c *Handler) executeMissionSubscribeCommand(args *model.CommandArgs) (*mode
 		StatusTypes:     statusTypes,
 		UpdateFrequency: frequency,
 		LastUpdated:     time.Now(),
+		Digest:          digest,
+
+		NotifyDebounceSeconds: subscription.DefaultNotifyDebounceSeconds,
 	}
 
 	// Add the subscription
@@ -113,7 +118,11 @@ func (c *Handler) executeMissionSubscribeCommand(args *model.CommandArgs) (*mode
 	}
 
 	// Send confirmation message
-	_, err = c.bot.PostMessageFromBot(args.ChannelId, fmt.Sprintf("✅ Subscribed to %s. Updates will be sent every %d seconds (ID: `%s`).", statusTypesText, frequency, subscription.ID))
+	confirmation := fmt.Sprintf("✅ Subscribed to %s. Updates will be sent every %d seconds (ID: `%s`).", statusTypesText, frequency, subscription.ID)
+	if digest {
+		confirmation = fmt.Sprintf("✅ Subscribed to a digest of %s. Status changes will be summarized every %d seconds (ID: `%s`).", statusTypesText, frequency, subscription.ID)
+	}
+	_, err = c.bot.PostMessageFromBot(args.ChannelId, confirmation)
 
 	if err != nil {
 		c.client.Log.Error("Error sending confirmation message", "error", err.Error())
@@ -135,14 +144,17 @@ func executeMissionSubscribeHelpCommand(args *model.CommandArgs) (*model.Command
 	helpText := "**Mission Subscription Command Help**\n\n" +
 		"The subscribe command allows you to receive automatic updates about missions with specific statuses.\n\n" +
 		"**Usage:**\n" +
-		"- `/mission subscribe --type [status1,status2,...] --frequency [seconds]` - Subscribe to specific mission statuses\n" +
-		"- `/mission subscribe --type all --frequency [seconds]` - Subscribe to all mission statuses\n\n" +
+		"- `/mission subscribe --type [status1,status2,...] --update-frequency [duration]` - Subscribe to specific mission statuses\n" +
+		"- `/mission subscribe --type all --update-frequency [duration]` - Subscribe to all mission statuses\n" +
+		"- `/mission subscribe --type all --update-frequency [duration] --digest` - Get one summary of status changes per update instead of a post for each change\n\n" +
 		"**Parameters:**\n" +
 		"- `--type` or `--types`: Comma-separated list of statuses to subscribe to (stalled, in-air, completed, cancelled), or 'all'\n" +
-		"- `--frequency`: How often to receive updates, in seconds (minimum 300 seconds / 5 minutes)\n\n" +
+		"- `--update-frequency`: How often to receive updates, as a duration like 30m, 1h or 1h30m, or in seconds (minimum 5 minutes). `--frequency` is accepted as an alias\n" +
+		"- `--digest`: Collect status changes and post them as one summary at the update frequency, instead of posting each change as it happens\n\n" +
 		"**Examples:**\n" +
-		"- `/mission subscribe --type stalled,in-air --frequency 3600` - Hourly updates for stalled and in-air missions\n" +
-		"- `/mission subscribe --type all --frequency 1800` - Updates every 30 minutes for all mission statuses\n\n" +
+		"- `/mission subscribe --type stalled,in-air --update-frequency 1h` - Hourly updates for stalled and in-air missions\n" +
+		"- `/mission subscribe --type all --update-frequency 30m` - Updates every 30 minutes for all mission statuses\n" +
+		"- `/mission subscribe --type completed,cancelled --update-frequency 1h --digest` - An hourly summary of missions that finished\n\n" +
 		"To view existing subscriptions, use `/mission subscriptions`\n" +
 		"To cancel a subscription, use `/mission unsubscribe --id [subscription_id]`"
 
@@ -46,6 +46,9 @@ func (c *Handler) executeMissionSubscriptionsCommand(args *model.CommandArgs) (*
 		if len(sub.StatusTypes) > 0 {
 			statusTypesText = strings.Join(sub.StatusTypes, ", ")
 		}
+		if sub.Digest {
+			statusTypesText += " (digest)"
+		}
 
 		// Calculate time until next update
 		nextUpdateTime := sub.LastUpdated.Add(time.Duration(sub.UpdateFrequency) * time.Second)
@@ -0,0 +1,136 @@
+package command
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/coltoneshaw/demokit/missionops-plugin/server/mission"
+	"github.com/coltoneshaw/demokit/missionops-plugin/server/template"
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+// executeMissionTemplateCommand handles the /mission template command
+func (c *Handler) executeMissionTemplateCommand(args *model.CommandArgs) (*model.CommandResponse, error) {
+	split := strings.Fields(args.Command)
+	action := ""
+	if len(split) > 2 {
+		action = split[2]
+	}
+
+	switch action {
+	case "save":
+		return c.executeMissionTemplateSaveCommand(args)
+	case "list":
+		return c.executeMissionTemplateListCommand()
+	default:
+		return &model.CommandResponse{
+			ResponseType: model.CommandResponseTypeEphemeral,
+			Text:         "Unknown template command. Use `/mission template save --name [name] ...` or `/mission template list`",
+		}, nil
+	}
+}
+
+// executeMissionTemplateSaveCommand handles the /mission template save command
+func (c *Handler) executeMissionTemplateSaveCommand(args *model.CommandArgs) (*model.CommandResponse, error) {
+	// Parse arguments
+	commandArgs := parseArgs(args.Command)
+
+	t := &template.MissionTemplate{
+		Name:             commandArgs["name"],
+		CallsignPrefix:   commandArgs["callsign-prefix"],
+		DepartureAirport: strings.ToUpper(commandArgs["departureAirport"]),
+		ArrivalAirport:   strings.ToUpper(commandArgs["arrivalAirport"]),
+		CreatedBy:        args.UserId,
+		UpdatedAt:        time.Now(),
+	}
+
+	if t.Name == "" {
+		return &model.CommandResponse{
+			ResponseType: model.CommandResponseTypeEphemeral,
+			Text:         "Template name is required. Use `--name [name]`",
+		}, nil
+	}
+	if t.CallsignPrefix == "" && t.DepartureAirport == "" && t.ArrivalAirport == "" {
+		return &model.CommandResponse{
+			ResponseType: model.CommandResponseTypeEphemeral,
+			Text:         "A template needs at least one default. Use `--callsign-prefix`, `--departureAirport` or `--arrivalAirport`",
+		}, nil
+	}
+
+	for _, code := range []string{t.DepartureAirport, t.ArrivalAirport} {
+		if code != "" && !mission.IsValidAirportCode(code) {
+			return &model.CommandResponse{
+				ResponseType: model.CommandResponseTypeEphemeral,
+				Text:         fmt.Sprintf("Unknown airport code: %s. Use a 3-letter IATA (e.g. JFK) or 4-letter ICAO (e.g. KJFK) code", code),
+			}, nil
+		}
+	}
+
+	if err := c.template.SaveTemplate(t); err != nil {
+		return c.logCommandError(fmt.Sprintf("Error saving mission template: %v", err)), nil
+	}
+
+	return &model.CommandResponse{
+		ResponseType: model.CommandResponseTypeEphemeral,
+		Text:         fmt.Sprintf("✅ Saved mission template **%s**. Use it with `/mission start --template %s --name [name] --crew @user1 ...`", t.Name, t.Name),
+	}, nil
+}
+
+// executeMissionTemplateListCommand handles the /mission template list command
+func (c *Handler) executeMissionTemplateListCommand() (*model.CommandResponse, error) {
+	templates, err := c.template.GetAllTemplates()
+	if err != nil {
+		return c.logCommandError(fmt.Sprintf("Error getting mission templates: %v", err)), nil
+	}
+
+	if len(templates) == 0 {
+		return &model.CommandResponse{
+			ResponseType: model.CommandResponseTypeEphemeral,
+			Text:         "No mission templates found. Create one with `/mission template save --name [name] ...`",
+		}, nil
+	}
+
+	var sb strings.Builder
+	sb.WriteString("# Mission Templates\n\n")
+	sb.WriteString("| Name | Callsign Prefix | Departure | Arrival |\n")
+	sb.WriteString("|------|-----------------|-----------|---------|\n")
+	for _, t := range templates {
+		sb.WriteString(fmt.Sprintf("| %s | %s | %s | %s |\n", t.Name, t.CallsignPrefix, t.DepartureAirport, t.ArrivalAirport))
+	}
+
+	return &model.CommandResponse{
+		ResponseType: model.CommandResponseTypeEphemeral,
+		Text:         sb.String(),
+	}, nil
+}
+
+// applyTemplate fills start arguments that were not given explicitly with the template's values.
+// Without an explicit callsign, the next free callsign with the template's prefix is used.
+func applyTemplate(commandArgs map[string]string, t *template.MissionTemplate, missions []*mission.Mission) {
+	if commandArgs["callsign"] == "" && t.CallsignPrefix != "" {
+		commandArgs["callsign"] = nextTemplateCallsign(t.CallsignPrefix, missions)
+	}
+	if commandArgs["departureAirport"] == "" {
+		commandArgs["departureAirport"] = t.DepartureAirport
+	}
+	if commandArgs["arrivalAirport"] == "" {
+		commandArgs["arrivalAirport"] = t.ArrivalAirport
+	}
+}
+
+// nextTemplateCallsign returns the prefix followed by one more than the highest number already used
+// after it in a mission callsign, e.g. TRN3 when TRN1 and TRN2 exist
+func nextTemplateCallsign(prefix string, missions []*mission.Mission) string {
+	highest := 0
+	for _, m := range missions {
+		if len(m.Callsign) <= len(prefix) || !strings.EqualFold(m.Callsign[:len(prefix)], prefix) {
+			continue
+		}
+		if n, err := strconv.Atoi(m.Callsign[len(prefix):]); err == nil && n > highest {
+			highest = n
+		}
+	}
+	return fmt.Sprintf("%s%d", prefix, highest+1)
+}
@@ -0,0 +1,52 @@
+package command
+
+import (
+	"testing"
+
+	"github.com/coltoneshaw/demokit/missionops-plugin/server/mission"
+	"github.com/coltoneshaw/demokit/missionops-plugin/server/template"
+)
+
+func TestApplyTemplate(t *testing.T) {
+	training := &template.MissionTemplate{Name: "training", CallsignPrefix: "TRN", DepartureAirport: "JFK", ArrivalAirport: "LAX"}
+	missions := []*mission.Mission{
+		{Callsign: "TRN1"},
+		{Callsign: "trn4"},
+		{Callsign: "TRNX"},
+		{Callsign: "Eagle7"},
+	}
+
+	testCases := []struct {
+		name     string
+		args     map[string]string
+		expected map[string]string
+	}{
+		{
+			name:     "fills missing values",
+			args:     map[string]string{"name": "Alpha"},
+			expected: map[string]string{"name": "Alpha", "callsign": "TRN5", "departureAirport": "JFK", "arrivalAirport": "LAX"},
+		},
+		{
+			name:     "explicit flags override the template",
+			args:     map[string]string{"name": "Bravo", "callsign": "Eagle8", "arrivalAirport": "SFO"},
+			expected: map[string]string{"name": "Bravo", "callsign": "Eagle8", "departureAirport": "JFK", "arrivalAirport": "SFO"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			applyTemplate(tc.args, training, missions)
+			for key, value := range tc.expected {
+				if tc.args[key] != value {
+					t.Errorf("Expected %s to be %q, got %q", key, value, tc.args[key])
+				}
+			}
+		})
+	}
+}
+
+func TestNextTemplateCallsign(t *testing.T) {
+	if callsign := nextTemplateCallsign("CGO", nil); callsign != "CGO1" {
+		t.Errorf("Expected the first callsign to be CGO1, got %q", callsign)
+	}
+}
@@ -0,0 +1,84 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/coltoneshaw/demokit/missionops-plugin/server/mission"
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+// executeMissionTimelineCommand handles the /mission timeline command
+func (c *Handler) executeMissionTimelineCommand(args *model.CommandArgs) (*model.CommandResponse, error) {
+	// Parse arguments
+	commandArgs := parseArgs(args.Command)
+	missionID := commandArgs["id"]
+
+	// If no mission ID provided, try to find the mission based on channel ID
+	if missionID == "" {
+		m, err := c.mission.GetMissionByChannelID(args.ChannelId)
+		if err != nil {
+			return &model.CommandResponse{
+				ResponseType: model.CommandResponseTypeEphemeral,
+				Text:         "This command must be run in a mission channel, or provide --id [mission_id]",
+			}, nil
+		}
+		missionID = m.ID
+	}
+
+	m, err := c.mission.GetMission(missionID)
+	if err != nil {
+		return &model.CommandResponse{
+			ResponseType: model.CommandResponseTypeEphemeral,
+			Text:         "Mission not found with the provided ID.",
+		}, nil
+	}
+
+	if len(m.Timeline) == 0 {
+		return &model.CommandResponse{
+			ResponseType: model.CommandResponseTypeEphemeral,
+			Text:         fmt.Sprintf("Mission **%s** has no status changes yet. Current status: %s %s", m.Name, c.mission.GetStatusEmoji(m.Status), m.Status),
+		}, nil
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("# Timeline for %s (%s)\n\n", m.Name, m.Callsign))
+	for _, event := range m.Timeline {
+		sb.WriteString(c.formatMissionEvent(event))
+	}
+
+	return &model.CommandResponse{
+		ResponseType: model.CommandResponseTypeEphemeral,
+		Text:         sb.String(),
+	}, nil
+}
+
+// formatMissionEvent formats a status change as a Markdown list item
+func (c *Handler) formatMissionEvent(event mission.MissionEvent) string {
+	oldStatus := event.OldStatus
+	if oldStatus == "" {
+		oldStatus = "none"
+	}
+
+	return fmt.Sprintf("- %s: %s %s → %s %s by %s\n",
+		event.Timestamp.Format(time.RFC1123),
+		c.mission.GetStatusEmoji(event.OldStatus), oldStatus,
+		c.mission.GetStatusEmoji(event.NewStatus), event.NewStatus,
+		c.describeUser(event.UserID))
+}
+
+// describeUser returns @username for a user ID, falling back to the ID when the user can't be found
+func (c *Handler) describeUser(userID string) string {
+	if userID == "" {
+		return "unknown user"
+	}
+
+	user, err := c.client.User.Get(userID)
+	if err != nil {
+		c.client.Log.Warn("Error getting user for mission timeline", "userId", userID, "error", err.Error())
+		return userID
+	}
+
+	return "@" + user.Username
+}
@@ -1,6 +1,36 @@
 package command
 
-import "strings"
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// minUpdateFrequency is the shortest allowed subscription update frequency, in seconds
+const minUpdateFrequency = 300
+
+// parseUpdateFrequency parses a subscription update frequency given in seconds or as a duration like 30m or 1h
+// and returns it in seconds
+func parseUpdateFrequency(value string) (int64, error) {
+	// Try to parse as seconds first
+	frequency, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		// Try to parse as duration
+		duration, err := time.ParseDuration(value)
+		if err != nil {
+			return 0, fmt.Errorf("invalid frequency: %s. Please use seconds (e.g., 3600 for hourly) or a duration like 30m, 1h, 1h30m", value)
+		}
+		frequency = int64(duration / time.Second)
+	}
+
+	// Validate minimum frequency (5 minutes = 300 seconds)
+	if frequency < minUpdateFrequency {
+		return 0, fmt.Errorf("frequency must be at least %d seconds (5 minutes)", minUpdateFrequency)
+	}
+
+	return frequency, nil
+}
 
 // parseArgs parses command arguments from Mattermost slash command format
 func parseArgs(command string) map[string]string {
@@ -46,3 +76,29 @@ func parseArgs(command string) map[string]string {
 
 	return args
 }
+
+// parseRepeatedArg returns the value of every --key flag in the command, for flags that may be given
+// more than once, like --tag alpha --tag bravo. parseArgs only keeps the last one.
+func parseRepeatedArg(command string, key string) []string {
+	var values []string
+	var value []string
+	inKey := false
+
+	for _, part := range strings.Fields(command) {
+		if strings.HasPrefix(part, "--") {
+			if inKey && len(value) > 0 {
+				values = append(values, strings.Join(value, " "))
+			}
+			inKey = strings.TrimPrefix(part, "--") == key
+			value = nil
+		} else if inKey {
+			value = append(value, part)
+		}
+	}
+
+	if inKey && len(value) > 0 {
+		values = append(values, strings.Join(value, " "))
+	}
+
+	return values
+}
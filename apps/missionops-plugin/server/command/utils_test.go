@@ -0,0 +1,59 @@
+package command
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestParseUpdateFrequency(t *testing.T) {
+	testCases := []struct {
+		input       string
+		expected    int64
+		expectError bool
+	}{
+		{input: "3600", expected: 3600},
+		{input: "1h", expected: 3600},
+		{input: "30m", expected: 1800},
+		{input: "1h30m", expected: 5400},
+		{input: "invalid", expectError: true},
+		{input: "10s", expectError: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			frequency, err := parseUpdateFrequency(tc.input)
+			if tc.expectError {
+				if err == nil {
+					t.Errorf("Expected an error for %q, got %d", tc.input, frequency)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseUpdateFrequency(%q) returned error: %v", tc.input, err)
+			}
+			if frequency != tc.expected {
+				t.Errorf("Expected %d seconds for %q, got %d", tc.expected, tc.input, frequency)
+			}
+		})
+	}
+}
+
+func TestParseRepeatedArg(t *testing.T) {
+	testCases := []struct {
+		command  string
+		expected []string
+	}{
+		{command: "/mission start --name Alpha", expected: nil},
+		{command: "/mission start --tag alpha --tag bravo", expected: []string{"alpha", "bravo"}},
+		{command: "/mission start --tag alpha,bravo --name Alpha", expected: []string{"alpha,bravo"}},
+		{command: "/mission start --tag --name Alpha --tag night ops", expected: []string{"night ops"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.command, func(t *testing.T) {
+			if values := parseRepeatedArg(tc.command, "tag"); !slices.Equal(values, tc.expected) {
+				t.Errorf("Expected %v for %q, got %v", tc.expected, tc.command, values)
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package mission
+
+import "strings"
+
+// airportCodes maps the IATA code of each airport missions can fly between to its ICAO code.
+// Missions start /weather lookups for both airports, so unknown codes are rejected up front.
+var airportCodes = map[string]string{
+	// United States
+	"ATL": "KATL", "AUS": "KAUS", "BNA": "KBNA", "BOS": "KBOS", "BWI": "KBWI",
+	"CLT": "KCLT", "DCA": "KDCA", "DEN": "KDEN", "DFW": "KDFW", "DTW": "KDTW",
+	"EWR": "KEWR", "FLL": "KFLL", "HNL": "PHNL", "IAD": "KIAD", "IAH": "KIAH",
+	"JFK": "KJFK", "LAS": "KLAS", "LAX": "KLAX", "LGA": "KLGA", "MCO": "KMCO",
+	"MDW": "KMDW", "MIA": "KMIA", "MSP": "KMSP", "ORD": "KORD", "PDX": "KPDX",
+	"PHL": "KPHL", "PHX": "KPHX", "RDU": "KRDU", "SAN": "KSAN", "SEA": "KSEA",
+	"SFO": "KSFO", "SLC": "KSLC", "STL": "KSTL", "TPA": "KTPA", "ANC": "PANC",
+
+	// US military airfields
+	"ADW": "KADW", "BAB": "KBAB", "BLV": "KBLV", "DOV": "KDOV", "EDW": "KEDW",
+	"FFO": "KFFO", "LSV": "KLSV", "NKX": "KNKX", "NZY": "KNZY", "SKA": "KSKA",
+	"SUU": "KSUU", "TCM": "KTCM", "VPS": "KVPS", "WRI": "KWRI",
+
+	// Canada and Mexico
+	"MEX": "MMMX", "YUL": "CYUL", "YVR": "CYVR", "YYC": "CYYC", "YYZ": "CYYZ",
+
+	// Europe
+	"AMS": "EHAM", "ARN": "ESSA", "BCN": "LEBL", "CDG": "LFPG", "CPH": "EKCH",
+	"DUB": "EIDW", "FCO": "LIRF", "FRA": "EDDF", "IST": "LTFM", "LGW": "EGKK",
+	"LHR": "EGLL", "MAD": "LEMD", "MUC": "EDDM", "OSL": "ENGM", "RMS": "ETAR",
+	"SVO": "UUEE", "VIE": "LOWW", "ZRH": "LSZH",
+
+	// Asia, Middle East and Oceania
+	"AKL": "NZAA", "BKK": "VTBS", "DEL": "VIDP", "DOH": "OTHH", "DXB": "OMDB",
+	"HKG": "VHHH", "HND": "RJTT", "ICN": "RKSI", "KIX": "RJBB", "MEL": "YMML",
+	"NRT": "RJAA", "OSN": "RKSO", "PEK": "ZBAA", "PVG": "ZSPD", "SIN": "WSSS",
+	"SYD": "YSSY",
+
+	// South America and Africa
+	"BOG": "SKBO", "CPT": "FACT", "EZE": "SAEZ", "GIG": "SBGL", "GRU": "SBGR",
+	"JNB": "FAOR", "LIM": "SPJC", "NBO": "HKJK", "SCL": "SCEL",
+}
+
+// icaoCodes is the set of ICAO codes in airportCodes
+var icaoCodes = func() map[string]bool {
+	codes := make(map[string]bool, len(airportCodes))
+	for _, icao := range airportCodes {
+		codes[icao] = true
+	}
+	return codes
+}()
+
+// IsValidAirportCode reports whether code is a known 3-letter IATA or 4-letter ICAO airport code
+func IsValidAirportCode(code string) bool {
+	code = strings.ToUpper(code)
+	switch len(code) {
+	case 3:
+		_, ok := airportCodes[code]
+		return ok
+	case 4:
+		return icaoCodes[code]
+	default:
+		return false
+	}
+}
@@ -0,0 +1,28 @@
+package mission
+
+import "testing"
+
+func TestIsValidAirportCode(t *testing.T) {
+	testCases := []struct {
+		code     string
+		expected bool
+	}{
+		{code: "JFK", expected: true},
+		{code: "lax", expected: true},
+		{code: "KJFK", expected: true},
+		{code: "EGLL", expected: true},
+		{code: "JFX", expected: false},
+		{code: "KJFX", expected: false},
+		{code: "JF", expected: false},
+		{code: "KJFKX", expected: false},
+		{code: "", expected: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.code, func(t *testing.T) {
+			if valid := IsValidAirportCode(tc.code); valid != tc.expected {
+				t.Errorf("Expected IsValidAirportCode(%q) to be %v, got %v", tc.code, tc.expected, valid)
+			}
+		})
+	}
+}
@@ -7,16 +7,15 @@ import (
 
 // completeMission is called when the dialog is submitted
 func (m *Mission) CompleteMission(missionID, objectivesCompletion, notableEvents, crewPerformance, missionDurationStr, userID string) error {
-	// Set status to completed
-	if err := m.UpdateMissionStatus(missionID, "completed"); err != nil {
-		m.client.Log.Error("Error updating mission status", "error", err.Error())
-		return err
-	}
-
-	// Get the updated mission
-	mission, err := m.GetMission(missionID)
+	mission, err := m.saveCompletion(missionID, &MissionReport{
+		ObjectivesCompletion: objectivesCompletion,
+		Duration:             missionDurationStr,
+		CrewPerformance:      crewPerformance,
+		NotableEvents:        notableEvents,
+		SubmittedBy:          userID,
+		SubmittedAt:          time.Now(),
+	})
 	if err != nil {
-		m.client.Log.Error("Mission not found after update", "error", err.Error())
 		return err
 	}
 
@@ -45,37 +44,8 @@ func (m *Mission) CompleteMission(missionID, objectivesCompletion, notableEvents
 	reportMsg += fmt.Sprintf("**Mission:** %s (Callsign: **%s**)\n", mission.Name, mission.Callsign)
 	reportMsg += fmt.Sprintf("**Route:** %s → %s\n", mission.DepartureAirport, mission.ArrivalAirport)
 	reportMsg += fmt.Sprintf("**Duration:** %s hours\n", missionDurationStr)
-	// Format objectives completion
-	var objectivesText string
-
-	switch objectivesCompletion {
-	case "all_completed":
-		objectivesText = "✅ All objectives completed"
-	case "partial":
-		objectivesText = "⚠️ Partial objectives completed"
-	case "none":
-		objectivesText = "❌ Mission objectives not met"
-	default:
-		objectivesText = "Unknown"
-	}
-
-	reportMsg += fmt.Sprintf("**Objectives:** %s\n", objectivesText)
-
-	// Format crew performance
-	var performanceText string
-	switch crewPerformance {
-	case "excellent":
-		performanceText = "⭐⭐⭐⭐⭐ Excellent"
-	case "good":
-		performanceText = "⭐⭐⭐⭐ Good"
-	case "satisfactory":
-		performanceText = "⭐⭐⭐ Satisfactory"
-	case "needs_improvement":
-		performanceText = "⭐⭐ Needs Improvement"
-	default:
-		performanceText = "Unknown"
-	}
-	reportMsg += fmt.Sprintf("**Crew Performance:** %s\n", performanceText)
+	reportMsg += fmt.Sprintf("**Objectives:** %s\n", withPrefix(objectivesEmoji[objectivesCompletion], ObjectivesText(objectivesCompletion)))
+	reportMsg += fmt.Sprintf("**Crew Performance:** %s\n", withPrefix(crewPerformanceStars[crewPerformance], CrewPerformanceText(crewPerformance)))
 
 	// Add notable events if provided
 	if notableEvents != "" {
@@ -86,7 +56,7 @@ func (m *Mission) CompleteMission(missionID, objectivesCompletion, notableEvents
 	if err != nil {
 		m.client.Log.Error("Error getting user", "error", err.Error())
 	}
-	reportMsg += fmt.Sprintf("\n*Report submitted by @%s on %s*", submittingUser.Username, time.Now().Format(time.RFC1123))
+	reportMsg += fmt.Sprintf("\n*Report submitted by @%s on %s*", submittingUser.Username, mission.Report.SubmittedAt.Format(time.RFC1123))
 
 	// Post to mission channel
 	_, err = m.bot.PostMessageFromBot(mission.ChannelID, reportMsg)
@@ -105,3 +75,31 @@ func (m *Mission) CompleteMission(missionID, objectivesCompletion, notableEvents
 
 	return nil
 }
+
+// saveCompletion marks a mission completed and saves its report in one update, returning the saved mission
+func (m *Mission) saveCompletion(missionID string, report *MissionReport) (*Mission, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	// Set status to completed
+	if err := m.updateMissionStatus(missionID, "completed", report.SubmittedBy); err != nil {
+		m.client.Log.Error("Error updating mission status", "error", err.Error())
+		return nil, err
+	}
+
+	// Get the updated mission
+	mission, err := m.getMission(missionID)
+	if err != nil {
+		m.client.Log.Error("Mission not found after update", "error", err.Error())
+		return nil, err
+	}
+
+	// Keep the submitted report so it can be exported later with /mission report
+	mission.Report = report
+	if err := m.saveMission(mission); err != nil {
+		m.client.Log.Error("Error saving mission report", "error", err.Error())
+		return nil, err
+	}
+
+	return mission, nil
+}
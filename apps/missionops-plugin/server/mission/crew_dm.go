@@ -0,0 +1,61 @@
+package mission
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+)
+
+// crewDMStatuses are the statuses that send the crew a direct message when MISSION_CREW_DMS is enabled
+var crewDMStatuses = []string{"in-air", "cancelled"}
+
+// crewDMsFromEnv reads MISSION_CREW_DMS, returning false (disabled) when it is unset or invalid
+func crewDMsFromEnv() bool {
+	enabled, err := strconv.ParseBool(os.Getenv("MISSION_CREW_DMS"))
+	return err == nil && enabled
+}
+
+// startCrewStatusDMs direct messages the crew in the background when crew DMs are enabled and the
+// mission has just moved to one of crewDMStatuses, so the status update isn't held up
+func (m *Mission) startCrewStatusDMs(mission *Mission, oldStatus, userID string) {
+	if !m.crewDMs || mission.Status == oldStatus || !slices.Contains(crewDMStatuses, mission.Status) {
+		return
+	}
+
+	go m.sendCrewStatusDMs(mission, oldStatus, userID)
+}
+
+// sendCrewStatusDMs direct messages each crew member, except the user who changed the status, about
+// the status change. Crew members who can't be messaged, e.g. because direct messages are restricted,
+// are logged and skipped.
+func (m *Mission) sendCrewStatusDMs(mission *Mission, oldStatus, userID string) {
+	changedBy := "someone"
+	if user, err := m.client.User.Get(userID); err == nil {
+		changedBy = "@" + user.Username
+	}
+
+	message := fmt.Sprintf("%s Mission **%s** (callsign **%s**) is now **%s** (was %s), changed by %s. Mission channel: ~%s",
+		m.GetStatusEmoji(mission.Status), mission.Name, mission.Callsign, mission.Status, oldStatus, changedBy, mission.ChannelName)
+
+	botUserID := m.bot.GetBotUserInfo().UserId
+	sent := 0
+	for _, crewID := range mission.Crew {
+		if crewID == userID {
+			continue
+		}
+
+		dm, err := m.client.Channel.GetDirect(botUserID, crewID)
+		if err != nil {
+			m.client.Log.Warn("Could not open a direct message with crew member", "missionId", mission.ID, "userId", crewID, "error", err.Error())
+			continue
+		}
+		if _, err := m.bot.PostMessageFromBot(dm.Id, message); err != nil {
+			m.client.Log.Warn("Could not send status direct message to crew member", "missionId", mission.ID, "userId", crewID, "error", err.Error())
+			continue
+		}
+		sent++
+	}
+
+	m.client.Log.Debug("Sent mission status direct messages to crew", "missionId", mission.ID, "status", mission.Status, "sent", sent)
+}
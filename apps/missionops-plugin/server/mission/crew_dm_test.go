@@ -0,0 +1,70 @@
+package mission
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/coltoneshaw/demokit/missionops-plugin/server/bot"
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/plugin/plugintest"
+	"github.com/mattermost/mattermost/server/public/pluginapi"
+)
+
+// fakeBot records the messages posted to each channel; other BotInterface methods are not implemented
+type fakeBot struct {
+	bot.BotInterface
+	posts map[string][]string
+}
+
+func (f *fakeBot) GetBotUserInfo() *model.Bot {
+	return &model.Bot{UserId: "bot1"}
+}
+
+func (f *fakeBot) PostMessageFromBot(channelID, message string) (*model.Post, error) {
+	f.posts[channelID] = append(f.posts[channelID], message)
+	return &model.Post{ChannelId: channelID, Message: message}, nil
+}
+
+func TestSendCrewStatusDMs(t *testing.T) {
+	api := &plugintest.API{}
+	api.On("GetUser", "user1").Return(&model.User{Id: "user1", Username: "maverick"}, nil)
+	api.On("GetDirectChannel", "bot1", "user2").Return(&model.Channel{Id: "dm2"}, nil)
+	api.On("GetDirectChannel", "bot1", "user3").Return(nil, model.NewAppError("GetDirectChannel", "api.channel.create_direct_channel.direct_messages_restricted.app_error", nil, "", http.StatusForbidden))
+	api.On("GetDirectChannel", "bot1", "user4").Return(&model.Channel{Id: "dm4"}, nil)
+
+	fake := &fakeBot{posts: map[string][]string{}}
+	m := &Mission{client: pluginapi.NewClient(&testAPI{API: api}, nil), bot: fake}
+
+	mission := &Mission{ID: "m1", Name: "Alpha", Callsign: "Eagle1", ChannelName: "eagle1-alpha", Status: "in-air", Crew: []string{"user1", "user2", "user3", "user4"}}
+	m.sendCrewStatusDMs(mission, "stalled", "user1")
+
+	api.AssertNotCalled(t, "GetDirectChannel", "bot1", "user1")
+	if len(fake.posts) != 2 || len(fake.posts["dm2"]) != 1 || len(fake.posts["dm4"]) != 1 {
+		t.Fatalf("Expected one DM each to user2 and user4, got %v", fake.posts)
+	}
+	if message := fake.posts["dm2"][0]; !strings.Contains(message, "**Alpha**") || !strings.Contains(message, "now **in-air** (was stalled), changed by @maverick") {
+		t.Errorf("Unexpected DM: %q", message)
+	}
+}
+
+func TestStartCrewStatusDMsOnlyForEnabledStatuses(t *testing.T) {
+	testCases := []struct {
+		name      string
+		enabled   bool
+		oldStatus string
+		status    string
+	}{
+		{name: "disabled", enabled: false, oldStatus: "stalled", status: "in-air"},
+		{name: "status not messaged", enabled: true, oldStatus: "in-air", status: "completed"},
+		{name: "status unchanged", enabled: true, oldStatus: "in-air", status: "in-air"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// A nil client and bot would panic if any message were sent
+			m := &Mission{crewDMs: tc.enabled}
+			m.startCrewStatusDMs(&Mission{Status: tc.status, Crew: []string{"user2"}}, tc.oldStatus, "user1")
+		})
+	}
+}
@@ -1,6 +1,11 @@
 package mission
 
 import (
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
 	"github.com/coltoneshaw/demokit/missionops-plugin/server/bot"
 	"github.com/mattermost/mattermost/server/public/pluginapi"
 )
@@ -11,9 +16,25 @@ type MissionInterface interface {
 	// GetMission retrieves a mission by ID
 	GetMission(id string) (*Mission, error)
 	GetMissionByChannelID(channelID string) (*Mission, error)
-	UpdateMissionStatus(id string, status string) error
+	// DeleteMission removes a mission and its entry in the missions list
+	DeleteMission(id string) error
+	UpdateMissionStatus(id string, status string, userID string) error
 	GetAllMissions() ([]*Mission, error)
 	GetMissionsByStatus(status string) ([]*Mission, error)
+	// GetMissionsByTag gets all missions tagged with tag, ignoring case
+	GetMissionsByTag(tag string) ([]*Mission, error)
+	// GetActiveMissions gets all missions that are not archived
+	GetActiveMissions() ([]*Mission, error)
+	// ExcludeArchived returns the missions that are not archived, including auto-archived ones
+	ExcludeArchived(missions []*Mission) []*Mission
+	ArchiveMission(id string) error
+	// UpdateCrew adds and removes crew members by user ID
+	UpdateCrew(id string, add []string, remove []string) error
+	// ReopenMission moves a completed or cancelled mission back to stalled or in-air
+	ReopenMission(id string, status string, userID string) error
+	// UpdateChannelDisplayName sets the mission channel's name to show the mission's current status emoji
+	UpdateChannelDisplayName(mission *Mission) error
+	IsArchived(mission *Mission) bool
 	GetStatusEmoji(status string) string
 	CategorizeMissionChannel(channelID, teamID string) error
 	CompleteMission(missionID, objectivesCompletion, notableEvents, crewPerformance, missionDurationStr, userID string) error
@@ -21,7 +42,19 @@ type MissionInterface interface {
 
 func NewMissionHandler(client *pluginapi.Client, bot bot.BotInterface) MissionInterface {
 	return &Mission{
-		client: client,
-		bot:    bot,
+		client:           client,
+		bot:              bot,
+		autoArchiveAfter: autoArchiveAfterFromEnv(),
+		crewDMs:          crewDMsFromEnv(),
+		mutex:            &sync.RWMutex{},
+	}
+}
+
+// autoArchiveAfterFromEnv reads MISSION_AUTO_ARCHIVE_DAYS, returning zero (disabled) when it is unset or invalid
+func autoArchiveAfterFromEnv() time.Duration {
+	days, err := strconv.Atoi(os.Getenv("MISSION_AUTO_ARCHIVE_DAYS"))
+	if err != nil || days <= 0 {
+		return 0
 	}
+	return time.Duration(days) * 24 * time.Hour
 }
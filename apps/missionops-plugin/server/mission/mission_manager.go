@@ -3,6 +3,7 @@ package mission
 import (
 	"encoding/json"
 	"fmt"
+	"slices"
 	"time"
 
 	"github.com/pkg/errors"
@@ -10,6 +11,14 @@ import (
 
 // AddMission adds a mission to the KV store
 func (m *Mission) AddMission(mission *Mission) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	return m.saveMission(mission)
+}
+
+// saveMission stores a mission and adds it to the missions list. Callers must hold the write lock.
+func (m *Mission) saveMission(mission *Mission) error {
 	m.client.Log.Info("Adding mission", "name", mission.Name, "callsign", mission.Callsign)
 
 	// Store in KV store
@@ -34,6 +43,14 @@ func (m *Mission) AddMission(mission *Mission) error {
 
 // GetMission retrieves a mission from the KV store
 func (m *Mission) GetMission(id string) (*Mission, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	return m.getMission(id)
+}
+
+// getMission reads a mission from the KV store. Callers must hold the lock.
+func (m *Mission) getMission(id string) (*Mission, error) {
 	m.client.Log.Info("Getting mission", "id", id)
 
 	key := MissionPrefix + id
@@ -55,10 +72,27 @@ func (m *Mission) GetMission(id string) (*Mission, error) {
 	return &mission, nil
 }
 
+// DeleteMission removes a mission from the KV store and the missions list
+func (m *Mission) DeleteMission(id string) error {
+	m.client.Log.Info("Deleting mission", "id", id)
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if err := m.client.KV.Delete(MissionPrefix + id); err != nil {
+		return errors.Wrap(err, "failed to delete mission from KV store")
+	}
+
+	return m.removeMissionFromList(id)
+}
+
 // GetMissionByChannelID retrieves a mission by its channel ID
 func (m *Mission) GetMissionByChannelID(channelID string) (*Mission, error) {
 	m.client.Log.Info("Getting mission by channel ID", "channelId", channelID)
 
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
 	// Get all mission IDs
 	missionIDs, err := m.getMissionsList()
 	if err != nil {
@@ -66,7 +100,7 @@ func (m *Mission) GetMissionByChannelID(channelID string) (*Mission, error) {
 	}
 
 	for _, id := range missionIDs {
-		mission, err := m.GetMission(id)
+		mission, err := m.getMission(id)
 		if err != nil {
 			m.client.Log.Error("Failed to get mission", "id", id, "error", err.Error())
 			continue
@@ -80,17 +114,26 @@ func (m *Mission) GetMissionByChannelID(channelID string) (*Mission, error) {
 	return nil, fmt.Errorf("no mission found for channel: %s", channelID)
 }
 
-// UpdateMissionStatus updates a mission's status
-func (m *Mission) UpdateMissionStatus(id string, status string) error {
-	m.client.Log.Debug("Updating mission status", "id", id, "status", status)
+// UpdateMissionStatus updates a mission's status and records the change in its timeline
+func (m *Mission) UpdateMissionStatus(id string, status string, userID string) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
 
-	mission, err := m.GetMission(id)
+	return m.updateMissionStatus(id, status, userID)
+}
+
+// updateMissionStatus is UpdateMissionStatus for callers that already hold the write lock
+func (m *Mission) updateMissionStatus(id string, status string, userID string) error {
+	m.client.Log.Debug("Updating mission status", "id", id, "status", status, "userId", userID)
+
+	mission, err := m.getMission(id)
 	if err != nil {
 		return errors.Wrap(err, "failed to get mission")
 	}
+	oldStatus := mission.Status
 
 	// Update status
-	mission.Status = status
+	setStatus(mission, status, userID)
 
 	// If completing or cancelling, set completed time
 	if status == "completed" || status == "cancelled" {
@@ -98,13 +141,34 @@ func (m *Mission) UpdateMissionStatus(id string, status string) error {
 	}
 
 	// Save the updated mission
-	return m.AddMission(mission)
+	if err := m.saveMission(mission); err != nil {
+		return err
+	}
+
+	m.startCrewStatusDMs(mission, oldStatus, userID)
+	return nil
+}
+
+// setStatus changes a mission's status, recording the change in its timeline
+func setStatus(mission *Mission, status string, userID string) {
+	if mission.Status != status {
+		mission.Timeline = append(mission.Timeline, MissionEvent{
+			Timestamp: time.Now(),
+			OldStatus: mission.Status,
+			NewStatus: status,
+			UserID:    userID,
+		})
+	}
+	mission.Status = status
 }
 
 // GetAllMissions gets all missions
 func (m *Mission) GetAllMissions() ([]*Mission, error) {
 	m.client.Log.Debug("Getting all missions")
 
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
 	// Get all mission IDs
 	missionIDs, err := m.getMissionsList()
 	if err != nil {
@@ -113,7 +177,7 @@ func (m *Mission) GetAllMissions() ([]*Mission, error) {
 
 	var missions []*Mission
 	for _, id := range missionIDs {
-		mission, err := m.GetMission(id)
+		mission, err := m.getMission(id)
 		if err != nil {
 			m.client.Log.Error("Failed to get mission", "id", id, "error", err.Error())
 			continue
@@ -125,10 +189,15 @@ func (m *Mission) GetAllMissions() ([]*Mission, error) {
 	return missions, nil
 }
 
-// GetMissionsByStatus gets all missions with a specific status
+// GetMissionsByStatus gets all missions with a specific status.
+// It returns an error if the status is not one of ValidStatuses.
 func (m *Mission) GetMissionsByStatus(status string) ([]*Mission, error) {
 	m.client.Log.Debug("Getting missions by status", "status", status)
 
+	if !IsValidStatus(status) {
+		return nil, fmt.Errorf("unknown mission status: %s", status)
+	}
+
 	// Get all missions
 	allMissions, err := m.GetAllMissions()
 	if err != nil {
@@ -146,6 +215,27 @@ func (m *Mission) GetMissionsByStatus(status string) ([]*Mission, error) {
 	return filteredMissions, nil
 }
 
+// GetMissionsByTag gets all missions tagged with tag, ignoring case.
+func (m *Mission) GetMissionsByTag(tag string) ([]*Mission, error) {
+	m.client.Log.Debug("Getting missions by tag", "tag", tag)
+
+	// Get all missions, which reads them under the read lock
+	allMissions, err := m.GetAllMissions()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get all missions")
+	}
+
+	// Filter by tag
+	var filteredMissions []*Mission
+	for _, mission := range allMissions {
+		if mission.HasTag(tag) {
+			filteredMissions = append(filteredMissions, mission)
+		}
+	}
+
+	return filteredMissions, nil
+}
+
 // getMissionsList retrieves the list of all mission IDs
 func (m *Mission) getMissionsList() ([]string, error) {
 	var missionLists []byte
@@ -225,3 +315,126 @@ func (m *Mission) removeMissionFromList(id string) error {
 
 	return nil
 }
+
+// ArchiveMission hides a completed or cancelled mission from the default mission list
+func (m *Mission) ArchiveMission(id string) error {
+	m.client.Log.Debug("Archiving mission", "id", id)
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	mission, err := m.getMission(id)
+	if err != nil {
+		return errors.Wrap(err, "failed to get mission")
+	}
+
+	if !IsFinishedStatus(mission.Status) {
+		return fmt.Errorf("only completed or cancelled missions can be archived, mission %s is %s", mission.Name, mission.Status)
+	}
+	if mission.Archived {
+		return fmt.Errorf("mission %s is already archived", mission.Name)
+	}
+
+	mission.Archived = true
+	mission.ArchivedAt = time.Now()
+
+	// Save the updated mission
+	return m.saveMission(mission)
+}
+
+// ReopenMission moves a completed or cancelled mission back to an active status, unarchiving it
+func (m *Mission) ReopenMission(id string, status string, userID string) error {
+	m.client.Log.Debug("Reopening mission", "id", id, "status", status, "userId", userID)
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if !IsValidStatus(status) || IsFinishedStatus(status) {
+		return fmt.Errorf("a mission can only be reopened as stalled or in-air, not %s", status)
+	}
+
+	mission, err := m.getMission(id)
+	if err != nil {
+		return errors.Wrap(err, "failed to get mission")
+	}
+
+	if !IsFinishedStatus(mission.Status) {
+		return fmt.Errorf("only completed or cancelled missions can be reopened, mission %s is %s", mission.Name, mission.Status)
+	}
+
+	oldStatus := mission.Status
+	setStatus(mission, status, userID)
+	mission.CompletedAt = time.Time{}
+	mission.Archived = false
+	mission.ArchivedAt = time.Time{}
+
+	// Save the updated mission
+	if err := m.saveMission(mission); err != nil {
+		return err
+	}
+
+	m.startCrewStatusDMs(mission, oldStatus, userID)
+	return nil
+}
+
+// UpdateCrew adds and removes crew members, by user ID, and saves the mission
+func (m *Mission) UpdateCrew(id string, add []string, remove []string) error {
+	m.client.Log.Debug("Updating mission crew", "id", id, "add", add, "remove", remove)
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	mission, err := m.getMission(id)
+	if err != nil {
+		return errors.Wrap(err, "failed to get mission")
+	}
+
+	crew := make([]string, 0, len(mission.Crew)+len(add))
+	for _, userID := range mission.Crew {
+		// Skip blank IDs left by missions started before the crew list was built correctly
+		if userID != "" && !slices.Contains(remove, userID) && !slices.Contains(crew, userID) {
+			crew = append(crew, userID)
+		}
+	}
+	for _, userID := range add {
+		if !slices.Contains(crew, userID) {
+			crew = append(crew, userID)
+		}
+	}
+	mission.Crew = crew
+
+	// Save the updated mission
+	return m.saveMission(mission)
+}
+
+// IsArchived reports whether a mission was archived, or finished longer ago than the auto-archive period
+func (m *Mission) IsArchived(mission *Mission) bool {
+	if mission.Archived {
+		return true
+	}
+
+	if m.autoArchiveAfter <= 0 || !IsFinishedStatus(mission.Status) || mission.CompletedAt.IsZero() {
+		return false
+	}
+	return time.Since(mission.CompletedAt) >= m.autoArchiveAfter
+}
+
+// ExcludeArchived returns the missions that are not archived
+func (m *Mission) ExcludeArchived(missions []*Mission) []*Mission {
+	var active []*Mission
+	for _, mission := range missions {
+		if !m.IsArchived(mission) {
+			active = append(active, mission)
+		}
+	}
+	return active
+}
+
+// GetActiveMissions gets all missions that are not archived
+func (m *Mission) GetActiveMissions() ([]*Mission, error) {
+	missions, err := m.GetAllMissions()
+	if err != nil {
+		return nil, err
+	}
+	return m.ExcludeArchived(missions), nil
+}
@@ -0,0 +1,593 @@
+package mission
+
+import (
+	"encoding/json"
+	"fmt"
+	"runtime"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/plugin/plugintest"
+	"github.com/mattermost/mattermost/server/public/pluginapi"
+	"github.com/stretchr/testify/mock"
+)
+
+// testAPI wraps the plugin API mock and ignores log calls
+type testAPI struct {
+	*plugintest.API
+}
+
+func (a *testAPI) LogDebug(msg string, keyValuePairs ...any) {}
+func (a *testAPI) LogInfo(msg string, keyValuePairs ...any)  {}
+func (a *testAPI) LogWarn(msg string, keyValuePairs ...any)  {}
+func (a *testAPI) LogError(msg string, keyValuePairs ...any) {}
+
+// newTestMissionHandler creates a mission handler whose KV store holds the given missions
+func newTestMissionHandler(t *testing.T, missions []*Mission) *Mission {
+	t.Helper()
+
+	m, _ := newTestMissionHandlerWithAPI(t, missions)
+	return m
+}
+
+// newTestMissionHandlerWithAPI is newTestMissionHandler, also returning the API mock so tests can expect writes
+func newTestMissionHandlerWithAPI(t *testing.T, missions []*Mission) (*Mission, *plugintest.API) {
+	t.Helper()
+
+	api := &plugintest.API{}
+
+	ids := make([]string, 0, len(missions))
+	for _, mission := range missions {
+		ids = append(ids, mission.ID)
+
+		data, err := json.Marshal(mission)
+		if err != nil {
+			t.Fatalf("Failed to marshal mission: %v", err)
+		}
+		api.On("KVGet", MissionPrefix+mission.ID).Return(data, nil)
+	}
+
+	idsData, err := json.Marshal(ids)
+	if err != nil {
+		t.Fatalf("Failed to marshal missions list: %v", err)
+	}
+	api.On("KVGet", MissionsListKey).Return(idsData, nil)
+
+	return &Mission{client: pluginapi.NewClient(&testAPI{API: api}, nil), mutex: &sync.RWMutex{}}, api
+}
+
+func TestGetMissionsByStatus(t *testing.T) {
+	missions := []*Mission{
+		{ID: "m1", Name: "Alpha", Status: "in-air"},
+		{ID: "m2", Name: "Bravo", Status: "stalled"},
+		{ID: "m3", Name: "Charlie", Status: "in-air"},
+	}
+
+	testCases := []struct {
+		name          string
+		missions      []*Mission
+		status        string
+		expectedIDs   []string
+		expectedError bool
+	}{
+		{
+			name:        "no missions stored",
+			missions:    nil,
+			status:      "in-air",
+			expectedIDs: nil,
+		},
+		{
+			name:        "no missions with status",
+			missions:    missions,
+			status:      "completed",
+			expectedIDs: nil,
+		},
+		{
+			name:        "multiple matches",
+			missions:    missions,
+			status:      "in-air",
+			expectedIDs: []string{"m1", "m3"},
+		},
+		{
+			name:        "single match",
+			missions:    missions,
+			status:      "stalled",
+			expectedIDs: []string{"m2"},
+		},
+		{
+			name:          "unknown status",
+			missions:      missions,
+			status:        "landed",
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			m := newTestMissionHandler(t, tc.missions)
+
+			result, err := m.GetMissionsByStatus(tc.status)
+			if tc.expectedError {
+				if err == nil {
+					t.Fatalf("Expected error for status %q, got %d missions", tc.status, len(result))
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetMissionsByStatus returned error: %v", err)
+			}
+
+			if len(result) != len(tc.expectedIDs) {
+				t.Fatalf("Expected %d missions, got %d", len(tc.expectedIDs), len(result))
+			}
+			for i, mission := range result {
+				if mission.ID != tc.expectedIDs[i] {
+					t.Errorf("Expected mission %s at position %d, got %s", tc.expectedIDs[i], i, mission.ID)
+				}
+				if mission.Status != tc.status {
+					t.Errorf("Expected mission %s to have status %s, got %s", mission.ID, tc.status, mission.Status)
+				}
+			}
+		})
+	}
+}
+
+func TestGetMissionsByTag(t *testing.T) {
+	missions := []*Mission{
+		{ID: "m1", Name: "Alpha", Tags: []string{"training", "night"}},
+		{ID: "m2", Name: "Bravo"},
+		{ID: "m3", Name: "Charlie", Tags: []string{"night"}},
+	}
+
+	testCases := []struct {
+		name        string
+		missions    []*Mission
+		tag         string
+		expectedIDs []string
+	}{
+		{
+			name:        "no missions stored",
+			missions:    nil,
+			tag:         "night",
+			expectedIDs: nil,
+		},
+		{
+			name:        "no missions with tag",
+			missions:    missions,
+			tag:         "cargo",
+			expectedIDs: nil,
+		},
+		{
+			name:        "multiple matches",
+			missions:    missions,
+			tag:         "night",
+			expectedIDs: []string{"m1", "m3"},
+		},
+		{
+			name:        "case-insensitive match",
+			missions:    missions,
+			tag:         "Training",
+			expectedIDs: []string{"m1"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			m := newTestMissionHandler(t, tc.missions)
+
+			result, err := m.GetMissionsByTag(tc.tag)
+			if err != nil {
+				t.Fatalf("GetMissionsByTag returned error: %v", err)
+			}
+
+			if len(result) != len(tc.expectedIDs) {
+				t.Fatalf("Expected %d missions, got %d", len(tc.expectedIDs), len(result))
+			}
+			for i, mission := range result {
+				if mission.ID != tc.expectedIDs[i] {
+					t.Errorf("Expected mission %s at position %d, got %s", tc.expectedIDs[i], i, mission.ID)
+				}
+			}
+		})
+	}
+}
+
+func TestParseTags(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected []string
+	}{
+		{input: "", expected: nil},
+		{input: "alpha", expected: []string{"alpha"}},
+		{input: "alpha,bravo", expected: []string{"alpha", "bravo"}},
+		{input: "Alpha, bravo alpha", expected: []string{"alpha", "bravo"}},
+		{input: " , ", expected: nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			if tags := ParseTags(tc.input); !slices.Equal(tags, tc.expected) {
+				t.Errorf("Expected %v for %q, got %v", tc.expected, tc.input, tags)
+			}
+		})
+	}
+}
+
+func TestUpdateMissionStatusRecordsTimeline(t *testing.T) {
+	previous := MissionEvent{Timestamp: time.Now().Add(-time.Hour), OldStatus: "", NewStatus: "stalled", UserID: "user0"}
+
+	testCases := []struct {
+		name             string
+		status           string
+		expectedTimeline []MissionEvent
+	}{
+		{
+			name:   "status change is appended",
+			status: "in-air",
+			expectedTimeline: []MissionEvent{
+				previous,
+				{OldStatus: "stalled", NewStatus: "in-air", UserID: "user1"},
+			},
+		},
+		{
+			name:             "same status is not recorded",
+			status:           "stalled",
+			expectedTimeline: []MissionEvent{previous},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			m, api := newTestMissionHandlerWithAPI(t, []*Mission{
+				{ID: "m1", Name: "Alpha", Status: "stalled", Timeline: []MissionEvent{previous}},
+			})
+
+			var saved Mission
+			api.On("KVSetWithOptions", MissionPrefix+"m1", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+				if err := json.Unmarshal(args.Get(1).([]byte), &saved); err != nil {
+					t.Fatalf("Failed to unmarshal saved mission: %v", err)
+				}
+			}).Return(true, nil)
+
+			if err := m.UpdateMissionStatus("m1", tc.status, "user1"); err != nil {
+				t.Fatalf("UpdateMissionStatus returned error: %v", err)
+			}
+
+			if saved.Status != tc.status {
+				t.Errorf("Expected saved status %s, got %s", tc.status, saved.Status)
+			}
+			if len(saved.Timeline) != len(tc.expectedTimeline) {
+				t.Fatalf("Expected %d timeline events, got %+v", len(tc.expectedTimeline), saved.Timeline)
+			}
+			for i, event := range saved.Timeline {
+				expected := tc.expectedTimeline[i]
+				if event.OldStatus != expected.OldStatus || event.NewStatus != expected.NewStatus || event.UserID != expected.UserID {
+					t.Errorf("Expected event %+v, got %+v", expected, event)
+				}
+				if event.Timestamp.IsZero() {
+					t.Errorf("Expected event %d to have a timestamp", i)
+				}
+			}
+		})
+	}
+}
+
+func TestArchiveMission(t *testing.T) {
+	testCases := []struct {
+		name          string
+		mission       *Mission
+		expectedError bool
+	}{
+		{
+			name:    "completed mission",
+			mission: &Mission{ID: "m1", Name: "Alpha", Status: "completed"},
+		},
+		{
+			name:    "cancelled mission",
+			mission: &Mission{ID: "m1", Name: "Alpha", Status: "cancelled"},
+		},
+		{
+			name:          "mission still in the air",
+			mission:       &Mission{ID: "m1", Name: "Alpha", Status: "in-air"},
+			expectedError: true,
+		},
+		{
+			name:          "already archived",
+			mission:       &Mission{ID: "m1", Name: "Alpha", Status: "completed", Archived: true},
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			m, api := newTestMissionHandlerWithAPI(t, []*Mission{tc.mission})
+
+			var saved Mission
+			api.On("KVSetWithOptions", MissionPrefix+"m1", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+				if err := json.Unmarshal(args.Get(1).([]byte), &saved); err != nil {
+					t.Fatalf("Failed to unmarshal saved mission: %v", err)
+				}
+			}).Return(true, nil)
+
+			err := m.ArchiveMission("m1")
+			if tc.expectedError {
+				if err == nil {
+					t.Fatal("Expected an error archiving the mission")
+				}
+				api.AssertNotCalled(t, "KVSetWithOptions", MissionPrefix+"m1", mock.Anything, mock.Anything)
+				return
+			}
+			if err != nil {
+				t.Fatalf("ArchiveMission returned error: %v", err)
+			}
+
+			if !saved.Archived || saved.ArchivedAt.IsZero() {
+				t.Errorf("Expected the saved mission to be archived with a timestamp, got %+v", saved)
+			}
+		})
+	}
+}
+
+func TestExcludeArchived(t *testing.T) {
+	now := time.Now()
+	missions := []*Mission{
+		{ID: "active", Status: "in-air"},
+		{ID: "archived", Status: "completed", Archived: true},
+		{ID: "recently-completed", Status: "completed", CompletedAt: now.Add(-time.Hour)},
+		{ID: "long-cancelled", Status: "cancelled", CompletedAt: now.Add(-10 * 24 * time.Hour)},
+	}
+
+	testCases := []struct {
+		name             string
+		autoArchiveAfter time.Duration
+		expectedIDs      []string
+	}{
+		{
+			name:        "auto-archive disabled",
+			expectedIDs: []string{"active", "recently-completed", "long-cancelled"},
+		},
+		{
+			name:             "auto-archive after a week",
+			autoArchiveAfter: 7 * 24 * time.Hour,
+			expectedIDs:      []string{"active", "recently-completed"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			m := &Mission{autoArchiveAfter: tc.autoArchiveAfter}
+
+			var ids []string
+			for _, mission := range m.ExcludeArchived(missions) {
+				ids = append(ids, mission.ID)
+			}
+			if !slices.Equal(ids, tc.expectedIDs) {
+				t.Errorf("Expected missions %v, got %v", tc.expectedIDs, ids)
+			}
+		})
+	}
+}
+
+func TestReopenMission(t *testing.T) {
+	completedAt := time.Now().Add(-time.Hour)
+
+	testCases := []struct {
+		name          string
+		mission       *Mission
+		status        string
+		expectedError bool
+	}{
+		{
+			name:    "completed mission back to stalled",
+			mission: &Mission{ID: "m1", Name: "Alpha", Status: "completed", CompletedAt: completedAt},
+			status:  "stalled",
+		},
+		{
+			name:    "archived cancelled mission back in the air",
+			mission: &Mission{ID: "m1", Name: "Alpha", Status: "cancelled", CompletedAt: completedAt, Archived: true, ArchivedAt: completedAt},
+			status:  "in-air",
+		},
+		{
+			name:          "mission that is not finished",
+			mission:       &Mission{ID: "m1", Name: "Alpha", Status: "in-air"},
+			status:        "stalled",
+			expectedError: true,
+		},
+		{
+			name:          "reopened as finished",
+			mission:       &Mission{ID: "m1", Name: "Alpha", Status: "completed", CompletedAt: completedAt},
+			status:        "cancelled",
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			m, api := newTestMissionHandlerWithAPI(t, []*Mission{tc.mission})
+
+			var saved Mission
+			api.On("KVSetWithOptions", MissionPrefix+"m1", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+				if err := json.Unmarshal(args.Get(1).([]byte), &saved); err != nil {
+					t.Fatalf("Failed to unmarshal saved mission: %v", err)
+				}
+			}).Return(true, nil)
+
+			err := m.ReopenMission("m1", tc.status, "user1")
+			if tc.expectedError {
+				if err == nil {
+					t.Fatal("Expected an error reopening the mission")
+				}
+				api.AssertNotCalled(t, "KVSetWithOptions", MissionPrefix+"m1", mock.Anything, mock.Anything)
+				return
+			}
+			if err != nil {
+				t.Fatalf("ReopenMission returned error: %v", err)
+			}
+
+			if saved.Status != tc.status {
+				t.Errorf("Expected saved status %s, got %s", tc.status, saved.Status)
+			}
+			if !saved.CompletedAt.IsZero() || saved.Archived || !saved.ArchivedAt.IsZero() {
+				t.Errorf("Expected completion and archive state to be cleared, got %+v", saved)
+			}
+			if len(saved.Timeline) != 1 || saved.Timeline[0].OldStatus != tc.mission.Status || saved.Timeline[0].NewStatus != tc.status {
+				t.Errorf("Expected the reopen to be recorded in the timeline, got %+v", saved.Timeline)
+			}
+		})
+	}
+}
+
+func TestUpdateCrew(t *testing.T) {
+	testCases := []struct {
+		name         string
+		crew         []string
+		add          []string
+		remove       []string
+		expectedCrew []string
+	}{
+		{
+			name:         "add and remove",
+			crew:         []string{"user1", "user2"},
+			add:          []string{"user3"},
+			remove:       []string{"user1"},
+			expectedCrew: []string{"user2", "user3"},
+		},
+		{
+			name:         "existing members are not duplicated",
+			crew:         []string{"user1"},
+			add:          []string{"user1", "user2", "user2"},
+			expectedCrew: []string{"user1", "user2"},
+		},
+		{
+			name:         "blank IDs are dropped",
+			crew:         []string{"", "", "user1"},
+			remove:       []string{"user9"},
+			expectedCrew: []string{"user1"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			m, api := newTestMissionHandlerWithAPI(t, []*Mission{{ID: "m1", Name: "Alpha", Crew: tc.crew}})
+
+			var saved Mission
+			api.On("KVSetWithOptions", MissionPrefix+"m1", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+				if err := json.Unmarshal(args.Get(1).([]byte), &saved); err != nil {
+					t.Fatalf("Failed to unmarshal saved mission: %v", err)
+				}
+			}).Return(true, nil)
+
+			if err := m.UpdateCrew("m1", tc.add, tc.remove); err != nil {
+				t.Fatalf("UpdateCrew returned error: %v", err)
+			}
+
+			if !slices.Equal(saved.Crew, tc.expectedCrew) {
+				t.Errorf("Expected crew %v, got %v", tc.expectedCrew, saved.Crew)
+			}
+		})
+	}
+}
+
+// newTestMissionHandlerWithMemoryKV creates a mission handler backed by an in-memory KV store that
+// is safe to use from several goroutines
+func newTestMissionHandlerWithMemoryKV() *Mission {
+	api := &plugintest.API{}
+
+	var kvMutex sync.Mutex
+	kv := make(map[string][]byte)
+	api.On("KVGet", mock.Anything).Return(func(key string) []byte {
+		kvMutex.Lock()
+		value := kv[key]
+		kvMutex.Unlock()
+
+		// Let other goroutines run between a read and the write that follows it, as a real KV store would
+		runtime.Gosched()
+		return value
+	}, func(string) *model.AppError { return nil })
+	api.On("KVSetWithOptions", mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		kvMutex.Lock()
+		defer kvMutex.Unlock()
+		kv[args.String(0)] = args.Get(1).([]byte)
+	}).Return(true, nil)
+
+	return &Mission{client: pluginapi.NewClient(&testAPI{API: api}, nil), mutex: &sync.RWMutex{}}
+}
+
+// TestConcurrentMissionUpdates runs status changes, crew changes, new missions and reads at the same
+// time and checks no update is lost. Run with -race to also catch unsynchronized access.
+func TestConcurrentMissionUpdates(t *testing.T) {
+	m := newTestMissionHandlerWithMemoryKV()
+	if err := m.AddMission(&Mission{ID: "m1", Name: "Alpha", ChannelID: "channel1", Status: "stalled"}); err != nil {
+		t.Fatalf("AddMission returned error: %v", err)
+	}
+
+	const workers = 20
+	statuses := []string{"in-air", "stalled"}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, workers*5)
+	for i := range workers {
+		wg.Add(5)
+		go func() {
+			defer wg.Done()
+			errs <- m.UpdateMissionStatus("m1", statuses[i%2], fmt.Sprintf("user%d", i))
+		}()
+		go func() {
+			defer wg.Done()
+			errs <- m.UpdateCrew("m1", []string{fmt.Sprintf("crew%d", i)}, nil)
+		}()
+		go func() {
+			defer wg.Done()
+			errs <- m.AddMission(&Mission{ID: fmt.Sprintf("new%d", i), Name: "Bravo", Status: "stalled"})
+		}()
+		go func() {
+			defer wg.Done()
+			_, err := m.GetMissionByChannelID("channel1")
+			errs <- err
+		}()
+		go func() {
+			defer wg.Done()
+			_, err := m.GetAllMissions()
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("Concurrent mission operation returned error: %v", err)
+		}
+	}
+
+	mission, err := m.GetMission("m1")
+	if err != nil {
+		t.Fatalf("GetMission returned error: %v", err)
+	}
+	if len(mission.Crew) != workers {
+		t.Errorf("Expected %d crew members, got %d: %v", workers, len(mission.Crew), mission.Crew)
+	}
+
+	// Every status change is recorded, so the timeline must match replaying them in order
+	status := "stalled"
+	for _, event := range mission.Timeline {
+		if event.OldStatus != status {
+			t.Fatalf("Timeline event from %s does not follow status %s: %+v", event.OldStatus, status, mission.Timeline)
+		}
+		status = event.NewStatus
+	}
+	if status != mission.Status {
+		t.Errorf("Timeline ends at %s but the mission is %s", status, mission.Status)
+	}
+
+	missions, err := m.GetAllMissions()
+	if err != nil {
+		t.Fatalf("GetAllMissions returned error: %v", err)
+	}
+	if len(missions) != workers+1 {
+		t.Errorf("Expected %d missions in the list, got %d", workers+1, len(missions))
+	}
+}
@@ -1,7 +1,11 @@
 package mission
 
 import (
+	"slices"
+	"strings"
+	"sync"
 	"time"
+	"unicode"
 
 	"github.com/coltoneshaw/demokit/missionops-plugin/server/bot"
 	"github.com/mattermost/mattermost/server/public/model"
@@ -10,22 +14,49 @@ import (
 
 // Mission represents a mission with its properties
 type Mission struct {
-	ID               string    `json:"id"`
-	Name             string    `json:"name"`
-	Callsign         string    `json:"callsign"`
-	DepartureAirport string    `json:"departureAirport"`
-	ArrivalAirport   string    `json:"arrivalAirport"`
-	CreatedBy        string    `json:"createdBy"`
-	CreatedAt        time.Time `json:"createdAt"`
-	Crew             []string  `json:"crew"`
-	ChannelID        string    `json:"channelId"`
-	TeamID           string    `json:"teamId"`
-	ChannelName      string    `json:"channelName"`
-	Status           string    `json:"status"`
-	CompletedAt      time.Time `json:"completedAt,omitempty"`
+	ID               string         `json:"id"`
+	Name             string         `json:"name"`
+	Callsign         string         `json:"callsign"`
+	DepartureAirport string         `json:"departureAirport"`
+	ArrivalAirport   string         `json:"arrivalAirport"`
+	CreatedBy        string         `json:"createdBy"`
+	CreatedAt        time.Time      `json:"createdAt"`
+	Crew             []string       `json:"crew"`
+	ChannelID        string         `json:"channelId"`
+	TeamID           string         `json:"teamId"`
+	ChannelName      string         `json:"channelName"`
+	Status           string         `json:"status"`
+	CompletedAt      time.Time      `json:"completedAt,omitempty"`
+	Timeline         []MissionEvent `json:"timeline,omitempty"`
+	Archived         bool           `json:"archived,omitempty"`
+	ArchivedAt       time.Time      `json:"archivedAt,omitempty"`
+	Report           *MissionReport `json:"report,omitempty"`
+	Priority         int            `json:"priority,omitempty"` // PriorityLow, PriorityMedium or PriorityHigh
+	Tags             []string       `json:"tags,omitempty"`     // Lowercase labels for filtering /mission list
 
-	client *pluginapi.Client
-	bot    bot.BotInterface
+	client           *pluginapi.Client
+	bot              bot.BotInterface
+	autoArchiveAfter time.Duration // Finished missions count as archived this long after completion; zero disables
+	crewDMs          bool          // Direct message the crew when a mission goes in-air or is cancelled
+	mutex            *sync.RWMutex // Guards read-modify-write of missions and the missions list in the KV store
+}
+
+// MissionEvent records a mission status change and the user who made it
+type MissionEvent struct {
+	Timestamp time.Time `json:"timestamp"`
+	OldStatus string    `json:"oldStatus"`
+	NewStatus string    `json:"newStatus"`
+	UserID    string    `json:"userId"`
+}
+
+// MissionReport holds the post-mission report fields submitted with /mission complete
+type MissionReport struct {
+	ObjectivesCompletion string    `json:"objectivesCompletion"`
+	Duration             string    `json:"duration"`
+	CrewPerformance      string    `json:"crewPerformance"`
+	NotableEvents        string    `json:"notableEvents"`
+	SubmittedBy          string    `json:"submittedBy"`
+	SubmittedAt          time.Time `json:"submittedAt"`
 }
 
 type MissionInfo struct {
@@ -33,6 +64,8 @@ type MissionInfo struct {
 	Callsign         string
 	DepartureAirport string
 	ArrivalAirport   string
+	Priority         int
+	Tags             []string
 	Crew             []model.User
 }
 
@@ -51,6 +84,37 @@ const (
 	MissionsListKey = "missions_list"
 )
 
+// ValidStatuses lists the statuses a mission can have, in lifecycle order
+var ValidStatuses = []string{"stalled", "in-air", "completed", "cancelled"}
+
+// IsValidStatus reports whether status is one of ValidStatuses
+func IsValidStatus(status string) bool {
+	return slices.Contains(ValidStatuses, status)
+}
+
+// IsFinishedStatus reports whether a mission with status is over and can be archived
+func IsFinishedStatus(status string) bool {
+	return status == "completed" || status == "cancelled"
+}
+
+// ParseTags splits a comma or space separated list of tags into lowercase tags, dropping empty and
+// repeated ones
+func ParseTags(value string) []string {
+	var tags []string
+	for _, tag := range strings.FieldsFunc(value, func(r rune) bool { return r == ',' || unicode.IsSpace(r) }) {
+		tag = strings.ToLower(tag)
+		if !slices.Contains(tags, tag) {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// HasTag reports whether the mission is tagged with tag, ignoring case
+func (m *Mission) HasTag(tag string) bool {
+	return slices.Contains(m.Tags, strings.ToLower(tag))
+}
+
 // GetStatusEmoji returns an emoji for a given status
 func (*Mission) GetStatusEmoji(status string) string {
 	switch status {
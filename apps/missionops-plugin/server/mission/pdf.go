@@ -0,0 +1,140 @@
+package mission
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// Page layout for rendered PDFs, in points on US Letter paper
+const (
+	pdfPageWidth   = 612
+	pdfPageHeight  = 792
+	pdfMargin      = 56
+	pdfTitleSize   = 16
+	pdfFontSize    = 11
+	pdfLeading     = 15
+	pdfLineChars   = 85 // Wrap width for Helvetica at pdfFontSize within the margins
+	pdfTitleHeight = 30
+)
+
+// renderTextPDF renders a title and lines of plain text as a PDF using the built-in Helvetica fonts.
+// Long lines are wrapped and the text flows onto further pages as needed.
+func renderTextPDF(title string, lines []string) []byte {
+	var wrapped []string
+	for _, line := range lines {
+		wrapped = append(wrapped, wrapText(line, pdfLineChars)...)
+	}
+
+	// Split the lines into pages, leaving room for the title on the first page
+	var pages [][]string
+	for len(wrapped) > 0 || len(pages) == 0 {
+		top := pdfPageHeight - pdfMargin
+		if len(pages) == 0 {
+			top -= pdfTitleHeight
+		}
+		perPage := (top-pdfMargin)/pdfLeading + 1
+		n := min(perPage, len(wrapped))
+		pages = append(pages, wrapped[:n])
+		wrapped = wrapped[n:]
+	}
+
+	// Objects 1-4 are the catalog, page tree and fonts; each page then has a page object and a content stream
+	objects := []string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"", // Page tree, filled in once the page object numbers are known
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>",
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>",
+	}
+
+	var kids []string
+	for i, pageLines := range pages {
+		var content strings.Builder
+		top := pdfPageHeight - pdfMargin
+		if i == 0 {
+			fmt.Fprintf(&content, "BT /F2 %d Tf %d %d Td (%s) Tj ET\n", pdfTitleSize, pdfMargin, top, pdfEscape(title))
+			top -= pdfTitleHeight
+		}
+		fmt.Fprintf(&content, "BT /F1 %d Tf %d TL %d %d Td\n", pdfFontSize, pdfLeading, pdfMargin, top)
+		for _, line := range pageLines {
+			fmt.Fprintf(&content, "(%s) Tj T*\n", pdfEscape(line))
+		}
+		content.WriteString("ET\n")
+
+		pageNum := len(objects) + 1
+		kids = append(kids, fmt.Sprintf("%d 0 R", pageNum))
+		objects = append(objects,
+			fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %d %d] /Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>",
+				pdfPageWidth, pdfPageHeight, pageNum+1),
+			fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", content.Len(), content.String()),
+		)
+	}
+	objects[1] = fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(pages))
+
+	var buf bytes.Buffer
+	buf.WriteString("%PDF-1.4\n")
+	offsets := make([]int, len(objects))
+	for i, object := range objects {
+		offsets[i] = buf.Len()
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", i+1, object)
+	}
+
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xref)
+
+	return buf.Bytes()
+}
+
+// wrapText splits a line into lines of at most width characters, breaking between words where possible
+func wrapText(line string, width int) []string {
+	words := strings.Fields(line)
+	if len(words) == 0 {
+		return []string{""}
+	}
+
+	var lines []string
+	var current []rune
+	for _, word := range words {
+		runes := []rune(word)
+		for len(runes) > 0 {
+			if len(current) > 0 && len(current)+1+len(runes) <= width {
+				current = append(append(current, ' '), runes...)
+				runes = nil
+				continue
+			}
+			if len(current) > 0 {
+				lines = append(lines, string(current))
+			}
+			n := min(width, len(runes))
+			current = runes[:n:n]
+			runes = runes[n:]
+		}
+	}
+	return append(lines, string(current))
+}
+
+// pdfEscape escapes text for a PDF string literal. Characters outside Latin-1, which the
+// standard fonts can't show, are replaced with '?'.
+func pdfEscape(text string) string {
+	var sb strings.Builder
+	for _, r := range text {
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			sb.WriteByte('\\')
+			sb.WriteRune(r)
+		case r >= 0x20 && r < 0x7f:
+			sb.WriteRune(r)
+		case r >= 0xa0 && r <= 0xff:
+			fmt.Fprintf(&sb, "\\%03o", r)
+		case r < 0x20:
+			sb.WriteByte(' ')
+		default:
+			sb.WriteByte('?')
+		}
+	}
+	return sb.String()
+}
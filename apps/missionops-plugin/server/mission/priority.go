@@ -0,0 +1,60 @@
+package mission
+
+import (
+	"slices"
+	"strings"
+)
+
+// Mission priorities, higher is more urgent
+const (
+	PriorityLow    = 1
+	PriorityMedium = 2
+	PriorityHigh   = 3
+)
+
+// PriorityNames lists the --priority values accepted by /mission start, from lowest to highest
+var PriorityNames = []string{"low", "medium", "high"}
+
+// ParsePriority returns the priority for a name in PriorityNames, ignoring case
+func ParsePriority(name string) (int, bool) {
+	index := slices.Index(PriorityNames, strings.ToLower(strings.TrimSpace(name)))
+	if index < 0 {
+		return 0, false
+	}
+	return index + PriorityLow, true
+}
+
+// GetPriority returns the mission's priority; missions saved before priorities existed are medium
+func (m *Mission) GetPriority() int {
+	if m.Priority < PriorityLow || m.Priority > PriorityHigh {
+		return PriorityMedium
+	}
+	return m.Priority
+}
+
+// GetPriorityName returns the mission's priority as one of PriorityNames
+func (m *Mission) GetPriorityName() string {
+	return PriorityNames[m.GetPriority()-PriorityLow]
+}
+
+// GetPriorityEmoji returns an emoji for the mission's priority
+func (m *Mission) GetPriorityEmoji() string {
+	switch m.GetPriority() {
+	case PriorityLow:
+		return "🟢"
+	case PriorityHigh:
+		return "🔴"
+	default:
+		return "🟡"
+	}
+}
+
+// SortByPriority orders missions by priority, highest first, then by creation time, oldest first
+func SortByPriority(missions []*Mission) {
+	slices.SortStableFunc(missions, func(a, b *Mission) int {
+		if a.GetPriority() != b.GetPriority() {
+			return b.GetPriority() - a.GetPriority()
+		}
+		return a.CreatedAt.Compare(b.CreatedAt)
+	})
+}
@@ -0,0 +1,63 @@
+package mission
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSortByPriority(t *testing.T) {
+	start := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name     string
+		missions []*Mission
+		expected string
+	}{
+		{
+			name: "mixed priorities",
+			missions: []*Mission{
+				{Name: "low", Priority: PriorityLow, CreatedAt: start},
+				{Name: "medium-new", Priority: PriorityMedium, CreatedAt: start.Add(2 * time.Hour)},
+				{Name: "high", Priority: PriorityHigh, CreatedAt: start.Add(3 * time.Hour)},
+				{Name: "unset", CreatedAt: start.Add(time.Hour)},
+				{Name: "medium-old", Priority: PriorityMedium, CreatedAt: start},
+			},
+			expected: "high,medium-old,unset,medium-new,low",
+		},
+		{
+			name: "identical priorities",
+			missions: []*Mission{
+				{Name: "third", Priority: PriorityHigh, CreatedAt: start.Add(2 * time.Hour)},
+				{Name: "first", Priority: PriorityHigh, CreatedAt: start},
+				{Name: "second", Priority: PriorityHigh, CreatedAt: start.Add(time.Hour)},
+			},
+			expected: "first,second,third",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			SortByPriority(tc.missions)
+
+			names := make([]string, 0, len(tc.missions))
+			for _, m := range tc.missions {
+				names = append(names, m.Name)
+			}
+			if got := strings.Join(names, ","); got != tc.expected {
+				t.Errorf("Expected order %s, got %s", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestParsePriority(t *testing.T) {
+	for name, expected := range map[string]int{"low": PriorityLow, "Medium": PriorityMedium, " HIGH ": PriorityHigh} {
+		if priority, ok := ParsePriority(name); !ok || priority != expected {
+			t.Errorf("Expected %q to parse as %d, got %d (%v)", name, expected, priority, ok)
+		}
+	}
+	if _, ok := ParsePriority("urgent"); ok {
+		t.Error("Expected an unknown priority to be rejected")
+	}
+}
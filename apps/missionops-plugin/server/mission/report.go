@@ -0,0 +1,131 @@
+package mission
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Formats a post-mission report can be exported in
+const (
+	ReportFormatCSV = "csv"
+	ReportFormatPDF = "pdf"
+)
+
+// ReportFormats lists the supported report export formats
+var ReportFormats = []string{ReportFormatPDF, ReportFormatCSV}
+
+var objectivesLabels = map[string]string{
+	"all_completed": "All objectives completed",
+	"partial":       "Partial objectives completed",
+	"none":          "Mission objectives not met",
+}
+
+var objectivesEmoji = map[string]string{
+	"all_completed": "✅",
+	"partial":       "⚠️",
+	"none":          "❌",
+}
+
+var crewPerformanceLabels = map[string]string{
+	"excellent":         "Excellent",
+	"good":              "Good",
+	"satisfactory":      "Satisfactory",
+	"needs_improvement": "Needs Improvement",
+}
+
+var crewPerformanceStars = map[string]string{
+	"excellent":         "⭐⭐⭐⭐⭐",
+	"good":              "⭐⭐⭐⭐",
+	"satisfactory":      "⭐⭐⭐",
+	"needs_improvement": "⭐⭐",
+}
+
+// ObjectivesText returns the label for an objectives completion value from the report dialog
+func ObjectivesText(value string) string {
+	if label, ok := objectivesLabels[value]; ok {
+		return label
+	}
+	return "Unknown"
+}
+
+// CrewPerformanceText returns the label for a crew performance value from the report dialog
+func CrewPerformanceText(value string) string {
+	if label, ok := crewPerformanceLabels[value]; ok {
+		return label
+	}
+	return "Unknown"
+}
+
+// withPrefix joins an emoji prefix and a label, leaving the label alone when there is no prefix
+func withPrefix(prefix, label string) string {
+	if prefix == "" {
+		return label
+	}
+	return prefix + " " + label
+}
+
+// ReportFileName returns the attachment file name for a mission report in format
+func ReportFileName(mission *Mission, format string) string {
+	name := strings.ToLower(strings.Join(strings.Fields(mission.Callsign), "-"))
+	if name == "" {
+		name = mission.ID
+	}
+	return fmt.Sprintf("mission-report-%s.%s", name, format)
+}
+
+// reportRows returns the report as field and value pairs, in the order they are rendered
+func reportRows(mission *Mission, submittedBy string) [][]string {
+	report := mission.Report
+	return [][]string{
+		{"Mission", mission.Name},
+		{"Callsign", mission.Callsign},
+		{"Route", fmt.Sprintf("%s -> %s", mission.DepartureAirport, mission.ArrivalAirport)},
+		{"Duration (hours)", report.Duration},
+		{"Objectives", ObjectivesText(report.ObjectivesCompletion)},
+		{"Crew Performance", CrewPerformanceText(report.CrewPerformance)},
+		{"Notable Events", report.NotableEvents},
+		{"Submitted By", submittedBy},
+		{"Submitted At", report.SubmittedAt.Format(time.RFC1123)},
+	}
+}
+
+// RenderReport renders a completed mission's report as a CSV or PDF file.
+// submittedBy is the name shown for the user who submitted the report.
+func RenderReport(mission *Mission, format, submittedBy string) ([]byte, error) {
+	if mission.Report == nil {
+		return nil, fmt.Errorf("mission %s has no post-mission report", mission.Name)
+	}
+
+	rows := reportRows(mission, submittedBy)
+
+	switch format {
+	case ReportFormatCSV:
+		var buf bytes.Buffer
+		w := csv.NewWriter(&buf)
+		if err := w.Write([]string{"Field", "Value"}); err != nil {
+			return nil, err
+		}
+		if err := w.WriteAll(rows); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	case ReportFormatPDF:
+		var lines []string
+		for _, row := range rows {
+			if row[0] == "Notable Events" {
+				continue
+			}
+			lines = append(lines, fmt.Sprintf("%s: %s", row[0], row[1]))
+		}
+		if events := strings.TrimSpace(mission.Report.NotableEvents); events != "" {
+			lines = append(lines, "", "Notable Events")
+			lines = append(lines, strings.Split(events, "\n")...)
+		}
+		return renderTextPDF("Post-Mission Report: "+mission.Name, lines), nil
+	default:
+		return nil, fmt.Errorf("unsupported report format %q, use %s", format, strings.Join(ReportFormats, " or "))
+	}
+}
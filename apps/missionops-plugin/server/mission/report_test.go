@@ -0,0 +1,116 @@
+package mission
+
+import (
+	"bytes"
+	"encoding/csv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testReportMission() *Mission {
+	return &Mission{
+		ID:               "m1",
+		Name:             "Alpha",
+		Callsign:         "Eagle 1",
+		DepartureAirport: "JFK",
+		ArrivalAirport:   "LAX",
+		Status:           "completed",
+		Report: &MissionReport{
+			ObjectivesCompletion: "partial",
+			Duration:             "5.5",
+			CrewPerformance:      "excellent",
+			NotableEvents:        "Diverted (weather) over Denver\nRefuelled at DEN",
+			SubmittedBy:          "user1",
+			SubmittedAt:          time.Date(2025, 3, 1, 18, 30, 0, 0, time.UTC),
+		},
+	}
+}
+
+func TestRenderReportCSV(t *testing.T) {
+	data, err := RenderReport(testReportMission(), ReportFormatCSV, "@maverick")
+	if err != nil {
+		t.Fatalf("RenderReport returned error: %v", err)
+	}
+
+	records, err := csv.NewReader(bytes.NewReader(data)).ReadAll()
+	if err != nil {
+		t.Fatalf("Report is not valid CSV: %v", err)
+	}
+
+	values := make(map[string]string)
+	for _, record := range records[1:] {
+		values[record[0]] = record[1]
+	}
+
+	expected := map[string]string{
+		"Callsign":         "Eagle 1",
+		"Route":            "JFK -> LAX",
+		"Duration (hours)": "5.5",
+		"Objectives":       "Partial objectives completed",
+		"Crew Performance": "Excellent",
+		"Notable Events":   "Diverted (weather) over Denver\nRefuelled at DEN",
+		"Submitted By":     "@maverick",
+		"Submitted At":     "Sat, 01 Mar 2025 18:30:00 UTC",
+	}
+	for field, value := range expected {
+		if values[field] != value {
+			t.Errorf("Expected %s to be %q, got %q", field, value, values[field])
+		}
+	}
+}
+
+func TestRenderReportPDF(t *testing.T) {
+	mission := testReportMission()
+	mission.Report.NotableEvents += "\n" + strings.Repeat("Holding pattern over the field. ", 100)
+
+	data, err := RenderReport(mission, ReportFormatPDF, "@maverick")
+	if err != nil {
+		t.Fatalf("RenderReport returned error: %v", err)
+	}
+
+	pdf := string(data)
+	if !strings.HasPrefix(pdf, "%PDF-1.4\n") || !strings.HasSuffix(pdf, "%%EOF\n") {
+		t.Fatalf("Expected a PDF header and trailer, got %q", pdf)
+	}
+	for _, text := range []string{"(Post-Mission Report: Alpha) Tj", "(Crew Performance: Excellent) Tj", `(Diverted \(weather\) over Denver) Tj`} {
+		if !strings.Contains(pdf, text) {
+			t.Errorf("Expected PDF to contain %q", text)
+		}
+	}
+	if !strings.Contains(pdf, "/Count 2 >>") {
+		t.Errorf("Expected long notable events to flow onto a second page")
+	}
+}
+
+func TestRenderReportErrors(t *testing.T) {
+	if _, err := RenderReport(testReportMission(), "docx", ""); err == nil {
+		t.Error("Expected an error for an unsupported format")
+	}
+	if _, err := RenderReport(&Mission{Name: "Bravo"}, ReportFormatCSV, ""); err == nil {
+		t.Error("Expected an error for a mission without a report")
+	}
+}
+
+func TestWrapText(t *testing.T) {
+	testCases := []struct {
+		name     string
+		line     string
+		width    int
+		expected []string
+	}{
+		{name: "empty", line: "", width: 10, expected: []string{""}},
+		{name: "fits", line: "short line", width: 10, expected: []string{"short line"}},
+		{name: "breaks between words", line: "one two three four", width: 9, expected: []string{"one two", "three", "four"}},
+		{name: "splits long words", line: "abcdefghijkl xy", width: 5, expected: []string{"abcde", "fghij", "kl xy"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			lines := wrapText(tc.line, tc.width)
+			if strings.Join(lines, "|") != strings.Join(tc.expected, "|") {
+				t.Errorf("Expected %q, got %q", tc.expected, lines)
+			}
+		})
+	}
+}
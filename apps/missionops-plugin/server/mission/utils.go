@@ -88,3 +88,22 @@ func (m *Mission) CategorizeMissionChannel(channelID, teamID string) error {
 		"categoryName", categoryName)
 	return nil
 }
+
+// UpdateChannelDisplayName sets the mission channel's display name to the status emoji, callsign and name
+func (m *Mission) UpdateChannelDisplayName(mission *Mission) error {
+	channel, err := m.client.Channel.Get(mission.ChannelID)
+	if err != nil {
+		return errors.Wrap(err, "failed to get mission channel")
+	}
+
+	displayName := fmt.Sprintf("%s %s: %s", m.GetStatusEmoji(mission.Status), mission.Callsign, mission.Name)
+	if channel.DisplayName == displayName {
+		return nil
+	}
+
+	channel.DisplayName = displayName
+	if err := m.client.Channel.Update(channel); err != nil {
+		return errors.Wrap(err, "failed to update mission channel")
+	}
+	return nil
+}
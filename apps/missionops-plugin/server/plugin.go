@@ -8,6 +8,7 @@ import (
 	"github.com/coltoneshaw/demokit/missionops-plugin/server/command"
 	"github.com/coltoneshaw/demokit/missionops-plugin/server/mission"
 	"github.com/coltoneshaw/demokit/missionops-plugin/server/subscription"
+	"github.com/coltoneshaw/demokit/missionops-plugin/server/template"
 	"github.com/gorilla/mux"
 	"github.com/mattermost/mattermost/server/public/model"
 	"github.com/mattermost/mattermost/server/public/plugin"
@@ -33,6 +34,7 @@ type Plugin struct {
 	bot          bot.BotInterface
 	mission      mission.MissionInterface
 	subscription subscription.SubscriptionInterface
+	template     template.TemplateInterface
 }
 
 // OnActivate is invoked when the plugin is activated.
@@ -55,7 +57,8 @@ func (p *Plugin) OnActivate() error {
 
 	p.mission = mission.NewMissionHandler(p.client, p.bot)
 	p.subscription = subscription.NewSubscriptionManager(p.client, p.bot, p.mission)
-	p.commandClient = command.NewCommandHandler(p.client, p.mission, p.bot, p.subscription)
+	p.template = template.NewTemplateManager(p.client)
+	p.commandClient = command.NewCommandHandler(p.client, p.mission, p.bot, p.subscription, p.template)
 
 	// // Initialize subscription manager
 	// if err := p.initSubscriptionManager(); err != nil {
@@ -93,6 +96,7 @@ func (p *Plugin) ServeHTTP(c *plugin.Context, w http.ResponseWriter, r *http.Req
 	router := mux.NewRouter()
 
 	// API routes
+	router.HandleFunc("/api/v1/missions/start", p.commandClient.HandleMissionStart).Methods("POST")
 	router.HandleFunc("/api/v1/missions/{mission_id}/complete", p.commandClient.HandleMissionComplete).Methods("POST")
 
 	router.ServeHTTP(w, r)
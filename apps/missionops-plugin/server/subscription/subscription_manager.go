@@ -3,6 +3,7 @@ package subscription
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
 	"sync"
 	"time"
 
@@ -15,6 +16,10 @@ import (
 const SubscriptionPrefix = "subscription_"
 const SubscriptionsListKey = "subscriptions_list"
 
+// DefaultNotifyDebounceSeconds is the shortest time between two status change notifications to a
+// subscription, so a mission cycling between statuses does not flood the channel
+const DefaultNotifyDebounceSeconds = 30
+
 // MissionSubscription represents a subscription to mission status updates
 type MissionSubscription struct {
 	ID              string    `json:"id"`
@@ -23,6 +28,53 @@ type MissionSubscription struct {
 	StatusTypes     []string  `json:"statusTypes"`     // Empty means all status types
 	UpdateFrequency int64     `json:"updateFrequency"` // In seconds
 	LastUpdated     time.Time `json:"lastUpdated"`
+	Digest          bool      `json:"digest,omitempty"` // Post status changes as one summary per update instead of as they happen
+
+	LastNotifiedByMission map[string]time.Time `json:"lastNotifiedByMission,omitempty"` // When the last status change notification was posted, by mission ID
+	NotifyDebounceSeconds int64                `json:"notifyDebounceSeconds,omitempty"` // 0 uses DefaultNotifyDebounceSeconds, negative disables the debounce
+}
+
+// notifyDebounce returns the shortest time between two status change notifications, negative when disabled
+func (sub *MissionSubscription) notifyDebounce() time.Duration {
+	debounce := sub.NotifyDebounceSeconds
+	if debounce == 0 {
+		debounce = DefaultNotifyDebounceSeconds
+	}
+	return time.Duration(debounce) * time.Second
+}
+
+// notifiedWithinDebounce reports whether a status change notification for the mission was posted too recently to send another at now
+func (sub *MissionSubscription) notifiedWithinDebounce(missionID string, now time.Time) bool {
+	debounce := sub.notifyDebounce()
+	lastNotifiedAt := sub.LastNotifiedByMission[missionID]
+	if debounce < 0 || lastNotifiedAt.IsZero() {
+		return false
+	}
+	return now.Sub(lastNotifiedAt) < debounce
+}
+
+// trailingKey identifies a subscription's trailing notification about one mission
+type trailingKey struct {
+	subscriptionID string
+	missionID      string
+}
+
+// trailingNotification is a status change skipped by a subscription's debounce, posted with the
+// mission's latest status when the debounce window ends
+type trailingNotification struct {
+	mission    mission.Mission // The mission as of the latest skipped change
+	fromStatus string          // The status the subscription was last notified of
+	timer      *time.Timer
+}
+
+// StatusChange is a mission status change waiting to be posted in a digest
+type StatusChange struct {
+	MissionName string
+	Callsign    string
+	ChannelName string
+	OldStatus   string
+	NewStatus   string
+	ChangedAt   time.Time
 }
 
 // SubscriptionInterface defines methods for managing mission subscriptions
@@ -45,6 +97,12 @@ type SubscriptionManager struct {
 	mutex     sync.RWMutex
 	jobsMutex sync.RWMutex
 	jobs      map[string]chan struct{} // Map of subscription ID to stop channel
+
+	digestMutex    sync.Mutex
+	pendingChanges map[string][]StatusChange // Status changes waiting for the next digest, by subscription ID
+
+	notifyMutex sync.Mutex                            // Serializes status change notifications and removals so each sees the last LastNotifiedByMission
+	trailing    map[trailingKey]*trailingNotification // Skipped status changes waiting for the debounce to end, guarded by notifyMutex
 }
 
 // NewSubscriptionManager creates a new subscription manager
@@ -54,6 +112,9 @@ func NewSubscriptionManager(client *pluginapi.Client, bot bot.BotInterface, miss
 		bot:     bot,
 		mission: mission,
 		jobs:    make(map[string]chan struct{}),
+
+		pendingChanges: make(map[string][]StatusChange),
+		trailing:       make(map[trailingKey]*trailingNotification),
 	}
 }
 
@@ -66,7 +127,16 @@ func (s *SubscriptionManager) AddSubscription(sub *MissionSubscription) error {
 		sub.ID = fmt.Sprintf("mission-sub-%s-%d", sub.ChannelID, time.Now().Unix())
 	}
 
-	// Store in KV store
+	if err := s.saveSubscription(sub); err != nil {
+		return err
+	}
+
+	// Add to list of subscriptions
+	return s.addSubscriptionToList(sub.ID)
+}
+
+// saveSubscription stores the subscription in the KV store without adding it to the subscriptions list
+func (s *SubscriptionManager) saveSubscription(sub *MissionSubscription) error {
 	subJSON, err := json.Marshal(sub)
 	if err != nil {
 		return errors.Wrap(err, "failed to marshal subscription")
@@ -78,9 +148,7 @@ func (s *SubscriptionManager) AddSubscription(sub *MissionSubscription) error {
 	if !isSet {
 		return errors.Wrap(err, "failed to store subscription in KV store")
 	}
-
-	// Add to list of subscriptions
-	return s.addSubscriptionToList(sub.ID)
+	return nil
 }
 
 // GetSubscription retrieves a subscription from the KV store
@@ -116,6 +184,21 @@ func (s *SubscriptionManager) RemoveSubscription(id string) error {
 		// Continue with removal anyway
 	}
 
+	s.digestMutex.Lock()
+	delete(s.pendingChanges, id)
+	s.digestMutex.Unlock()
+
+	// Wait for any status change notification in progress so it cannot save the subscription back
+	s.notifyMutex.Lock()
+	defer s.notifyMutex.Unlock()
+
+	for key, pending := range s.trailing {
+		if key.subscriptionID == id {
+			pending.timer.Stop()
+			delete(s.trailing, key)
+		}
+	}
+
 	// Remove from KV store
 	key := SubscriptionPrefix + id
 	if err := s.client.KV.Delete(key); err != nil {
@@ -422,21 +505,26 @@ func (s *SubscriptionManager) runSubscriptionJob(sub *MissionSubscription, stopC
 		}
 
 		// Update last updated time
-		sub.LastUpdated = now
-		err = s.AddSubscription(sub) // Save updated subscription
+		err = s.saveLastUpdated(sub, now) // Save updated subscription
 		if err != nil {
 			s.client.Log.Error("Failed to update subscription last updated time", "error", err.Error())
 		}
 	}
 
+	// Digest subscriptions post the status changes collected since the last update instead
+	sendUpdate := fetchAndSendMissionUpdates
+	if sub.Digest {
+		sendUpdate = func() { s.sendDigest(sub) }
+	}
+
 	// Fetch and send initial data
-	fetchAndSendMissionUpdates()
+	sendUpdate()
 
 	// Wait for updates or cancellation
 	for {
 		select {
 		case <-ticker.C:
-			fetchAndSendMissionUpdates()
+			sendUpdate()
 		case <-stopChan:
 			s.client.Log.Debug("Stopping subscription job", "id", sub.ID)
 			return
@@ -446,6 +534,18 @@ func (s *SubscriptionManager) runSubscriptionJob(sub *MissionSubscription, stopC
 
 // notifySubscribersOfStatusChange notifies all relevant subscribers when a mission status changes
 func (c *SubscriptionManager) NotifySubscribersOfStatusChange(mission *mission.Mission, oldStatus string) {
+	c.notifyMutex.Lock()
+
+	// Removing a subscription takes notifyMutex, so subscriptions whose channel no longer
+	// exists are cleaned up once it has been released
+	var invalid []*MissionSubscription
+	defer func() {
+		c.notifyMutex.Unlock()
+		for _, sub := range invalid {
+			c.cleanupInvalidSubscription(sub, "channel no longer exists")
+		}
+	}()
+
 	// Find all subscriptions that care about this status change
 	subs, err := c.GetSubscriptionsForStatus(mission.Status)
 	if err != nil {
@@ -458,16 +558,6 @@ func (c *SubscriptionManager) NotifySubscribersOfStatusChange(mission *mission.M
 		return
 	}
 
-	// Create the notification message
-	statusChangeMsg := fmt.Sprintf("# Mission Status Change Alert\n\n"+
-		"**Mission:** %s (Callsign: **%s**)\n"+
-		"**Status Changed:** %s → %s\n"+
-		"**Departure:** %s\n"+
-		"**Arrival:** %s\n\n"+
-		"[View Mission Channel](~%s)",
-		mission.Name, mission.Callsign, oldStatus, mission.Status,
-		mission.DepartureAirport, mission.ArrivalAirport, mission.ChannelName)
-
 	// Send notification to each subscribed channel
 	for _, sub := range subs {
 		// Skip the mission's own channel (it already gets notifications)
@@ -475,20 +565,237 @@ func (c *SubscriptionManager) NotifySubscribersOfStatusChange(mission *mission.M
 			continue
 		}
 
-		c.client.Log.Debug("Sending status change notification", "subscriptionId", sub.ID, "channelId", sub.ChannelID)
-		
-		// Check if channel still exists before sending notification
-		if !c.isChannelValid(sub.ChannelID) {
-			c.client.Log.Info("Channel no longer exists for status change notification, removing subscription", "channel_id", sub.ChannelID, "subscription_id", sub.ID)
-			c.cleanupInvalidSubscription(sub, "channel no longer exists")
+		if sub.Digest {
+			c.queueDigestChange(sub.ID, StatusChange{
+				MissionName: mission.Name,
+				Callsign:    mission.Callsign,
+				ChannelName: mission.ChannelName,
+				OldStatus:   oldStatus,
+				NewStatus:   mission.Status,
+				ChangedAt:   time.Now(),
+			})
 			continue
 		}
-		
-		_, err := c.bot.PostMessageFromBot(sub.ChannelID, statusChangeMsg)
-		if err != nil {
-			c.client.Log.Error("Error sending status change notification", "subscriptionId", sub.ID, "error", err.Error())
+
+		now := time.Now()
+		if sub.notifiedWithinDebounce(mission.ID, now) {
+			c.client.Log.Debug("Delaying status change notification, subscription was notified recently about the mission",
+				"subscriptionId", sub.ID, "missionId", mission.ID, "lastNotifiedAt", sub.LastNotifiedByMission[mission.ID])
+			c.queueTrailingNotification(sub, mission, oldStatus, now)
+			continue
 		}
+
+		// This notification carries the latest status, so a trailing one is no longer needed
+		c.cancelTrailingNotification(trailingKey{subscriptionID: sub.ID, missionID: mission.ID})
+
+		if !c.postStatusChange(sub, mission, oldStatus, now) {
+			invalid = append(invalid, sub)
+		}
+	}
+}
+
+// statusChangeMessage formats the alert posted when a mission changes status
+func statusChangeMessage(mission *mission.Mission, oldStatus string) string {
+	return fmt.Sprintf("# Mission Status Change Alert\n\n"+
+		"**Mission:** %s (Callsign: **%s**)\n"+
+		"**Status Changed:** %s → %s\n"+
+		"**Departure:** %s\n"+
+		"**Arrival:** %s\n\n"+
+		"[View Mission Channel](~%s)",
+		mission.Name, mission.Callsign, oldStatus, mission.Status,
+		mission.DepartureAirport, mission.ArrivalAirport, mission.ChannelName)
+}
+
+// postStatusChange posts a status change alert to the subscription's channel and records when it
+// was sent. It returns false when the channel no longer exists, so the caller can remove the
+// subscription once notifyMutex is released. The caller must hold notifyMutex.
+func (c *SubscriptionManager) postStatusChange(sub *MissionSubscription, mission *mission.Mission, oldStatus string, now time.Time) bool {
+	c.client.Log.Debug("Sending status change notification", "subscriptionId", sub.ID, "channelId", sub.ChannelID)
+
+	// Check if channel still exists before sending notification
+	if !c.isChannelValid(sub.ChannelID) {
+		c.client.Log.Info("Channel no longer exists for status change notification, removing subscription", "channel_id", sub.ChannelID, "subscription_id", sub.ID)
+		return false
 	}
+
+	_, err := c.bot.PostMessageFromBot(sub.ChannelID, statusChangeMessage(mission, oldStatus))
+	if err != nil {
+		c.client.Log.Error("Error sending status change notification", "subscriptionId", sub.ID, "error", err.Error())
+		return true
+	}
+
+	if err := c.saveLastNotified(sub.ID, mission.ID, now); err != nil {
+		c.client.Log.Error("Failed to update subscription last notified time", "subscriptionId", sub.ID, "error", err.Error())
+	}
+	return true
+}
+
+// queueTrailingNotification holds a status change skipped by the subscription's debounce and posts
+// the mission's latest status when the debounce window ends. Later skipped changes only update the
+// status that will be posted. The caller must hold notifyMutex.
+func (c *SubscriptionManager) queueTrailingNotification(sub *MissionSubscription, mission *mission.Mission, oldStatus string, now time.Time) {
+	key := trailingKey{subscriptionID: sub.ID, missionID: mission.ID}
+	if pending, ok := c.trailing[key]; ok {
+		pending.mission = *mission
+		return
+	}
+
+	pending := &trailingNotification{mission: *mission, fromStatus: oldStatus}
+	wait := sub.LastNotifiedByMission[mission.ID].Add(sub.notifyDebounce()).Sub(now)
+	pending.timer = time.AfterFunc(wait, func() { c.sendTrailingNotification(key) })
+	c.trailing[key] = pending
+}
+
+// cancelTrailingNotification drops a trailing notification that has not been posted yet.
+// The caller must hold notifyMutex.
+func (c *SubscriptionManager) cancelTrailingNotification(key trailingKey) {
+	if pending, ok := c.trailing[key]; ok {
+		pending.timer.Stop()
+		delete(c.trailing, key)
+	}
+}
+
+// sendTrailingNotification posts the mission's latest status to a subscription whose debounce
+// skipped it. Nothing is posted when the mission is back at the status the subscription was
+// last notified of, or when the subscription has been removed.
+func (c *SubscriptionManager) sendTrailingNotification(key trailingKey) {
+	c.notifyMutex.Lock()
+
+	var invalid *MissionSubscription
+	defer func() {
+		c.notifyMutex.Unlock()
+		if invalid != nil {
+			c.cleanupInvalidSubscription(invalid, "channel no longer exists")
+		}
+	}()
+
+	pending, ok := c.trailing[key]
+	if !ok {
+		return
+	}
+	c.cancelTrailingNotification(key)
+
+	if pending.mission.Status == pending.fromStatus {
+		c.client.Log.Debug("Dropping trailing status change notification, mission is back at the last notified status",
+			"subscriptionId", key.subscriptionID, "missionId", key.missionID, "status", pending.fromStatus)
+		return
+	}
+
+	sub, err := c.GetSubscription(key.subscriptionID)
+	if err != nil {
+		c.client.Log.Debug("Dropping trailing status change notification, subscription no longer exists", "subscriptionId", key.subscriptionID)
+		return
+	}
+
+	if !c.postStatusChange(sub, &pending.mission, pending.fromStatus, time.Now()) {
+		invalid = sub
+	}
+}
+
+// saveLastNotified records when a status change notification for the mission was posted,
+// leaving the rest of the stored subscription as it is. A subscription removed since it
+// was loaded is not saved back. The caller must hold notifyMutex.
+func (s *SubscriptionManager) saveLastNotified(id, missionID string, now time.Time) error {
+	stored, err := s.GetSubscription(id)
+	if err != nil {
+		s.client.Log.Debug("Not saving last notified time, subscription no longer exists", "subscriptionId", id)
+		return nil
+	}
+
+	if stored.LastNotifiedByMission == nil {
+		stored.LastNotifiedByMission = make(map[string]time.Time)
+	}
+	stored.LastNotifiedByMission[missionID] = now
+	return s.saveSubscription(stored)
+}
+
+// saveLastUpdated records when the subscription's periodic update was posted, keeping the
+// LastNotifiedByMission that status change notifications may have saved since the job loaded it.
+// A subscription removed since the job loaded it is not saved back.
+func (s *SubscriptionManager) saveLastUpdated(sub *MissionSubscription, now time.Time) error {
+	s.notifyMutex.Lock()
+	defer s.notifyMutex.Unlock()
+
+	stored, err := s.GetSubscription(sub.ID)
+	if err != nil {
+		s.client.Log.Debug("Not saving last updated time, subscription no longer exists", "subscriptionId", sub.ID)
+		return nil
+	}
+
+	sub.LastUpdated = now
+	sub.LastNotifiedByMission = stored.LastNotifiedByMission
+	return s.saveSubscription(sub)
+}
+
+// queueDigestChange holds a status change for the subscription's next digest
+func (s *SubscriptionManager) queueDigestChange(subscriptionID string, change StatusChange) {
+	s.digestMutex.Lock()
+	defer s.digestMutex.Unlock()
+
+	s.pendingChanges[subscriptionID] = append(s.pendingChanges[subscriptionID], change)
+}
+
+// sendDigest posts the status changes queued for a digest subscription as a single summary.
+// Nothing is posted when there are no changes, and changes that fail to post are kept for the next digest.
+func (s *SubscriptionManager) sendDigest(sub *MissionSubscription) {
+	s.digestMutex.Lock()
+	changes := s.pendingChanges[sub.ID]
+	delete(s.pendingChanges, sub.ID)
+	s.digestMutex.Unlock()
+
+	if len(changes) == 0 {
+		s.client.Log.Debug("No status changes for digest subscription", "id", sub.ID)
+		return
+	}
+
+	// Check if channel still exists before sending the digest
+	if !s.isChannelValid(sub.ChannelID) {
+		s.client.Log.Info("Channel no longer exists, removing mission subscription", "channel_id", sub.ChannelID, "subscription_id", sub.ID)
+		s.cleanupInvalidSubscription(sub, "channel no longer exists")
+		return
+	}
+
+	now := time.Now()
+	if _, err := s.bot.PostMessageFromBot(sub.ChannelID, formatDigest(sub, changes, now)); err != nil {
+		s.client.Log.Error("Failed to send mission status digest", "id", sub.ID, "error", err.Error())
+
+		s.digestMutex.Lock()
+		s.pendingChanges[sub.ID] = append(changes, s.pendingChanges[sub.ID]...)
+		s.digestMutex.Unlock()
+		return
+	}
+
+	// Update last updated time
+	if err := s.saveLastUpdated(sub, now); err != nil {
+		s.client.Log.Error("Failed to update subscription last updated time", "error", err.Error())
+	}
+}
+
+// formatDigest formats queued status changes as a table, oldest first
+func formatDigest(sub *MissionSubscription, changes []StatusChange, now time.Time) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("# Mission Status Digest (%s)\n\n", now.Format(time.RFC1123)))
+	if len(changes) == 1 {
+		sb.WriteString("1 status change since the last digest:\n\n")
+	} else {
+		sb.WriteString(fmt.Sprintf("%d status changes since the last digest:\n\n", len(changes)))
+	}
+	sb.WriteString("| Time | Name | Callsign | Status Change | Channel |\n")
+	sb.WriteString("|------|------|----------|---------------|---------|\n")
+	for _, change := range changes {
+		sb.WriteString(fmt.Sprintf("| %s | %s | %s | %s → %s | ~%s |\n",
+			change.ChangedAt.Format(time.Kitchen), change.MissionName, change.Callsign,
+			change.OldStatus, change.NewStatus, change.ChannelName))
+	}
+
+	statusTypesText := "all statuses"
+	if len(sub.StatusTypes) > 0 {
+		statusTypesText = strings.Join(sub.StatusTypes, ", ")
+	}
+	sb.WriteString(fmt.Sprintf("\n\n*This is an automated digest of changes to mission statuses: %s. Posted every %d seconds when missions change.*",
+		statusTypesText, sub.UpdateFrequency))
+
+	return sb.String()
 }
 
 // isChannelValid checks if a channel still exists
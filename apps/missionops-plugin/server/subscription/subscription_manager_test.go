@@ -0,0 +1,314 @@
+package subscription
+
+import (
+	"encoding/json"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/coltoneshaw/demokit/missionops-plugin/server/bot"
+	"github.com/coltoneshaw/demokit/missionops-plugin/server/mission"
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/plugin/plugintest"
+	"github.com/mattermost/mattermost/server/public/pluginapi"
+	"github.com/stretchr/testify/mock"
+)
+
+// testAPI wraps the plugin API mock and ignores log calls
+type testAPI struct {
+	*plugintest.API
+}
+
+func (a *testAPI) LogDebug(msg string, keyValuePairs ...any) {}
+func (a *testAPI) LogInfo(msg string, keyValuePairs ...any)  {}
+func (a *testAPI) LogWarn(msg string, keyValuePairs ...any)  {}
+func (a *testAPI) LogError(msg string, keyValuePairs ...any) {}
+
+// fakeBot records the messages posted to each channel; other BotInterface methods are not implemented
+type fakeBot struct {
+	bot.BotInterface
+	posts map[string][]string
+}
+
+func (f *fakeBot) PostMessageFromBot(channelID, message string) (*model.Post, error) {
+	f.posts[channelID] = append(f.posts[channelID], message)
+	return &model.Post{ChannelId: channelID, Message: message}, nil
+}
+
+// newTestSubscriptionManager creates a manager whose KV store holds the given subscriptions
+func newTestSubscriptionManager(t *testing.T, subs ...*MissionSubscription) (*SubscriptionManager, *fakeBot) {
+	t.Helper()
+
+	api := &plugintest.API{}
+
+	// Back the KV store with a map so saved subscriptions are read back
+	var storeMutex sync.Mutex
+	store := map[string][]byte{}
+	ids := make([]string, 0, len(subs))
+	for _, sub := range subs {
+		data, err := json.Marshal(sub)
+		if err != nil {
+			t.Fatalf("Failed to marshal subscription: %v", err)
+		}
+		store[SubscriptionPrefix+sub.ID] = data
+		ids = append(ids, sub.ID)
+	}
+	list, err := json.Marshal(ids)
+	if err != nil {
+		t.Fatalf("Failed to marshal subscriptions list: %v", err)
+	}
+	store[SubscriptionsListKey] = list
+
+	api.On("KVGet", mock.Anything).Return(func(key string) ([]byte, *model.AppError) {
+		storeMutex.Lock()
+		defer storeMutex.Unlock()
+		return store[key], nil
+	})
+	api.On("KVSetWithOptions", mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		storeMutex.Lock()
+		defer storeMutex.Unlock()
+		store[args.String(0)] = args.Get(1).([]byte)
+	}).Return(true, nil)
+	api.On("GetChannel", mock.Anything).Return(&model.Channel{}, nil)
+
+	fake := &fakeBot{posts: map[string][]string{}}
+	sm := NewSubscriptionManager(pluginapi.NewClient(&testAPI{API: api}, nil), fake, nil).(*SubscriptionManager)
+	return sm, fake
+}
+
+func TestDigestSubscriptionCollectsStatusChanges(t *testing.T) {
+	immediate := &MissionSubscription{ID: "immediate", ChannelID: "ops", UpdateFrequency: 3600, NotifyDebounceSeconds: -1}
+	digest := &MissionSubscription{ID: "digest", ChannelID: "command", UpdateFrequency: 3600, Digest: true}
+	sm, fake := newTestSubscriptionManager(t, immediate, digest)
+
+	m := &mission.Mission{Name: "Alpha", Callsign: "Eagle1", ChannelID: "mission", ChannelName: "eagle1-alpha", Status: "in-air"}
+	sm.NotifySubscribersOfStatusChange(m, "stalled")
+	m.Status = "completed"
+	sm.NotifySubscribersOfStatusChange(m, "in-air")
+
+	if len(fake.posts["ops"]) != 2 {
+		t.Errorf("Expected 2 immediate notifications, got %d", len(fake.posts["ops"]))
+	}
+	if len(fake.posts["command"]) != 0 {
+		t.Fatalf("Expected no posts for the digest subscription before its update, got %v", fake.posts["command"])
+	}
+
+	sm.sendDigest(digest)
+	if len(fake.posts["command"]) != 1 {
+		t.Fatalf("Expected a single digest post, got %d", len(fake.posts["command"]))
+	}
+	post := fake.posts["command"][0]
+	for _, text := range []string{"2 status changes", "| Alpha | Eagle1 | stalled → in-air | ~eagle1-alpha |", "| Alpha | Eagle1 | in-air → completed | ~eagle1-alpha |"} {
+		if !strings.Contains(post, text) {
+			t.Errorf("Expected the digest to contain %q, got:\n%s", text, post)
+		}
+	}
+
+	// Nothing has changed since the digest was posted
+	sm.sendDigest(digest)
+	if len(fake.posts["command"]) != 1 {
+		t.Errorf("Expected no digest without new changes, got %d posts", len(fake.posts["command"]))
+	}
+}
+
+func TestNotifySubscribersDebouncesRapidStatusChanges(t *testing.T) {
+	debounced := &MissionSubscription{ID: "debounced", ChannelID: "ops", UpdateFrequency: 3600}
+	recent := &MissionSubscription{ID: "recent", ChannelID: "command", UpdateFrequency: 3600, NotifyDebounceSeconds: 600, LastNotifiedByMission: map[string]time.Time{"alpha": time.Now().Add(-5 * time.Minute)}}
+	stale := &MissionSubscription{ID: "stale", ChannelID: "intel", UpdateFrequency: 3600, NotifyDebounceSeconds: 60, LastNotifiedByMission: map[string]time.Time{"alpha": time.Now().Add(-2 * time.Minute)}}
+	sm, fake := newTestSubscriptionManager(t, debounced, recent, stale)
+
+	m := &mission.Mission{ID: "alpha", Name: "Alpha", Callsign: "Eagle1", ChannelID: "mission", ChannelName: "eagle1-alpha", Status: "in-air"}
+	sm.NotifySubscribersOfStatusChange(m, "stalled")
+	m.Status = "stalled"
+	sm.NotifySubscribersOfStatusChange(m, "in-air")
+	m.Status = "in-air"
+	sm.NotifySubscribersOfStatusChange(m, "stalled")
+
+	if len(fake.posts["ops"]) != 1 {
+		t.Errorf("Expected the default debounce to allow 1 notification, got %d", len(fake.posts["ops"]))
+	}
+	if len(fake.posts["command"]) != 0 {
+		t.Errorf("Expected no notification within the subscription's debounce, got %d", len(fake.posts["command"]))
+	}
+	if len(fake.posts["intel"]) != 1 {
+		t.Errorf("Expected 1 notification once the debounce had passed, got %d", len(fake.posts["intel"]))
+	}
+
+	saved, err := sm.GetSubscription("debounced")
+	if err != nil {
+		t.Fatalf("GetSubscription returned error: %v", err)
+	}
+	if time.Since(saved.LastNotifiedByMission["alpha"]) > time.Minute {
+		t.Errorf("Expected LastNotifiedByMission to be saved for the mission, got %v", saved.LastNotifiedByMission)
+	}
+}
+
+func TestNotifySubscribersDebouncesEachMission(t *testing.T) {
+	sub := &MissionSubscription{ID: "ops-sub", ChannelID: "ops", UpdateFrequency: 3600}
+	sm, fake := newTestSubscriptionManager(t, sub)
+
+	alpha := &mission.Mission{ID: "alpha", Name: "Alpha", Callsign: "Eagle1", ChannelID: "mission-a", ChannelName: "eagle1-alpha", Status: "in-air"}
+	bravo := &mission.Mission{ID: "bravo", Name: "Bravo", Callsign: "Hawk2", ChannelID: "mission-b", ChannelName: "hawk2-bravo", Status: "in-air"}
+	sm.NotifySubscribersOfStatusChange(alpha, "stalled")
+	sm.NotifySubscribersOfStatusChange(bravo, "stalled")
+	alpha.Status = "completed"
+	sm.NotifySubscribersOfStatusChange(alpha, "in-air")
+
+	posts := fake.posts["ops"]
+	if len(posts) != 2 {
+		t.Fatalf("Expected 1 notification for each mission, got %d", len(posts))
+	}
+	if !strings.Contains(posts[0], "Alpha") || !strings.Contains(posts[1], "Bravo") {
+		t.Errorf("Expected notifications for Alpha then Bravo, got:\n%s", strings.Join(posts, "\n"))
+	}
+
+	saved, err := sm.GetSubscription("ops-sub")
+	if err != nil {
+		t.Fatalf("GetSubscription returned error: %v", err)
+	}
+	if saved.LastNotifiedByMission["alpha"].IsZero() || saved.LastNotifiedByMission["bravo"].IsZero() {
+		t.Errorf("Expected LastNotifiedByMission to be saved for both missions, got %v", saved.LastNotifiedByMission)
+	}
+}
+
+func TestNotifySubscribersPostsTrailingStatusAfterDebounce(t *testing.T) {
+	testCases := []struct {
+		name     string
+		statuses []string
+		expected string
+	}{
+		{name: "status changed", statuses: []string{"stalled"}, expected: "**Status Changed:** in-air → stalled"},
+		{name: "latest of several changes", statuses: []string{"stalled", "completed"}, expected: "**Status Changed:** in-air → completed"},
+		{name: "back at the notified status", statuses: []string{"stalled", "in-air"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sub := &MissionSubscription{ID: "ops-sub", ChannelID: "ops", UpdateFrequency: 3600}
+			sm, fake := newTestSubscriptionManager(t, sub)
+
+			m := &mission.Mission{ID: "alpha", Name: "Alpha", Callsign: "Eagle1", ChannelID: "mission", ChannelName: "eagle1-alpha", Status: "in-air"}
+			sm.NotifySubscribersOfStatusChange(m, "stalled")
+			for _, status := range tc.statuses {
+				oldStatus := m.Status
+				m.Status = status
+				sm.NotifySubscribersOfStatusChange(m, oldStatus)
+			}
+			if len(fake.posts["ops"]) != 1 {
+				t.Fatalf("Expected only the first change to be posted within the debounce, got %d posts", len(fake.posts["ops"]))
+			}
+
+			// Post the trailing notification now rather than waiting for the debounce to end
+			key := trailingKey{subscriptionID: "ops-sub", missionID: "alpha"}
+			if _, ok := sm.trailing[key]; !ok {
+				t.Fatal("Expected a trailing notification to be queued")
+			}
+			sm.sendTrailingNotification(key)
+
+			posts := fake.posts["ops"]
+			if tc.expected == "" {
+				if len(posts) != 1 {
+					t.Errorf("Expected no trailing notification, got:\n%s", posts[len(posts)-1])
+				}
+				return
+			}
+			if len(posts) != 2 {
+				t.Fatalf("Expected a trailing notification, got %d posts", len(posts))
+			}
+			if !strings.Contains(posts[1], tc.expected) {
+				t.Errorf("Expected the trailing notification to contain %q, got:\n%s", tc.expected, posts[1])
+			}
+			if _, ok := sm.trailing[key]; ok {
+				t.Error("Expected the trailing notification to be removed once posted")
+			}
+		})
+	}
+}
+
+func TestRemoveSubscriptionCancelsTrailingNotification(t *testing.T) {
+	sub := &MissionSubscription{ID: "ops-sub", ChannelID: "ops", UpdateFrequency: 3600, LastNotifiedByMission: map[string]time.Time{"alpha": time.Now()}}
+	sm, fake := newTestSubscriptionManager(t, sub)
+
+	m := &mission.Mission{ID: "alpha", Name: "Alpha", Callsign: "Eagle1", ChannelID: "mission", ChannelName: "eagle1-alpha", Status: "stalled"}
+	sm.NotifySubscribersOfStatusChange(m, "in-air")
+	if len(sm.trailing) != 1 {
+		t.Fatalf("Expected a trailing notification to be queued, got %d", len(sm.trailing))
+	}
+
+	if err := sm.RemoveSubscription("ops-sub"); err != nil {
+		t.Fatalf("RemoveSubscription returned error: %v", err)
+	}
+	if len(sm.trailing) != 0 {
+		t.Errorf("Expected the trailing notification to be cancelled, got %d", len(sm.trailing))
+	}
+	if len(fake.posts["ops"]) != 0 {
+		t.Errorf("Expected nothing posted to the removed subscription, got %v", fake.posts["ops"])
+	}
+}
+
+func TestSaveLastNotifiedSkipsRemovedSubscription(t *testing.T) {
+	sub := &MissionSubscription{ID: "ops-sub", ChannelID: "ops", UpdateFrequency: 3600}
+	sm, _ := newTestSubscriptionManager(t, sub)
+
+	if err := sm.RemoveSubscription("ops-sub"); err != nil {
+		t.Fatalf("RemoveSubscription returned error: %v", err)
+	}
+	if err := sm.saveLastNotified("ops-sub", "alpha", time.Now()); err != nil {
+		t.Fatalf("saveLastNotified returned error: %v", err)
+	}
+
+	if _, err := sm.GetSubscription("ops-sub"); err == nil {
+		t.Error("Expected the removed subscription not to be saved back")
+	}
+	ids, err := sm.getSubscriptionsList()
+	if err != nil {
+		t.Fatalf("getSubscriptionsList returned error: %v", err)
+	}
+	if len(ids) != 0 {
+		t.Errorf("Expected an empty subscriptions list, got %v", ids)
+	}
+}
+
+func TestNotifiedWithinDebounce(t *testing.T) {
+	now := time.Date(2025, 3, 1, 14, 0, 0, 0, time.UTC)
+	testCases := []struct {
+		name     string
+		sub      MissionSubscription
+		expected bool
+	}{
+		{name: "never notified", sub: MissionSubscription{}},
+		{name: "default debounce", sub: MissionSubscription{LastNotifiedByMission: map[string]time.Time{"alpha": now.Add(-29 * time.Second)}}, expected: true},
+		{name: "default debounce passed", sub: MissionSubscription{LastNotifiedByMission: map[string]time.Time{"alpha": now.Add(-30 * time.Second)}}},
+		{name: "custom debounce", sub: MissionSubscription{LastNotifiedByMission: map[string]time.Time{"alpha": now.Add(-time.Minute)}, NotifyDebounceSeconds: 120}, expected: true},
+		{name: "other mission notified", sub: MissionSubscription{LastNotifiedByMission: map[string]time.Time{"bravo": now}}},
+		{name: "disabled", sub: MissionSubscription{LastNotifiedByMission: map[string]time.Time{"alpha": now}, NotifyDebounceSeconds: -1}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.sub.notifiedWithinDebounce("alpha", now); got != tc.expected {
+				t.Errorf("Expected %v, got %v", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestFormatDigest(t *testing.T) {
+	sub := &MissionSubscription{StatusTypes: []string{"completed", "cancelled"}, UpdateFrequency: 1800}
+	changes := []StatusChange{{
+		MissionName: "Bravo",
+		Callsign:    "Hawk2",
+		ChannelName: "hawk2-bravo",
+		OldStatus:   "in-air",
+		NewStatus:   "cancelled",
+		ChangedAt:   time.Date(2025, 3, 1, 14, 5, 0, 0, time.UTC),
+	}}
+
+	digest := formatDigest(sub, changes, time.Date(2025, 3, 1, 14, 30, 0, 0, time.UTC))
+	for _, text := range []string{"1 status change since", "| 2:05PM | Bravo | Hawk2 | in-air → cancelled | ~hawk2-bravo |", "mission statuses: completed, cancelled. Posted every 1800 seconds"} {
+		if !strings.Contains(digest, text) {
+			t.Errorf("Expected the digest to contain %q, got:\n%s", text, digest)
+		}
+	}
+}
@@ -0,0 +1,139 @@
+package template
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/pluginapi"
+	"github.com/pkg/errors"
+)
+
+// TemplatesKey is the KV store key holding every template as one JSON document, keyed by lowercase name
+const TemplatesKey = "mission_templates"
+
+// MissionTemplate holds the default values for a repeated sortie type
+type MissionTemplate struct {
+	Name             string    `json:"name"`
+	CallsignPrefix   string    `json:"callsignPrefix,omitempty"`
+	DepartureAirport string    `json:"departureAirport,omitempty"`
+	ArrivalAirport   string    `json:"arrivalAirport,omitempty"`
+	CreatedBy        string    `json:"createdBy"`
+	UpdatedAt        time.Time `json:"updatedAt"`
+}
+
+// TemplateInterface defines methods for managing mission templates
+type TemplateInterface interface {
+	// SaveTemplate creates a template, or replaces the one with the same name
+	SaveTemplate(template *MissionTemplate) error
+	// GetTemplate retrieves a template by name, ignoring case
+	GetTemplate(name string) (*MissionTemplate, error)
+	// GetAllTemplates returns every template sorted by name
+	GetAllTemplates() ([]*MissionTemplate, error)
+}
+
+// TemplateManager manages mission templates using the plugin KV store
+type TemplateManager struct {
+	client *pluginapi.Client
+	mutex  sync.Mutex
+}
+
+// NewTemplateManager creates a new template manager
+func NewTemplateManager(client *pluginapi.Client) TemplateInterface {
+	return &TemplateManager{
+		client: client,
+	}
+}
+
+// SaveTemplate creates a template, or replaces the one with the same name
+func (t *TemplateManager) SaveTemplate(template *MissionTemplate) error {
+	t.client.Log.Info("Saving mission template", "name", template.Name)
+
+	if strings.TrimSpace(template.Name) == "" {
+		return errors.New("template name is required")
+	}
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	templates, err := t.getTemplates()
+	if err != nil {
+		return err
+	}
+	templates[strings.ToLower(template.Name)] = template
+
+	data, err := json.Marshal(templates)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal templates")
+	}
+
+	kvSet, err := t.client.KV.Set(TemplatesKey, data)
+	if !kvSet {
+		return errors.Wrap(err, "failed to store templates in KV store")
+	}
+
+	return nil
+}
+
+// GetTemplate retrieves a template by name, ignoring case
+func (t *TemplateManager) GetTemplate(name string) (*MissionTemplate, error) {
+	t.client.Log.Info("Getting mission template", "name", name)
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	templates, err := t.getTemplates()
+	if err != nil {
+		return nil, err
+	}
+
+	template, ok := templates[strings.ToLower(name)]
+	if !ok {
+		return nil, fmt.Errorf("template not found: %s", name)
+	}
+
+	return template, nil
+}
+
+// GetAllTemplates returns every template sorted by name
+func (t *TemplateManager) GetAllTemplates() ([]*MissionTemplate, error) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	templates, err := t.getTemplates()
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*MissionTemplate, 0, len(templates))
+	for _, template := range templates {
+		result = append(result, template)
+	}
+	slices.SortFunc(result, func(a, b *MissionTemplate) int {
+		return strings.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name))
+	})
+
+	return result, nil
+}
+
+// getTemplates reads the templates document from the KV store. Callers must hold the mutex.
+func (t *TemplateManager) getTemplates() (map[string]*MissionTemplate, error) {
+	var data []byte
+	if err := t.client.KV.Get(TemplatesKey, &data); err != nil {
+		return nil, errors.Wrap(err, "failed to get templates from KV store")
+	}
+
+	templates := make(map[string]*MissionTemplate)
+	if data == nil {
+		return templates, nil
+	}
+
+	if err := json.Unmarshal(data, &templates); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal templates")
+	}
+
+	return templates, nil
+}
@@ -0,0 +1,72 @@
+package template
+
+import (
+	"testing"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/plugin/plugintest"
+	"github.com/mattermost/mattermost/server/public/pluginapi"
+	"github.com/stretchr/testify/mock"
+)
+
+// testAPI wraps the plugin API mock and ignores log calls
+type testAPI struct {
+	*plugintest.API
+}
+
+func (a *testAPI) LogDebug(msg string, keyValuePairs ...any) {}
+func (a *testAPI) LogInfo(msg string, keyValuePairs ...any)  {}
+func (a *testAPI) LogWarn(msg string, keyValuePairs ...any)  {}
+func (a *testAPI) LogError(msg string, keyValuePairs ...any) {}
+
+// newTestTemplateManager creates a template manager backed by an in-memory templates document
+func newTestTemplateManager() TemplateInterface {
+	api := &plugintest.API{}
+
+	var stored []byte
+	api.On("KVGet", TemplatesKey).Return(func(string) []byte { return stored }, func(string) *model.AppError { return nil })
+	api.On("KVSetWithOptions", TemplatesKey, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		stored = args.Get(1).([]byte)
+	}).Return(true, nil)
+
+	return NewTemplateManager(pluginapi.NewClient(&testAPI{API: api}, nil))
+}
+
+func TestSaveAndGetTemplate(t *testing.T) {
+	manager := newTestTemplateManager()
+
+	if _, err := manager.GetTemplate("training"); err == nil {
+		t.Fatal("Expected an error getting a template before any are saved")
+	}
+
+	templates := []*MissionTemplate{
+		{Name: "Training", CallsignPrefix: "TRN", DepartureAirport: "JFK", ArrivalAirport: "LAX"},
+		{Name: "cargo", CallsignPrefix: "CGO"},
+		{Name: "training", CallsignPrefix: "TRN", DepartureAirport: "BOS", ArrivalAirport: "LAX"},
+	}
+	for _, template := range templates {
+		if err := manager.SaveTemplate(template); err != nil {
+			t.Fatalf("SaveTemplate returned error: %v", err)
+		}
+	}
+
+	template, err := manager.GetTemplate("TRAINING")
+	if err != nil {
+		t.Fatalf("GetTemplate returned error: %v", err)
+	}
+	if template.DepartureAirport != "BOS" {
+		t.Errorf("Expected saving the same name to replace the template, got departure %q", template.DepartureAirport)
+	}
+
+	all, err := manager.GetAllTemplates()
+	if err != nil {
+		t.Fatalf("GetAllTemplates returned error: %v", err)
+	}
+	if len(all) != 2 || all[0].Name != "cargo" || all[1].Name != "training" {
+		t.Errorf("Expected templates cargo and training sorted by name, got %+v", all)
+	}
+
+	if err := manager.SaveTemplate(&MissionTemplate{Name: " "}); err == nil {
+		t.Error("Expected an error saving a template without a name")
+	}
+}
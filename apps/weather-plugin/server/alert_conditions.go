@@ -0,0 +1,143 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// AlertCondition is a single rule evaluated against fetched weather values.
+// Category conditions (rain, snow, ...) match on weather code ranges, while
+// numeric conditions compare a field against a threshold.
+type AlertCondition struct {
+	Category  string  `json:"category,omitempty"`
+	Field     string  `json:"field,omitempty"`
+	Operator  string  `json:"operator,omitempty"`
+	Threshold float64 `json:"threshold,omitempty"`
+}
+
+// weatherCategoryRanges maps alert categories to inclusive weather code ranges
+var weatherCategoryRanges = map[string][2]int{
+	"clear":        {1000, 1000},
+	"cloudy":       {1001, 1102},
+	"fog":          {2000, 2999},
+	"rain":         {4000, 4999},
+	"snow":         {5000, 5999},
+	"freezing":     {6000, 6999},
+	"ice":          {7000, 7999},
+	"thunderstorm": {8000, 8999},
+}
+
+// numericAlertFields lists the fields that support > and < comparisons
+var numericAlertFields = map[string]func(values WeatherValues) float64{
+	"temp":     func(v WeatherValues) float64 { return v.Temperature },
+	"feels":    func(v WeatherValues) float64 { return v.TemperatureApparent },
+	"humidity": func(v WeatherValues) float64 { return float64(v.Humidity) },
+	"wind":     func(v WeatherValues) float64 { return v.WindSpeed },
+	"gust":     func(v WeatherValues) float64 { return v.WindGust },
+	"precip":   func(v WeatherValues) float64 { return float64(v.PrecipitationProbability) },
+	"cloud":    func(v WeatherValues) float64 { return float64(v.CloudCover) },
+}
+
+// ParseAlertConditions parses a comma-separated list such as "rain,wind>40,temp<0"
+func ParseAlertConditions(input string) ([]AlertCondition, error) {
+	var conditions []AlertCondition
+
+	for _, part := range strings.Split(input, ",") {
+		part = strings.ToLower(strings.TrimSpace(part))
+		if part == "" {
+			continue
+		}
+
+		condition, err := parseAlertCondition(part)
+		if err != nil {
+			return nil, err
+		}
+		conditions = append(conditions, condition)
+	}
+
+	if len(conditions) == 0 {
+		return nil, fmt.Errorf("no alert conditions provided")
+	}
+
+	return conditions, nil
+}
+
+func parseAlertCondition(expr string) (AlertCondition, error) {
+	opIndex := strings.IndexAny(expr, "<>")
+	if opIndex == -1 {
+		if _, ok := weatherCategoryRanges[expr]; !ok {
+			return AlertCondition{}, fmt.Errorf("unknown weather condition: %s", expr)
+		}
+		return AlertCondition{Category: expr}, nil
+	}
+
+	field := strings.TrimSpace(expr[:opIndex])
+	operator := string(expr[opIndex])
+	valueStr := strings.TrimSpace(expr[opIndex+1:])
+
+	if _, ok := numericAlertFields[field]; !ok {
+		return AlertCondition{}, fmt.Errorf("unknown alert field: %s", field)
+	}
+
+	threshold, err := strconv.ParseFloat(valueStr, 64)
+	if err != nil {
+		return AlertCondition{}, fmt.Errorf("invalid threshold for %s: %s", field, valueStr)
+	}
+
+	return AlertCondition{Field: field, Operator: operator, Threshold: threshold}, nil
+}
+
+// Matches reports whether the condition is met by the given weather values
+func (ac AlertCondition) Matches(values WeatherValues) bool {
+	if ac.Category != "" {
+		codeRange, ok := weatherCategoryRanges[ac.Category]
+		if !ok {
+			return false
+		}
+		return values.WeatherCode >= codeRange[0] && values.WeatherCode <= codeRange[1]
+	}
+
+	getter, ok := numericAlertFields[ac.Field]
+	if !ok {
+		return false
+	}
+
+	value := getter(values)
+	switch ac.Operator {
+	case ">":
+		return value > ac.Threshold
+	case "<":
+		return value < ac.Threshold
+	default:
+		return false
+	}
+}
+
+// String returns the condition in the same form it was parsed from
+func (ac AlertCondition) String() string {
+	if ac.Category != "" {
+		return ac.Category
+	}
+	return ac.Field + ac.Operator + strconv.FormatFloat(ac.Threshold, 'f', -1, 64)
+}
+
+// MatchingAlertConditions returns every condition met by the given values
+func MatchingAlertConditions(conditions []AlertCondition, values WeatherValues) []AlertCondition {
+	var matched []AlertCondition
+	for _, condition := range conditions {
+		if condition.Matches(values) {
+			matched = append(matched, condition)
+		}
+	}
+	return matched
+}
+
+// FormatAlertConditions joins conditions for display in messages
+func FormatAlertConditions(conditions []AlertCondition) string {
+	parts := make([]string, 0, len(conditions))
+	for _, condition := range conditions {
+		parts = append(parts, condition.String())
+	}
+	return strings.Join(parts, ", ")
+}
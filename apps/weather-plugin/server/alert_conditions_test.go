@@ -0,0 +1,131 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestParseAlertConditions(t *testing.T) {
+	testCases := []struct {
+		name          string
+		input         string
+		expected      []AlertCondition
+		expectedError bool
+	}{
+		{
+			name:     "single category",
+			input:    "rain",
+			expected: []AlertCondition{{Category: "rain"}},
+		},
+		{
+			name:  "categories and thresholds",
+			input: "rain, Snow,wind>40,temp<0",
+			expected: []AlertCondition{
+				{Category: "rain"},
+				{Category: "snow"},
+				{Field: "wind", Operator: ">", Threshold: 40},
+				{Field: "temp", Operator: "<", Threshold: 0},
+			},
+		},
+		{
+			name:     "negative and fractional threshold",
+			input:    "feels<-5.5",
+			expected: []AlertCondition{{Field: "feels", Operator: "<", Threshold: -5.5}},
+		},
+		{
+			name:          "unknown category",
+			input:         "tornado",
+			expectedError: true,
+		},
+		{
+			name:          "unknown field",
+			input:         "pressure>1000",
+			expectedError: true,
+		},
+		{
+			name:          "invalid threshold",
+			input:         "wind>fast",
+			expectedError: true,
+		},
+		{
+			name:          "empty input",
+			input:         " , ",
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			conditions, err := ParseAlertConditions(tc.input)
+			if tc.expectedError {
+				if err == nil {
+					t.Fatalf("Expected error for %q, got %v", tc.input, conditions)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error for %q: %v", tc.input, err)
+			}
+			if len(conditions) != len(tc.expected) {
+				t.Fatalf("Expected %d conditions, got %d", len(tc.expected), len(conditions))
+			}
+			for i := range conditions {
+				if conditions[i] != tc.expected[i] {
+					t.Errorf("Condition %d: expected %+v, got %+v", i, tc.expected[i], conditions[i])
+				}
+			}
+		})
+	}
+}
+
+func TestAlertConditionMatches(t *testing.T) {
+	testCases := []struct {
+		name     string
+		expr     string
+		values   WeatherValues
+		expected bool
+	}{
+		{name: "rain matches drizzle", expr: "rain", values: WeatherValues{WeatherCode: 4000}, expected: true},
+		{name: "rain matches heavy rain", expr: "rain", values: WeatherValues{WeatherCode: 4201}, expected: true},
+		{name: "rain does not match snow", expr: "rain", values: WeatherValues{WeatherCode: 5000}, expected: false},
+		{name: "snow matches light snow", expr: "snow", values: WeatherValues{WeatherCode: 5100}, expected: true},
+		{name: "clear matches clear", expr: "clear", values: WeatherValues{WeatherCode: 1000}, expected: true},
+		{name: "cloudy matches mostly cloudy", expr: "cloudy", values: WeatherValues{WeatherCode: 1102}, expected: true},
+		{name: "thunderstorm matches", expr: "thunderstorm", values: WeatherValues{WeatherCode: 8000}, expected: true},
+		{name: "wind above threshold", expr: "wind>40", values: WeatherValues{WindSpeed: 40.1}, expected: true},
+		{name: "wind at threshold", expr: "wind>40", values: WeatherValues{WindSpeed: 40}, expected: false},
+		{name: "temp below threshold", expr: "temp<0", values: WeatherValues{Temperature: -0.5}, expected: true},
+		{name: "temp at threshold", expr: "temp<0", values: WeatherValues{Temperature: 0}, expected: false},
+		{name: "humidity above threshold", expr: "humidity>80", values: WeatherValues{Humidity: 82}, expected: true},
+		{name: "precip below threshold", expr: "precip<10", values: WeatherValues{PrecipitationProbability: 20}, expected: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			condition, err := parseAlertCondition(tc.expr)
+			if err != nil {
+				t.Fatalf("Failed to parse %q: %v", tc.expr, err)
+			}
+			if got := condition.Matches(tc.values); got != tc.expected {
+				t.Errorf("Expected %q to match=%v for %+v, got %v", tc.expr, tc.expected, tc.values, got)
+			}
+		})
+	}
+}
+
+func TestMatchingAlertConditions(t *testing.T) {
+	conditions, err := ParseAlertConditions("snow,wind>40,temp<0")
+	if err != nil {
+		t.Fatalf("Failed to parse conditions: %v", err)
+	}
+
+	values := WeatherValues{WeatherCode: 5101, WindSpeed: 12, Temperature: -3}
+	matched := MatchingAlertConditions(conditions, values)
+
+	if got := FormatAlertConditions(matched); got != "snow, temp<0" {
+		t.Errorf("Expected matched conditions %q, got %q", "snow, temp<0", got)
+	}
+
+	if matched := MatchingAlertConditions(conditions, WeatherValues{WeatherCode: 1000, Temperature: 20}); len(matched) != 0 {
+		t.Errorf("Expected no matches for clear weather, got %v", matched)
+	}
+}
@@ -0,0 +1,273 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/plugin"
+)
+
+// ServeHTTP implements the http.Handler interface for the plugin
+func (p *Plugin) ServeHTTP(c *plugin.Context, w http.ResponseWriter, r *http.Request) {
+	router := mux.NewRouter()
+
+	router.HandleFunc("/metrics", p.handleMetrics).Methods(http.MethodGet)
+	router.HandleFunc("/weather/compare", p.handleCompareWeather).Methods(http.MethodGet)
+	router.HandleFunc("/subscriptions/export", p.requireSystemAdmin(p.handleExportSubscriptions)).Methods(http.MethodGet)
+	router.HandleFunc("/subscriptions/import", p.requireSystemAdmin(p.handleImportSubscriptions)).Methods(http.MethodPost)
+	router.HandleFunc("/subscriptions", p.requireSystemAdmin(p.handleListSubscriptions)).Methods(http.MethodGet)
+	router.HandleFunc("/subscriptions", p.requireSystemAdmin(p.handleCreateSubscription)).Methods(http.MethodPost)
+	router.HandleFunc("/subscriptions/{id}", p.requireSystemAdmin(p.handleGetSubscription)).Methods(http.MethodGet)
+	router.HandleFunc("/subscriptions/{id}", p.requireSystemAdmin(p.handleDeleteSubscription)).Methods(http.MethodDelete)
+	router.HandleFunc("/subscriptions/{id}/history", p.requireSystemAdmin(p.handleSubscriptionHistory)).Methods(http.MethodGet)
+
+	router.ServeHTTP(w, r)
+}
+
+// requireSystemAdmin rejects requests that are not from a system admin. Mattermost sets the
+// Mattermost-User-ID header on requests authenticated with a session or personal access token.
+func (p *Plugin) requireSystemAdmin(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := r.Header.Get("Mattermost-User-ID")
+		if userID == "" {
+			http.Error(w, "not authorized", http.StatusUnauthorized)
+			return
+		}
+		if !p.API.HasPermissionTo(userID, model.PermissionManageSystem) {
+			http.Error(w, "forbidden", http.StatusForbidden)
+			return
+		}
+
+		next(w, r)
+	}
+}
+
+// handleExportSubscriptions returns all subscriptions as a CSV attachment
+func (p *Plugin) handleExportSubscriptions(w http.ResponseWriter, r *http.Request) {
+	data, err := p.subscriptionManager.ExportCSV()
+	if err != nil {
+		p.client.Log.Error("Failed to export subscriptions", "error", err)
+		http.Error(w, "failed to export subscriptions", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/csv")
+	w.Header().Set("Content-Disposition", "attachment; filename=subscriptions.csv")
+	if _, err := w.Write(data); err != nil {
+		p.client.Log.Error("Failed to write subscriptions export", "error", err)
+	}
+}
+
+// maxSubscriptionImportSize bounds the size of an uploaded subscriptions CSV
+const maxSubscriptionImportSize = 10 << 20
+
+// handleImportSubscriptions creates subscriptions from a CSV uploaded as the "file" field of a multipart form
+func (p *Plugin) handleImportSubscriptions(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxSubscriptionImportSize)
+	if err := r.ParseMultipartForm(maxSubscriptionImportSize); err != nil {
+		http.Error(w, "expected a multipart/form-data body with a CSV file", http.StatusBadRequest)
+		return
+	}
+
+	file, _, err := r.FormFile("file")
+	if err != nil {
+		http.Error(w, "missing CSV file in the file form field", http.StatusBadRequest)
+		return
+	}
+	defer file.Close()
+
+	imported, err := p.subscriptionManager.ImportFromCSV(file)
+	if err != nil {
+		p.client.Log.Warn("Failed to import subscriptions", "imported", imported, "error", err)
+		http.Error(w, fmt.Sprintf("failed to import subscriptions after %d rows: %v", imported, err), http.StatusBadRequest)
+		return
+	}
+	p.client.Log.Info("Imported weather subscriptions via API", "count", imported)
+
+	p.writeJSON(w, http.StatusOK, map[string]int{"imported": imported})
+}
+
+// handleMetrics serves plugin metrics in the Prometheus text exposition format
+func (p *Plugin) handleMetrics(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	if _, err := p.metrics.WriteTo(w); err != nil {
+		p.client.Log.Error("Failed to write metrics", "error", err)
+	}
+}
+
+// handleCompareWeather returns a Markdown table comparing the weather in the locations given as
+// ?locations=NYC,London,Tokyo. Locations that fail are listed in a warning below the table.
+func (p *Plugin) handleCompareWeather(w http.ResponseWriter, r *http.Request) {
+	locations := parseCompareLocations(r.URL.Query()["locations"])
+	if len(locations) == 0 {
+		http.Error(w, "locations is required, e.g. ?locations=NYC,London,Tokyo", http.StatusBadRequest)
+		return
+	}
+	if len(locations) > maxCompareLocations {
+		http.Error(w, fmt.Sprintf("at most %d locations can be compared at once", maxCompareLocations), http.StatusBadRequest)
+		return
+	}
+
+	results, err := compareWeather(r.Context(), locations, p.weatherService)
+	if len(results) == 0 {
+		http.Error(w, fmt.Sprintf("failed to get weather for any location: %v", err), http.StatusBadGateway)
+		return
+	}
+
+	body := p.formatter.FormatComparisonTable(results)
+	if err != nil {
+		p.client.Log.Warn("Failed to get weather for some compared locations", "error", err.Error())
+		body += fmt.Sprintf("\n⚠️ Could not get weather for %d of %d locations:\n%s\n", len(locations)-len(results), len(locations), err)
+	}
+
+	w.Header().Set("Content-Type", "text/markdown; charset=utf-8")
+	if _, err := w.Write([]byte(body)); err != nil {
+		p.client.Log.Error("Failed to write weather comparison", "error", err)
+	}
+}
+
+// handleSubscriptionHistory returns the recent weather readings for a subscription as JSON
+func (p *Plugin) handleSubscriptionHistory(w http.ResponseWriter, r *http.Request) {
+	subscriptionID := mux.Vars(r)["id"]
+
+	sub, exists := p.subscriptionManager.GetSubscription(subscriptionID)
+	if !exists {
+		http.Error(w, "subscription not found", http.StatusNotFound)
+		return
+	}
+
+	response := struct {
+		SubscriptionID string         `json:"subscription_id"`
+		Location       string         `json:"location"`
+		Entries        []WeatherEntry `json:"entries"`
+	}{
+		SubscriptionID: sub.ID,
+		Location:       sub.Location,
+		Entries:        p.subscriptionManager.GetHistory(sub.ID),
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		p.client.Log.Error("Failed to write subscription history", "subscription_id", subscriptionID, "error", err)
+	}
+}
+
+// createSubscriptionRequest is the JSON body accepted by POST /subscriptions
+type createSubscriptionRequest struct {
+	Location  string `json:"location"`
+	ChannelID string `json:"channel"`
+	Frequency string `json:"frequency"` // Milliseconds or a duration such as 30s, 5m, 1h
+	Units     string `json:"units"`     // Only "metric" is supported; empty defaults to metric
+}
+
+// handleListSubscriptions returns all subscriptions as JSON
+func (p *Plugin) handleListSubscriptions(w http.ResponseWriter, r *http.Request) {
+	subs := p.subscriptionManager.GetAllSubscriptions()
+	if subs == nil {
+		subs = []*Subscription{}
+	}
+
+	p.writeJSON(w, http.StatusOK, subs)
+}
+
+// handleGetSubscription returns a single subscription as JSON
+func (p *Plugin) handleGetSubscription(w http.ResponseWriter, r *http.Request) {
+	sub, exists := p.subscriptionManager.GetSubscription(mux.Vars(r)["id"])
+	if !exists {
+		http.Error(w, "subscription not found", http.StatusNotFound)
+		return
+	}
+
+	p.writeJSON(w, http.StatusOK, sub)
+}
+
+// handleCreateSubscription creates a subscription with the same validation as /weather subscribe
+func (p *Plugin) handleCreateSubscription(w http.ResponseWriter, r *http.Request) {
+	var request createSubscriptionRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, "invalid JSON body", http.StatusBadRequest)
+		return
+	}
+
+	request.Location = strings.TrimSpace(request.Location)
+	if request.Location == "" || request.ChannelID == "" || request.Frequency == "" {
+		http.Error(w, "missing required fields: location, channel and frequency", http.StatusBadRequest)
+		return
+	}
+	if request.Units != "" && request.Units != unitsMetric {
+		http.Error(w, fmt.Sprintf("unsupported units %q: only metric is supported", request.Units), http.StatusBadRequest)
+		return
+	}
+
+	args := &SubscribeArgs{Location: request.Location, FrequencyStr: request.Frequency}
+	if err := NewCommandParser().parseFrequency(args); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if !p.subscriptionManager.isChannelValid(request.ChannelID) {
+		http.Error(w, "channel not found", http.StatusNotFound)
+		return
+	}
+
+	// Validate the location up front so typos are rejected instead of failing on the first tick
+	weatherData, err := p.weatherService.GetWeatherData(r.Context(), request.Location)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("could not get weather for %s: %v", request.Location, err), http.StatusBadRequest)
+		return
+	}
+
+	subscription := &Subscription{
+		ID:              fmt.Sprintf("sub_%d", time.Now().UnixNano()),
+		Location:        request.Location,
+		ChannelID:       request.ChannelID,
+		UserID:          r.Header.Get("Mattermost-User-ID"),
+		UpdateFrequency: args.UpdateFrequency,
+		LastUpdated:     time.Now(),
+		Units:           unitsMetric,
+	}
+
+	if err := p.subscriptionManager.AddSubscription(subscription); err != nil {
+		var duplicateErr *DuplicateSubscriptionError
+		if errors.As(err, &duplicateErr) {
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		}
+		p.client.Log.Error("Failed to create subscription", "error", err)
+		http.Error(w, "failed to create subscription", http.StatusInternalServerError)
+		return
+	}
+	p.client.Log.Info("Created weather subscription via API", "subscription_id", subscription.ID, "location", subscription.Location, "channel_id", subscription.ChannelID)
+
+	go p.subscriptionManager.StartSubscription(subscription, weatherData)
+
+	p.writeJSON(w, http.StatusCreated, subscription)
+}
+
+// handleDeleteSubscription removes a subscription and stops its updates
+func (p *Plugin) handleDeleteSubscription(w http.ResponseWriter, r *http.Request) {
+	subscriptionID := mux.Vars(r)["id"]
+
+	if !p.subscriptionManager.RemoveSubscription(subscriptionID) {
+		http.Error(w, "subscription not found", http.StatusNotFound)
+		return
+	}
+	p.client.Log.Info("Removed weather subscription via API", "subscription_id", subscriptionID)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// writeJSON writes a JSON response with the given status code
+func (p *Plugin) writeJSON(w http.ResponseWriter, status int, value any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(value); err != nil {
+		p.client.Log.Error("Failed to write JSON response", "error", err)
+	}
+}
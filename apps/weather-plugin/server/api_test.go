@@ -0,0 +1,214 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/plugin"
+)
+
+const (
+	testAdminUserID = "admin1"
+	testUserID      = "user1"
+)
+
+// newTestAPIPlugin creates a plugin wired to a test subscription manager for exercising the HTTP API.
+// testAdminUserID is a system admin and testUserID is not.
+func newTestAPIPlugin(t *testing.T) *Plugin {
+	t.Helper()
+
+	api := newTestPluginAPI()
+	api.On("HasPermissionTo", testAdminUserID, model.PermissionManageSystem).Return(true)
+	api.On("HasPermissionTo", testUserID, model.PermissionManageSystem).Return(false)
+
+	sm := newTestSubscriptionManagerWithAPI(t, api, newFakeWeatherClient())
+	t.Cleanup(sm.GracefulStop)
+
+	return &Plugin{
+		MattermostPlugin:    plugin.MattermostPlugin{API: api},
+		client:              sm.client,
+		weatherService:      sm.weatherService,
+		subscriptionManager: sm,
+	}
+}
+
+// serveAPIRequest sends a request from the given user through the plugin router and returns the recorded response
+func serveAPIRequest(p *Plugin, method, path, body, userID string) *httptest.ResponseRecorder {
+	r := httptest.NewRequest(method, path, strings.NewReader(body))
+	if userID != "" {
+		r.Header.Set("Mattermost-User-ID", userID)
+	}
+	w := httptest.NewRecorder()
+	p.ServeHTTP(nil, w, r)
+	return w
+}
+
+func TestCreateSubscriptionAPI(t *testing.T) {
+	testCases := []struct {
+		name           string
+		existing       *Subscription
+		body           string
+		userID         string
+		expectedStatus int
+		expectedCount  int
+	}{
+		{
+			name:           "creates subscription",
+			body:           `{"location": "London", "channel": "channel1", "frequency": "1h", "units": "metric"}`,
+			userID:         testAdminUserID,
+			expectedStatus: http.StatusCreated,
+			expectedCount:  1,
+		},
+		{
+			name:           "requires a logged-in user",
+			body:           `{"location": "London", "channel": "channel1", "frequency": "1h"}`,
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "requires a system admin",
+			body:           `{"location": "London", "channel": "channel1", "frequency": "1h"}`,
+			userID:         testUserID,
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "rejects missing fields",
+			body:           `{"location": "London"}`,
+			userID:         testAdminUserID,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "rejects frequency below the minimum",
+			body:           `{"location": "London", "channel": "channel1", "frequency": "10s"}`,
+			userID:         testAdminUserID,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "rejects unsupported units",
+			body:           `{"location": "London", "channel": "channel1", "frequency": "1h", "units": "imperial"}`,
+			userID:         testAdminUserID,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "rejects unknown location",
+			body:           `{"location": "Atlantis", "channel": "channel1", "frequency": "1h"}`,
+			userID:         testAdminUserID,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "rejects duplicate subscription",
+			existing:       &Subscription{ID: "sub_existing", Location: "London", ChannelID: "channel1"},
+			body:           `{"location": "London", "channel": "channel1", "frequency": "1h"}`,
+			userID:         testAdminUserID,
+			expectedStatus: http.StatusConflict,
+			expectedCount:  1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			p := newTestAPIPlugin(t)
+			if tc.existing != nil {
+				if err := p.subscriptionManager.AddSubscription(tc.existing); err != nil {
+					t.Fatalf("Failed to add existing subscription: %v", err)
+				}
+			}
+
+			w := serveAPIRequest(p, http.MethodPost, "/subscriptions", tc.body, tc.userID)
+			if w.Code != tc.expectedStatus {
+				t.Fatalf("Expected status %d, got %d: %s", tc.expectedStatus, w.Code, w.Body.String())
+			}
+
+			if count := len(p.subscriptionManager.GetAllSubscriptions()); count != tc.expectedCount {
+				t.Errorf("Expected %d subscriptions, got %d", tc.expectedCount, count)
+			}
+
+			if tc.expectedStatus == http.StatusCreated {
+				var created Subscription
+				if err := json.NewDecoder(w.Body).Decode(&created); err != nil {
+					t.Fatalf("Failed to decode response: %v", err)
+				}
+				if created.ID == "" || created.Location != "London" || created.ChannelID != "channel1" || created.UpdateFrequency != 3600000 {
+					t.Errorf("Unexpected subscription in response: %+v", created)
+				}
+			}
+		})
+	}
+}
+
+func TestSubscriptionAPIGetListDelete(t *testing.T) {
+	p := newTestAPIPlugin(t)
+	if err := p.subscriptionManager.AddSubscription(&Subscription{ID: "sub_1", Location: "London", ChannelID: "channel1"}); err != nil {
+		t.Fatalf("Failed to add subscription: %v", err)
+	}
+
+	w := serveAPIRequest(p, http.MethodGet, "/subscriptions", "", testAdminUserID)
+	var subs []Subscription
+	if err := json.NewDecoder(w.Body).Decode(&subs); err != nil || w.Code != http.StatusOK {
+		t.Fatalf("Expected list to succeed, got status %d and error %v", w.Code, err)
+	}
+	if len(subs) != 1 || subs[0].ID != "sub_1" {
+		t.Errorf("Expected sub_1 in list, got %+v", subs)
+	}
+
+	if w := serveAPIRequest(p, http.MethodGet, "/subscriptions/sub_1", "", testAdminUserID); w.Code != http.StatusOK {
+		t.Errorf("Expected get to return %d, got %d", http.StatusOK, w.Code)
+	}
+	if w := serveAPIRequest(p, http.MethodGet, "/subscriptions/missing", "", testAdminUserID); w.Code != http.StatusNotFound {
+		t.Errorf("Expected get of missing subscription to return %d, got %d", http.StatusNotFound, w.Code)
+	}
+
+	if w := serveAPIRequest(p, http.MethodDelete, "/subscriptions/sub_1", "", testAdminUserID); w.Code != http.StatusNoContent {
+		t.Errorf("Expected delete to return %d, got %d", http.StatusNoContent, w.Code)
+	}
+	if w := serveAPIRequest(p, http.MethodDelete, "/subscriptions/sub_1", "", testAdminUserID); w.Code != http.StatusNotFound {
+		t.Errorf("Expected second delete to return %d, got %d", http.StatusNotFound, w.Code)
+	}
+
+	w = serveAPIRequest(p, http.MethodGet, "/subscriptions", "", testAdminUserID)
+	if body := strings.TrimSpace(w.Body.String()); body != "[]" {
+		t.Errorf("Expected empty list after delete, got %s", body)
+	}
+}
+
+func TestImportSubscriptionsAPI(t *testing.T) {
+	p := newTestAPIPlugin(t)
+
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	part, err := form.CreateFormFile("file", "subscriptions.csv")
+	if err != nil {
+		t.Fatalf("Failed to create form file: %v", err)
+	}
+	if _, err := part.Write([]byte("id,location,channel_id,user_id,update_frequency_ms,paused\nsub_1,London,channel1,user1,60000,true\n")); err != nil {
+		t.Fatalf("Failed to write form file: %v", err)
+	}
+	if err := form.Close(); err != nil {
+		t.Fatalf("Failed to close form: %v", err)
+	}
+
+	r := httptest.NewRequest(http.MethodPost, "/subscriptions/import", &body)
+	r.Header.Set("Content-Type", form.FormDataContentType())
+	r.Header.Set("Mattermost-User-ID", testAdminUserID)
+	w := httptest.NewRecorder()
+	p.ServeHTTP(nil, w, r)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	if got := strings.TrimSpace(w.Body.String()); got != `{"imported":1}` {
+		t.Errorf("Unexpected response: %s", got)
+	}
+	if _, exists := p.subscriptionManager.GetSubscription("sub_1"); !exists {
+		t.Error("Expected sub_1 to be imported")
+	}
+
+	if w := serveAPIRequest(p, http.MethodPost, "/subscriptions/import", "not a form", testAdminUserID); w.Code != http.StatusBadRequest {
+		t.Errorf("Expected a non-multipart body to return %d, got %d", http.StatusBadRequest, w.Code)
+	}
+}
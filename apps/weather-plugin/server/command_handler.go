@@ -16,7 +16,7 @@ type CommandHandler struct {
 
 func NewCommandHandler(
 	client *pluginapi.Client,
-	weatherService *WeatherService,
+	weatherService WeatherClient,
 	subscriptionManager *SubscriptionManager,
 	formatter *WeatherFormatter,
 	messageService *MessageService,
@@ -27,7 +27,7 @@ func NewCommandHandler(
 		client:              client,
 		weatherCommand:      NewWeatherCommand(weatherService, formatter, messageService),
 		helpCommand:         NewHelpCommand(messageService),
-		subscriptionCommand: NewSubscriptionCommand(client, subscriptionManager, messageService, parser),
+		subscriptionCommand: NewSubscriptionCommand(client, weatherService, subscriptionManager, messageService, parser),
 	}
 
 	err := client.SlashCommand.Register(&model.Command{
@@ -57,6 +57,10 @@ func NewCommandHandler(
 										Item:     "--all",
 										HelpText: "Show all subscriptions on the server",
 									},
+									{
+										Item:     "--page",
+										HelpText: "Show a later page of subscriptions",
+									},
 								},
 							},
 							HelpText: "Optional: show all subscriptions on server",
@@ -86,6 +90,52 @@ func NewCommandHandler(
 							HelpText: "Update frequency",
 							Required: true,
 						},
+						{
+							Type: model.AutocompleteArgTypeText,
+							Data: &model.AutocompleteTextArg{
+								Hint: "[channel name]",
+							},
+							Name:     "channel",
+							HelpText: "Optional: post updates to another channel",
+							Required: false,
+						},
+						{
+							Type: model.AutocompleteArgTypeText,
+							Data: &model.AutocompleteTextArg{
+								Hint: "[conditions like rain,snow,wind>40,temp<0]",
+							},
+							Name:     "alert-on",
+							HelpText: "Optional: only post when a condition is met",
+							Required: false,
+						},
+						{
+							Type: model.AutocompleteArgTypeStaticList,
+							Data: &model.AutocompleteStaticListArg{
+								PossibleArguments: []model.AutocompleteListItem{
+									{
+										Item:     "--no-emoji",
+										HelpText: "Don't prefix the condition with an emoji",
+									},
+								},
+							},
+							HelpText: "Optional: disable condition emoji",
+							Required: false,
+						},
+					},
+				},
+				{
+					Trigger:  "resume",
+					HelpText: "Resume a paused subscription",
+					Arguments: []*model.AutocompleteArg{
+						{
+							Type: model.AutocompleteArgTypeText,
+							Data: &model.AutocompleteTextArg{
+								Hint: "[subscription-id]",
+							},
+							Name:     "id",
+							HelpText: "Subscription ID",
+							Required: true,
+						},
 					},
 				},
 				{
@@ -125,8 +175,7 @@ func (ch *CommandHandler) Handle(args *model.CommandArgs) (*model.CommandRespons
 	case "help", "--help":
 		return ch.helpCommand.Execute(args)
 	case "list":
-		showAll := len(split) > 2 && split[2] == "--all"
-		return ch.subscriptionCommand.ExecuteList(args, showAll)
+		return ch.subscriptionCommand.ExecuteList(args, split)
 	case "subscribe":
 		return ch.subscriptionCommand.ExecuteSubscribe(args, split)
 	case "unsubscribe":
@@ -135,9 +184,23 @@ func (ch *CommandHandler) Handle(args *model.CommandArgs) (*model.CommandRespons
 			subscriptionID = split[2]
 		}
 		return ch.subscriptionCommand.ExecuteUnsubscribe(args, subscriptionID)
+	case "resume":
+		subscriptionID := ""
+		if len(split) >= 3 {
+			subscriptionID = split[2]
+		}
+		return ch.subscriptionCommand.ExecuteResume(args, subscriptionID)
 	default:
 		// Treat as location for regular weather request
-		location := strings.Join(split[1:], " ")
-		return ch.weatherCommand.Execute(args, location)
+		var locationParts []string
+		noEmoji := false
+		for _, field := range split[1:] {
+			if field == "--no-emoji" {
+				noEmoji = true
+				continue
+			}
+			locationParts = append(locationParts, field)
+		}
+		return ch.weatherCommand.Execute(args, strings.Join(locationParts, " "), noEmoji)
 	}
 }
\ No newline at end of file
@@ -13,6 +13,14 @@ type SubscribeArgs struct {
 	Location        string
 	FrequencyStr    string
 	UpdateFrequency int64
+	AlertConditions []AlertCondition
+	NoEmoji         bool
+	Channel         string
+}
+
+type ListArgs struct {
+	ShowAll bool
+	Page    int
 }
 
 func NewCommandParser() *CommandParser {
@@ -31,6 +39,12 @@ func (cp *CommandParser) ParseSubscribeCommand(commandFields []string) (*Subscri
 		// Simple syntax: /weather subscribe <location> <frequency>
 		args.Location = commandFields[2]
 		args.FrequencyStr = commandFields[3]
+
+		// Optional flags may follow the positional arguments
+		err := cp.parseFlagSyntax(commandFields[4:], args)
+		if err != nil {
+			return nil, err
+		}
 	} else {
 		// Flag syntax: /weather subscribe --location <location> --frequency <frequency>
 		err := cp.parseFlagSyntax(commandFields[2:], args)
@@ -49,11 +63,6 @@ func (cp *CommandParser) ParseSubscribeCommand(commandFields []string) (*Subscri
 		return nil, err
 	}
 
-	// Validate minimum frequency (30 seconds)
-	if args.UpdateFrequency < 30000 {
-		return nil, fmt.Errorf("update frequency must be at least 30000 milliseconds (30 seconds)")
-	}
-
 	return args, nil
 }
 
@@ -76,11 +85,54 @@ func (cp *CommandParser) parseFlagSyntax(fields []string, args *SubscribeArgs) e
 				args.FrequencyStr = fields[i+1]
 				i++ // Skip the frequency value
 			}
+		case "--channel":
+			if i+1 < len(fields) {
+				args.Channel = strings.TrimPrefix(fields[i+1], "~")
+				i++ // Skip the channel value
+			}
+		case "--no-emoji":
+			args.NoEmoji = true
+		case "--alert-on":
+			if i+1 >= len(fields) {
+				return fmt.Errorf("--alert-on requires at least one condition")
+			}
+			conditions, err := ParseAlertConditions(fields[i+1])
+			if err != nil {
+				return err
+			}
+			args.AlertConditions = append(args.AlertConditions, conditions...)
+			i++ // Skip the conditions value
 		}
 	}
 	return nil
 }
 
+// ParseListCommand parses /weather list [--all] [--page <number>]
+func (cp *CommandParser) ParseListCommand(commandFields []string) (*ListArgs, error) {
+	args := &ListArgs{Page: 1}
+
+	for i := 2; i < len(commandFields); i++ {
+		switch commandFields[i] {
+		case "--all":
+			args.ShowAll = true
+		case "--page":
+			if i+1 >= len(commandFields) {
+				return nil, fmt.Errorf("--page requires a page number")
+			}
+			page, err := strconv.Atoi(commandFields[i+1])
+			if err != nil || page < 1 {
+				return nil, fmt.Errorf("invalid page: %s. Please use a number starting at 1", commandFields[i+1])
+			}
+			args.Page = page
+			i++ // Skip the page number
+		default:
+			return nil, fmt.Errorf("unknown option: %s", commandFields[i])
+		}
+	}
+
+	return args, nil
+}
+
 func (cp *CommandParser) parseFrequency(args *SubscribeArgs) error {
 	// Try to parse as milliseconds first
 	updateFrequency, err := strconv.ParseInt(args.FrequencyStr, 10, 64)
@@ -93,6 +145,11 @@ func (cp *CommandParser) parseFrequency(args *SubscribeArgs) error {
 		updateFrequency = duration.Milliseconds()
 	}
 
+	// Validate minimum frequency (30 seconds)
+	if updateFrequency < minUpdateFrequency {
+		return fmt.Errorf("update frequency must be at least 30000 milliseconds (30 seconds)")
+	}
+
 	args.UpdateFrequency = updateFrequency
 	return nil
 }
\ No newline at end of file
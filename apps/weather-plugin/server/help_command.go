@@ -18,19 +18,28 @@ func (hc *HelpCommand) Execute(args *model.CommandArgs) (*model.CommandResponse,
 	helpText := "**Weather Bot Commands**\n\n" +
 		"**Basic Commands:**\n" +
 		"- `/weather <location>` - Get current weather for a location\n" +
+		"- `/weather <location> --no-emoji` - Get current weather without the condition emoji\n" +
 		"- `/weather help` - Show this help message\n" +
 		"- `/weather list` - List active subscriptions in this channel\n" +
-		"- `/weather list --all` - List all subscriptions on the server\n\n" +
+		"- `/weather list --all` - List all subscriptions on the server\n" +
+		"- Add `--page <number>` to either list to see later pages of 10 subscriptions\n\n" +
 		"**Subscription Commands:**\n" +
 		"- `/weather subscribe --location <location> --frequency <frequency>` - Subscribe to weather updates\n" +
-		"- `/weather unsubscribe <subscription_id>` - Unsubscribe from specific weather updates\n\n" +
+		"- `/weather subscribe --location <location> --frequency <frequency> --alert-on <conditions>` - Only post when a condition is met\n" +
+		"- Add `--no-emoji` to a subscription to post conditions without an emoji\n" +
+		"- Add `--channel <channel>` to a subscription to post updates to another channel you belong to\n" +
+		"- `/weather unsubscribe <subscription_id>` - Unsubscribe from specific weather updates\n" +
+		"- `/weather unsubscribe` - Show this channel's subscriptions with when each is next due\n" +
+		"- `/weather resume <subscription_id>` - Resume a subscription that was imported as paused\n\n" +
 		"**Parameters:**\n" +
 		"- `location` - Any location name (returns random weather data)\n" +
-		"- `frequency` - How often to send updates in milliseconds (e.g., 3600000 for hourly) or duration (e.g., 1h, 30m)\n\n" +
+		"- `frequency` - How often to send updates in milliseconds (e.g., 3600000 for hourly) or duration (e.g., 1h, 30m)\n" +
+		"- `conditions` - Comma-separated list of categories (`clear`, `cloudy`, `fog`, `rain`, `snow`, `freezing`, `ice`, `thunderstorm`) or thresholds on `temp`, `feels`, `humidity`, `wind`, `gust`, `precip`, `cloud` using `>` or `<` (e.g., `wind>40`, `temp<0`)\n\n" +
 		"**Examples:**\n" +
 		"- `/weather London` - Get current weather for London\n" +
 		"- `/weather subscribe --location Tokyo --frequency 1h` - Get hourly weather updates for Tokyo\n" +
-		"- `/weather subscribe --location \"New York\" --frequency 30m` - Get updates every 30 minutes for New York"
+		"- `/weather subscribe --location \"New York\" --frequency 30m` - Get updates every 30 minutes for New York\n" +
+		"- `/weather subscribe --location Chicago --frequency 15m --alert-on snow,temp<0` - Only post when it snows or drops below freezing in Chicago"
 
 	return hc.messageService.SendEphemeralResponse(args, helpText)
 }
\ No newline at end of file
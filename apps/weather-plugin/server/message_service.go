@@ -1,19 +1,40 @@
 package main
 
 import (
+	"errors"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
 	"github.com/mattermost/mattermost/server/public/model"
 	"github.com/mattermost/mattermost/server/public/pluginapi"
 )
 
+const (
+	defaultPostMaxRetries = 3
+	defaultPostRetryDelay = time.Second
+)
+
+// errPostCancelled is returned by CreatePostWithRetry when it is stopped while waiting to retry
+var errPostCancelled = errors.New("post creation cancelled")
+
 type MessageService struct {
-	client    *pluginapi.Client
-	botUserID string
+	client     *pluginapi.Client
+	botUserID  string
+	maxRetries int
+	retryDelay time.Duration
+	metrics    *Metrics
 }
 
-func NewMessageService(client *pluginapi.Client, botUserID string) *MessageService {
+func NewMessageService(client *pluginapi.Client, botUserID string, metrics *Metrics) *MessageService {
 	return &MessageService{
-		client:    client,
-		botUserID: botUserID,
+		client:     client,
+		botUserID:  botUserID,
+		metrics:    metrics,
+		maxRetries: getEnvInt("WEATHER_POST_MAX_RETRIES", defaultPostMaxRetries),
+		retryDelay: getEnvDuration("WEATHER_POST_RETRY_DELAY", defaultPostRetryDelay),
 	}
 }
 
@@ -33,6 +54,40 @@ func (ms *MessageService) SendPublicResponse(args *model.CommandArgs, post *mode
 	return ms.sendResponse(post, args.UserId, false)
 }
 
+// CreatePostWithRetry creates a bot post, retrying transient failures with
+// exponential back-off (1s, 2s, 4s by default). Client errors are not retried.
+// Closing stop abandons the retries and returns errPostCancelled.
+func (ms *MessageService) CreatePostWithRetry(post *model.Post, stop <-chan struct{}) error {
+	post.UserId = ms.botUserID
+
+	delay := ms.retryDelay
+	var err error
+	for attempt := 0; attempt <= ms.maxRetries; attempt++ {
+		if attempt > 0 {
+			ms.client.Log.Warn("Retrying post creation", "channel_id", post.ChannelId, "attempt", attempt, "delay", delay.String(), "error", err)
+			select {
+			case <-time.After(delay):
+			case <-stop:
+				return errPostCancelled
+			}
+			delay *= 2
+		}
+
+		err = ms.client.Post.CreatePost(post)
+		if err == nil {
+			return nil
+		}
+		if !isTransientError(err) {
+			ms.metrics.IncPostFailures()
+			return err
+		}
+	}
+
+	ms.client.Log.Error("Failed to create post after retries", "channel_id", post.ChannelId, "retries", ms.maxRetries, "error", err)
+	ms.metrics.IncPostFailures()
+	return err
+}
+
 func (ms *MessageService) sendResponse(post *model.Post, userID string, isEphemeral bool) (*model.CommandResponse, error) {
 	ms.sendBotPost(post, userID, isEphemeral)
 
@@ -52,4 +107,38 @@ func (ms *MessageService) sendBotPost(post *model.Post, userID string, isEphemer
 
 	ms.client.Post.CreatePost(post)
 	return post
-}
\ No newline at end of file
+}
+
+// isTransientError reports whether an error is worth retrying: server-side
+// (5xx) app errors and network timeouts are, client (4xx) errors are not.
+func isTransientError(err error) bool {
+	var appErr *model.AppError
+	if errors.As(err, &appErr) {
+		return appErr.StatusCode >= http.StatusInternalServerError
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return netErr.Timeout()
+	}
+
+	return false
+}
+
+// getEnvInt reads a non-negative integer from the environment, falling back to the default
+func getEnvInt(key string, defaultValue int) int {
+	value, err := strconv.Atoi(os.Getenv(key))
+	if err != nil || value < 0 {
+		return defaultValue
+	}
+	return value
+}
+
+// getEnvDuration reads a duration such as "500ms" or "2s" from the environment, falling back to the default
+func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	value, err := time.ParseDuration(os.Getenv(key))
+	if err != nil || value <= 0 {
+		return defaultValue
+	}
+	return value
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sync/atomic"
+)
+
+// Metrics holds the counters exposed on the plugin's /metrics endpoint.
+// All methods are safe to call on a nil *Metrics so components can be used without instrumentation.
+type Metrics struct {
+	weatherRequests      atomic.Int64
+	weatherRequestErrors atomic.Int64
+	postFailures         atomic.Int64
+	activeSubscriptions  atomic.Int64
+}
+
+func NewMetrics() *Metrics {
+	return &Metrics{}
+}
+
+// IncWeatherRequests records a weather data lookup and whether it failed
+func (m *Metrics) IncWeatherRequests(failed bool) {
+	if m == nil {
+		return
+	}
+	m.weatherRequests.Add(1)
+	if failed {
+		m.weatherRequestErrors.Add(1)
+	}
+}
+
+// IncPostFailures records a post that could not be created after all retries
+func (m *Metrics) IncPostFailures() {
+	if m == nil {
+		return
+	}
+	m.postFailures.Add(1)
+}
+
+// SetActiveSubscriptions updates the active subscription gauge
+func (m *Metrics) SetActiveSubscriptions(count int) {
+	if m == nil {
+		return
+	}
+	m.activeSubscriptions.Store(int64(count))
+}
+
+// WriteTo writes all metrics in the Prometheus text exposition format
+func (m *Metrics) WriteTo(w io.Writer) (int64, error) {
+	if m == nil {
+		return 0, nil
+	}
+
+	metrics := []struct {
+		name       string
+		help       string
+		metricType string
+		value      int64
+	}{
+		{"weather_plugin_weather_requests_total", "Total weather data lookups.", "counter", m.weatherRequests.Load()},
+		{"weather_plugin_weather_request_errors_total", "Weather data lookups that returned an error.", "counter", m.weatherRequestErrors.Load()},
+		{"weather_plugin_post_failures_total", "Posts that failed after all retries.", "counter", m.postFailures.Load()},
+		{"weather_plugin_active_subscriptions", "Number of active weather subscriptions.", "gauge", m.activeSubscriptions.Load()},
+	}
+
+	var written int64
+	for _, metric := range metrics {
+		n, err := fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n%s %d\n",
+			metric.name, metric.help, metric.name, metric.metricType, metric.name, metric.value)
+		written += int64(n)
+		if err != nil {
+			return written, err
+		}
+	}
+	return written, nil
+}
@@ -8,16 +8,18 @@ type WeatherResponse struct {
 	Data struct {
 		Time   string `json:"time"`
 		Values struct {
-			Temperature              float64 `json:"temperature"`
-			TemperatureApparent      float64 `json:"temperatureApparent"`
-			Humidity                 int     `json:"humidity"`
-			PrecipitationProbability int     `json:"precipitationProbability"`
-			RainIntensity            float64 `json:"rainIntensity"`
-			WindSpeed                float64 `json:"windSpeed"`
-			WindGust                 float64 `json:"windGust"`
-			WindDirection            int     `json:"windDirection"`
-			CloudCover               int     `json:"cloudCover"`
-			WeatherCode              int     `json:"weatherCode"`
+			Temperature              float64  `json:"temperature"`
+			TemperatureApparent      float64  `json:"temperatureApparent"`
+			Humidity                 int      `json:"humidity"`
+			PrecipitationProbability int      `json:"precipitationProbability"`
+			RainIntensity            float64  `json:"rainIntensity"`
+			WindSpeed                float64  `json:"windSpeed"`
+			WindGust                 float64  `json:"windGust"`
+			WindDirection            int      `json:"windDirection"`
+			CloudCover               int      `json:"cloudCover"`
+			WeatherCode              int      `json:"weatherCode"`
+			EPAIndex                 *int     `json:"epaIndex,omitempty"`
+			ParticulateMatter25      *float64 `json:"particulateMatter25,omitempty"`
 		} `json:"values"`
 	} `json:"data"`
 	Location struct {
@@ -29,14 +31,24 @@ type WeatherResponse struct {
 }
 
 type Subscription struct {
-	ID              string        `json:"id"`
-	Location        string        `json:"location"`
-	ChannelID       string        `json:"channel_id"`
-	UserID          string        `json:"user_id"`
-	UpdateFrequency int64         `json:"update_frequency"`
-	LastUpdated     time.Time     `json:"last_updated"`
+	ID              string           `json:"id"`
+	Location        string           `json:"location"`
+	ChannelID       string           `json:"channel_id"`
+	UserID          string           `json:"user_id"`
+	UpdateFrequency int64            `json:"update_frequency"`
+	LastUpdated     time.Time        `json:"last_updated"`
+	AlertConditions []AlertCondition `json:"alert_conditions,omitempty"`
+	NoEmoji         bool             `json:"no_emoji,omitempty"`
+	Units           string           `json:"units,omitempty"`
+	Paused          bool             `json:"paused,omitempty"` // Imported paused; kept without running updates, including after a restart
 }
 
+// unitsMetric is the only unit system the bundled weather data is reported in
+const unitsMetric = "metric"
+
+// minUpdateFrequency is the shortest allowed subscription update interval in milliseconds
+const minUpdateFrequency = 30000
+
 var WeatherCodeDescription = map[int]string{
 	1000: "Clear",
 	1100: "Mostly Clear",
@@ -61,4 +73,45 @@ var WeatherCodeDescription = map[int]string{
 	7101: "Heavy Ice Pellets",
 	7102: "Light Ice Pellets",
 	8000: "Thunderstorm",
-}
\ No newline at end of file
+}
+
+// WeatherCategoryDescription names the category of each block of a thousand weather codes, used
+// for codes the API added after WeatherCodeDescription was written
+var WeatherCategoryDescription = map[int]string{
+	1: "Clear/Cloudy",
+	2: "Fog",
+	4: "Rain",
+	5: "Snow",
+	6: "Freezing Rain",
+	7: "Ice Pellets",
+	8: "Thunderstorm",
+}
+
+// defaultWeatherEmoji is used for weather codes without a specific emoji
+const defaultWeatherEmoji = "🌡️"
+
+var WeatherCodeEmoji = map[int]string{
+	1000: "☀️",
+	1100: "🌤️",
+	1101: "⛅",
+	1102: "🌥️",
+	1001: "☁️",
+	2000: "🌫️",
+	2100: "🌫️",
+	4000: "🌦️",
+	4001: "🌧️",
+	4200: "🌦️",
+	4201: "🌧️",
+	5000: "🌨️",
+	5001: "🌨️",
+	5100: "🌨️",
+	5101: "❄️",
+	6000: "🌧️",
+	6001: "🧊",
+	6200: "🧊",
+	6201: "🧊",
+	7000: "🧊",
+	7101: "🧊",
+	7102: "🧊",
+	8000: "⛈️",
+}
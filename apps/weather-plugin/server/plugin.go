@@ -1,6 +1,8 @@
 package main
 
 import (
+	"os"
+	"strings"
 	"sync"
 
 	"github.com/mattermost/mattermost/server/public/model"
@@ -16,11 +18,12 @@ type Plugin struct {
 	configuration     *configuration
 	client            *pluginapi.Client
 
-	weatherService      *WeatherService
+	weatherService      WeatherClient
 	subscriptionManager *SubscriptionManager
 	commandHandler      *CommandHandler
 	formatter           *WeatherFormatter
 	messageService      *MessageService
+	metrics             *Metrics
 	botUserID           string
 }
 
@@ -40,10 +43,11 @@ func (p *Plugin) OnActivate() error {
 	}
 	p.botUserID = botUserID
 
-	p.weatherService = NewWeatherService(bundlePath)
+	p.metrics = NewMetrics()
+	p.weatherService = NewWeatherService(bundlePath, p.metrics)
 	p.formatter = NewWeatherFormatter()
-	p.messageService = NewMessageService(p.client, p.botUserID)
-	p.subscriptionManager = NewSubscriptionManager(p.client, p.weatherService, p.formatter, p.messageService)
+	p.messageService = NewMessageService(p.client, p.botUserID, p.metrics)
+	p.subscriptionManager = NewSubscriptionManager(p.client, p.weatherService, p.formatter, p.messageService, p.metrics)
 	p.commandHandler = NewCommandHandler(p.client, p.weatherService, p.subscriptionManager, p.formatter, p.messageService)
 
 	p.client.Log.Info("Weather plugin activated", "bundle_path", bundlePath, "bot_user_id", p.botUserID)
@@ -52,7 +56,7 @@ func (p *Plugin) OnActivate() error {
 
 func (p *Plugin) OnDeactivate() error {
 	if p.subscriptionManager != nil {
-		p.subscriptionManager.StopAll()
+		p.subscriptionManager.GracefulStop()
 	}
 	return nil
 }
@@ -60,7 +64,7 @@ func (p *Plugin) OnDeactivate() error {
 func (p *Plugin) ExecuteCommand(c *plugin.Context, args *model.CommandArgs) (*model.CommandResponse, *model.AppError) {
 	response, err := p.commandHandler.Handle(args)
 	if err != nil {
-		p.client.Log.Error("Error executing command", "error", err.Error())
+		p.client.Log.Error("Error executing command", "error", err.Error(), "command", args.Command, "channel_id", args.ChannelId, "user_id", args.UserId)
 		return &model.CommandResponse{
 			ResponseType: model.CommandResponseTypeEphemeral,
 			Text:         err.Error(),
@@ -104,6 +108,20 @@ func (p *Plugin) ensureBotUser() (string, error) {
 	return botID, nil
 }
 
+// loadSecret returns the environment variable if set, otherwise the contents of the
+// secret file with surrounding whitespace trimmed, or "" when neither is available
+func loadSecret(envVar, secretPath string) string {
+	if value := os.Getenv(envVar); value != "" {
+		return value
+	}
+
+	data, err := os.ReadFile(secretPath)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
+
 func main() {
 	plugin.ClientMain(&Plugin{})
 }
\ No newline at end of file
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadSecret(t *testing.T) {
+	testCases := []struct {
+		name        string
+		envValue    string
+		fileContent *string
+		expected    string
+	}{
+		{
+			name:        "env var takes precedence over the file",
+			envValue:    "env-token",
+			fileContent: stringPtr("file-token"),
+			expected:    "env-token",
+		},
+		{
+			name:        "falls back to the secret file",
+			fileContent: stringPtr("file-token"),
+			expected:    "file-token",
+		},
+		{
+			name:     "missing secret file",
+			expected: "",
+		},
+		{
+			name:        "trims trailing newline from the file",
+			fileContent: stringPtr("file-token\n"),
+			expected:    "file-token",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv("TEST_WEATHER_SECRET", tc.envValue)
+
+			secretPath := filepath.Join(t.TempDir(), "weather_secret")
+			if tc.fileContent != nil {
+				if err := os.WriteFile(secretPath, []byte(*tc.fileContent), 0600); err != nil {
+					t.Fatalf("Failed to write secret file: %v", err)
+				}
+			}
+
+			if secret := loadSecret("TEST_WEATHER_SECRET", secretPath); secret != tc.expected {
+				t.Errorf("Expected %q, got %q", tc.expected, secret)
+			}
+		})
+	}
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
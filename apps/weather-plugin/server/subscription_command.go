@@ -1,6 +1,8 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
@@ -11,32 +13,47 @@ import (
 
 type SubscriptionCommand struct {
 	client              *pluginapi.Client
+	weatherService      WeatherClient
 	subscriptionManager *SubscriptionManager
 	messageService      *MessageService
 	parser              *CommandParser
 }
 
-func NewSubscriptionCommand(client *pluginapi.Client, subscriptionManager *SubscriptionManager, messageService *MessageService, parser *CommandParser) *SubscriptionCommand {
+func NewSubscriptionCommand(client *pluginapi.Client, weatherService WeatherClient, subscriptionManager *SubscriptionManager, messageService *MessageService, parser *CommandParser) *SubscriptionCommand {
 	return &SubscriptionCommand{
 		client:              client,
+		weatherService:      weatherService,
 		subscriptionManager: subscriptionManager,
 		messageService:      messageService,
 		parser:              parser,
 	}
 }
 
-func (sc *SubscriptionCommand) ExecuteList(args *model.CommandArgs, showAll bool) (*model.CommandResponse, error) {
+func (sc *SubscriptionCommand) ExecuteList(args *model.CommandArgs, commandFields []string) (*model.CommandResponse, error) {
+	listArgs, err := sc.parser.ParseListCommand(commandFields)
+	if err != nil {
+		return sc.messageService.SendEphemeralResponse(args, fmt.Sprintf("Invalid command: %v. Usage: `/weather list [--all] [--page <number>]`", err))
+	}
+	showAll := listArgs.ShowAll
+	offset := (listArgs.Page - 1) * defaultSubscriptionPageSize
+
 	var subs []*Subscription
+	var total int
 	var title string
 
 	if showAll {
-		subs = sc.subscriptionManager.GetAllSubscriptions()
+		subs, total = sc.subscriptionManager.GetAllSubscriptionsPaged(offset, defaultSubscriptionPageSize)
 		title = "**All Weather Subscriptions on Server:**"
 	} else {
-		subs = sc.subscriptionManager.GetSubscriptionsForChannel(args.ChannelId)
+		subs, total = sc.subscriptionManager.GetSubscriptionsForChannelPaged(args.ChannelId, offset, defaultSubscriptionPageSize)
 		title = "**Active Weather Subscriptions in this Channel:**"
 	}
 
+	totalPages := (total + defaultSubscriptionPageSize - 1) / defaultSubscriptionPageSize
+	if total > 0 && len(subs) == 0 {
+		return sc.messageService.SendEphemeralResponse(args, fmt.Sprintf("Page %d does not exist. There are %d pages of subscriptions.", listArgs.Page, totalPages))
+	}
+
 	if len(subs) == 0 {
 		message := "No active weather subscriptions found"
 		if showAll {
@@ -60,16 +77,18 @@ func (sc *SubscriptionCommand) ExecuteList(args *model.CommandArgs, showAll bool
 				sub.ID, sub.Location, channelName, sub.UpdateFrequency, sub.LastUpdated.Format(time.RFC1123)))
 		}
 	} else {
-		subList.WriteString("| ID | Location | Frequency | Last Updated |\n")
-		subList.WriteString("|---|---------|-----------|-------------|\n")
+		subList.WriteString(FormatSubscriptionList(subs))
+	}
 
-		for _, sub := range subs {
-			subList.WriteString(fmt.Sprintf("| `%s` | %s | %d ms | %s |\n",
-				sub.ID, sub.Location, sub.UpdateFrequency, sub.LastUpdated.Format(time.RFC1123)))
+	subList.WriteString(fmt.Sprintf("\nPage %d of %d", listArgs.Page, totalPages))
+	if listArgs.Page < totalPages {
+		nextCommand := fmt.Sprintf("/weather list --page %d", listArgs.Page+1)
+		if showAll {
+			nextCommand = fmt.Sprintf("/weather list --all --page %d", listArgs.Page+1)
 		}
+		subList.WriteString(fmt.Sprintf(" - use `%s` for more", nextCommand))
 	}
-
-	subList.WriteString("\nTo unsubscribe, use: `/weather unsubscribe SUBSCRIPTION_ID`")
+	subList.WriteString("\n\nTo unsubscribe, use: `/weather unsubscribe SUBSCRIPTION_ID`")
 
 	return sc.messageService.SendEphemeralResponse(args, subList.String())
 }
@@ -77,40 +96,82 @@ func (sc *SubscriptionCommand) ExecuteList(args *model.CommandArgs, showAll bool
 func (sc *SubscriptionCommand) ExecuteSubscribe(args *model.CommandArgs, commandFields []string) (*model.CommandResponse, error) {
 	subscribeArgs, err := sc.parser.ParseSubscribeCommand(commandFields)
 	if err != nil {
-		usageMsg := "Usage: `/weather subscribe --location <location> --frequency <frequency>` or `/weather subscribe <location> <frequency>`. Example: `/weather subscribe --location \"New York\" --frequency 1h`"
+		usageMsg := fmt.Sprintf("%v. Usage: `/weather subscribe --location <location> --frequency <frequency> [--channel <channel>] [--alert-on <conditions>]` or `/weather subscribe <location> <frequency>`. Example: `/weather subscribe --location \"New York\" --frequency 1h --alert-on rain,wind>40`", err)
 		return sc.messageService.SendEphemeralResponse(args, usageMsg)
 	}
 
+	// Updates go to the current channel unless --channel was given
+	channelID := args.ChannelId
+	channelRef := "this channel"
+	if subscribeArgs.Channel != "" {
+		channel, err := sc.resolveTargetChannel(args, subscribeArgs.Channel)
+		if err != nil {
+			return sc.messageService.SendEphemeralResponse(args, fmt.Sprintf("⚠️ %v", err))
+		}
+		channelID = channel.Id
+		channelRef = "~" + channel.Name
+	}
+
+	// Validate the location up front so typos are rejected instead of failing on the first tick
+	weatherData, err := sc.weatherService.GetWeatherData(context.Background(), subscribeArgs.Location)
+	if err != nil {
+		return sc.messageService.SendEphemeralResponse(args, fmt.Sprintf("⚠️ Could not get weather for **%s**: %v", subscribeArgs.Location, err))
+	}
+
 	// Create subscription
 	subID := fmt.Sprintf("sub_%d", time.Now().UnixNano())
 	subscription := &Subscription{
 		ID:              subID,
 		Location:        subscribeArgs.Location,
-		ChannelID:       args.ChannelId,
+		ChannelID:       channelID,
 		UserID:          args.UserId,
 		UpdateFrequency: subscribeArgs.UpdateFrequency,
 		LastUpdated:     time.Now(),
+		AlertConditions: subscribeArgs.AlertConditions,
+		NoEmoji:         subscribeArgs.NoEmoji,
+		Units:           unitsMetric,
 	}
 
-	sc.subscriptionManager.AddSubscription(subscription)
+	if err := sc.subscriptionManager.AddSubscription(subscription); err != nil {
+		var duplicateErr *DuplicateSubscriptionError
+		if errors.As(err, &duplicateErr) {
+			message := fmt.Sprintf("⚠️ Weather updates for **%s** are already posted to %s (ID: `%s`). Use `/weather unsubscribe %s` first if you want to change it.",
+				duplicateErr.Existing.Location, channelRef, duplicateErr.Existing.ID, duplicateErr.Existing.ID)
+			return sc.messageService.SendEphemeralResponse(args, message)
+		}
+		return sc.messageService.SendEphemeralResponse(args, fmt.Sprintf("Failed to create subscription: %v", err))
+	}
+	sc.client.Log.Info("Created weather subscription", "subscription_id", subID, "location", subscription.Location, "channel_id", subscription.ChannelID, "user_id", subscription.UserID)
 
 	// Start the subscription goroutine
-	go sc.subscriptionManager.StartSubscription(subscription)
+	go sc.subscriptionManager.StartSubscription(subscription, weatherData)
 
-	confirmationMsg := fmt.Sprintf("✅ Subscribed to weather updates for **%s**. Updates will be sent every %d ms (ID: `%s`).", 
-		subscribeArgs.Location, subscribeArgs.UpdateFrequency, subID)
+	confirmationMsg := fmt.Sprintf("✅ Subscribed %s to weather updates for **%s**. Updates will be sent every %d ms (ID: `%s`).",
+		channelRef, subscribeArgs.Location, subscribeArgs.UpdateFrequency, subID)
+	if len(subscribeArgs.AlertConditions) > 0 {
+		confirmationMsg = fmt.Sprintf("✅ Subscribed %s to weather alerts for **%s**. Conditions are checked every %d ms and posted only when met: %s (ID: `%s`).",
+			channelRef, subscribeArgs.Location, subscribeArgs.UpdateFrequency, FormatAlertConditions(subscribeArgs.AlertConditions), subID)
+	}
 	
 	return sc.messageService.SendEphemeralResponse(args, confirmationMsg)
 }
 
 func (sc *SubscriptionCommand) ExecuteUnsubscribe(args *model.CommandArgs, subscriptionID string) (*model.CommandResponse, error) {
 	if subscriptionID == "" {
-		return sc.messageService.SendEphemeralResponse(args, "Usage: `/weather unsubscribe <subscription_id>`. Use `/weather list` to see your subscriptions.")
+		subs := sc.subscriptionManager.GetSubscriptionsForChannel(args.ChannelId)
+		if len(subs) == 0 {
+			return sc.messageService.SendEphemeralResponse(args, "Usage: `/weather unsubscribe <subscription_id>`. There are no active weather subscriptions in this channel.")
+		}
+
+		message := "**Active Weather Subscriptions in this Channel:**\n\n" + FormatSubscriptionList(subs) +
+			"\nTo unsubscribe, use: `/weather unsubscribe SUBSCRIPTION_ID`"
+		return sc.messageService.SendEphemeralResponse(args, message)
 	}
 
 	if sub, exists := sc.subscriptionManager.GetSubscription(subscriptionID); exists {
 		location := sub.Location
 		if sc.subscriptionManager.RemoveSubscription(subscriptionID) {
+			sc.client.Log.Info("Removed weather subscription", "subscription_id", subscriptionID, "location", location, "channel_id", sub.ChannelID, "user_id", args.UserId)
 			message := fmt.Sprintf("✅ Unsubscribed from weather updates for **%s** (ID: `%s`).", location, subscriptionID)
 			return sc.messageService.SendEphemeralResponse(args, message)
 		}
@@ -120,6 +181,67 @@ func (sc *SubscriptionCommand) ExecuteUnsubscribe(args *model.CommandArgs, subsc
 	return sc.messageService.SendEphemeralResponse(args, message)
 }
 
+// ExecuteResume resumes a subscription that was imported as paused
+func (sc *SubscriptionCommand) ExecuteResume(args *model.CommandArgs, subscriptionID string) (*model.CommandResponse, error) {
+	if subscriptionID == "" {
+		return sc.messageService.SendEphemeralResponse(args, "Usage: `/weather resume <subscription_id>`")
+	}
+
+	sub, exists := sc.subscriptionManager.GetSubscription(subscriptionID)
+	if !exists {
+		return sc.messageService.SendEphemeralResponse(args, fmt.Sprintf("No subscription found with ID: %s", subscriptionID))
+	}
+
+	if !sc.subscriptionManager.ResumeSubscription(subscriptionID) {
+		return sc.messageService.SendEphemeralResponse(args, fmt.Sprintf("Subscription `%s` is not paused.", subscriptionID))
+	}
+
+	sc.client.Log.Info("Resumed weather subscription", "subscription_id", subscriptionID, "location", sub.Location, "channel_id", sub.ChannelID, "user_id", args.UserId)
+	return sc.messageService.SendEphemeralResponse(args, fmt.Sprintf("✅ Resumed weather updates for **%s** (ID: `%s`).", sub.Location, subscriptionID))
+}
+
+// FormatSubscriptionList builds a Markdown table of subscriptions with when each is next due to post.
+// Subscriptions whose next update has already passed are shown as overdue, and paused ones as paused.
+func FormatSubscriptionList(subs []*Subscription) string {
+	return formatSubscriptionListAt(subs, time.Now())
+}
+
+func formatSubscriptionListAt(subs []*Subscription, now time.Time) string {
+	var sb strings.Builder
+	sb.WriteString("| ID | Location | Frequency | Last Updated | Next Update |\n")
+	sb.WriteString("|---|---------|-----------|-------------|-------------|\n")
+
+	for _, sub := range subs {
+		nextUpdate := sub.LastUpdated.Add(time.Duration(sub.UpdateFrequency) * time.Millisecond)
+		next := nextUpdate.Format(time.RFC1123)
+		if sub.Paused {
+			next = "paused"
+		} else if nextUpdate.Before(now) {
+			next = "overdue"
+		}
+
+		sb.WriteString(fmt.Sprintf("| `%s` | %s | %d ms | %s | %s |\n",
+			sub.ID, sub.Location, sub.UpdateFrequency, sub.LastUpdated.Format(time.RFC1123), next))
+	}
+
+	return sb.String()
+}
+
+// resolveTargetChannel looks up a channel by name in the current team and
+// ensures the requesting user is a member of it
+func (sc *SubscriptionCommand) resolveTargetChannel(args *model.CommandArgs, channelName string) (*model.Channel, error) {
+	channel, err := sc.client.Channel.GetByName(args.TeamId, channelName, false)
+	if err != nil {
+		return nil, fmt.Errorf("channel ~%s was not found in this team", channelName)
+	}
+
+	if _, err := sc.client.Channel.GetMember(channel.Id, args.UserId); err != nil {
+		return nil, fmt.Errorf("you must be a member of ~%s to subscribe it to weather updates", channelName)
+	}
+
+	return channel, nil
+}
+
 func (sc *SubscriptionCommand) getChannelName(channelID string) string {
 	channel, err := sc.client.Channel.Get(channelID)
 	if err != nil {
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFormatSubscriptionList(t *testing.T) {
+	now := time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name         string
+		sub          *Subscription
+		expectedNext string
+	}{
+		{
+			name:         "next update in the future",
+			sub:          &Subscription{ID: "sub_1", Location: "London", UpdateFrequency: time.Hour.Milliseconds(), LastUpdated: now.Add(-10 * time.Minute)},
+			expectedNext: now.Add(50 * time.Minute).Format(time.RFC1123),
+		},
+		{
+			name:         "next update already passed",
+			sub:          &Subscription{ID: "sub_2", Location: "Paris", UpdateFrequency: time.Hour.Milliseconds(), LastUpdated: now.Add(-2 * time.Hour)},
+			expectedNext: "overdue",
+		},
+		{
+			name:         "next update due now",
+			sub:          &Subscription{ID: "sub_3", Location: "Tokyo", UpdateFrequency: time.Minute.Milliseconds(), LastUpdated: now.Add(-time.Minute)},
+			expectedNext: now.Format(time.RFC1123),
+		},
+		{
+			name:         "paused",
+			sub:          &Subscription{ID: "sub_4", Location: "Oslo", UpdateFrequency: time.Hour.Milliseconds(), LastUpdated: now.Add(-2 * time.Hour), Paused: true},
+			expectedNext: "paused",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			table := formatSubscriptionListAt([]*Subscription{tc.sub}, now)
+			lines := strings.Split(strings.TrimSpace(table), "\n")
+
+			if len(lines) != 3 {
+				t.Fatalf("Expected header, separator and one row, got:\n%s", table)
+			}
+			if lines[0] != "| ID | Location | Frequency | Last Updated | Next Update |" {
+				t.Errorf("Unexpected header: %s", lines[0])
+			}
+			if !strings.HasSuffix(lines[2], "| "+tc.expectedNext+" |") {
+				t.Errorf("Expected next update %q, got row: %s", tc.expectedNext, lines[2])
+			}
+			if !strings.HasPrefix(lines[2], "| `"+tc.sub.ID+"` | "+tc.sub.Location+" |") {
+				t.Errorf("Expected row for %s, got: %s", tc.sub.ID, lines[2])
+			}
+		})
+	}
+}
+
+func TestFormatSubscriptionListEmpty(t *testing.T) {
+	table := FormatSubscriptionList(nil)
+	if lines := strings.Split(strings.TrimSpace(table), "\n"); len(lines) != 2 {
+		t.Errorf("Expected only header and separator for no subscriptions, got:\n%s", table)
+	}
+}
@@ -0,0 +1,171 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var subscriptionCSVHeader = []string{"id", "location", "channel_id", "user_id", "update_frequency_ms", "last_updated", "paused"}
+
+// subscriptionCSVRequiredColumns must be present in an imported CSV; last_updated is optional
+var subscriptionCSVRequiredColumns = []string{"id", "location", "channel_id", "user_id", "update_frequency_ms", "paused"}
+
+// ExportCSV serialises all subscriptions to CSV, sorted by ID. The paused column
+// is the subscription's paused flag, so an export can be imported again as is.
+func (sm *SubscriptionManager) ExportCSV() ([]byte, error) {
+	sm.mutex.RLock()
+	defer sm.mutex.RUnlock()
+
+	subs := make([]*Subscription, 0, len(sm.subscriptions))
+	for _, sub := range sm.subscriptions {
+		subs = append(subs, sub)
+	}
+	sort.Slice(subs, func(i, j int) bool { return subs[i].ID < subs[j].ID })
+
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+
+	if err := writer.Write(subscriptionCSVHeader); err != nil {
+		return nil, err
+	}
+
+	for _, sub := range subs {
+		record := []string{
+			sub.ID,
+			sub.Location,
+			sub.ChannelID,
+			sub.UserID,
+			strconv.FormatInt(sub.UpdateFrequency, 10),
+			sub.LastUpdated.UTC().Format(time.RFC3339),
+			strconv.FormatBool(sub.Paused),
+		}
+		if err := writer.Write(record); err != nil {
+			return nil, err
+		}
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// ImportFromCSV adds the subscriptions in a CSV with the same columns as ExportCSV, in any order.
+// Invalid rows and rows whose ID already exists are skipped with a logged warning. Imported
+// subscriptions that are not paused are started; paused ones are stored as paused so they are
+// not started when the plugin restarts either, until they are resumed. Returns the number of subscriptions imported.
+func (sm *SubscriptionManager) ImportFromCSV(r io.Reader) (int, error) {
+	reader := csv.NewReader(r)
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return 0, errors.New("CSV is empty")
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to read CSV header: %w", err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range subscriptionCSVRequiredColumns {
+		if _, exists := columns[name]; !exists {
+			return 0, fmt.Errorf("CSV header is missing the %s column", name)
+		}
+	}
+
+	imported := 0
+	for row := 2; ; row++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return imported, fmt.Errorf("failed to read CSV row %d: %w", row, err)
+		}
+
+		sub, err := parseSubscriptionCSVRecord(record, columns)
+		if err != nil {
+			sm.client.Log.Warn("Skipping invalid subscription CSV row", "row", row, "error", err.Error())
+			continue
+		}
+
+		if !sm.isChannelValid(sub.ChannelID) {
+			sm.client.Log.Warn("Skipping subscription CSV row for unknown channel", "row", row, "subscription_id", sub.ID, "channel_id", sub.ChannelID)
+			continue
+		}
+		if err := sm.AddSubscription(sub); errors.Is(err, errSubscriptionIDExists) {
+			sm.client.Log.Warn("Skipping subscription CSV row with duplicate ID", "row", row, "subscription_id", sub.ID)
+			continue
+		} else if err != nil {
+			sm.client.Log.Warn("Skipping subscription CSV row", "row", row, "subscription_id", sub.ID, "error", err.Error())
+			continue
+		}
+
+		if !sub.Paused {
+			go sm.startSubscriptionAfter(sub, time.Duration(imported)*subscriptionStartStagger)
+		}
+		imported++
+	}
+
+	sm.client.Log.Info("Imported subscriptions from CSV", "count", imported)
+	return imported, nil
+}
+
+// parseSubscriptionCSVRecord builds a subscription from a CSV record
+func parseSubscriptionCSVRecord(record []string, columns map[string]int) (*Subscription, error) {
+	field := func(name string) string {
+		i, exists := columns[name]
+		if !exists || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	sub := &Subscription{
+		ID:          field("id"),
+		Location:    field("location"),
+		ChannelID:   field("channel_id"),
+		UserID:      field("user_id"),
+		LastUpdated: time.Now(),
+		Units:       unitsMetric,
+	}
+	if sub.ID == "" || sub.Location == "" || sub.ChannelID == "" {
+		return nil, errors.New("id, location and channel_id are required")
+	}
+
+	frequency, err := strconv.ParseInt(field("update_frequency_ms"), 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid update_frequency_ms %q", field("update_frequency_ms"))
+	}
+	if frequency < minUpdateFrequency {
+		return nil, fmt.Errorf("update_frequency_ms must be at least %d", minUpdateFrequency)
+	}
+	sub.UpdateFrequency = frequency
+
+	if value := field("paused"); value != "" {
+		if sub.Paused, err = strconv.ParseBool(value); err != nil {
+			return nil, fmt.Errorf("invalid paused value %q", value)
+		}
+	}
+
+	if value := field("last_updated"); value != "" {
+		lastUpdated, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid last_updated %q", value)
+		}
+		sub.LastUpdated = lastUpdated
+	}
+
+	return sub, nil
+}
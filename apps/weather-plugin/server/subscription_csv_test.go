@@ -0,0 +1,220 @@
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/plugin/plugintest"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestExportCSV(t *testing.T) {
+	sm := newTestSubscriptionManager(t)
+	lastUpdated := time.Date(2025, 6, 1, 12, 30, 0, 0, time.UTC)
+
+	// Neither subscription is running, so only the paused flag decides the paused column
+	sm.subscriptions["sub_2"] = &Subscription{ID: "sub_2", Location: "Tokyo", ChannelID: "channel2", UserID: "user2", UpdateFrequency: 60000, LastUpdated: lastUpdated, Paused: true}
+	sm.subscriptions["sub_1"] = &Subscription{ID: "sub_1", Location: "Paris, France", ChannelID: "channel1", UserID: "user1", UpdateFrequency: 3600000, LastUpdated: lastUpdated}
+
+	data, err := sm.ExportCSV()
+	if err != nil {
+		t.Fatalf("ExportCSV failed: %v", err)
+	}
+
+	if !strings.Contains(string(data), `"Paris, France"`) {
+		t.Errorf("Expected location containing a comma to be quoted, got:\n%s", data)
+	}
+
+	records, err := csv.NewReader(strings.NewReader(string(data))).ReadAll()
+	if err != nil {
+		t.Fatalf("Exported CSV could not be parsed: %v", err)
+	}
+
+	expected := [][]string{
+		{"id", "location", "channel_id", "user_id", "update_frequency_ms", "last_updated", "paused"},
+		{"sub_1", "Paris, France", "channel1", "user1", "3600000", "2025-06-01T12:30:00Z", "false"},
+		{"sub_2", "Tokyo", "channel2", "user2", "60000", "2025-06-01T12:30:00Z", "true"},
+	}
+
+	if len(records) != len(expected) {
+		t.Fatalf("Expected %d records, got %d", len(expected), len(records))
+	}
+	for i := range expected {
+		if strings.Join(records[i], "|") != strings.Join(expected[i], "|") {
+			t.Errorf("Row %d: expected %v, got %v", i, expected[i], records[i])
+		}
+	}
+}
+
+func TestExportCSVEmpty(t *testing.T) {
+	sm := newTestSubscriptionManager(t)
+
+	data, err := sm.ExportCSV()
+	if err != nil {
+		t.Fatalf("ExportCSV failed: %v", err)
+	}
+
+	if got := strings.TrimSpace(string(data)); got != strings.Join(subscriptionCSVHeader, ",") {
+		t.Errorf("Expected header only, got %q", got)
+	}
+}
+
+func TestImportCSVRoundTrip(t *testing.T) {
+	source := newTestSubscriptionManager(t)
+	lastUpdated := time.Date(2025, 6, 1, 12, 30, 0, 0, time.UTC)
+
+	source.subscriptions["sub_1"] = &Subscription{ID: "sub_1", Location: "Paris, France", ChannelID: "channel1", UserID: "user1", UpdateFrequency: 3600000, LastUpdated: lastUpdated}
+	source.subscriptions["sub_2"] = &Subscription{ID: "sub_2", Location: "Tokyo", ChannelID: "channel2", UserID: "user2", UpdateFrequency: 60000, LastUpdated: lastUpdated}
+	source.jobs["sub_1"] = make(chan struct{})
+
+	exported, err := source.ExportCSV()
+	if err != nil {
+		t.Fatalf("ExportCSV failed: %v", err)
+	}
+
+	target := newTestSubscriptionManager(t)
+	t.Cleanup(target.GracefulStop)
+
+	imported, err := target.ImportFromCSV(strings.NewReader(string(exported)))
+	if err != nil {
+		t.Fatalf("ImportFromCSV failed: %v", err)
+	}
+	if imported != 2 {
+		t.Fatalf("Expected 2 imported subscriptions, got %d", imported)
+	}
+
+	for id, expected := range source.subscriptions {
+		sub, exists := target.GetSubscription(id)
+		if !exists {
+			t.Errorf("Expected %s to survive the round trip", id)
+			continue
+		}
+		if sub.Location != expected.Location || sub.ChannelID != expected.ChannelID || sub.UserID != expected.UserID ||
+			sub.UpdateFrequency != expected.UpdateFrequency || !sub.LastUpdated.Equal(expected.LastUpdated) {
+			t.Errorf("Expected %+v after the round trip, got %+v", expected, sub)
+		}
+	}
+}
+
+func TestImportCSVKeepsPausedSubscriptionsPausedAfterRestart(t *testing.T) {
+	csvData := `id,location,channel_id,user_id,update_frequency_ms,paused
+sub_running,Paris,channel1,user1,3600000,false
+sub_paused,Tokyo,channel1,user1,3600000,true
+`
+
+	// Record what the importing manager writes so a restarted manager can load it
+	var storeMutex sync.Mutex
+	var stored []byte
+	api := &plugintest.API{}
+	api.On("KVSetWithOptions", "weather_subscriptions", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		storeMutex.Lock()
+		defer storeMutex.Unlock()
+		stored = args.Get(1).([]byte)
+	}).Return(true, nil)
+	api.On("KVSetWithOptions", mock.Anything, mock.Anything, mock.Anything).Return(true, nil)
+	api.On("GetChannel", mock.Anything).Return(&model.Channel{Id: "channel1"}, nil)
+	api.On("CreatePost", mock.Anything).Return(&model.Post{}, nil)
+	api.On("SendEphemeralPost", mock.Anything, mock.Anything).Return(func(userID string, post *model.Post) *model.Post { return post })
+
+	source := newTestSubscriptionManagerWithAPI(t, api, newFakeWeatherClient())
+	if imported, err := source.ImportFromCSV(strings.NewReader(csvData)); err != nil || imported != 2 {
+		t.Fatalf("Expected 2 imported subscriptions, got %d: %v", imported, err)
+	}
+	source.GracefulStop()
+
+	storeMutex.Lock()
+	data := stored
+	storeMutex.Unlock()
+	if data == nil {
+		t.Fatal("Expected the imported subscriptions to be saved")
+	}
+
+	restartedAPI := newTestPluginAPI()
+	restartedAPI.On("KVGet", "weather_subscriptions").Return(data, nil)
+	restartedAPI.On("KVGet", subscriptionsVersionKey).Return([]byte(fmt.Sprint(subscriptionSchemaVersion)), nil)
+
+	restarted := newTestSubscriptionManagerWithAPI(t, restartedAPI, newFakeWeatherClient())
+	t.Cleanup(restarted.GracefulStop)
+	restarted.loadSubscriptions()
+
+	// Wait for the running subscription, then long enough for a staggered start of the paused one
+	deadline := time.Now().Add(2 * time.Second)
+	for restarted.activeJobs.Load() == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("Expected the unpaused subscription to start after the restart")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	time.Sleep(subscriptionStartStagger + 100*time.Millisecond)
+
+	restarted.mutex.RLock()
+	_, pausedRunning := restarted.jobs["sub_paused"]
+	restarted.mutex.RUnlock()
+	if pausedRunning {
+		t.Error("Expected the paused subscription not to start after the restart")
+	}
+	if sub, exists := restarted.GetSubscription("sub_paused"); !exists || !sub.Paused {
+		t.Errorf("Expected the paused subscription to be loaded as paused, got %+v", sub)
+	}
+
+	exported, err := restarted.ExportCSV()
+	if err != nil {
+		t.Fatalf("ExportCSV failed: %v", err)
+	}
+	records, err := csv.NewReader(strings.NewReader(string(exported))).ReadAll()
+	if err != nil {
+		t.Fatalf("Exported CSV is invalid: %v", err)
+	}
+	for _, record := range records[1:] {
+		if expected := strconv.FormatBool(record[0] == "sub_paused"); record[6] != expected {
+			t.Errorf("Expected %s to export with paused %s, got %s", record[0], expected, record[6])
+		}
+	}
+}
+
+func TestImportCSVSkipsInvalidRows(t *testing.T) {
+	sm := newTestSubscriptionManager(t)
+	t.Cleanup(sm.GracefulStop)
+	sm.subscriptions["sub_existing"] = &Subscription{ID: "sub_existing", Location: "Berlin", ChannelID: "channel1"}
+
+	input := strings.Join([]string{
+		"channel_id,id,location,user_id,update_frequency_ms,paused",
+		"channel1,sub_1,London,user1,60000,true",
+		"channel1,sub_existing,Madrid,user1,60000,true",
+		"channel1,sub_2,,user1,60000,true",
+		"channel1,sub_3,Rome,user1,1000,true",
+		"channel1,sub_4,Oslo,user1,hourly,true",
+		"channel1,sub_5,Lima,user1,60000,maybe",
+		"channel1,sub_6,london,user2,60000,true",
+	}, "\n")
+
+	imported, err := sm.ImportFromCSV(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ImportFromCSV failed: %v", err)
+	}
+	if imported != 1 {
+		t.Errorf("Expected 1 imported subscription, got %d", imported)
+	}
+	if _, exists := sm.GetSubscription("sub_1"); !exists {
+		t.Error("Expected sub_1 to be imported")
+	}
+	if existing, _ := sm.GetSubscription("sub_existing"); existing.Location != "Berlin" {
+		t.Errorf("Expected the existing subscription to be kept, got %+v", existing)
+	}
+}
+
+func TestImportCSVRejectsBadHeader(t *testing.T) {
+	sm := newTestSubscriptionManager(t)
+
+	for _, input := range []string{"", "id,location,channel_id\nsub_1,London,channel1"} {
+		if _, err := sm.ImportFromCSV(strings.NewReader(input)); err == nil {
+			t.Errorf("Expected an error importing %q", input)
+		}
+	}
+}
@@ -1,26 +1,68 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"slices"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/mattermost/mattermost/server/public/model"
 	"github.com/mattermost/mattermost/server/public/pluginapi"
 )
 
+// subscriptionShutdownTimeout bounds how long GracefulStop waits for subscription loops to exit
+const subscriptionShutdownTimeout = 5 * time.Second
+
+// subscriptionStartStagger spaces out the first fetch of subscriptions loaded at activation
+const subscriptionStartStagger = 500 * time.Millisecond
+
+// subscriptionSaveDelay coalesces rapid subscription changes into a single KV store write
+const subscriptionSaveDelay = 500 * time.Millisecond
+
+// subscriptionSaveInterval is how often update times recorded by running subscriptions are persisted
+const subscriptionSaveInterval = 6 * time.Hour
+
+// defaultSubscriptionPageSize is how many subscriptions the list command shows per page
+const defaultSubscriptionPageSize = 10
+
+// subscriptionSchemaVersion is the current version of the stored subscription format. Bump it and
+// add a step to migrateSubscriptions whenever a new Subscription field needs a non-zero default.
+const subscriptionSchemaVersion = 1
+
+// subscriptionsVersionKey stores the schema version of the subscriptions saved under weather_subscriptions
+const subscriptionsVersionKey = "weather_subscriptions_version"
+
 type SubscriptionManager struct {
 	client         *pluginapi.Client
 	subscriptions  map[string]*Subscription
 	jobs           map[string]chan struct{} // Track running subscription jobs
 	mutex          sync.RWMutex
-	weatherService *WeatherService
+	weatherService WeatherClient
 	formatter      *WeatherFormatter
 	messageService *MessageService
+	metrics        *Metrics
+	history        map[string]*WeatherHistory // Recent readings per subscription, guarded by mutex
+	historySize    int
+
+	jobsWG       sync.WaitGroup // Tracks running subscription loops
+	activeJobs   atomic.Int32   // Number of subscription loops currently running
+	shuttingDown bool           // Set by GracefulStop so no new loops are started
+	stopped      chan struct{}  // Closed by GracefulStop to cancel staggered starts
+
+	saveMutex sync.Mutex    // Guards saveTimer and lastSaved
+	saveTimer *time.Timer   // Pending debounced save, nil when none is scheduled
+	saveDelay time.Duration // How long to wait for further changes before saving
+	lastSaved time.Time     // When the subscriptions were last written to the KV store
+
+	Version int // Schema version of the loaded subscriptions, current once migrated
 }
 
-func NewSubscriptionManager(client *pluginapi.Client, weatherService *WeatherService, formatter *WeatherFormatter, messageService *MessageService) *SubscriptionManager {
+func NewSubscriptionManager(client *pluginapi.Client, weatherService WeatherClient, formatter *WeatherFormatter, messageService *MessageService, metrics *Metrics) *SubscriptionManager {
 	sm := &SubscriptionManager{
 		client:         client,
 		subscriptions:  make(map[string]*Subscription),
@@ -28,18 +70,64 @@ func NewSubscriptionManager(client *pluginapi.Client, weatherService *WeatherSer
 		weatherService: weatherService,
 		formatter:      formatter,
 		messageService: messageService,
+		metrics:        metrics,
+		history:        make(map[string]*WeatherHistory),
+		historySize:    getEnvInt("WEATHER_HISTORY_SIZE", defaultWeatherHistorySize),
+		stopped:        make(chan struct{}),
+		saveDelay:      subscriptionSaveDelay,
+		lastSaved:      time.Now(),
 	}
 	
 	sm.loadSubscriptions()
 	return sm
 }
 
-func (sm *SubscriptionManager) AddSubscription(sub *Subscription) {
+// DuplicateSubscriptionError is returned when a channel already has a subscription for the same location
+type DuplicateSubscriptionError struct {
+	Existing *Subscription
+}
+
+func (e *DuplicateSubscriptionError) Error() string {
+	return fmt.Sprintf("a subscription for %s already exists in this channel (ID: %s)", e.Existing.Location, e.Existing.ID)
+}
+
+// errSubscriptionIDExists is returned by AddSubscription when the ID is already in use
+var errSubscriptionIDExists = errors.New("a subscription with this ID already exists")
+
+// AddSubscription stores a new subscription. It rejects an ID that is already in use and a
+// location the channel is already subscribed to.
+func (sm *SubscriptionManager) AddSubscription(sub *Subscription) error {
 	sm.mutex.Lock()
 	defer sm.mutex.Unlock()
-	
+
+	if _, exists := sm.subscriptions[sub.ID]; exists {
+		return errSubscriptionIDExists
+	}
+	if existing := sm.findDuplicateSubscription(sub.ChannelID, sub.Location); existing != nil {
+		return &DuplicateSubscriptionError{Existing: existing}
+	}
+
 	sm.subscriptions[sub.ID] = sub
-	sm.saveSubscriptions()
+	sm.metrics.SetActiveSubscriptions(len(sm.subscriptions))
+	sm.scheduleSave()
+	return nil
+}
+
+// findDuplicateSubscription returns the subscription in a channel matching the
+// normalized location, if any. Callers must hold the mutex.
+func (sm *SubscriptionManager) findDuplicateSubscription(channelID, location string) *Subscription {
+	normalized := normalizeLocation(location)
+	for _, sub := range sm.subscriptions {
+		if sub.ChannelID == channelID && normalizeLocation(sub.Location) == normalized {
+			return sub
+		}
+	}
+	return nil
+}
+
+// normalizeLocation trims and lowercases a location for comparison
+func normalizeLocation(location string) string {
+	return strings.ToLower(strings.TrimSpace(location))
 }
 
 func (sm *SubscriptionManager) RemoveSubscription(id string) bool {
@@ -51,12 +139,31 @@ func (sm *SubscriptionManager) RemoveSubscription(id string) bool {
 		// Stop the subscription job if running
 		sm.stopSubscriptionJob(id)
 		delete(sm.subscriptions, id)
-		sm.saveSubscriptions()
+		delete(sm.history, id)
+		sm.metrics.SetActiveSubscriptions(len(sm.subscriptions))
+		sm.scheduleSave()
 		return true
 	}
 	return false
 }
 
+// ResumeSubscription clears a paused subscription's paused flag and starts its updates. It reports
+// false when no paused subscription has the ID.
+func (sm *SubscriptionManager) ResumeSubscription(id string) bool {
+	sm.mutex.Lock()
+	sub, exists := sm.subscriptions[id]
+	if !exists || !sub.Paused {
+		sm.mutex.Unlock()
+		return false
+	}
+	sub.Paused = false
+	sm.scheduleSave()
+	sm.mutex.Unlock()
+
+	go sm.runSubscription(sub, nil, true)
+	return true
+}
+
 // stopSubscriptionJob stops a subscription job if it's running
 func (sm *SubscriptionManager) stopSubscriptionJob(id string) {
 	// Check if job exists
@@ -70,14 +177,19 @@ func (sm *SubscriptionManager) stopSubscriptionJob(id string) {
 	delete(sm.jobs, id)
 }
 
+// GetSubscription returns a copy of the subscription, which stays safe to read while its update loop runs
 func (sm *SubscriptionManager) GetSubscription(id string) (*Subscription, bool) {
 	sm.mutex.RLock()
 	defer sm.mutex.RUnlock()
 	
 	sub, exists := sm.subscriptions[id]
-	return sub, exists
+	if !exists {
+		return nil, false
+	}
+	return copySubscription(sub), true
 }
 
+// GetSubscriptionsForChannel returns copies of the channel's subscriptions
 func (sm *SubscriptionManager) GetSubscriptionsForChannel(channelID string) []*Subscription {
 	sm.mutex.RLock()
 	defer sm.mutex.RUnlock()
@@ -85,26 +197,69 @@ func (sm *SubscriptionManager) GetSubscriptionsForChannel(channelID string) []*S
 	var subs []*Subscription
 	for _, sub := range sm.subscriptions {
 		if sub.ChannelID == channelID {
-			subs = append(subs, sub)
+			subs = append(subs, copySubscription(sub))
 		}
 	}
 	return subs
 }
 
+// GetAllSubscriptions returns copies of all subscriptions
 func (sm *SubscriptionManager) GetAllSubscriptions() []*Subscription {
 	sm.mutex.RLock()
 	defer sm.mutex.RUnlock()
 	
 	var subs []*Subscription
 	for _, sub := range sm.subscriptions {
-		subs = append(subs, sub)
+		subs = append(subs, copySubscription(sub))
 	}
 	return subs
 }
 
+// copySubscription returns a copy of a stored subscription. The update loops write to the stored
+// subscriptions under the mutex, so callers must hold it and hand out only copies.
+func copySubscription(sub *Subscription) *Subscription {
+	copied := *sub
+	copied.AlertConditions = slices.Clone(sub.AlertConditions)
+	return &copied
+}
 
+// GetSubscriptionsForChannelPaged returns up to limit of the channel's subscriptions ordered by ID,
+// starting at offset, and the total number of subscriptions in the channel
+func (sm *SubscriptionManager) GetSubscriptionsForChannelPaged(channelID string, offset, limit int) ([]*Subscription, int) {
+	return paginateSubscriptions(sm.GetSubscriptionsForChannel(channelID), offset, limit)
+}
+
+// GetAllSubscriptionsPaged returns up to limit of all subscriptions ordered by ID, starting at
+// offset, and the total number of subscriptions
+func (sm *SubscriptionManager) GetAllSubscriptionsPaged(offset, limit int) ([]*Subscription, int) {
+	return paginateSubscriptions(sm.GetAllSubscriptions(), offset, limit)
+}
+
+// paginateSubscriptions sorts subscriptions by ID so pages are stable, then returns the requested page
+func paginateSubscriptions(subs []*Subscription, offset, limit int) ([]*Subscription, int) {
+	total := len(subs)
+	if offset < 0 || limit <= 0 || offset >= total {
+		return []*Subscription{}, total
+	}
 
-func (sm *SubscriptionManager) StartSubscription(sub *Subscription) {
+	slices.SortFunc(subs, func(a, b *Subscription) int {
+		return strings.Compare(a.ID, b.ID)
+	})
+	return subs[offset:min(offset+limit, total)], total
+}
+
+
+
+// StartSubscription runs the update loop for a subscription until it is stopped.
+// initialData, when non-nil, is posted as the first update instead of fetching again.
+func (sm *SubscriptionManager) StartSubscription(sub *Subscription, initialData *WeatherResponse) {
+	sm.runSubscription(sub, initialData, false)
+}
+
+// runSubscription runs the update loop for StartSubscription. When onlyIfStored is set the loop only
+// starts if the subscription is still stored, checked under the mutex that registers the job, so a
+// subscription removed while its start was pending stays stopped.
+func (sm *SubscriptionManager) runSubscription(sub *Subscription, initialData *WeatherResponse, onlyIfStored bool) {
 	// Check if channel still exists before starting subscription
 	if !sm.isChannelValid(sub.ChannelID) {
 		sm.client.Log.Info("Channel no longer exists, removing subscription", "channel_id", sub.ChannelID, "subscription_id", sub.ID)
@@ -114,14 +269,44 @@ func (sm *SubscriptionManager) StartSubscription(sub *Subscription) {
 
 	// Create and store the stop channel for this job
 	sm.mutex.Lock()
+	if sm.shuttingDown {
+		sm.mutex.Unlock()
+		return
+	}
+	if _, stored := sm.subscriptions[sub.ID]; onlyIfStored && !stored {
+		sm.mutex.Unlock()
+		sm.client.Log.Info("Subscription was removed before it started", "subscription_id", sub.ID)
+		return
+	}
 	stopChan := make(chan struct{})
 	sm.jobs[sub.ID] = stopChan
+	sm.jobsWG.Add(1)
 	sm.mutex.Unlock()
 
-	// Get initial weather data
-	weatherData, err := sm.weatherService.GetWeatherData(sub.Location)
+	sm.activeJobs.Add(1)
+	defer func() {
+		sm.activeJobs.Add(-1)
+		sm.jobsWG.Done()
+	}()
+
+	// Stopping the subscription cancels a weather lookup in progress
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-stopChan:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	// Get initial weather data, reusing the lookup from subscription validation if available
+	weatherData, err := initialData, error(nil)
+	if weatherData == nil {
+		weatherData, err = sm.weatherService.GetWeatherData(ctx, sub.Location)
+	}
 	if err != nil {
-		sm.client.Log.Error("Error fetching initial weather data for subscription", "error", err, "subscription_id", sub.ID)
+		sm.client.Log.Error("Error fetching initial weather data for subscription", "error", err, "subscription_id", sub.ID, "location", sub.Location, "channel_id", sub.ChannelID)
 		errorMsg := fmt.Sprintf("⚠️ Could not fetch weather data for subscription to **%s** (ID: `%s`): %v", sub.Location, sub.ID, err)
 		
 		args := &model.CommandArgs{
@@ -130,12 +315,10 @@ func (sm *SubscriptionManager) StartSubscription(sub *Subscription) {
 		}
 		sm.messageService.SendEphemeralResponse(args, errorMsg)
 	} else {
-		post := sm.formatter.FormatAsAttachment(weatherData, sub.ChannelID, sm.messageService.GetBotUserID())
-		args := &model.CommandArgs{
-			ChannelId: sub.ChannelID,
-			UserId: sub.UserID,
+		sm.recordHistory(sub.ID, weatherData)
+		if _, err := sm.postSubscriptionUpdate(sub, weatherData, stopChan); err != nil {
+			sm.client.Log.Error("Error posting initial weather update for subscription", "error", err, "subscription_id", sub.ID, "location", sub.Location, "channel_id", sub.ChannelID)
 		}
-		sm.messageService.SendPublicResponse(args, post)
 	}
 
 	ticker := time.NewTicker(time.Duration(sub.UpdateFrequency) * time.Millisecond)
@@ -147,11 +330,37 @@ func (sm *SubscriptionManager) StartSubscription(sub *Subscription) {
 	for {
 		select {
 		case <-ticker.C:
-			weatherData, err := sm.weatherService.GetWeatherData(sub.Location)
+			weatherData, err := sm.weatherService.GetWeatherData(ctx, sub.Location)
+
+			if err == nil {
+				sm.recordHistory(sub.ID, weatherData)
+
+				// Check if channel still exists before posting update
+				if !sm.isChannelValid(sub.ChannelID) {
+					sm.client.Log.Info("Channel no longer exists during update, removing subscription", "channel_id", sub.ChannelID, "subscription_id", sub.ID)
+					sm.cleanupInvalidSubscription(sub, "channel no longer exists")
+					return
+				}
+
+				// Posts are retried with back-off, so an error here means all retries were exhausted
+				var posted bool
+				posted, err = sm.postSubscriptionUpdate(sub, weatherData, stopChan)
+				if errors.Is(err, errPostCancelled) {
+					sm.client.Log.Info("Stopping subscription while retrying update", "subscription_id", sub.ID, "location", sub.Location, "channel_id", sub.ChannelID)
+					return
+				}
+				if posted {
+					// Saves read the subscription under the mutex, and the getters copy it under the mutex
+					sm.mutex.Lock()
+					sub.LastUpdated = time.Now()
+					sm.mutex.Unlock()
+					sm.saveIfDue(time.Now())
+				}
+			}
 
 			if err != nil {
 				consecutiveFailures++
-				sm.client.Log.Error("Error fetching weather data for subscription", "error", err, "subscription_id", sub.ID, "failures", consecutiveFailures)
+				sm.client.Log.Error("Error updating weather for subscription", "error", err, "subscription_id", sub.ID, "location", sub.Location, "channel_id", sub.ChannelID, "failures", consecutiveFailures)
 
 				if consecutiveFailures == 1 || consecutiveFailures == maxConsecutiveFailures {
 					errorMsg := fmt.Sprintf("⚠️ Error updating weather for **%s**: %v", sub.Location, err)
@@ -169,43 +378,152 @@ func (sm *SubscriptionManager) StartSubscription(sub *Subscription) {
 			}
 
 			if consecutiveFailures > 0 {
-				sm.client.Log.Info("Successfully recovered subscription after failures", "subscription_id", sub.ID, "failures", consecutiveFailures)
+				sm.client.Log.Info("Successfully recovered subscription after failures", "subscription_id", sub.ID, "location", sub.Location, "channel_id", sub.ChannelID, "failures", consecutiveFailures)
 				consecutiveFailures = 0
 				ticker.Reset(time.Duration(sub.UpdateFrequency) * time.Millisecond)
 			}
 
-			// Check if channel still exists before posting update
-			if !sm.isChannelValid(sub.ChannelID) {
-				sm.client.Log.Info("Channel no longer exists during update, removing subscription", "channel_id", sub.ChannelID, "subscription_id", sub.ID)
-				sm.cleanupInvalidSubscription(sub, "channel no longer exists")
-				return
-			}
+		case <-stopChan:
+			sm.client.Log.Info("Stopping subscription", "subscription_id", sub.ID, "location", sub.Location, "channel_id", sub.ChannelID)
+			return
+		}
+	}
+}
 
-			post := sm.formatter.FormatAsAttachment(weatherData, sub.ChannelID, sm.messageService.GetBotUserID())
-			args := &model.CommandArgs{
-				ChannelId: sub.ChannelID,
-				UserId: sub.UserID,
-			}
-			sm.messageService.SendPublicResponse(args, post)
+// startSubscriptionAfter starts a subscription once the delay elapses, unless the manager is stopped
+// or the subscription is removed first
+func (sm *SubscriptionManager) startSubscriptionAfter(sub *Subscription, delay time.Duration) {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		sm.runSubscription(sub, nil, true)
+	case <-sm.stopped:
+	}
+}
 
-			sub.LastUpdated = time.Now()
+// recordHistory appends a weather reading to the subscription's history
+func (sm *SubscriptionManager) recordHistory(subscriptionID string, weatherData *WeatherResponse) {
+	sm.mutex.Lock()
+	history, exists := sm.history[subscriptionID]
+	if !exists {
+		history = NewWeatherHistory(sm.historySize)
+		sm.history[subscriptionID] = history
+	}
+	sm.mutex.Unlock()
 
-		case <-stopChan:
-			sm.client.Log.Info("Stopping subscription", "subscription_id", sub.ID)
-			return
+	history.Add(WeatherEntry{
+		Timestamp: time.Now(),
+		Values:    weatherData.Data.Values,
+	})
+}
+
+// GetHistory returns the recorded readings for a subscription from oldest to newest
+func (sm *SubscriptionManager) GetHistory(subscriptionID string) []WeatherEntry {
+	sm.mutex.RLock()
+	history, exists := sm.history[subscriptionID]
+	sm.mutex.RUnlock()
+
+	if !exists {
+		return []WeatherEntry{}
+	}
+	return history.Entries()
+}
+
+// postSubscriptionUpdate posts the weather update for a subscription. Alert
+// subscriptions only post when at least one of their conditions is met.
+// Returns true if a post was sent. Closing stop abandons retrying a failed post.
+func (sm *SubscriptionManager) postSubscriptionUpdate(sub *Subscription, weatherData *WeatherResponse, stop <-chan struct{}) (bool, error) {
+	post := sm.formatter.FormatAsAttachment(weatherData, sub.ChannelID, sm.messageService.GetBotUserID(), sub.NoEmoji)
+
+	if len(sub.AlertConditions) > 0 {
+		matched := MatchingAlertConditions(sub.AlertConditions, weatherData.Data.Values)
+		if len(matched) == 0 {
+			sm.client.Log.Debug("No alert conditions met, skipping update", "subscription_id", sub.ID, "location", sub.Location, "channel_id", sub.ChannelID)
+			return false, nil
 		}
+		post.Message = fmt.Sprintf("🚨 **Weather alert for %s:** %s", sub.Location, FormatAlertConditions(matched))
+	}
+
+	if err := sm.messageService.CreatePostWithRetry(post, stop); err != nil {
+		return false, err
 	}
+	return true, nil
 }
 
-func (sm *SubscriptionManager) StopAll() {
+// GracefulStop signals every subscription loop to stop, waits for them to exit and
+// then persists the subscriptions so state updated by the loops is not lost.
+// No new subscription loops can be started afterwards.
+func (sm *SubscriptionManager) GracefulStop() {
 	sm.mutex.Lock()
-	defer sm.mutex.Unlock()
-	
+	if !sm.shuttingDown {
+		close(sm.stopped)
+	}
+	sm.shuttingDown = true
 	for id := range sm.jobs {
 		sm.stopSubscriptionJob(id)
 	}
+	sm.mutex.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		sm.jobsWG.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(subscriptionShutdownTimeout):
+		sm.client.Log.Warn("Timed out waiting for subscriptions to stop", "active_jobs", sm.activeJobs.Load())
+	}
+
+	// Write synchronously instead of waiting for a pending debounced save
+	sm.saveMutex.Lock()
+	if sm.saveTimer != nil {
+		sm.saveTimer.Stop()
+		sm.saveTimer = nil
+	}
+	sm.saveMutex.Unlock()
+
+	sm.mutex.RLock()
+	sm.saveSubscriptions()
+	sm.mutex.RUnlock()
 }
 
+// scheduleSave persists the subscriptions after saveDelay, so a burst of
+// changes results in one write of the latest state
+func (sm *SubscriptionManager) scheduleSave() {
+	sm.saveMutex.Lock()
+	defer sm.saveMutex.Unlock()
+
+	if sm.saveTimer != nil {
+		return
+	}
+
+	sm.saveTimer = time.AfterFunc(sm.saveDelay, func() {
+		sm.saveMutex.Lock()
+		sm.saveTimer = nil
+		sm.saveMutex.Unlock()
+
+		sm.mutex.RLock()
+		sm.saveSubscriptions()
+		sm.mutex.RUnlock()
+	})
+}
+
+// saveIfDue schedules a save when subscriptionSaveInterval has passed since the last one
+func (sm *SubscriptionManager) saveIfDue(now time.Time) {
+	sm.saveMutex.Lock()
+	due := now.Sub(sm.lastSaved) >= subscriptionSaveInterval
+	sm.saveMutex.Unlock()
+
+	if due {
+		sm.scheduleSave()
+	}
+}
+
+// saveSubscriptions writes the subscriptions to the KV store. Callers must hold the mutex.
 func (sm *SubscriptionManager) saveSubscriptions() {
 	data, err := json.Marshal(sm.subscriptions)
 	if err != nil {
@@ -215,7 +533,12 @@ func (sm *SubscriptionManager) saveSubscriptions() {
 	
 	if _, err := sm.client.KV.Set("weather_subscriptions", data); err != nil {
 		sm.client.Log.Error("Failed to save subscriptions", "error", err)
+		return
 	}
+
+	sm.saveMutex.Lock()
+	sm.lastSaved = time.Now()
+	sm.saveMutex.Unlock()
 }
 
 func (sm *SubscriptionManager) loadSubscriptions() {
@@ -226,29 +549,79 @@ func (sm *SubscriptionManager) loadSubscriptions() {
 		return
 	}
 	
-	if data == nil {
+	if len(data) == 0 {
 		sm.client.Log.Debug("No existing subscriptions found")
+		sm.Version = subscriptionSchemaVersion
+		sm.saveSchemaVersion()
 		return
 	}
 	
+	// Keep the current in-memory state if the stored value is truncated or corrupt
 	var subscriptions map[string]*Subscription
 	if err := json.Unmarshal(data, &subscriptions); err != nil {
-		sm.client.Log.Error("Failed to unmarshal subscriptions", "error", err)
+		sm.client.Log.Error("Failed to unmarshal subscriptions, keeping current state", "error", err, "size", len(data))
 		return
 	}
 	
+	// Stores written before versioning have no version key and are treated as version 0
+	var version int
+	if err := sm.client.KV.Get(subscriptionsVersionKey, &version); err != nil {
+		sm.client.Log.Warn("Failed to load subscription schema version, assuming version 0", "error", err)
+		version = 0
+	}
+
 	sm.mutex.Lock()
 	sm.subscriptions = subscriptions
+	sm.metrics.SetActiveSubscriptions(len(subscriptions))
+	if version < subscriptionSchemaVersion {
+		sm.migrateSubscriptions(version)
+		sm.saveSubscriptions()
+		sm.saveSchemaVersion()
+	} else {
+		sm.Version = version
+	}
 	sm.mutex.Unlock()
 	
-	// Start subscriptions for all loaded subscriptions
+	// Start the loaded subscriptions that are not paused, staggered so they don't all fetch and post at once
+	i := 0
 	for _, sub := range subscriptions {
-		go sm.StartSubscription(sub)
+		if sub.Paused {
+			continue
+		}
+		go sm.startSubscriptionAfter(sub, time.Duration(i)*subscriptionStartStagger)
+		i++
 	}
 	
 	sm.client.Log.Info("Loaded subscriptions", "count", len(subscriptions))
 }
 
+// migrateSubscriptions fills in fields added after the given schema version was written, which
+// json.Unmarshal leaves zero-valued. Steps only touch zero values so they are safe to re-run.
+// Callers must hold the mutex.
+func (sm *SubscriptionManager) migrateSubscriptions(version int) {
+	if version < 1 {
+		for _, sub := range sm.subscriptions {
+			if sub.Units == "" {
+				sub.Units = unitsMetric
+			}
+			// Subscriptions saved before the minimum was enforced would tick too fast, or panic at zero
+			if sub.UpdateFrequency < minUpdateFrequency {
+				sub.UpdateFrequency = minUpdateFrequency
+			}
+		}
+	}
+
+	sm.client.Log.Info("Migrated subscriptions", "from_version", version, "to_version", subscriptionSchemaVersion, "count", len(sm.subscriptions))
+	sm.Version = subscriptionSchemaVersion
+}
+
+// saveSchemaVersion records the schema version of the stored subscriptions
+func (sm *SubscriptionManager) saveSchemaVersion() {
+	if _, err := sm.client.KV.Set(subscriptionsVersionKey, sm.Version); err != nil {
+		sm.client.Log.Error("Failed to save subscription schema version", "error", err)
+	}
+}
+
 // isChannelValid checks if a channel still exists
 func (sm *SubscriptionManager) isChannelValid(channelID string) bool {
 	_, err := sm.client.Channel.Get(channelID)
@@ -275,7 +648,7 @@ func (sm *SubscriptionManager) tryNotifyUserOfCleanup(sub *Subscription, reason
 	// Create a DM channel with the user
 	dmChannel, err := sm.client.Channel.GetDirect(sm.messageService.GetBotUserID(), sub.UserID)
 	if err != nil {
-		sm.client.Log.Debug("Could not create DM channel for cleanup notification", "user_id", sub.UserID, "error", err)
+		sm.client.Log.Debug("Could not create DM channel for cleanup notification", "user_id", sub.UserID, "subscription_id", sub.ID, "error", err)
 		return
 	}
 	
@@ -291,6 +664,6 @@ func (sm *SubscriptionManager) tryNotifyUserOfCleanup(sub *Subscription, reason
 	}
 	
 	if err := sm.client.Post.CreatePost(post); err != nil {
-		sm.client.Log.Debug("Could not send cleanup notification to user", "user_id", sub.UserID, "error", err)
+		sm.client.Log.Debug("Could not send cleanup notification to user", "user_id", sub.UserID, "subscription_id", sub.ID, "error", err)
 	}
 }
\ No newline at end of file
@@ -0,0 +1,520 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/plugin/plugintest"
+	"github.com/mattermost/mattermost/server/public/pluginapi"
+	"github.com/stretchr/testify/mock"
+)
+
+// testAPI wraps the plugin API mock and discards log calls, which are variadic and awkward to stub
+type testAPI struct {
+	*plugintest.API
+}
+
+func (a *testAPI) LogDebug(msg string, keyValuePairs ...any) {}
+func (a *testAPI) LogInfo(msg string, keyValuePairs ...any)  {}
+func (a *testAPI) LogWarn(msg string, keyValuePairs ...any)  {}
+func (a *testAPI) LogError(msg string, keyValuePairs ...any) {}
+
+// newTestPluginAPI creates a plugin API mock that accepts the calls made while managing subscriptions
+func newTestPluginAPI() *plugintest.API {
+	api := &plugintest.API{}
+	api.On("KVSetWithOptions", mock.Anything, mock.Anything, mock.Anything).Return(true, nil)
+	api.On("GetChannel", mock.Anything).Return(&model.Channel{Id: "channel1"}, nil)
+	api.On("CreatePost", mock.Anything).Return(&model.Post{}, nil)
+	api.On("SendEphemeralPost", mock.Anything, mock.Anything).Return(func(userID string, post *model.Post) *model.Post { return post })
+	return api
+}
+
+// newTestSubscriptionManager creates a manager backed by a mocked plugin API without loading stored subscriptions
+func newTestSubscriptionManager(t *testing.T) *SubscriptionManager {
+	t.Helper()
+
+	weatherService := &WeatherService{weatherData: []WeatherValues{{Temperature: 20, WeatherCode: 1000}}}
+	return newTestSubscriptionManagerWithAPI(t, newTestPluginAPI(), weatherService)
+}
+
+// newTestSubscriptionManagerWithAPI creates a manager using the given plugin API mock and weather client
+func newTestSubscriptionManagerWithAPI(t *testing.T, api *plugintest.API, weatherService WeatherClient) *SubscriptionManager {
+	t.Helper()
+
+	client := pluginapi.NewClient(&testAPI{API: api}, nil)
+
+	return &SubscriptionManager{
+		client:         client,
+		subscriptions:  make(map[string]*Subscription),
+		jobs:           make(map[string]chan struct{}),
+		history:        make(map[string]*WeatherHistory),
+		historySize:    defaultWeatherHistorySize,
+		stopped:        make(chan struct{}),
+		saveDelay:      subscriptionSaveDelay,
+		weatherService: weatherService,
+		formatter:      NewWeatherFormatter(),
+		messageService: NewMessageService(client, "bot1", nil),
+	}
+}
+
+func TestAddSubscriptionRejectsDuplicates(t *testing.T) {
+	sm := newTestSubscriptionManager(t)
+
+	original := &Subscription{ID: "sub_1", Location: "London", ChannelID: "channel1", UserID: "user1"}
+	if err := sm.AddSubscription(original); err != nil {
+		t.Fatalf("Failed to add subscription: %v", err)
+	}
+
+	duplicate := &Subscription{ID: "sub_2", Location: "  london ", ChannelID: "channel1", UserID: "user2"}
+	err := sm.AddSubscription(duplicate)
+
+	var duplicateErr *DuplicateSubscriptionError
+	if !errors.As(err, &duplicateErr) {
+		t.Fatalf("Expected DuplicateSubscriptionError, got %v", err)
+	}
+	if duplicateErr.Existing.ID != original.ID {
+		t.Errorf("Expected existing subscription ID %s, got %s", original.ID, duplicateErr.Existing.ID)
+	}
+
+	if count := len(sm.GetSubscriptionsForChannel("channel1")); count != 1 {
+		t.Errorf("Expected 1 subscription in channel, got %d", count)
+	}
+}
+
+func TestAddSubscriptionAllowsSameLocationInOtherChannel(t *testing.T) {
+	sm := newTestSubscriptionManager(t)
+
+	if err := sm.AddSubscription(&Subscription{ID: "sub_1", Location: "London", ChannelID: "channel1"}); err != nil {
+		t.Fatalf("Failed to add subscription: %v", err)
+	}
+	if err := sm.AddSubscription(&Subscription{ID: "sub_2", Location: "London", ChannelID: "channel2"}); err != nil {
+		t.Fatalf("Expected subscription in another channel to be allowed, got %v", err)
+	}
+
+	if count := len(sm.GetAllSubscriptions()); count != 2 {
+		t.Errorf("Expected 2 subscriptions, got %d", count)
+	}
+}
+
+func TestGracefulStopWaitsForSubscriptionLoops(t *testing.T) {
+	sm := newTestSubscriptionManager(t)
+
+	const count = 5
+	for i := 0; i < count; i++ {
+		sub := &Subscription{ID: fmt.Sprintf("sub_%d", i), Location: fmt.Sprintf("City %d", i), ChannelID: "channel1", UpdateFrequency: time.Hour.Milliseconds()}
+		go sm.StartSubscription(sub, nil)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for sm.activeJobs.Load() != count {
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected %d running subscriptions, got %d", count, sm.activeJobs.Load())
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	start := time.Now()
+	sm.GracefulStop()
+
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("Expected GracefulStop to return within 2s, took %s", elapsed)
+	}
+	if active := sm.activeJobs.Load(); active != 0 {
+		t.Errorf("Expected all subscription loops to exit, %d still running", active)
+	}
+
+	// Subscriptions started after shutdown must not spawn new loops
+	sm.StartSubscription(&Subscription{ID: "sub_late", Location: "Late", ChannelID: "channel1", UpdateFrequency: time.Hour.Milliseconds()}, nil)
+	if active := sm.activeJobs.Load(); active != 0 {
+		t.Errorf("Expected no loops after shutdown, got %d", active)
+	}
+}
+
+func TestGracefulStopInterruptsPostRetries(t *testing.T) {
+	api := &plugintest.API{}
+	api.On("GetChannel", mock.Anything).Return(&model.Channel{Id: "channel1"}, nil)
+	// Fail every post with a server error so it is retried, signalling the first attempt
+	attempted := make(chan struct{})
+	var once sync.Once
+	api.On("CreatePost", mock.Anything).Run(func(mock.Arguments) {
+		once.Do(func() { close(attempted) })
+	}).Return(nil, model.NewAppError("CreatePost", "app.post.save.app_error", nil, "", 503))
+	api.On("SendEphemeralPost", mock.Anything, mock.Anything).Return(func(userID string, post *model.Post) *model.Post { return post })
+	api.On("KVSetWithOptions", mock.Anything, mock.Anything, mock.Anything).Return(true, nil)
+
+	weatherService := &WeatherService{weatherData: []WeatherValues{{Temperature: 20, WeatherCode: 1000}}}
+	sm := newTestSubscriptionManagerWithAPI(t, api, weatherService)
+	sm.messageService.retryDelay = time.Minute
+
+	go sm.StartSubscription(&Subscription{ID: "sub_1", Location: "London", ChannelID: "channel1", UpdateFrequency: time.Hour.Milliseconds()}, nil)
+
+	// Wait for the first post to fail so the loop is waiting to retry it
+	select {
+	case <-attempted:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected the subscription to try posting an update")
+	}
+
+	start := time.Now()
+	sm.GracefulStop()
+
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("Expected GracefulStop to return within 2s, took %s", elapsed)
+	}
+	if active := sm.activeJobs.Load(); active != 0 {
+		t.Errorf("Expected the subscription loop to exit, %d still running", active)
+	}
+	api.AssertNumberOfCalls(t, "CreatePost", 1)
+}
+
+func TestSubscriptionUpdatesDoNotRaceWithSaves(t *testing.T) {
+	sm := newTestSubscriptionManager(t)
+
+	sub := &Subscription{ID: "sub_1", Location: "London", ChannelID: "channel1", UpdateFrequency: 1}
+	if err := sm.AddSubscription(sub); err != nil {
+		t.Fatalf("Failed to add subscription: %v", err)
+	}
+	go sm.StartSubscription(sub, nil)
+
+	// Read the subscription the way saves and the API handlers do while the loop records its update times
+	deadline := time.Now().Add(100 * time.Millisecond)
+	for time.Now().Before(deadline) {
+		sm.mutex.RLock()
+		if _, err := json.Marshal(sm.subscriptions); err != nil {
+			t.Errorf("Failed to marshal subscriptions: %v", err)
+		}
+		sm.mutex.RUnlock()
+
+		if _, err := json.Marshal(sm.GetAllSubscriptions()); err != nil {
+			t.Errorf("Failed to marshal listed subscriptions: %v", err)
+		}
+		if listed, exists := sm.GetSubscription("sub_1"); exists {
+			_ = FormatSubscriptionList([]*Subscription{listed})
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	sm.GracefulStop()
+
+	if stored, _ := sm.GetSubscription("sub_1"); stored.LastUpdated.IsZero() {
+		t.Error("Expected the subscription loop to record an update time")
+	}
+}
+
+func TestConcurrentSubscriptionChangesCoalesceSaves(t *testing.T) {
+	var saveMutex sync.Mutex
+	var saved [][]byte
+
+	api := &plugintest.API{}
+	api.On("KVSetWithOptions", "weather_subscriptions", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		saveMutex.Lock()
+		defer saveMutex.Unlock()
+		saved = append(saved, args.Get(1).([]byte))
+	}).Return(true, nil)
+
+	sm := newTestSubscriptionManagerWithAPI(t, api, &WeatherService{})
+	sm.saveDelay = 50 * time.Millisecond
+
+	const count = 50
+	var wg sync.WaitGroup
+	for i := 0; i < count; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			id := fmt.Sprintf("sub_%d", i)
+			if err := sm.AddSubscription(&Subscription{ID: id, Location: fmt.Sprintf("City %d", i), ChannelID: "channel1"}); err != nil {
+				t.Errorf("Failed to add subscription: %v", err)
+				return
+			}
+			if i%2 == 0 {
+				sm.RemoveSubscription(id)
+			}
+		}(i)
+	}
+	wg.Wait()
+	sm.GracefulStop()
+
+	saveMutex.Lock()
+	defer saveMutex.Unlock()
+
+	if len(saved) == 0 || len(saved) >= count {
+		t.Fatalf("Expected saves to be coalesced into fewer than %d writes, got %d", count, len(saved))
+	}
+	for i, data := range saved {
+		var subscriptions map[string]*Subscription
+		if err := json.Unmarshal(data, &subscriptions); err != nil {
+			t.Fatalf("Save %d is not valid JSON: %v", i, err)
+		}
+	}
+
+	var final map[string]*Subscription
+	_ = json.Unmarshal(saved[len(saved)-1], &final)
+	if len(final) != count/2 {
+		t.Errorf("Expected final save to contain %d subscriptions, got %d", count/2, len(final))
+	}
+}
+
+func TestLoadSubscriptionsKeepsStateOnCorruptData(t *testing.T) {
+	testCases := []struct {
+		name   string
+		stored []byte
+	}{
+		{name: "empty value", stored: []byte{}},
+		{name: "truncated value", stored: []byte(`{"sub_1":{"id":"sub_1","loca`)},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			api := newTestPluginAPI()
+			api.On("KVGet", "weather_subscriptions").Return(tc.stored, nil)
+
+			sm := newTestSubscriptionManagerWithAPI(t, api, &WeatherService{})
+			existing := &Subscription{ID: "sub_existing", Location: "London", ChannelID: "channel1"}
+			sm.subscriptions[existing.ID] = existing
+
+			sm.loadSubscriptions()
+
+			if _, exists := sm.GetSubscription(existing.ID); !exists || len(sm.GetAllSubscriptions()) != 1 {
+				t.Errorf("Expected in-memory subscriptions to be kept, got %v", sm.GetAllSubscriptions())
+			}
+		})
+	}
+}
+
+func TestLoadSubscriptionsMigratesOldSchema(t *testing.T) {
+	fixture, err := os.ReadFile(filepath.Join("testdata", "subscriptions_v0.json"))
+	if err != nil {
+		t.Fatalf("Failed to read fixture: %v", err)
+	}
+
+	api := newTestPluginAPI()
+	api.On("KVGet", "weather_subscriptions").Return(fixture, nil)
+	api.On("KVGet", subscriptionsVersionKey).Return(nil, nil)
+
+	sm := newTestSubscriptionManagerWithAPI(t, api, newFakeWeatherClient())
+	sm.loadSubscriptions()
+	sm.GracefulStop()
+
+	if sm.Version != subscriptionSchemaVersion {
+		t.Errorf("Expected version %d after migration, got %d", subscriptionSchemaVersion, sm.Version)
+	}
+	api.AssertCalled(t, "KVSetWithOptions", subscriptionsVersionKey, []byte(fmt.Sprint(subscriptionSchemaVersion)), mock.Anything)
+
+	subs := sm.GetAllSubscriptions()
+	if len(subs) != 2 {
+		t.Fatalf("Expected 2 subscriptions, got %d", len(subs))
+	}
+	for _, sub := range subs {
+		if sub.Units != unitsMetric {
+			t.Errorf("Expected %s to default to %s units, got %q", sub.ID, unitsMetric, sub.Units)
+		}
+		if sub.UpdateFrequency < minUpdateFrequency {
+			t.Errorf("Expected %s to have an update frequency of at least %d, got %d", sub.ID, minUpdateFrequency, sub.UpdateFrequency)
+		}
+	}
+
+	// Fields that were already stored must survive the migration
+	kept, _ := sm.GetSubscription("sub_1700000000000000000")
+	if kept.UpdateFrequency != 3600000 {
+		t.Errorf("Expected stored update frequency to be kept, got %d", kept.UpdateFrequency)
+	}
+	withAlerts, _ := sm.GetSubscription("sub_1700000000000000001")
+	if !withAlerts.NoEmoji || len(withAlerts.AlertConditions) != 1 {
+		t.Errorf("Expected stored options to be kept, got %+v", withAlerts)
+	}
+}
+
+func TestLoadSubscriptionsSkipsMigrationForCurrentSchema(t *testing.T) {
+	stored := []byte(`{"sub_1":{"id":"sub_1","location":"London","channel_id":"channel1","update_frequency":60000,"units":"metric"}}`)
+
+	api := newTestPluginAPI()
+	api.On("KVGet", "weather_subscriptions").Return(stored, nil)
+	api.On("KVGet", subscriptionsVersionKey).Return([]byte(fmt.Sprint(subscriptionSchemaVersion)), nil)
+
+	sm := newTestSubscriptionManagerWithAPI(t, api, newFakeWeatherClient())
+	sm.loadSubscriptions()
+	sm.GracefulStop()
+
+	if sm.Version != subscriptionSchemaVersion {
+		t.Errorf("Expected version %d, got %d", subscriptionSchemaVersion, sm.Version)
+	}
+	api.AssertNotCalled(t, "KVSetWithOptions", subscriptionsVersionKey, mock.Anything, mock.Anything)
+}
+
+func TestGetSubscriptionsPaged(t *testing.T) {
+	sm := newTestSubscriptionManager(t)
+	for i := 0; i < 25; i++ {
+		channelID := "channel1"
+		if i%5 == 0 {
+			channelID = "channel2"
+		}
+		id := fmt.Sprintf("sub_%02d", i)
+		sm.subscriptions[id] = &Subscription{ID: id, Location: "London", ChannelID: channelID}
+	}
+
+	testCases := []struct {
+		name          string
+		channelID     string
+		offset        int
+		limit         int
+		expectedFirst string
+		expectedCount int
+		expectedTotal int
+	}{
+		{name: "first page", offset: 0, limit: 10, expectedFirst: "sub_00", expectedCount: 10, expectedTotal: 25},
+		{name: "second page", offset: 10, limit: 10, expectedFirst: "sub_10", expectedCount: 10, expectedTotal: 25},
+		{name: "last partial page", offset: 20, limit: 10, expectedFirst: "sub_20", expectedCount: 5, expectedTotal: 25},
+		{name: "past the end", offset: 30, limit: 10, expectedCount: 0, expectedTotal: 25},
+		{name: "channel first page", channelID: "channel1", offset: 0, limit: 10, expectedFirst: "sub_01", expectedCount: 10, expectedTotal: 20},
+		{name: "channel last page", channelID: "channel1", offset: 10, limit: 10, expectedFirst: "sub_13", expectedCount: 10, expectedTotal: 20},
+		{name: "smaller channel", channelID: "channel2", offset: 0, limit: 10, expectedFirst: "sub_00", expectedCount: 5, expectedTotal: 5},
+		{name: "unknown channel", channelID: "channel3", offset: 0, limit: 10, expectedCount: 0, expectedTotal: 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var subs []*Subscription
+			var total int
+			if tc.channelID == "" {
+				subs, total = sm.GetAllSubscriptionsPaged(tc.offset, tc.limit)
+			} else {
+				subs, total = sm.GetSubscriptionsForChannelPaged(tc.channelID, tc.offset, tc.limit)
+			}
+
+			if total != tc.expectedTotal {
+				t.Errorf("Expected total %d, got %d", tc.expectedTotal, total)
+			}
+			if len(subs) != tc.expectedCount {
+				t.Fatalf("Expected %d subscriptions, got %d", tc.expectedCount, len(subs))
+			}
+			if tc.expectedCount > 0 && subs[0].ID != tc.expectedFirst {
+				t.Errorf("Expected page to start with %s, got %s", tc.expectedFirst, subs[0].ID)
+			}
+			for i := 1; i < len(subs); i++ {
+				if subs[i-1].ID >= subs[i].ID {
+					t.Errorf("Expected subscriptions ordered by ID, got %s before %s", subs[i-1].ID, subs[i].ID)
+				}
+				if tc.channelID != "" && subs[i].ChannelID != tc.channelID {
+					t.Errorf("Expected only %s subscriptions, got %s in %s", tc.channelID, subs[i].ID, subs[i].ChannelID)
+				}
+			}
+		})
+	}
+}
+
+func TestSaveIfDueWaitsForInterval(t *testing.T) {
+	testCases := []struct {
+		name        string
+		sinceSave   time.Duration
+		expectSaved bool
+	}{
+		{name: "just saved", sinceSave: 0, expectSaved: false},
+		{name: "before interval", sinceSave: subscriptionSaveInterval - time.Minute, expectSaved: false},
+		{name: "at interval", sinceSave: subscriptionSaveInterval, expectSaved: true},
+		{name: "after interval", sinceSave: subscriptionSaveInterval + time.Hour, expectSaved: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sm := newTestSubscriptionManager(t)
+			sm.saveDelay = time.Hour // Keep the scheduled save pending so it can be observed
+
+			now := time.Now()
+			sm.lastSaved = now.Add(-tc.sinceSave)
+			sm.saveIfDue(now)
+
+			sm.saveMutex.Lock()
+			scheduled := sm.saveTimer != nil
+			sm.saveMutex.Unlock()
+
+			if scheduled != tc.expectSaved {
+				t.Errorf("Expected save scheduled=%v, got %v", tc.expectSaved, scheduled)
+			}
+			sm.GracefulStop()
+		})
+	}
+}
+
+func TestStartSubscriptionAfterSkipsRemovedSubscription(t *testing.T) {
+	sm := newTestSubscriptionManager(t)
+
+	sub := &Subscription{ID: "sub_1", Location: "London", ChannelID: "channel1", UpdateFrequency: time.Hour.Milliseconds()}
+	if err := sm.AddSubscription(sub); err != nil {
+		t.Fatalf("Failed to add subscription: %v", err)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		sm.startSubscriptionAfter(sub, 50*time.Millisecond)
+		close(done)
+	}()
+	sm.RemoveSubscription(sub.ID)
+
+	// A started loop runs until it is stopped, so the call only returns if the start was skipped
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		sm.GracefulStop()
+		t.Fatal("Expected the removed subscription not to start")
+	}
+	if active := sm.activeJobs.Load(); active != 0 {
+		t.Errorf("Expected no running subscriptions, got %d", active)
+	}
+}
+
+func TestAddSubscriptionRejectsExistingID(t *testing.T) {
+	sm := newTestSubscriptionManager(t)
+
+	original := &Subscription{ID: "sub_1", Location: "London", ChannelID: "channel1"}
+	if err := sm.AddSubscription(original); err != nil {
+		t.Fatalf("Failed to add subscription: %v", err)
+	}
+
+	err := sm.AddSubscription(&Subscription{ID: "sub_1", Location: "Paris", ChannelID: "channel2"})
+	if !errors.Is(err, errSubscriptionIDExists) {
+		t.Fatalf("Expected errSubscriptionIDExists, got %v", err)
+	}
+	if sub, _ := sm.GetSubscription("sub_1"); sub.Location != "London" {
+		t.Errorf("Expected the original subscription to be kept, got %+v", sub)
+	}
+}
+
+func TestResumeSubscriptionStartsPausedSubscription(t *testing.T) {
+	sm := newTestSubscriptionManager(t)
+	t.Cleanup(sm.GracefulStop)
+
+	if err := sm.AddSubscription(&Subscription{ID: "sub_paused", Location: "London", ChannelID: "channel1", UpdateFrequency: time.Hour.Milliseconds(), Paused: true}); err != nil {
+		t.Fatalf("Failed to add subscription: %v", err)
+	}
+	if err := sm.AddSubscription(&Subscription{ID: "sub_running", Location: "Paris", ChannelID: "channel1", UpdateFrequency: time.Hour.Milliseconds()}); err != nil {
+		t.Fatalf("Failed to add subscription: %v", err)
+	}
+
+	if sm.ResumeSubscription("sub_running") {
+		t.Error("Expected a subscription that is not paused not to be resumed")
+	}
+	if sm.ResumeSubscription("sub_missing") {
+		t.Error("Expected an unknown subscription not to be resumed")
+	}
+	if !sm.ResumeSubscription("sub_paused") {
+		t.Fatal("Expected the paused subscription to be resumed")
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for sm.activeJobs.Load() != 1 {
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected the resumed subscription to start, %d running", sm.activeJobs.Load())
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if sub, _ := sm.GetSubscription("sub_paused"); sub.Paused {
+		t.Error("Expected the resumed subscription to no longer be paused")
+	}
+}
@@ -1,18 +1,19 @@
 package main
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/mattermost/mattermost/server/public/model"
 )
 
 type WeatherCommand struct {
-	weatherService *WeatherService
+	weatherService WeatherClient
 	formatter      *WeatherFormatter
 	messageService *MessageService
 }
 
-func NewWeatherCommand(weatherService *WeatherService, formatter *WeatherFormatter, messageService *MessageService) *WeatherCommand {
+func NewWeatherCommand(weatherService WeatherClient, formatter *WeatherFormatter, messageService *MessageService) *WeatherCommand {
 	return &WeatherCommand{
 		weatherService: weatherService,
 		formatter:      formatter,
@@ -20,16 +21,16 @@ func NewWeatherCommand(weatherService *WeatherService, formatter *WeatherFormatt
 	}
 }
 
-func (wc *WeatherCommand) Execute(args *model.CommandArgs, location string) (*model.CommandResponse, error) {
+func (wc *WeatherCommand) Execute(args *model.CommandArgs, location string, noEmoji bool) (*model.CommandResponse, error) {
 	if location == "" {
 		return wc.messageService.SendEphemeralResponse(args, "Please provide a location. Example: `/weather New York` or use `/weather help` for more commands.")
 	}
 
-	weatherData, err := wc.weatherService.GetWeatherData(location)
+	weatherData, err := wc.weatherService.GetWeatherData(context.Background(), location)
 	if err != nil {
 		return wc.messageService.SendEphemeralResponse(args, fmt.Sprintf("Error fetching weather data: %v", err))
 	}
 
-	post := wc.formatter.FormatAsAttachment(weatherData, args.ChannelId, wc.messageService.GetBotUserID())
+	post := wc.formatter.FormatAsAttachment(weatherData, args.ChannelId, wc.messageService.GetBotUserID(), noEmoji)
 	return wc.messageService.SendPublicResponse(args, post)
 }
\ No newline at end of file
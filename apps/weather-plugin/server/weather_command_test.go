@@ -0,0 +1,144 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/plugin/plugintest"
+	"github.com/stretchr/testify/mock"
+)
+
+// fakeWeatherClient returns canned responses per location without touching bundled data
+type fakeWeatherClient struct {
+	mutex     sync.Mutex
+	responses map[string]*WeatherResponse
+	calls     []string
+}
+
+func (f *fakeWeatherClient) GetWeatherData(ctx context.Context, location string) (*WeatherResponse, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	f.calls = append(f.calls, location)
+	if response, ok := f.responses[location]; ok {
+		return response, nil
+	}
+	return nil, fmt.Errorf("unknown location: %s", location)
+}
+
+func newFakeWeatherClient() *fakeWeatherClient {
+	return &fakeWeatherClient{
+		responses: map[string]*WeatherResponse{
+			"London": newTestWeatherResponse(4000),
+		},
+	}
+}
+
+// assertEphemeralContains checks that exactly one ephemeral post was sent and that it contains the expected text
+func assertEphemeralContains(t *testing.T, api *plugintest.API, expected string) {
+	t.Helper()
+
+	api.AssertNumberOfCalls(t, "SendEphemeralPost", 1)
+	api.AssertCalled(t, "SendEphemeralPost", mock.Anything, mock.MatchedBy(func(post *model.Post) bool {
+		return strings.Contains(post.Message, expected)
+	}))
+}
+
+func TestWeatherCommandExecute(t *testing.T) {
+	testCases := []struct {
+		name              string
+		location          string
+		expectedEphemeral string
+		expectPublic      bool
+	}{
+		{name: "missing location", location: "", expectedEphemeral: "Please provide a location"},
+		{name: "unknown location", location: "Atlantis", expectedEphemeral: "Error fetching weather data"},
+		{name: "known location", location: "London", expectPublic: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			api := newTestPluginAPI()
+			sm := newTestSubscriptionManagerWithAPI(t, api, newFakeWeatherClient())
+			command := NewWeatherCommand(sm.weatherService, sm.formatter, sm.messageService)
+
+			args := &model.CommandArgs{ChannelId: "channel1", UserId: "user1"}
+			if _, err := command.Execute(args, tc.location, false); err != nil {
+				t.Fatalf("Execute returned error: %v", err)
+			}
+
+			if tc.expectPublic {
+				api.AssertNumberOfCalls(t, "CreatePost", 1)
+			} else {
+				assertEphemeralContains(t, api, tc.expectedEphemeral)
+				api.AssertNotCalled(t, "CreatePost", mock.Anything)
+			}
+		})
+	}
+}
+
+func TestSubscriptionCommandExecuteSubscribe(t *testing.T) {
+	testCases := []struct {
+		name              string
+		existing          *Subscription
+		command           string
+		expectedEphemeral string
+		expectedCount     int
+	}{
+		{
+			name:              "creates subscription",
+			command:           "/weather subscribe London 1h",
+			expectedEphemeral: "✅ Subscribed this channel to weather updates for **London**",
+			expectedCount:     1,
+		},
+		{
+			name:              "rejects unknown location",
+			command:           "/weather subscribe Atlantis 1h",
+			expectedEphemeral: "⚠️ Could not get weather for **Atlantis**",
+			expectedCount:     0,
+		},
+		{
+			name:              "rejects invalid frequency",
+			command:           "/weather subscribe London soon",
+			expectedEphemeral: "Usage:",
+			expectedCount:     0,
+		},
+		{
+			name:              "rejects duplicate subscription",
+			existing:          &Subscription{ID: "sub_existing", Location: "London", ChannelID: "channel1"},
+			command:           "/weather subscribe London 1h",
+			expectedEphemeral: "already posted to this channel (ID: `sub_existing`)",
+			expectedCount:     1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			api := newTestPluginAPI()
+			sm := newTestSubscriptionManagerWithAPI(t, api, newFakeWeatherClient())
+			command := NewSubscriptionCommand(sm.client, sm.weatherService, sm, sm.messageService, NewCommandParser())
+
+			if tc.existing != nil {
+				if err := sm.AddSubscription(tc.existing); err != nil {
+					t.Fatalf("Failed to add existing subscription: %v", err)
+				}
+			}
+
+			args := &model.CommandArgs{ChannelId: "channel1", UserId: "user1", Command: tc.command}
+			if _, err := command.ExecuteSubscribe(args, strings.Fields(tc.command)); err != nil {
+				t.Fatalf("ExecuteSubscribe returned error: %v", err)
+			}
+			sm.GracefulStop()
+
+			assertEphemeralContains(t, api, tc.expectedEphemeral)
+
+			if count := len(sm.GetSubscriptionsForChannel("channel1")); count != tc.expectedCount {
+				t.Errorf("Expected %d subscriptions, got %d", tc.expectedCount, count)
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// maxCompareLocations bounds how many locations one comparison looks up
+const maxCompareLocations = 10
+
+// compareWeather looks up the weather for every location concurrently. Results are returned in
+// the order of locations, skipping the ones that failed; their errors are joined into the error.
+func compareWeather(ctx context.Context, locations []string, client WeatherClient) ([]WeatherResponse, error) {
+	responses := make([]*WeatherResponse, len(locations))
+	errs := make([]error, len(locations))
+
+	var wg sync.WaitGroup
+	for i, location := range locations {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			response, err := client.GetWeatherData(ctx, location)
+			if err != nil {
+				errs[i] = fmt.Errorf("%s: %w", location, err)
+				return
+			}
+			responses[i] = response
+		}()
+	}
+	wg.Wait()
+
+	results := make([]WeatherResponse, 0, len(locations))
+	for _, response := range responses {
+		if response != nil {
+			results = append(results, *response)
+		}
+	}
+	return results, errors.Join(errs...)
+}
+
+// parseCompareLocations splits comma-separated location lists, dropping blanks and duplicates.
+// Two numbers in a row are read as one "lat,lon" location, so coordinates can be compared too.
+func parseCompareLocations(values []string) []string {
+	var locations []string
+	seen := make(map[string]bool)
+	for _, value := range values {
+		parts := strings.Split(value, ",")
+		for i := 0; i < len(parts); i++ {
+			location := strings.TrimSpace(parts[i])
+			if i+1 < len(parts) {
+				if _, _, isCoordinates := parseCoordinates(location + "," + parts[i+1]); isCoordinates {
+					location += "," + strings.TrimSpace(parts[i+1])
+					i++
+				}
+			}
+			if location == "" || seen[strings.ToLower(location)] {
+				continue
+			}
+			seen[strings.ToLower(location)] = true
+			locations = append(locations, location)
+		}
+	}
+	return locations
+}
+
+// FormatComparisonTable renders weather for several locations as a Markdown table, one row per location
+func (wf *WeatherFormatter) FormatComparisonTable(results []WeatherResponse) string {
+	var sb strings.Builder
+	sb.WriteString("| Location | Temperature | Humidity | Wind | Condition |\n")
+	sb.WriteString("|----------|-------------|----------|------|-----------|\n")
+	for i := range results {
+		values := results[i].Data.Values
+		sb.WriteString(fmt.Sprintf("| %s | %.1f°C | %d%% | %.1f km/h %s | %s |\n",
+			escapeTableCell(wf.getLocationDisplay(&results[i])),
+			values.Temperature,
+			values.Humidity,
+			values.WindSpeed, wf.getWindDirection(values.WindDirection),
+			wf.getConditionDisplay(values.WeatherCode, false)))
+	}
+	return sb.String()
+}
+
+// escapeTableCell escapes pipes so text cannot split a Markdown table cell
+func escapeTableCell(text string) string {
+	return strings.ReplaceAll(text, "|", `\|`)
+}
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// newCompareWeatherClient returns a fake weather client that knows London and Tokyo
+func newCompareWeatherClient() *fakeWeatherClient {
+	ws := &WeatherService{}
+	return &fakeWeatherClient{
+		responses: map[string]*WeatherResponse{
+			"London": ws.buildWeatherResponse(WeatherValues{Temperature: 12.5, Humidity: 70, WindSpeed: 15, WindDirection: 90, WeatherCode: 4000}, "London"),
+			"Tokyo":  ws.buildWeatherResponse(WeatherValues{Temperature: 24, Humidity: 55, WindSpeed: 8.2, WindDirection: 180, WeatherCode: 1000}, "Tokyo"),
+		},
+	}
+}
+
+func TestCompareWeather(t *testing.T) {
+	client := newCompareWeatherClient()
+
+	results, err := compareWeather(context.Background(), []string{"Tokyo", "Atlantis", "London"}, client)
+	if err == nil || !strings.Contains(err.Error(), "Atlantis") {
+		t.Errorf("Expected an error naming the failed location, got %v", err)
+	}
+
+	names := make([]string, 0, len(results))
+	for _, result := range results {
+		names = append(names, result.Location.Name)
+	}
+	if !slices.Equal(names, []string{"Tokyo", "London"}) {
+		t.Errorf("Expected results for Tokyo and London in request order, got %v", names)
+	}
+
+	slices.Sort(client.calls)
+	if !slices.Equal(client.calls, []string{"Atlantis", "London", "Tokyo"}) {
+		t.Errorf("Expected one lookup per location, got %v", client.calls)
+	}
+}
+
+func TestParseCompareLocations(t *testing.T) {
+	testCases := []struct {
+		name     string
+		values   []string
+		expected []string
+	}{
+		{name: "names", values: []string{"NYC, London,,tokyo", "Tokyo", " Paris "}, expected: []string{"NYC", "London", "tokyo", "Paris"}},
+		{name: "coordinates", values: []string{"40.7128,-74.0060"}, expected: []string{"40.7128,-74.0060"}},
+		{name: "names and coordinates", values: []string{"London, 40.7128, -74.0060,Tokyo,35.68,139.69"}, expected: []string{"London", "40.7128,-74.0060", "Tokyo", "35.68,139.69"}},
+		{name: "a lone number stays its own location", values: []string{"10001,London"}, expected: []string{"10001", "London"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if locations := parseCompareLocations(tc.values); !slices.Equal(locations, tc.expected) {
+				t.Errorf("Expected %v, got %v", tc.expected, locations)
+			}
+		})
+	}
+}
+
+func TestFormatComparisonTableEscapesPipes(t *testing.T) {
+	ws := &WeatherService{}
+	table := NewWeatherFormatter().FormatComparisonTable([]WeatherResponse{
+		*ws.buildWeatherResponse(WeatherValues{Temperature: 20, WeatherCode: 1000}, "North|South"),
+	})
+
+	if !strings.Contains(table, `| North\|South | 20.0°C |`) {
+		t.Errorf("Expected the pipe in the location to be escaped, got:\n%s", table)
+	}
+}
+
+func TestCompareWeatherAPI(t *testing.T) {
+	testCases := []struct {
+		name           string
+		query          string
+		expectedStatus int
+		expected       []string
+	}{
+		{
+			name:           "all locations succeed",
+			query:          "?locations=London,Tokyo",
+			expectedStatus: http.StatusOK,
+			expected:       []string{"| London | 12.5°C | 70% | 15.0 km/h E |", "| Tokyo | 24.0°C | 55% | 8.2 km/h S |"},
+		},
+		{
+			name:           "partial results with a warning",
+			query:          "?locations=London,Atlantis",
+			expectedStatus: http.StatusOK,
+			expected:       []string{"| London |", "⚠️ Could not get weather for 1 of 2 locations", "Atlantis"},
+		},
+		{
+			name:           "every location fails",
+			query:          "?locations=Atlantis",
+			expectedStatus: http.StatusBadGateway,
+		},
+		{
+			name:           "missing locations",
+			query:          "",
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			p := newTestAPIPlugin(t)
+			p.weatherService = newCompareWeatherClient()
+			p.formatter = NewWeatherFormatter()
+
+			w := serveAPIRequest(p, http.MethodGet, "/weather/compare"+tc.query, "", "")
+			if w.Code != tc.expectedStatus {
+				t.Fatalf("Expected status %d, got %d: %s", tc.expectedStatus, w.Code, w.Body.String())
+			}
+			for _, text := range tc.expected {
+				if !strings.Contains(w.Body.String(), text) {
+					t.Errorf("Expected response to contain %q, got:\n%s", text, w.Body.String())
+				}
+			}
+		})
+	}
+}
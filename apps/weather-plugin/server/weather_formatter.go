@@ -20,12 +20,65 @@ func (wf *WeatherFormatter) getWindDirection(windDir int) string {
 	return directions[index]
 }
 
+// getWeatherDescription returns the description for a weather code. Codes without an exact match
+// fall back to the category of their thousands prefix, e.g. "Rain (code 4202)".
 func (wf *WeatherFormatter) getWeatherDescription(weatherCode int) string {
-	description, exists := WeatherCodeDescription[weatherCode]
+	if description, exists := WeatherCodeDescription[weatherCode]; exists {
+		return description
+	}
+	if category, exists := WeatherCategoryDescription[weatherCode/1000]; exists {
+		return fmt.Sprintf("%s (code %d)", category, weatherCode)
+	}
+	return "Unknown"
+}
+
+// getWeatherEmoji returns the emoji for a weather code, falling back to a neutral thermometer
+func (wf *WeatherFormatter) getWeatherEmoji(weatherCode int) string {
+	emoji, exists := WeatherCodeEmoji[weatherCode]
 	if !exists {
-		return "Unknown"
+		return defaultWeatherEmoji
+	}
+	return emoji
+}
+
+// getConditionDisplay returns the weather description, prefixed with its emoji unless disabled
+func (wf *WeatherFormatter) getConditionDisplay(weatherCode int, noEmoji bool) string {
+	description := wf.getWeatherDescription(weatherCode)
+	if noEmoji {
+		return description
+	}
+	return wf.getWeatherEmoji(weatherCode) + " " + description
+}
+
+// getAirQualityCategory maps an EPA air quality index to its category label
+func (wf *WeatherFormatter) getAirQualityCategory(epaIndex int) string {
+	switch {
+	case epaIndex <= 50:
+		return "Good"
+	case epaIndex <= 100:
+		return "Moderate"
+	case epaIndex <= 150:
+		return "Unhealthy for Sensitive Groups"
+	case epaIndex <= 200:
+		return "Unhealthy"
+	case epaIndex <= 300:
+		return "Very Unhealthy"
+	default:
+		return "Hazardous"
 	}
-	return description
+}
+
+// getAirQualityDisplay formats the air quality reading, returning false when the data has none
+func (wf *WeatherFormatter) getAirQualityDisplay(epaIndex *int, pm25 *float64) (string, bool) {
+	if epaIndex == nil {
+		return "", false
+	}
+
+	display := fmt.Sprintf("AQI %d (%s)", *epaIndex, wf.getAirQualityCategory(*epaIndex))
+	if pm25 != nil {
+		display += fmt.Sprintf(", PM2.5 %.1f µg/m³", *pm25)
+	}
+	return display, true
 }
 
 func (wf *WeatherFormatter) getLocationDisplay(weatherData *WeatherResponse) string {
@@ -55,9 +108,9 @@ func (wf *WeatherFormatter) getAttachmentColor(weatherCode int) string {
 	}
 }
 
-func (wf *WeatherFormatter) FormatAsText(weatherData *WeatherResponse) string {
+func (wf *WeatherFormatter) FormatAsText(weatherData *WeatherResponse, noEmoji bool) string {
 	locationDisplay := wf.getLocationDisplay(weatherData)
-	description := wf.getWeatherDescription(weatherData.Data.Values.WeatherCode)
+	description := wf.getConditionDisplay(weatherData.Data.Values.WeatherCode, noEmoji)
 	windDirection := wf.getWindDirection(weatherData.Data.Values.WindDirection)
 
 	weatherText := fmt.Sprintf("🌤️ **Weather for %s**\n\n", locationDisplay)
@@ -74,12 +127,16 @@ func (wf *WeatherFormatter) FormatAsText(weatherData *WeatherResponse) string {
 		weatherText += fmt.Sprintf("**Rain Intensity:** %.1f mm/h\n", weatherData.Data.Values.RainIntensity)
 	}
 
+	if airQuality, ok := wf.getAirQualityDisplay(weatherData.Data.Values.EPAIndex, weatherData.Data.Values.ParticulateMatter25); ok {
+		weatherText += fmt.Sprintf("**Air Quality:** %s\n", airQuality)
+	}
+
 	return strings.TrimSpace(weatherText)
 }
 
-func (wf *WeatherFormatter) FormatAsAttachment(weatherData *WeatherResponse, channelID, botUserID string) *model.Post {
+func (wf *WeatherFormatter) FormatAsAttachment(weatherData *WeatherResponse, channelID, botUserID string, noEmoji bool) *model.Post {
 	locationDisplay := wf.getLocationDisplay(weatherData)
-	description := wf.getWeatherDescription(weatherData.Data.Values.WeatherCode)
+	description := wf.getConditionDisplay(weatherData.Data.Values.WeatherCode, noEmoji)
 	windDirection := wf.getWindDirection(weatherData.Data.Values.WindDirection)
 
 	fields := []*model.SlackAttachmentField{
@@ -123,6 +180,14 @@ func (wf *WeatherFormatter) FormatAsAttachment(weatherData *WeatherResponse, cha
 		})
 	}
 
+	if airQuality, ok := wf.getAirQualityDisplay(weatherData.Data.Values.EPAIndex, weatherData.Data.Values.ParticulateMatter25); ok {
+		fields = append(fields, &model.SlackAttachmentField{
+			Title: "Air Quality",
+			Value: airQuality,
+			Short: true,
+		})
+	}
+
 	if weatherData.Data.Values.WindGust > 0 {
 		fields = append(fields, &model.SlackAttachmentField{
 			Title: "Wind Gusts",
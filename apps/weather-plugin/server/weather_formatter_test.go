@@ -0,0 +1,145 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func newTestWeatherResponse(weatherCode int) *WeatherResponse {
+	ws := &WeatherService{}
+	return ws.buildWeatherResponse(WeatherValues{
+		Temperature:              12.5,
+		TemperatureApparent:      10.1,
+		Humidity:                 70,
+		PrecipitationProbability: 40,
+		WindSpeed:                15,
+		WindDirection:            90,
+		CloudCover:               50,
+		WeatherCode:              weatherCode,
+	}, "London")
+}
+
+func TestFormatAsTextConditionEmoji(t *testing.T) {
+	testCases := []struct {
+		weatherCode int
+		expected    string
+	}{
+		{weatherCode: 1000, expected: "**Condition:** ☀️ Clear"},
+		{weatherCode: 1101, expected: "**Condition:** ⛅ Partly Cloudy"},
+		{weatherCode: 2000, expected: "**Condition:** 🌫️ Fog"},
+		{weatherCode: 4001, expected: "**Condition:** 🌧️ Rain"},
+		{weatherCode: 5101, expected: "**Condition:** ❄️ Heavy Snow"},
+		{weatherCode: 6001, expected: "**Condition:** 🧊 Freezing Rain"},
+		{weatherCode: 8000, expected: "**Condition:** ⛈️ Thunderstorm"},
+		{weatherCode: 9999, expected: "**Condition:** 🌡️ Unknown"},
+	}
+
+	formatter := NewWeatherFormatter()
+	for _, tc := range testCases {
+		t.Run(WeatherCodeDescription[tc.weatherCode], func(t *testing.T) {
+			text := formatter.FormatAsText(newTestWeatherResponse(tc.weatherCode), false)
+			if !strings.Contains(text, tc.expected+"\n") {
+				t.Errorf("Expected text to contain %q, got:\n%s", tc.expected, text)
+			}
+		})
+	}
+}
+
+func TestFormatAsTextNoEmoji(t *testing.T) {
+	formatter := NewWeatherFormatter()
+	text := formatter.FormatAsText(newTestWeatherResponse(8000), true)
+
+	if !strings.Contains(text, "**Condition:** Thunderstorm\n") {
+		t.Errorf("Expected condition without emoji, got:\n%s", text)
+	}
+	if strings.Contains(text, "⛈️") {
+		t.Errorf("Expected no emoji in output, got:\n%s", text)
+	}
+}
+
+func TestFormatAsAttachmentConditionField(t *testing.T) {
+	formatter := NewWeatherFormatter()
+
+	for noEmoji, expected := range map[bool]string{false: "🌨️ Snow", true: "Snow"} {
+		post := formatter.FormatAsAttachment(newTestWeatherResponse(5000), "channel1", "bot1", noEmoji)
+
+		attachments, ok := post.Props["attachments"].([]*model.SlackAttachment)
+		if !ok || len(attachments) != 1 {
+			t.Fatalf("Expected a single attachment, got %v", post.Props["attachments"])
+		}
+
+		var condition string
+		for _, field := range attachments[0].Fields {
+			if field.Title == "Condition" {
+				condition, _ = field.Value.(string)
+			}
+		}
+		if condition != expected {
+			t.Errorf("noEmoji=%v: expected condition %q, got %q", noEmoji, expected, condition)
+		}
+	}
+}
+
+func TestFormatAsTextAirQuality(t *testing.T) {
+	formatter := NewWeatherFormatter()
+
+	testCases := []struct {
+		name     string
+		epaIndex *int
+		pm25     *float64
+		expected string
+	}{
+		{name: "good", epaIndex: intPtr(42), pm25: floatPtr(9.8), expected: "**Air Quality:** AQI 42 (Good), PM2.5 9.8 µg/m³"},
+		{name: "moderate", epaIndex: intPtr(87), expected: "**Air Quality:** AQI 87 (Moderate)"},
+		{name: "unhealthy", epaIndex: intPtr(168), pm25: floatPtr(78.3), expected: "**Air Quality:** AQI 168 (Unhealthy), PM2.5 78.3 µg/m³"},
+		{name: "missing", expected: ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			weatherData := newTestWeatherResponse(1000)
+			weatherData.Data.Values.EPAIndex = tc.epaIndex
+			weatherData.Data.Values.ParticulateMatter25 = tc.pm25
+
+			text := formatter.FormatAsText(weatherData, false)
+			if tc.expected == "" {
+				if strings.Contains(text, "Air Quality") {
+					t.Errorf("Expected no air quality line when data is missing, got:\n%s", text)
+				}
+				return
+			}
+			if !strings.Contains(text, tc.expected) {
+				t.Errorf("Expected text to contain %q, got:\n%s", tc.expected, text)
+			}
+		})
+	}
+}
+
+func intPtr(v int) *int { return &v }
+
+func floatPtr(v float64) *float64 { return &v }
+
+func TestGetWeatherDescription(t *testing.T) {
+	testCases := []struct {
+		weatherCode int
+		expected    string
+	}{
+		{weatherCode: 1000, expected: "Clear"},
+		{weatherCode: 4201, expected: "Heavy Rain"},
+		{weatherCode: 7102, expected: "Light Ice Pellets"},
+		{weatherCode: 4999, expected: "Rain (code 4999)"},
+		{weatherCode: 1103, expected: "Clear/Cloudy (code 1103)"},
+		{weatherCode: 2101, expected: "Fog (code 2101)"},
+		{weatherCode: 3000, expected: "Unknown"},
+		{weatherCode: 9999, expected: "Unknown"},
+	}
+
+	formatter := NewWeatherFormatter()
+	for _, tc := range testCases {
+		if got := formatter.getWeatherDescription(tc.weatherCode); got != tc.expected {
+			t.Errorf("getWeatherDescription(%d) = %q, expected %q", tc.weatherCode, got, tc.expected)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"sync"
+	"time"
+)
+
+// defaultWeatherHistorySize is the number of readings kept per subscription unless WEATHER_HISTORY_SIZE is set
+const defaultWeatherHistorySize = 24
+
+// WeatherEntry is a single weather reading recorded for a subscription
+type WeatherEntry struct {
+	Timestamp time.Time     `json:"timestamp"`
+	Values    WeatherValues `json:"values"`
+}
+
+// WeatherHistory is a bounded ring buffer of the most recent weather readings
+type WeatherHistory struct {
+	mutex   sync.RWMutex
+	entries []WeatherEntry
+	maxSize int
+	start   int // Index of the oldest entry once the buffer is full
+}
+
+func NewWeatherHistory(maxSize int) *WeatherHistory {
+	if maxSize <= 0 {
+		maxSize = defaultWeatherHistorySize
+	}
+	return &WeatherHistory{
+		entries: make([]WeatherEntry, 0, maxSize),
+		maxSize: maxSize,
+	}
+}
+
+// Add records a reading, overwriting the oldest one when the buffer is full
+func (wh *WeatherHistory) Add(entry WeatherEntry) {
+	wh.mutex.Lock()
+	defer wh.mutex.Unlock()
+
+	if len(wh.entries) < wh.maxSize {
+		wh.entries = append(wh.entries, entry)
+		return
+	}
+
+	wh.entries[wh.start] = entry
+	wh.start = (wh.start + 1) % wh.maxSize
+}
+
+// Entries returns the recorded readings from oldest to newest
+func (wh *WeatherHistory) Entries() []WeatherEntry {
+	wh.mutex.RLock()
+	defer wh.mutex.RUnlock()
+
+	entries := make([]WeatherEntry, 0, len(wh.entries))
+	entries = append(entries, wh.entries[wh.start:]...)
+	entries = append(entries, wh.entries[:wh.start]...)
+	return entries
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWeatherHistory(t *testing.T) {
+	base := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	entryAt := func(hour int) WeatherEntry {
+		return WeatherEntry{Timestamp: base.Add(time.Duration(hour) * time.Hour), Values: WeatherValues{Temperature: float64(hour)}}
+	}
+
+	testCases := []struct {
+		name     string
+		maxSize  int
+		added    int
+		expected []float64
+	}{
+		{name: "empty", maxSize: 3, added: 0, expected: []float64{}},
+		{name: "partially filled", maxSize: 3, added: 2, expected: []float64{0, 1}},
+		{name: "exactly full", maxSize: 3, added: 3, expected: []float64{0, 1, 2}},
+		{name: "wrapped", maxSize: 3, added: 5, expected: []float64{2, 3, 4}},
+		{name: "wrapped multiple times", maxSize: 2, added: 7, expected: []float64{5, 6}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			history := NewWeatherHistory(tc.maxSize)
+			for i := 0; i < tc.added; i++ {
+				history.Add(entryAt(i))
+			}
+
+			entries := history.Entries()
+			if len(entries) != len(tc.expected) {
+				t.Fatalf("Expected %d entries, got %d", len(tc.expected), len(entries))
+			}
+			for i, entry := range entries {
+				if entry.Values.Temperature != tc.expected[i] {
+					t.Errorf("Entry %d: expected temperature %v, got %v", i, tc.expected[i], entry.Values.Temperature)
+				}
+			}
+		})
+	}
+}
+
+func TestNewWeatherHistoryDefaultSize(t *testing.T) {
+	if history := NewWeatherHistory(0); history.maxSize != defaultWeatherHistorySize {
+		t.Errorf("Expected default size %d, got %d", defaultWeatherHistorySize, history.maxSize)
+	}
+}
@@ -1,40 +1,73 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"math/rand"
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
 	"time"
 )
 
+// defaultMaxConcurrency bounds simultaneous weather lookups unless WEATHER_MAX_CONCURRENCY is set
+const defaultMaxConcurrency = 4
+
+// defaultLookupTimeout bounds how long a weather lookup may wait unless WEATHER_API_TIMEOUT_SECONDS is set
+const defaultLookupTimeout = 10 * time.Second
+
+// WeatherClient fetches current weather for a location, giving up when ctx is done.
+// WeatherService is the production implementation; tests substitute a fake.
+type WeatherClient interface {
+	GetWeatherData(ctx context.Context, location string) (*WeatherResponse, error)
+}
+
 type WeatherService struct {
 	weatherData []WeatherValues
 	bundlePath  string
+	metrics     *Metrics
+	semaphore   chan struct{} // Bounds concurrent weather lookups
+	timeout     time.Duration // Bounds how long a lookup waits; zero means defaultLookupTimeout
+	onLookup    func()        // Called while a lookup holds its slot; tests use it to observe concurrency
 }
 
 type WeatherValues struct {
-	Temperature              float64 `json:"temperature"`
-	TemperatureApparent      float64 `json:"temperatureApparent"`
-	Humidity                 int     `json:"humidity"`
-	PrecipitationProbability int     `json:"precipitationProbability"`
-	RainIntensity            float64 `json:"rainIntensity"`
-	WindSpeed                float64 `json:"windSpeed"`
-	WindGust                 float64 `json:"windGust"`
-	WindDirection            int     `json:"windDirection"`
-	CloudCover               int     `json:"cloudCover"`
-	WeatherCode              int     `json:"weatherCode"`
+	Temperature              float64  `json:"temperature"`
+	TemperatureApparent      float64  `json:"temperatureApparent"`
+	Humidity                 int      `json:"humidity"`
+	PrecipitationProbability int      `json:"precipitationProbability"`
+	RainIntensity            float64  `json:"rainIntensity"`
+	WindSpeed                float64  `json:"windSpeed"`
+	WindGust                 float64  `json:"windGust"`
+	WindDirection            int      `json:"windDirection"`
+	CloudCover               int      `json:"cloudCover"`
+	WeatherCode              int      `json:"weatherCode"`
+	EPAIndex                 *int     `json:"epaIndex,omitempty"`
+	ParticulateMatter25      *float64 `json:"particulateMatter25,omitempty"`
 }
 
-func NewWeatherService(bundlePath string) *WeatherService {
+func NewWeatherService(bundlePath string, metrics *Metrics) *WeatherService {
 	ws := &WeatherService{
 		bundlePath: bundlePath,
+		metrics:    metrics,
+		semaphore:  make(chan struct{}, maxConcurrencyFromEnv()),
+		timeout:    time.Duration(getEnvInt("WEATHER_API_TIMEOUT_SECONDS", 0)) * time.Second,
 	}
 	ws.loadWeatherData()
 	return ws
 }
 
+// maxConcurrencyFromEnv reads WEATHER_MAX_CONCURRENCY, falling back to defaultMaxConcurrency when it is
+// unset or below 1, since a semaphore without room would block every lookup until it times out
+func maxConcurrencyFromEnv() int {
+	if concurrency := getEnvInt("WEATHER_MAX_CONCURRENCY", defaultMaxConcurrency); concurrency >= 1 {
+		return concurrency
+	}
+	return defaultMaxConcurrency
+}
+
 func (ws *WeatherService) loadWeatherData() error {
 	weatherFile := filepath.Join(ws.bundlePath, "assets", "weather.json")
 	data, err := os.ReadFile(weatherFile)
@@ -49,39 +82,132 @@ func (ws *WeatherService) loadWeatherData() error {
 	return nil
 }
 
-func (ws *WeatherService) GetWeatherData(location string) (*WeatherResponse, error) {
+// ValidateLocation rejects empty locations and out-of-range "lat,lon" coordinates
+func (ws *WeatherService) ValidateLocation(location string) error {
+	location = strings.TrimSpace(location)
+	if location == "" {
+		return fmt.Errorf("location is required")
+	}
+
+	if lat, lon, isCoordinates := parseCoordinates(location); isCoordinates {
+		if lat < -90 || lat > 90 || lon < -180 || lon > 180 {
+			return fmt.Errorf("coordinates out of range: %s", location)
+		}
+	}
+
+	return nil
+}
+
+// parseCoordinates parses a location of the form "40.7128,-74.0060"
+func parseCoordinates(location string) (float64, float64, bool) {
+	latStr, lonStr, found := strings.Cut(location, ",")
+	if !found {
+		return 0, 0, false
+	}
+
+	lat, err := strconv.ParseFloat(strings.TrimSpace(latStr), 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	lon, err := strconv.ParseFloat(strings.TrimSpace(lonStr), 64)
+	if err != nil {
+		return 0, 0, false
+	}
+
+	return lat, lon, true
+}
+
+func (ws *WeatherService) GetWeatherData(ctx context.Context, location string) (*WeatherResponse, error) {
+	if err := ws.ValidateLocation(location); err != nil {
+		ws.metrics.IncWeatherRequests(true)
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, ws.lookupTimeout())
+	defer cancel()
+
+	// A stuck lookup must not hold the subscription goroutine forever
+	if err := ws.acquire(ctx); err != nil {
+		ws.metrics.IncWeatherRequests(true)
+		return nil, fmt.Errorf("timed out waiting for weather data for %s: %w", location, err)
+	}
+	defer ws.release()
+
+	if ws.onLookup != nil {
+		ws.onLookup()
+	}
+
 	if len(ws.weatherData) == 0 {
 		// Try to reload data if empty
 		if err := ws.loadWeatherData(); err != nil {
+			ws.metrics.IncWeatherRequests(true)
 			return nil, fmt.Errorf("no weather data available: %v", err)
 		}
 	}
 
+	ws.metrics.IncWeatherRequests(false)
+
 	// Pick a random weather entry
 	randomIndex := rand.Intn(len(ws.weatherData))
 	weatherValues := ws.weatherData[randomIndex]
-	
+
 	// Create a WeatherResponse with the user's requested location
 	weatherResponse := ws.buildWeatherResponse(weatherValues, location)
-	
+
 	return weatherResponse, nil
 }
 
+// lookupTimeout returns how long a lookup may wait, falling back to defaultLookupTimeout
+func (ws *WeatherService) lookupTimeout() time.Duration {
+	if ws.timeout <= 0 {
+		return defaultLookupTimeout
+	}
+	return ws.timeout
+}
+
+// acquire blocks until a lookup slot is available or ctx is done. A service without a semaphore is unbounded.
+func (ws *WeatherService) acquire(ctx context.Context) error {
+	if ws.semaphore == nil {
+		return nil
+	}
+
+	select {
+	case ws.semaphore <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (ws *WeatherService) release() {
+	if ws.semaphore != nil {
+		<-ws.semaphore
+	}
+}
+
 func (ws *WeatherService) buildWeatherResponse(values WeatherValues, location string) *WeatherResponse {
+	locationType := "city"
+	lat, lon, isCoordinates := parseCoordinates(location)
+	if isCoordinates {
+		locationType = "coordinates"
+	}
+
 	return &WeatherResponse{
 		Data: struct {
 			Time   string `json:"time"`
 			Values struct {
-				Temperature              float64 `json:"temperature"`
-				TemperatureApparent      float64 `json:"temperatureApparent"`
-				Humidity                 int     `json:"humidity"`
-				PrecipitationProbability int     `json:"precipitationProbability"`
-				RainIntensity            float64 `json:"rainIntensity"`
-				WindSpeed                float64 `json:"windSpeed"`
-				WindGust                 float64 `json:"windGust"`
-				WindDirection            int     `json:"windDirection"`
-				CloudCover               int     `json:"cloudCover"`
-				WeatherCode              int     `json:"weatherCode"`
+				Temperature              float64  `json:"temperature"`
+				TemperatureApparent      float64  `json:"temperatureApparent"`
+				Humidity                 int      `json:"humidity"`
+				PrecipitationProbability int      `json:"precipitationProbability"`
+				RainIntensity            float64  `json:"rainIntensity"`
+				WindSpeed                float64  `json:"windSpeed"`
+				WindGust                 float64  `json:"windGust"`
+				WindDirection            int      `json:"windDirection"`
+				CloudCover               int      `json:"cloudCover"`
+				WeatherCode              int      `json:"weatherCode"`
+				EPAIndex                 *int     `json:"epaIndex,omitempty"`
+				ParticulateMatter25      *float64 `json:"particulateMatter25,omitempty"`
 			} `json:"values"`
 		}{
 			Time:   time.Now().Format(time.RFC3339),
@@ -93,11 +219,10 @@ func (ws *WeatherService) buildWeatherResponse(values WeatherValues, location st
 			Name string  `json:"name"`
 			Type string  `json:"type"`
 		}{
-			Lat:  0,
-			Lon:  0,
+			Lat:  lat,
+			Lon:  lon,
 			Name: location,
-			Type: "city",
+			Type: locationType,
 		},
 	}
 }
-
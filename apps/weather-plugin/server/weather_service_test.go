@@ -0,0 +1,189 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestValidateLocation(t *testing.T) {
+	testCases := []struct {
+		location      string
+		expectedError bool
+	}{
+		{location: "London", expectedError: false},
+		{location: "New York", expectedError: false},
+		{location: "40.7128,-74.0060", expectedError: false},
+		{location: "-33.8688, 151.2093", expectedError: false},
+		{location: "", expectedError: true},
+		{location: "   ", expectedError: true},
+		{location: "91,0", expectedError: true},
+		{location: "0,-181", expectedError: true},
+	}
+
+	ws := &WeatherService{}
+	for _, tc := range testCases {
+		t.Run(tc.location, func(t *testing.T) {
+			err := ws.ValidateLocation(tc.location)
+			if tc.expectedError && err == nil {
+				t.Errorf("Expected error for %q", tc.location)
+			}
+			if !tc.expectedError && err != nil {
+				t.Errorf("Unexpected error for %q: %v", tc.location, err)
+			}
+		})
+	}
+}
+
+func TestBuildWeatherResponseCoordinates(t *testing.T) {
+	ws := &WeatherService{}
+	response := ws.buildWeatherResponse(WeatherValues{}, "40.7128,-74.0060")
+
+	if response.Location.Lat != 40.7128 || response.Location.Lon != -74.0060 {
+		t.Errorf("Expected coordinates 40.7128,-74.0060, got %v,%v", response.Location.Lat, response.Location.Lon)
+	}
+	if response.Location.Type != "coordinates" {
+		t.Errorf("Expected location type coordinates, got %s", response.Location.Type)
+	}
+}
+
+func TestWeatherServiceConcurrencyLimit(t *testing.T) {
+	const limit = 2
+	const lookups = 10
+
+	var inFlight, maxInFlight atomic.Int32
+	ws := &WeatherService{
+		weatherData: []WeatherValues{{Temperature: 20, WeatherCode: 1000}},
+		metrics:     NewMetrics(),
+		semaphore:   make(chan struct{}, limit),
+		timeout:     5 * time.Second,
+		onLookup: func() {
+			current := inFlight.Add(1)
+			defer inFlight.Add(-1)
+			for {
+				seen := maxInFlight.Load()
+				if current <= seen || maxInFlight.CompareAndSwap(seen, current) {
+					break
+				}
+			}
+			time.Sleep(20 * time.Millisecond)
+		},
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < lookups; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := ws.GetWeatherData(context.Background(), "London"); err != nil {
+				t.Errorf("GetWeatherData returned error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := maxInFlight.Load(); got != limit {
+		t.Errorf("Expected at most %d lookups in flight and the limit to be reached, got %d", limit, got)
+	}
+}
+
+func TestGetWeatherDataTimesOut(t *testing.T) {
+	const timeout = 100 * time.Millisecond
+	ws := &WeatherService{
+		weatherData: []WeatherValues{{Temperature: 20, WeatherCode: 1000}},
+		metrics:     NewMetrics(),
+		semaphore:   make(chan struct{}, 1),
+		timeout:     timeout,
+	}
+
+	// Hold the only lookup slot, as a hung lookup would
+	if err := ws.acquire(context.Background()); err != nil {
+		t.Fatalf("acquire returned error: %v", err)
+	}
+	defer ws.release()
+
+	start := time.Now()
+	_, err := ws.GetWeatherData(context.Background(), "London")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected context deadline exceeded, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > timeout+time.Second {
+		t.Errorf("Expected lookup to give up after %s, took %s", timeout, elapsed)
+	}
+}
+
+func TestGetWeatherDataStopsWhenContextEnds(t *testing.T) {
+	ws := &WeatherService{
+		weatherData: []WeatherValues{{Temperature: 20, WeatherCode: 1000}},
+		metrics:     NewMetrics(),
+		semaphore:   make(chan struct{}, 1),
+		timeout:     time.Minute,
+	}
+
+	// Hold the only lookup slot so the lookup has to wait for one
+	if err := ws.acquire(context.Background()); err != nil {
+		t.Fatalf("acquire returned error: %v", err)
+	}
+	defer ws.release()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	start := time.Now()
+	_, err := ws.GetWeatherData(ctx, "London")
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected context canceled, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Expected the lookup to stop with the caller's context, took %s", elapsed)
+	}
+}
+
+func TestLookupTimeoutFromEnv(t *testing.T) {
+	testCases := []struct {
+		name     string
+		value    string
+		expected time.Duration
+	}{
+		{name: "unset", value: "", expected: defaultLookupTimeout},
+		{name: "seconds", value: "3", expected: 3 * time.Second},
+		{name: "zero", value: "0", expected: defaultLookupTimeout},
+		{name: "invalid", value: "soon", expected: defaultLookupTimeout},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv("WEATHER_API_TIMEOUT_SECONDS", tc.value)
+			ws := NewWeatherService(t.TempDir(), NewMetrics())
+			if timeout := ws.lookupTimeout(); timeout != tc.expected {
+				t.Errorf("Expected timeout %s, got %s", tc.expected, timeout)
+			}
+		})
+	}
+}
+
+func TestMaxConcurrencyFromEnv(t *testing.T) {
+	testCases := []struct {
+		name     string
+		value    string
+		expected int
+	}{
+		{name: "unset", value: "", expected: defaultMaxConcurrency},
+		{name: "set", value: "8", expected: 8},
+		{name: "zero", value: "0", expected: defaultMaxConcurrency},
+		{name: "negative", value: "-2", expected: defaultMaxConcurrency},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv("WEATHER_MAX_CONCURRENCY", tc.value)
+			ws := NewWeatherService(t.TempDir(), NewMetrics())
+			if concurrency := cap(ws.semaphore); concurrency != tc.expected {
+				t.Errorf("Expected concurrency %d, got %d", tc.expected, concurrency)
+			}
+		})
+	}
+}
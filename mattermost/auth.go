@@ -14,7 +14,7 @@ func (c *Client) Login() error {
 	user, resp, err := c.API.Login(context.Background(), c.AdminUser, c.AdminPass)
 	if err != nil {
 		// If login failed and we're in local environment, try to create the default user
-		if c.Config != nil && c.Config.Environment == "local" && resp != nil && resp.StatusCode == 401 {
+		if !c.DryRun && c.Config != nil && c.Config.Environment == "local" && resp != nil && resp.StatusCode == 401 {
 			Log.WithFields(logrus.Fields{
 				"username": c.AdminUser,
 			}).Info("Default user not found in local environment, attempting to create...")
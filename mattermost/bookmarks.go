@@ -0,0 +1,53 @@
+package mattermost
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/sirupsen/logrus"
+)
+
+// CreateChannelBookmarks adds link bookmarks to the channel. Bookmarks whose link is already
+// bookmarked in the channel are skipped, so setup can be re-run without creating duplicates.
+func (c *Client) CreateChannelBookmarks(teamName, channelName string, bookmarks []BookmarkConfig) error {
+	channel, resp, err := c.API.GetChannelByNameForTeamName(context.Background(), channelName, teamName, "")
+	if err != nil {
+		return handleAPIError(fmt.Sprintf("failed to find channel '%s' in team '%s'", channelName, teamName), err, resp)
+	}
+
+	existing, resp, err := c.API.ListChannelBookmarksForChannel(context.Background(), channel.Id, 0)
+	if err != nil {
+		return handleAPIError(fmt.Sprintf("failed to list bookmarks for channel '%s'", channelName), err, resp)
+	}
+
+	links := make(map[string]bool, len(existing))
+	for _, bookmark := range existing {
+		if bookmark.DeleteAt == 0 {
+			links[bookmark.LinkUrl] = true
+		}
+	}
+
+	for _, bookmark := range bookmarks {
+		if links[bookmark.Link] {
+			Log.WithFields(logrus.Fields{"channel_name": channelName, "bookmark": bookmark.DisplayName}).Debug("⏭️ Bookmark already exists, skipping")
+			continue
+		}
+
+		_, resp, err := c.API.CreateChannelBookmark(context.Background(), &model.ChannelBookmark{
+			ChannelId:   channel.Id,
+			DisplayName: bookmark.DisplayName,
+			LinkUrl:     bookmark.Link,
+			Emoji:       bookmark.Emoji,
+			Type:        model.ChannelBookmarkLink,
+		})
+		if err != nil {
+			return handleAPIError(fmt.Sprintf("failed to add bookmark '%s' to channel '%s'", bookmark.DisplayName, channelName), err, resp)
+		}
+		links[bookmark.Link] = true
+
+		Log.WithFields(logrus.Fields{"channel_name": channelName, "bookmark": bookmark.DisplayName}).Info("🔖 Added channel bookmark")
+	}
+
+	return nil
+}
@@ -0,0 +1,49 @@
+package mattermost
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+// TestCreateChannelBookmarks verifies bookmarks are added as links and existing links are skipped
+func TestCreateChannelBookmarks(t *testing.T) {
+	existing := []*model.ChannelBookmarkWithFileInfo{
+		{ChannelBookmark: &model.ChannelBookmark{Id: "b1", ChannelId: "channel-ops", DisplayName: "Old Name", LinkUrl: "https://grafana.example.com/d/ops"}},
+	}
+	var created []*model.ChannelBookmark
+
+	server := newMockServer()
+	server.handleChannelsByTeamName()
+	server.handle("GET /api/v4/channels/channel-ops/bookmarks", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, existing)
+	})
+	server.handle("POST /api/v4/channels/channel-ops/bookmarks", func(w http.ResponseWriter, r *http.Request) {
+		var bookmark model.ChannelBookmark
+		if !decodeJSON(w, r, &bookmark) {
+			return
+		}
+		created = append(created, &bookmark)
+		writeJSON(w, http.StatusCreated, &model.ChannelBookmarkWithFileInfo{ChannelBookmark: &bookmark})
+	})
+	client := setupMockClient(t, server)
+
+	err := client.CreateChannelBookmarks("demo", "ops", []BookmarkConfig{
+		{DisplayName: "Ops Dashboard", Link: "https://grafana.example.com/d/ops"},
+		{DisplayName: "Runbook", Link: "https://wiki.example.com/runbook", Emoji: "book"},
+		{DisplayName: "Runbook Again", Link: "https://wiki.example.com/runbook"},
+	})
+	if err != nil {
+		t.Fatalf("CreateChannelBookmarks returned error: %v", err)
+	}
+
+	if len(created) != 1 {
+		t.Fatalf("Expected only the new link to be bookmarked, got %d bookmarks", len(created))
+	}
+	bookmark := created[0]
+	if bookmark.ChannelId != "channel-ops" || bookmark.DisplayName != "Runbook" || bookmark.LinkUrl != "https://wiki.example.com/runbook" ||
+		bookmark.Emoji != "book" || bookmark.Type != model.ChannelBookmarkLink {
+		t.Errorf("Unexpected bookmark payload: %+v", bookmark)
+	}
+}
@@ -0,0 +1,143 @@
+package mattermost
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/sirupsen/logrus"
+)
+
+// processBots creates the bot accounts from the import file. Bots that already exist are skipped and
+// a bot that cannot be created is logged and skipped. Access tokens created for bots are kept in
+// BotTokens so EchoLogins can show them, since the server only returns a token when it is created.
+func (c *Client) processBots(bulkImportPath string) error {
+	Log.WithFields(logrus.Fields{"file_path": bulkImportPath}).Info("🤖 Processing bot accounts")
+
+	file, err := os.Open(bulkImportPath)
+	if err != nil {
+		return err
+	}
+	defer closeWithLog(file, "bulk import file")
+
+	var bots []BotImport
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		var botImport BotImport
+		if err := json.Unmarshal([]byte(line), &botImport); err != nil {
+			continue
+		}
+		if botImport.Type == "bot" {
+			bots = append(bots, botImport)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	if len(bots) == 0 {
+		Log.Info("✅ No bot accounts to process")
+		return nil
+	}
+
+	// Bots are owned by the session user until reassigned, so it must be the admin
+	if c.API.AuthToken == "" {
+		if err := c.Login(); err != nil {
+			return err
+		}
+	}
+
+	createdCount := 0
+	errorCount := 0
+	for _, botImport := range bots {
+		created, err := c.ensureBot(botImport)
+		if err != nil {
+			Log.WithFields(logrus.Fields{
+				"bot_username": botImport.Bot.Username,
+				"error":        err.Error(),
+			}).Warn("⚠️ Failed to set up bot account")
+			errorCount++
+			continue
+		}
+		if created {
+			createdCount++
+		}
+	}
+
+	Log.WithFields(logrus.Fields{
+		"created_count": createdCount,
+		"error_count":   errorCount,
+	}).Info("✅ Bot account setup complete")
+	return nil
+}
+
+// ensureBot creates a bot unless a user with its username already exists, assigns its owner and
+// creates an access token when requested. It reports whether the bot was created.
+func (c *Client) ensureBot(botImport BotImport) (bool, error) {
+	ctx := context.Background()
+	username := botImport.Bot.Username
+
+	if user, _, err := c.API.GetUserByUsername(ctx, username, ""); err == nil {
+		if !user.IsBot {
+			Log.WithFields(logrus.Fields{"bot_username": username}).Warn("⚠️ A regular user already has the bot's username, skipping bot")
+		} else {
+			Log.WithFields(logrus.Fields{"bot_username": username}).Debug("⏭️ Bot already exists")
+		}
+		return false, nil
+	}
+
+	bot, resp, err := c.API.CreateBot(ctx, &model.Bot{
+		Username:    username,
+		DisplayName: botImport.Bot.DisplayName,
+		Description: botImport.Bot.Description,
+	})
+	if err != nil {
+		return false, handleAPIError(fmt.Sprintf("failed to create bot '%s'", username), err, resp)
+	}
+	Log.WithFields(logrus.Fields{"bot_username": username}).Info("✅ Created bot account")
+
+	// In dry-run mode the bot was not created, so there is nothing to assign or create a token for
+	if bot.UserId == "" {
+		return true, nil
+	}
+
+	if owner := botImport.Bot.Owner; owner != "" && owner != c.AdminUser {
+		ownerUser, resp, err := c.API.GetUserByUsername(ctx, owner, "")
+		if err != nil {
+			Log.WithFields(logrus.Fields{
+				"bot_username": username,
+				"owner":        owner,
+				"error":        handleAPIError("failed to get owner", err, resp).Error(),
+			}).Warn("⚠️ Bot owner not found, leaving the admin as owner")
+		} else if _, resp, err := c.API.AssignBot(ctx, bot.UserId, ownerUser.Id); err != nil {
+			Log.WithFields(logrus.Fields{
+				"bot_username": username,
+				"owner":        owner,
+				"error":        handleAPIError("failed to assign bot", err, resp).Error(),
+			}).Warn("⚠️ Failed to assign bot owner, leaving the admin as owner")
+		}
+	}
+
+	if botImport.Bot.CreateToken {
+		token, resp, err := c.API.CreateUserAccessToken(ctx, bot.UserId, "Created by demo-kit setup")
+		if err != nil {
+			return true, handleAPIError(fmt.Sprintf("failed to create access token for bot '%s' (is ServiceSettings.EnableUserAccessTokens on?)", username), err, resp)
+		}
+		if c.BotTokens == nil {
+			c.BotTokens = make(map[string]string)
+		}
+		c.BotTokens[username] = token.Token
+	}
+
+	return true, nil
+}
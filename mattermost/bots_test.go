@@ -0,0 +1,70 @@
+package mattermost
+
+import (
+	"net/http"
+	"slices"
+	"testing"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+// TestProcessBots verifies bots are created once, reassigned to their owner and get a token when requested
+func TestProcessBots(t *testing.T) {
+	path := writeScanTestFile(t,
+		`{"type": "bot", "bot": {"username": "weather-bot", "display_name": "Weather", "description": "Posts forecasts", "owner": "alice", "create_token": true}}`,
+		`{"type": "bot", "bot": {"username": "existing-bot", "display_name": "Existing"}}`,
+		`{"type": "bot", "bot": {"username": "ops-bot", "owner": "ghost"}}`,
+	)
+
+	users := map[string]*model.User{
+		"alice":        {Id: "user-alice", Username: "alice"},
+		"existing-bot": {Id: "bot-existing", Username: "existing-bot", IsBot: true},
+	}
+	var bots, assigned, tokens []string
+
+	server := newMockServer()
+	server.handle("GET /api/v4/users/username/{username}", func(w http.ResponseWriter, r *http.Request) {
+		user, ok := users[r.PathValue("username")]
+		if !ok {
+			writeAppError(w, http.StatusNotFound, "app.user.missing_account.const")
+			return
+		}
+		writeJSON(w, http.StatusOK, user)
+	})
+	server.handle("POST /api/v4/bots", func(w http.ResponseWriter, r *http.Request) {
+		var bot model.Bot
+		if !decodeJSON(w, r, &bot) {
+			return
+		}
+		bot.UserId = "bot-" + bot.Username
+		bots = append(bots, bot.Username)
+		writeJSON(w, http.StatusCreated, &bot)
+	})
+	server.handle("POST /api/v4/bots/{bot}/assign/{owner}", func(w http.ResponseWriter, r *http.Request) {
+		assigned = append(assigned, r.PathValue("bot")+"/assign/"+r.PathValue("owner"))
+		writeJSON(w, http.StatusOK, &model.Bot{UserId: r.PathValue("bot"), OwnerId: r.PathValue("owner")})
+	})
+	server.handle("POST /api/v4/users/{user}/tokens", func(w http.ResponseWriter, r *http.Request) {
+		userID := r.PathValue("user")
+		tokens = append(tokens, userID)
+		writeJSON(w, http.StatusOK, &model.UserAccessToken{Id: "token-id", Token: "token-" + userID, UserId: userID})
+	})
+	client := setupMockClient(t, server)
+
+	if err := client.processBots(path); err != nil {
+		t.Fatalf("processBots returned error: %v", err)
+	}
+
+	if !slices.Equal(bots, []string{"weather-bot", "ops-bot"}) {
+		t.Errorf("Expected weather-bot and ops-bot to be created, got %v", bots)
+	}
+	if !slices.Equal(assigned, []string{"bot-weather-bot/assign/user-alice"}) {
+		t.Errorf("Expected only weather-bot to be assigned to alice, got %v", assigned)
+	}
+	if !slices.Equal(tokens, []string{"bot-weather-bot"}) {
+		t.Errorf("Expected a token for weather-bot only, got %v", tokens)
+	}
+	if client.BotTokens["weather-bot"] != "token-bot-weather-bot" || len(client.BotTokens) != 1 {
+		t.Errorf("Expected the weather-bot token to be kept for EchoLogins, got %v", client.BotTokens)
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"io"
 	"net/http"
 	"os"
+	"slices"
 	"strings"
 
 	"github.com/mattermost/mattermost/server/public/model"
@@ -29,7 +30,7 @@ func (c *Client) categorizeChannelAPI(channelID string, channelName string, cate
 	}
 	checkReq.Header.Set("Authorization", "Bearer "+c.API.AuthToken)
 
-	client := &http.Client{}
+	client := c.httpClient()
 	checkResp, err := client.Do(checkReq)
 	if err != nil {
 		return fmt.Errorf("failed to check existing actions: %w", err)
@@ -305,16 +306,48 @@ func (c *Client) processCommands(bulkImportPath string) error {
 	return scanner.Err()
 }
 
-// categorizeChannel categorizes a channel by name
-func (c *Client) categorizeChannel(teamName, channelName, categoryName string) error {
+// ChannelSummary is a channel in a team, as returned by ListTeamChannels
+type ChannelSummary struct {
+	*model.Channel
+}
+
+// ListTeamChannels returns the public and private channels of a team, each channel once
+func (c *Client) ListTeamChannels(teamName string) ([]ChannelSummary, error) {
 	team, resp, err := c.API.GetTeamByName(context.Background(), teamName, "")
 	if err != nil {
-		return handleAPIError(fmt.Sprintf("failed to get team '%s'", teamName), err, resp)
+		return nil, handleAPIError(fmt.Sprintf("failed to get team '%s'", teamName), err, resp)
 	}
 
-	channels, _, _ := c.API.GetPublicChannelsForTeam(context.Background(), team.Id, 0, 1000, "")
-	privateChannels, _, _ := c.API.GetPrivateChannelsForTeam(context.Background(), team.Id, 0, 1000, "")
-	channels = append(channels, privateChannels...)
+	publicChannels, resp, err := c.API.GetPublicChannelsForTeam(context.Background(), team.Id, 0, 1000, "")
+	if err != nil {
+		return nil, handleAPIError(fmt.Sprintf("failed to get public channels for team '%s'", teamName), err, resp)
+	}
+
+	// Private channels are optional, a failure leaves only the public ones
+	privateChannels, _, err := c.API.GetPrivateChannelsForTeam(context.Background(), team.Id, 0, 1000, "")
+	if err != nil {
+		Log.WithFields(logrus.Fields{"team_name": teamName, "error": err.Error()}).Warn("⚠️ Failed to get private channels for team")
+	}
+
+	seen := make(map[string]bool, len(publicChannels)+len(privateChannels))
+	channels := make([]ChannelSummary, 0, len(publicChannels)+len(privateChannels))
+	for _, channel := range append(publicChannels, privateChannels...) {
+		if seen[channel.Id] {
+			continue
+		}
+		seen[channel.Id] = true
+		channels = append(channels, ChannelSummary{Channel: channel})
+	}
+
+	return channels, nil
+}
+
+// categorizeChannel categorizes a channel by name
+func (c *Client) categorizeChannel(teamName, channelName, categoryName string) error {
+	channels, err := c.ListTeamChannels(teamName)
+	if err != nil {
+		return err
+	}
 
 	for _, channel := range channels {
 		if channel.Name == channelName {
@@ -327,15 +360,11 @@ func (c *Client) categorizeChannel(teamName, channelName, categoryName string) e
 
 // setChannelBanner sets a banner for a channel by name
 func (c *Client) setChannelBanner(teamName, channelName, text, backgroundColor string, enabled bool) error {
-	team, resp, err := c.API.GetTeamByName(context.Background(), teamName, "")
+	channels, err := c.ListTeamChannels(teamName)
 	if err != nil {
-		return handleAPIError(fmt.Sprintf("failed to get team '%s'", teamName), err, resp)
+		return err
 	}
 
-	channels, _, _ := c.API.GetPublicChannelsForTeam(context.Background(), team.Id, 0, 1000, "")
-	privateChannels, _, _ := c.API.GetPrivateChannelsForTeam(context.Background(), team.Id, 0, 1000, "")
-	channels = append(channels, privateChannels...)
-
 	for _, channel := range channels {
 		if channel.Name == channelName {
 			return c.setChannelBannerAPI(channel.Id, channel.Name, text, backgroundColor, enabled)
@@ -347,15 +376,11 @@ func (c *Client) setChannelBanner(teamName, channelName, text, backgroundColor s
 
 // executeCommand executes a command in a channel
 func (c *Client) executeCommand(teamName, channelName, commandText string) error {
-	team, resp, err := c.API.GetTeamByName(context.Background(), teamName, "")
+	channels, err := c.ListTeamChannels(teamName)
 	if err != nil {
-		return handleAPIError(fmt.Sprintf("failed to get team '%s'", teamName), err, resp)
+		return err
 	}
 
-	channels, _, _ := c.API.GetPublicChannelsForTeam(context.Background(), team.Id, 0, 1000, "")
-	privateChannels, _, _ := c.API.GetPrivateChannelsForTeam(context.Background(), team.Id, 0, 1000, "")
-	channels = append(channels, privateChannels...)
-
 	for _, channel := range channels {
 		if channel.Name == channelName {
 			_, resp, err := c.API.ExecuteCommand(context.Background(), channel.Id, commandText)
@@ -364,4 +389,167 @@ func (c *Client) executeCommand(teamName, channelName, commandText string) error
 	}
 
 	return fmt.Errorf("channel '%s' not found in team '%s'", channelName, teamName)
-}
\ No newline at end of file
+}
+// defaultChannelBannerColor is used when a config channel has banner text but no banner color
+const defaultChannelBannerColor = "#0066CC"
+
+// setupConfigChannels creates the channels from the config's channels list and sets their banners.
+// Channels that already exist are kept as they are, apart from the banner.
+func (c *Client) setupConfigChannels() error {
+	if c.Config == nil || len(c.Config.Channels) == 0 {
+		return nil
+	}
+
+	Log.WithFields(logrus.Fields{"channel_count": len(c.Config.Channels)}).Info("📺 Setting up config channels")
+
+	for _, channelConfig := range c.Config.Channels {
+		teamName := channelConfig.Team
+		if teamName == "" {
+			teamName = c.Config.DefaultTeam
+		}
+
+		team, resp, err := c.API.GetTeamByName(context.Background(), teamName, "")
+		if err != nil {
+			return handleAPIError(fmt.Sprintf("failed to get team '%s'", teamName), err, resp)
+		}
+
+		channel, resp, err := c.API.CreateChannel(context.Background(), &model.Channel{
+			TeamId:      team.Id,
+			Name:        channelConfig.Name,
+			DisplayName: channelConfig.DisplayName,
+			Purpose:     channelConfig.Purpose,
+			Header:      channelConfig.Header,
+			Type:        model.ChannelTypeOpen,
+		})
+		if err != nil {
+			if resp == nil || resp.StatusCode != http.StatusBadRequest {
+				return handleAPIError(fmt.Sprintf("failed to create channel '%s' in team '%s'", channelConfig.Name, teamName), err, resp)
+			}
+
+			// A 400 means the channel already exists, so look it up to set the banner
+			Log.WithFields(logrus.Fields{"channel_name": channelConfig.Name, "team_name": teamName}).Info("⏭️ Channel already exists, skipping creation")
+			channel, resp, err = c.API.GetChannelByName(context.Background(), channelConfig.Name, team.Id, "")
+			if err != nil {
+				return handleAPIError(fmt.Sprintf("failed to find channel '%s' in team '%s'", channelConfig.Name, teamName), err, resp)
+			}
+		} else {
+			Log.WithFields(logrus.Fields{"channel_name": channel.Name, "team_name": teamName}).Info("✅ Created channel")
+		}
+
+		if channelConfig.BannerText != "" {
+			color := channelConfig.BannerColor
+			if color == "" {
+				color = defaultChannelBannerColor
+			}
+			if err := c.setChannelBannerAPI(channel.Id, channel.Name, channelConfig.BannerText, color, true); err != nil {
+				Log.WithFields(logrus.Fields{
+					"channel_name": channel.Name,
+					"team_name":    teamName,
+					"error":        err.Error(),
+				}).Warn("⚠️ Failed to set channel banner")
+			}
+		}
+
+		if len(channelConfig.Bookmarks) > 0 {
+			if err := c.CreateChannelBookmarks(teamName, channel.Name, channelConfig.Bookmarks); err != nil {
+				Log.WithFields(logrus.Fields{
+					"channel_name": channel.Name,
+					"team_name":    teamName,
+					"error":        err.Error(),
+				}).Warn("⚠️ Failed to add channel bookmarks")
+			}
+		}
+	}
+
+	return nil
+}
+
+// channelMembersPerPage is the page size used when listing channel members
+const channelMembersPerPage = 200
+
+// SetDefaultChannelMemberRole gives every member of the channel the role, promoting members to
+// channel_admin or demoting them to channel_user as needed. Members that already have the role and
+// guests are left alone, so it is safe to run again after more members join.
+func (c *Client) SetDefaultChannelMemberRole(teamName, channelName, role string) error {
+	if role != model.ChannelUserRoleId && role != model.ChannelAdminRoleId {
+		return fmt.Errorf("invalid channel role '%s', must be '%s' or '%s'", role, model.ChannelUserRoleId, model.ChannelAdminRoleId)
+	}
+	wantAdmin := role == model.ChannelAdminRoleId
+	roles := model.ChannelUserRoleId
+	if wantAdmin {
+		roles = model.ChannelUserRoleId + " " + model.ChannelAdminRoleId
+	}
+
+	ctx := context.Background()
+	team, resp, err := c.API.GetTeamByName(ctx, teamName, "")
+	if err != nil {
+		return handleAPIError(fmt.Sprintf("failed to get team '%s'", teamName), err, resp)
+	}
+
+	channel, resp, err := c.API.GetChannelByName(ctx, channelName, team.Id, "")
+	if err != nil {
+		return handleAPIError(fmt.Sprintf("failed to find channel '%s' in team '%s'", channelName, teamName), err, resp)
+	}
+
+	updatedCount := 0
+	for page := 0; ; page++ {
+		members, resp, err := c.API.GetChannelMembers(ctx, channel.Id, page, channelMembersPerPage, "")
+		if err != nil {
+			return handleAPIError(fmt.Sprintf("failed to list members of channel '%s'", channelName), err, resp)
+		}
+
+		for _, member := range members {
+			memberRoles := strings.Fields(member.Roles)
+			if slices.Contains(memberRoles, model.ChannelGuestRoleId) || slices.Contains(memberRoles, model.ChannelAdminRoleId) == wantAdmin {
+				continue
+			}
+
+			if resp, err := c.API.UpdateChannelRoles(ctx, channel.Id, member.UserId, roles); err != nil {
+				return handleAPIError(fmt.Sprintf("failed to update roles of user '%s' in channel '%s'", member.UserId, channelName), err, resp)
+			}
+			updatedCount++
+		}
+
+		if len(members) < channelMembersPerPage {
+			break
+		}
+	}
+
+	Log.WithFields(logrus.Fields{
+		"channel_name":  channelName,
+		"team_name":     teamName,
+		"role":          role,
+		"updated_count": updatedCount,
+	}).Info("✅ Set channel member roles")
+	return nil
+}
+
+// applyChannelDefaultRoles sets the member roles of the team config channels that have a default_role.
+// A channel that fails is logged and skipped.
+func (c *Client) applyChannelDefaultRoles() error {
+	if c.Config == nil {
+		return nil
+	}
+
+	for key, team := range c.Config.Teams {
+		teamName := team.Name
+		if teamName == "" {
+			teamName = key
+		}
+
+		for _, channelConfig := range team.Channels {
+			if channelConfig.DefaultRole == "" {
+				continue
+			}
+			if err := c.SetDefaultChannelMemberRole(teamName, channelConfig.Name, channelConfig.DefaultRole); err != nil {
+				Log.WithFields(logrus.Fields{
+					"channel_name": channelConfig.Name,
+					"team_name":    teamName,
+					"error":        err.Error(),
+				}).Warn("⚠️ Failed to set channel member roles")
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,195 @@
+package mattermost
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+// TestSetupConfigChannels verifies config channels are created, existing ones skipped and banners applied to both
+func TestSetupConfigChannels(t *testing.T) {
+	var created []*model.Channel
+	patched := make(map[string]*model.ChannelPatch)
+
+	server := newMockServer()
+	server.handleTeamsByName()
+	server.handle("POST /api/v4/channels", func(w http.ResponseWriter, r *http.Request) {
+		var channel model.Channel
+		if !decodeJSON(w, r, &channel) {
+			return
+		}
+		if channel.Name == "ops-alerts" {
+			writeAppError(w, http.StatusBadRequest, "store.sql_channel.save_channel.exists.app_error")
+			return
+		}
+		channel.Id = "channel-" + channel.Name
+		created = append(created, &channel)
+		writeJSON(w, http.StatusCreated, &channel)
+	})
+	server.handle("GET /api/v4/teams/{team}/channels/name/{channel}", func(w http.ResponseWriter, r *http.Request) {
+		name := r.PathValue("channel")
+		writeJSON(w, http.StatusOK, &model.Channel{Id: "channel-" + name, Name: name, TeamId: r.PathValue("team")})
+	})
+	server.handle("PUT /api/v4/channels/{channel}/patch", func(w http.ResponseWriter, r *http.Request) {
+		var patch model.ChannelPatch
+		if !decodeJSON(w, r, &patch) {
+			return
+		}
+		patched[r.PathValue("channel")] = &patch
+		writeJSON(w, http.StatusOK, &model.Channel{})
+	})
+	client := setupMockClient(t, server)
+	client.Config = &Config{
+		DefaultTeam: "demo",
+		Channels: []SetupChannelConfig{
+			{Name: "briefing", DisplayName: "Briefing", Team: "ops", Purpose: "Daily briefings", Header: "Read before flight"},
+			{Name: "ops-alerts", DisplayName: "Ops Alerts", BannerText: "Alerts only", BannerColor: "#FF0000"},
+			{Name: "weather", DisplayName: "Weather", BannerText: "Live feed"},
+		},
+	}
+
+	if err := client.setupConfigChannels(); err != nil {
+		t.Fatalf("setupConfigChannels returned error: %v", err)
+	}
+
+	if len(created) != 2 {
+		t.Fatalf("Expected two channels to be created, got %d", len(created))
+	}
+	if channel := created[0]; channel.TeamId != "team-ops" || channel.Purpose != "Daily briefings" || channel.Header != "Read before flight" || channel.Type != model.ChannelTypeOpen {
+		t.Errorf("Unexpected channel payload: %+v", channel)
+	}
+	if created[1].TeamId != "team-demo" {
+		t.Errorf("Expected channel without a team to use the default team, got %q", created[1].TeamId)
+	}
+
+	if len(patched) != 2 {
+		t.Fatalf("Expected banners on two channels, got %d", len(patched))
+	}
+	if banner := patched["channel-ops-alerts"].BannerInfo; *banner.Text != "Alerts only" || *banner.BackgroundColor != "#FF0000" || !*banner.Enabled {
+		t.Errorf("Unexpected banner for existing channel: %+v", banner)
+	}
+	if banner := patched["channel-weather"].BannerInfo; *banner.BackgroundColor != defaultChannelBannerColor {
+		t.Errorf("Expected the default banner color, got %q", *banner.BackgroundColor)
+	}
+}
+
+// TestListTeamChannelsDeduplicates verifies channels returned by both the public and private lists appear once
+func TestListTeamChannelsDeduplicates(t *testing.T) {
+	public := []*model.Channel{
+		{Id: "c1", Name: "general", Type: model.ChannelTypeOpen},
+		{Id: "c2", Name: "ops", Type: model.ChannelTypeOpen},
+	}
+	private := []*model.Channel{
+		{Id: "c2", Name: "ops", Type: model.ChannelTypeOpen},
+		{Id: "c3", Name: "command-staff", Type: model.ChannelTypePrivate},
+	}
+	var executed []string
+
+	server := newMockServer()
+	server.handleTeamsByName()
+	server.handle("GET /api/v4/teams/team-demo/channels", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, public)
+	})
+	server.handle("GET /api/v4/teams/team-demo/channels/private", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, private)
+	})
+	server.handle("POST /api/v4/commands/execute", func(w http.ResponseWriter, r *http.Request) {
+		var command model.CommandArgs
+		if !decodeJSON(w, r, &command) {
+			return
+		}
+		executed = append(executed, command.ChannelId+" "+command.Command)
+		writeJSON(w, http.StatusOK, &model.CommandResponse{})
+	})
+	client := setupMockClient(t, server)
+
+	channels, err := client.ListTeamChannels("demo")
+	if err != nil {
+		t.Fatalf("ListTeamChannels returned error: %v", err)
+	}
+
+	names := make([]string, 0, len(channels))
+	for _, channel := range channels {
+		names = append(names, channel.Name)
+	}
+	if strings.Join(names, ",") != "general,ops,command-staff" {
+		t.Errorf("Expected each channel once, got %v", names)
+	}
+
+	if err := client.executeCommand("demo", "command-staff", "/weather London"); err != nil {
+		t.Fatalf("executeCommand returned error: %v", err)
+	}
+	if len(executed) != 1 || executed[0] != "c3 /weather London" {
+		t.Errorf("Expected the command to run in the private channel, got %v", executed)
+	}
+
+	if _, err := client.ListTeamChannels("missing"); err == nil {
+		t.Error("Expected an error when the team's channels cannot be listed")
+	}
+}
+
+// newMemberRoleServer answers team, channel and member list lookups and records role updates in updated
+func newMemberRoleServer(members model.ChannelMembers, updated map[string]string) *mockServer {
+	server := newMockServer()
+	server.handleTeamsByName()
+	server.handle("GET /api/v4/teams/team-demo/channels/name/ops", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, &model.Channel{Id: "channel-ops", Name: "ops", TeamId: "team-demo"})
+	})
+	server.handle("GET /api/v4/channels/channel-ops/members", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, members)
+	})
+	server.handle("PUT /api/v4/channels/channel-ops/members/{user}/roles", func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]string
+		if !decodeJSON(w, r, &body) {
+			return
+		}
+		updated[r.PathValue("user")] = body["roles"]
+		writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+	})
+	return server
+}
+
+// TestSetDefaultChannelMemberRole verifies only members without the role are updated and guests are left alone
+func TestSetDefaultChannelMemberRole(t *testing.T) {
+	members := model.ChannelMembers{
+		{UserId: "user-1", Roles: "channel_user"},
+		{UserId: "user-2", Roles: "channel_user channel_admin"},
+		{UserId: "user-3", Roles: "channel_guest"},
+	}
+
+	testCases := []struct {
+		name     string
+		role     string
+		expected map[string]string
+	}{
+		{name: "promote to admin", role: model.ChannelAdminRoleId, expected: map[string]string{"user-1": "channel_user channel_admin"}},
+		{name: "demote to user", role: model.ChannelUserRoleId, expected: map[string]string{"user-2": "channel_user"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			updated := make(map[string]string)
+			client := setupMockClient(t, newMemberRoleServer(members, updated))
+
+			if err := client.SetDefaultChannelMemberRole("demo", "ops", tc.role); err != nil {
+				t.Fatalf("SetDefaultChannelMemberRole returned error: %v", err)
+			}
+
+			if len(updated) != len(tc.expected) {
+				t.Fatalf("Expected updates %v, got %v", tc.expected, updated)
+			}
+			for userID, roles := range tc.expected {
+				if updated[userID] != roles {
+					t.Errorf("Expected %s to get roles %q, got %q", userID, roles, updated[userID])
+				}
+			}
+		})
+	}
+
+	client := setupMockClient(t, newMemberRoleServer(nil, make(map[string]string)))
+	if err := client.SetDefaultChannelMemberRole("demo", "ops", "team_admin"); err == nil {
+		t.Error("Expected an invalid role to be rejected")
+	}
+}
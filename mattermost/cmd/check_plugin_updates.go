@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/coltoneshaw/demokit/mattermost"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+// checkPluginUpdatesImportFile is the import file whose GitHub plugins are checked
+var checkPluginUpdatesImportFile string
+
+// checkPluginUpdatesCmd represents the check-plugin-updates command
+var checkPluginUpdatesCmd = &cobra.Command{
+	Use:   "check-plugin-updates",
+	Short: "Show which GitHub plugins have a newer release than the installed version",
+	Long: `Compare the installed version of each GitHub plugin in the import file with the
+latest release of its repository and print a table of the results.
+
+Plugins pinned to a version in the import file are not checked. Nothing is installed;
+run setup --check-updates to install the newer versions.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		client := newServerConfigClient()
+		if checkPluginUpdatesImportFile != "" {
+			client.BulkImportPath = checkPluginUpdatesImportFile
+		}
+
+		updates, err := client.PluginManager.CheckForUpdates()
+		if err != nil {
+			mattermost.Log.WithFields(logrus.Fields{
+				"error": err.Error(),
+			}).Warn("⚠️ Failed to check some plugins for updates")
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		_, _ = fmt.Fprintln(w, "PLUGIN\tINSTALLED\tLATEST\tUPDATE")
+		for _, update := range updates {
+			installed := update.InstalledVersion
+			if installed == "" {
+				installed = "not installed"
+			}
+			needsUpdate := "no"
+			if update.NeedsUpdate {
+				needsUpdate = "yes"
+			}
+			_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", update.PluginID, installed, update.LatestVersion, needsUpdate)
+		}
+		_ = w.Flush()
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(checkPluginUpdatesCmd)
+
+	checkPluginUpdatesCmd.Flags().StringVar(&checkPluginUpdatesImportFile, "import-file", "", "Check the plugins in a custom JSONL import file instead of bulk_import.jsonl")
+}
@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"github.com/coltoneshaw/demokit/mattermost"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+// exportPostsPerChannel is the number of most recent posts exported from each channel
+var exportPostsPerChannel int
+
+// exportCmd represents the export command
+var exportCmd = &cobra.Command{
+	Use:   "export [path]",
+	Short: "Write the teams, channels, users and recent posts on the server to an import file",
+	Long: `Write the current server state to a JSONL file that setup can import, so a demo
+curated by hand on one server can be reproduced on another.
+
+Teams, channels, users with their team and channel memberships, and the most recent
+posts of each channel are exported, along with channel-category entries for channels
+categorized through Playbooks and channel-banner entries for channels with a banner.
+Bots, deactivated users, system messages and file attachments are left out, and
+passwords cannot be exported. The file is written to export.jsonl by default:
+
+  mmsetup export demo.jsonl --posts-per-channel 50`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		path := "export.jsonl"
+		if len(args) > 0 {
+			path = args[0]
+		}
+
+		client := newServerConfigClient()
+		client.ExportPostsPerChannel = exportPostsPerChannel
+
+		if err := client.Export(path); err != nil {
+			mattermost.Log.WithFields(logrus.Fields{
+				"path":  path,
+				"error": err.Error(),
+			}).Fatal("❌ Export failed")
+		}
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(exportCmd)
+
+	exportCmd.Flags().IntVar(&exportPostsPerChannel, "posts-per-channel", mattermost.DefaultExportPostsPerChannel, "Number of most recent posts to export from each channel")
+}
@@ -56,7 +56,9 @@ func showConfigHelp() {
           "displayName": "General",
           "purpose": "General discussion",
           "type": "O",
-          "members": ["admin-user", "regular-user"]
+          "members": ["admin-user", "regular-user"],
+          "groups": ["operators"],
+          "default_role": "channel_user"
         }
       ]
     },
@@ -65,8 +67,38 @@ func showConfigHelp() {
       "displayName": "My Second Team",
       "type": "O"
     }
-  }
+  },
+  "channels": [
+    {
+      "name": "ops-alerts",
+      "display_name": "Ops Alerts",
+      "team": "team1",
+      "purpose": "Operational alerts",
+      "header": "Alerts only, discuss in general",
+      "banner_text": "This channel is monitored 24/7",
+      "banner_color": "#FF0000",
+      "bookmarks": [
+        {"display_name": "Ops Dashboard", "link": "https://grafana.example.com/d/ops", "emoji": "bar_chart"}
+      ]
+    }
+  ]
 }`)
+	fmt.Println("\nTeam channel groups:")
+	fmt.Println("  \"groups\" on a team channel lists LDAP groups (by name) linked to the channel during setup --ldap,")
+	fmt.Println("  so their members are added to it by the LDAP sync. Groups already linked to the channel are skipped.")
+	fmt.Println("\nTeam channel default role:")
+	fmt.Println("  \"default_role\" on a team channel (\"channel_user\" or \"channel_admin\") is given to every member")
+	fmt.Println("  after users are imported. Members that already have it, and guests, are left alone.")
+	fmt.Println("\nChannels:")
+	fmt.Println("  The top-level \"channels\" list creates public channels after the bulk import.")
+	fmt.Println("  name, display_name   Required channel name and display name")
+	fmt.Println("  team                 Team to create the channel in (defaults to default_team)")
+	fmt.Println("  purpose, header      Optional channel purpose and header")
+	fmt.Println("  banner_text          Optional banner shown at the top of the channel")
+	fmt.Println("  banner_color         Banner background color (defaults to #0066CC)")
+	fmt.Println("  bookmarks            Optional links (display_name, link, emoji) added to the bookmarks bar")
+	fmt.Println("  Channels that already exist are skipped, but their banner and bookmarks are still set.")
+	fmt.Println("  Bookmarks whose link is already bookmarked in the channel are skipped.")
 	fmt.Println("\nPlace this file in the root directory or specify a custom path with --config flag.")
 	fmt.Println("\nUsage examples:")
 	fmt.Println("  # Setup against local server (default)")
@@ -42,6 +42,17 @@ The reset operation requires the Mattermost server to have the following setting
 		client := mattermost.NewClient(config.Server, config.AdminUsername, config.AdminPassword, config.DefaultTeam, configPath)
 		client.Config = config
 
+		// Nothing is deleted in dry-run mode, so skip the confirmation prompt
+		if dryRun {
+			client.EnableDryRun(os.Stdout)
+			if err := client.Reset(); err != nil {
+				mattermost.Log.WithFields(logrus.Fields{
+					"error": err.Error(),
+				}).Fatal("Reset dry run failed")
+			}
+			return
+		}
+
 		// Load the bulk import data to show what will be deleted
 		data, err := client.LoadBulkImportData()
 		if err != nil {
@@ -92,4 +103,6 @@ The reset operation requires the Mattermost server to have the following setting
 
 func init() {
 	RootCmd.AddCommand(resetCmd)
+
+	resetCmd.Flags().BoolVar(&dryRun, "dry-run", false, "Print the API calls that would delete data without sending them")
 }
\ No newline at end of file
@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"strings"
+
+	"github.com/coltoneshaw/demokit/mattermost"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var (
+	// Restore-config flags
+	restoreConfigKeys string
+)
+
+// backupConfigCmd represents the backup-config command
+var backupConfigCmd = &cobra.Command{
+	Use:   "backup-config <path>",
+	Short: "Save the Mattermost server configuration to a JSON file",
+	Long: `Save the Mattermost server configuration to a JSON file, so it can be kept
+in version control and restored with restore-config.
+
+Secrets such as passwords and keys are masked by the server. Restoring a masked
+value keeps the secret the server already has.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		client := newServerConfigClient()
+
+		if err := client.BackupServerConfig(args[0]); err != nil {
+			mattermost.Log.WithFields(logrus.Fields{
+				"path":  args[0],
+				"error": err.Error(),
+			}).Fatal("❌ Server config backup failed")
+		}
+	},
+}
+
+// restoreConfigCmd represents the restore-config command
+var restoreConfigCmd = &cobra.Command{
+	Use:   "restore-config <path>",
+	Short: "Restore the Mattermost server configuration from a JSON file",
+	Long: `Restore the Mattermost server configuration from a file written by backup-config.
+
+By default the whole configuration is replaced. Use --keys to restore only some
+top-level sections and keep the rest of the current configuration, e.g.:
+
+  mmsetup restore-config config-backup.json --keys ServiceSettings,LdapSettings`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		client := newServerConfigClient()
+
+		var keys []string
+		for _, key := range strings.Split(restoreConfigKeys, ",") {
+			if key = strings.TrimSpace(key); key != "" {
+				keys = append(keys, key)
+			}
+		}
+
+		if err := client.RestoreServerConfig(args[0], keys...); err != nil {
+			mattermost.Log.WithFields(logrus.Fields{
+				"path":  args[0],
+				"error": err.Error(),
+			}).Fatal("❌ Server config restore failed")
+		}
+	},
+}
+
+// newServerConfigClient loads config.json and returns a client logged in to the server
+func newServerConfigClient() *mattermost.Client {
+	config, err := mattermost.LoadConfig(configPath)
+	if err != nil {
+		mattermost.Log.WithFields(logrus.Fields{
+			"error": err.Error(),
+			"path":  configPath,
+		}).Fatal("Failed to load config file")
+	}
+
+	client := mattermost.NewClient(config.Server, config.AdminUsername, config.AdminPassword, config.DefaultTeam, configPath)
+	client.Config = config
+
+	if err := client.Login(); err != nil {
+		mattermost.Log.WithFields(logrus.Fields{
+			"error": err.Error(),
+		}).Fatal("Failed to log in to Mattermost")
+	}
+	return client
+}
+
+func init() {
+	RootCmd.AddCommand(backupConfigCmd)
+	RootCmd.AddCommand(restoreConfigCmd)
+
+	restoreConfigCmd.Flags().StringVar(&restoreConfigKeys, "keys", "", "Comma-separated top-level config keys to restore, e.g. ServiceSettings,LdapSettings (default: all)")
+}
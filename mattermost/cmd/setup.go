@@ -1,7 +1,9 @@
 package cmd
 
 import (
+	"context"
 	"os"
+	"time"
 	
 	"github.com/coltoneshaw/demokit/mattermost"
 	"github.com/sirupsen/logrus"
@@ -16,7 +18,19 @@ var (
 	ldapBindDN        string
 	ldapBindPassword  string
 	ldapBaseDN        string
+	ldapTLSMode       string
+	ldapInsecureTLS   bool
+	setupSaml         bool
+	samlMetadataURL   string
 	customImportFile  string
+	dryRun             bool
+	pluginConcurrency  int
+	pluginBuildConcurrency int
+	verifyBeforeImport bool
+	resetOnFailure     bool
+	setupTimeout       time.Duration
+	apiRetries         int
+	skipDMs            bool
 )
 
 // setupCmd represents the setup command
@@ -35,18 +49,33 @@ It uses a two-phase bulk import system (infrastructure first, then users) for op
 
 Import Options:
   --import-file               Use a custom JSONL import file instead of bulk_import.jsonl
+  --dry-run                   Print the API calls that would change the server without sending them
+  --verify-before-import      Verify the import file and abort before importing if it has errors
+                              (default: true, use --verify-before-import=false to skip)
+  --reset-on-failure          Delete the users and teams in the import file if any setup phase fails
+  --timeout                   Deadline for the whole setup, including LDAP (default: 30m)
+  --api-retries               Attempts for API calls that fail with 5xx or connection errors (default: 3)
+  --skip-dms                  Do not send the direct-message entries from the import file
 
 Plugin Options:
   --reinstall-plugins local   Rebuild and redeploy custom local plugins only
   --reinstall-plugins all     Rebuild all plugins and redeploy everything
   --check-updates             Check for and install newer plugin versions from GitHub
+  --plugin-concurrency        Number of GitHub plugins to download at once (default: 3)
+  --plugin-build-concurrency  Number of local plugins to build at once (default: number of CPUs)
 
 LDAP Options:
   --ldap                      Setup LDAP directory and migrate existing users to LDAP auth
   --ldap-url                  LDAP server URL (default: ldap://localhost:10389)
   --ldap-bind-dn              LDAP admin bind DN (default: cn=admin,dc=planetexpress,dc=com)
   --ldap-bind-password        LDAP admin password (default: GoodNewsEveryone)
-  --ldap-base-dn              LDAP base DN (default: dc=planetexpress,dc=com)`,
+  --ldap-base-dn              LDAP base DN (default: dc=planetexpress,dc=com)
+  --ldap-tls-mode             LDAP connection security: none, tls (ldaps://) or starttls (default: none)
+  --ldap-insecure-skip-verify Skip LDAP server certificate verification (test environments only)
+
+SAML Options:
+  --saml                      Setup SAML authentication from the IdP metadata in config.json (saml.idp_metadata_url)
+  --saml-metadata-url         IdP metadata URL, overrides saml.idp_metadata_url`,
 	Run: func(cmd *cobra.Command, args []string) {
 		// Load the config first
 		config, err := mattermost.LoadConfig(configPath)
@@ -64,6 +93,16 @@ LDAP Options:
 		// Create client using config values
 		client := mattermost.NewClient(config.Server, config.AdminUsername, config.AdminPassword, config.DefaultTeam, configPath)
 		client.Config = config
+		client.PluginConcurrency = pluginConcurrency
+		client.PluginBuildConcurrency = pluginBuildConcurrency
+		client.VerifyBeforeImport = verifyBeforeImport
+		client.ResetOnFailure = resetOnFailure
+		client.RetryPolicy.MaxAttempts = apiRetries
+		client.SkipDirectMessages = skipDMs
+		if dryRun {
+			client.EnableDryRun(os.Stdout)
+			mattermost.Log.Info("Dry run enabled, no changes will be made")
+		}
 
 		// If custom import file is specified, override the default
 		if customImportFile != "" {
@@ -91,12 +130,21 @@ LDAP Options:
 		forceGitHubPlugins := reinstallPlugins == "all"
 		forceAll := false // Data import forcing would need a separate flag
 
-		if err := client.SetupWithForceAndUpdates(forcePlugins, forceGitHubPlugins, forceAll, checkUpdates); err != nil {
+		// The deadline covers the whole setup, including LDAP
+		ctx, cancel := context.WithTimeout(context.Background(), setupTimeout)
+		defer cancel()
+
+		if err := client.SetupWithForceAndUpdates(ctx, forcePlugins, forceGitHubPlugins, forceAll, checkUpdates); err != nil {
 			mattermost.Log.WithFields(logrus.Fields{
 				"error": err.Error(),
 			}).Fatal("Setup failed")
 		}
 
+		// Bot access tokens can only be shown by the run that created them, so show them with the webhook URLs
+		if len(client.BotTokens) > 0 || len(client.WebhookURLs) > 0 {
+			client.EchoLogins()
+		}
+
 		// Setup LDAP if requested
 		if setupLdap {
 			// Load LDAP configuration from config file and CLI flags
@@ -107,12 +155,30 @@ LDAP Options:
 				}).Fatal("Failed to build LDAP configuration")
 			}
 
-			if err := client.SetupLDAPWithConfig(ldapConfig); err != nil {
+			if err := client.SetupLDAPWithConfig(ctx, ldapConfig); err != nil {
 				mattermost.Log.WithFields(logrus.Fields{
 					"error": err.Error(),
 				}).Fatal("LDAP setup failed")
 			}
 		}
+
+		// Setup SAML if requested
+		if setupSaml {
+			// Load SAML configuration from config file and CLI flags
+			samlConfig := client.Config.SAML
+			if samlMetadataURL != "" {
+				samlConfig.IdpMetadataURL = samlMetadataURL
+			}
+			if samlConfig.IdpMetadataURL == "" {
+				mattermost.Log.Fatal("SAML setup requires --saml-metadata-url or saml.idp_metadata_url in config.json")
+			}
+
+			if err := client.SetupSAMLWithConfig(&samlConfig); err != nil {
+				mattermost.Log.WithFields(logrus.Fields{
+					"error": err.Error(),
+				}).Fatal("SAML setup failed")
+			}
+		}
 	},
 }
 
@@ -129,6 +195,8 @@ func buildLDAPConfig(config *mattermost.Config) (*mattermost.LDAPConfig, error)
 		ldapConfig.BaseDN = config.LDAP.BaseDN
 		ldapConfig.SchemaBindDN = config.LDAP.SchemaBindDN
 		ldapConfig.SchemaPassword = config.LDAP.SchemaPassword
+		ldapConfig.TLSMode = config.LDAP.TLSMode
+		ldapConfig.InsecureSkipVerify = config.LDAP.InsecureSkipVerify
 	}
 
 	// Override with CLI flags if provided
@@ -144,6 +212,12 @@ func buildLDAPConfig(config *mattermost.Config) (*mattermost.LDAPConfig, error)
 	if ldapBaseDN != "" {
 		ldapConfig.BaseDN = ldapBaseDN
 	}
+	if ldapTLSMode != "" {
+		ldapConfig.TLSMode = ldapTLSMode
+	}
+	if ldapInsecureTLS {
+		ldapConfig.InsecureSkipVerify = true
+	}
 
 	// Set defaults for required fields if still empty
 	if ldapConfig.URL == "" {
@@ -173,12 +247,32 @@ func init() {
 	
 	// Add the import file flag
 	setupCmd.Flags().StringVar(&customImportFile, "import-file", "", "Use a custom JSONL import file instead of bulk_import.jsonl")
+
+	// Add the dry-run flag
+	setupCmd.Flags().BoolVar(&dryRun, "dry-run", false, "Print the API calls that would change the server without sending them")
+
+	// Add the verify-before-import flag
+	setupCmd.Flags().BoolVar(&verifyBeforeImport, "verify-before-import", true, "Verify the import file and abort before importing if it has errors")
+
+	// Add the reset-on-failure flag
+	setupCmd.Flags().BoolVar(&resetOnFailure, "reset-on-failure", false, "Delete the users and teams in the import file if any setup phase fails")
+
+	// Add the timeout flag
+	setupCmd.Flags().DurationVar(&setupTimeout, "timeout", mattermost.DefaultSetupTimeout, "Deadline for the whole setup, including LDAP (e.g. 45m, 1h)")
+
+	// Add the api-retries flag
+	setupCmd.Flags().IntVar(&apiRetries, "api-retries", mattermost.DefaultRetryPolicy.MaxAttempts, "Attempts for API calls that fail with 5xx or connection errors")
+
+	// Add the skip-dms flag
+	setupCmd.Flags().BoolVar(&skipDMs, "skip-dms", false, "Do not send the direct-message entries from the import file")
 	
 	// Add the reinstall-plugins flag
 	setupCmd.Flags().StringVar(&reinstallPlugins, "reinstall-plugins", "", "Plugin reinstall options: 'local' (rebuild custom plugins only), 'all' (rebuild all plugins)")
 	
 	// Add the check-updates flag
 	setupCmd.Flags().BoolVar(&checkUpdates, "check-updates", false, "Check for and install newer plugin versions from GitHub")
+	setupCmd.Flags().IntVar(&pluginConcurrency, "plugin-concurrency", mattermost.DefaultPluginConcurrency, "Number of GitHub plugins to download at once")
+	setupCmd.Flags().IntVar(&pluginBuildConcurrency, "plugin-build-concurrency", 0, "Number of local plugins to build at once (0 uses the number of CPUs)")
 	
 	// Add the ldap flags
 	setupCmd.Flags().BoolVar(&setupLdap, "ldap", false, "Setup LDAP directory and migrate existing users to LDAP auth")
@@ -186,4 +280,10 @@ func init() {
 	setupCmd.Flags().StringVar(&ldapBindDN, "ldap-bind-dn", "", "LDAP admin bind DN (default: cn=admin,dc=planetexpress,dc=com)")
 	setupCmd.Flags().StringVar(&ldapBindPassword, "ldap-bind-password", "", "LDAP admin password (default: GoodNewsEveryone)")
 	setupCmd.Flags().StringVar(&ldapBaseDN, "ldap-base-dn", "", "LDAP base DN (default: dc=planetexpress,dc=com)")
+	setupCmd.Flags().StringVar(&ldapTLSMode, "ldap-tls-mode", "", "LDAP connection security: none, tls (ldaps://) or starttls (default: none)")
+	setupCmd.Flags().BoolVar(&ldapInsecureTLS, "ldap-insecure-skip-verify", false, "Skip LDAP server certificate verification (test environments only)")
+
+	// Add the saml flags
+	setupCmd.Flags().BoolVar(&setupSaml, "saml", false, "Setup SAML authentication from the IdP metadata in config.json")
+	setupCmd.Flags().StringVar(&samlMetadataURL, "saml-metadata-url", "", "IdP metadata URL, overrides saml.idp_metadata_url")
 }
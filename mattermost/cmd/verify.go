@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"github.com/coltoneshaw/demokit/mattermost"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+// verifyCmd represents the verify command
+var verifyCmd = &cobra.Command{
+	Use:   "verify [import-file]",
+	Short: "Verify a bulk import file without importing it",
+	Long: `Verify a JSONL bulk import file without contacting the Mattermost server.
+
+This command checks every line of the file (bulk_import.jsonl by default) and reports:
+- Lines that are not valid JSON
+- Lines with a missing or unknown type
+- Lines missing the fields required for their type (e.g. a user's username and email)
+- Lines out of import order (e.g. a user after the first post), which would not be imported
+- Channels, users and posts naming a team or channel the file does not define
+- Posts and replies by users the file does not define
+
+All problems are reported together, by line number, so they can be fixed in one pass.
+Setup runs the same checks before importing unless --verify-before-import=false is given.`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		client := mattermost.NewClient("", "", "", "", configPath)
+
+		importFile := client.BulkImportPath
+		if len(args) == 1 {
+			importFile = args[0]
+		}
+
+		if err := client.VerifyBulkImport(importFile); err != nil {
+			mattermost.Log.WithFields(logrus.Fields{
+				"file":  importFile,
+				"error": err.Error(),
+			}).Fatal("❌ Bulk import file is invalid")
+		}
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(verifyCmd)
+}
@@ -1,6 +1,8 @@
 package cmd
 
 import (
+	"context"
+
 	"github.com/coltoneshaw/demokit/mattermost"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
@@ -14,7 +16,10 @@ var waitForStartCmd = &cobra.Command{
 
 This command polls the Mattermost server's ping endpoint until it responds 
 successfully or times out. Useful for automation scripts that need to wait
-for the server to be ready before proceeding.`,
+for the server to be ready before proceeding.
+
+Set MM_WAIT_TIMEOUT (default: 120s) and MM_WAIT_INTERVAL (default: 1s) to change
+how long to wait and how often to ping, e.g. MM_WAIT_TIMEOUT=10m for cold CI containers.`,
 	Run: func(cmd *cobra.Command, args []string) {
 		// Load the config first
 		config, err := mattermost.LoadConfig(configPath)
@@ -29,7 +34,7 @@ for the server to be ready before proceeding.`,
 		client := mattermost.NewClient(config.Server, config.AdminUsername, config.AdminPassword, config.DefaultTeam, configPath)
 		client.Config = config
 
-		if err := client.WaitForStart(); err != nil {
+		if err := client.WaitForStart(context.Background()); err != nil {
 			mattermost.Log.WithFields(logrus.Fields{
 				"error": err.Error(),
 				"server": config.Server,
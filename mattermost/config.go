@@ -2,11 +2,14 @@ package mattermost
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"net/mail"
 	"os"
 	"path/filepath"
 	"strings"
 
+	"github.com/mattermost/mattermost/server/public/model"
 	"github.com/sirupsen/logrus"
 	
 	ldapPkg "github.com/coltoneshaw/demokit/mattermost/ldap"
@@ -78,6 +81,15 @@ type Config struct {
 
 	// LDAP contains LDAP server configuration
 	LDAP LDAPConfigFile `json:"ldap,omitempty"`
+
+	// Channels is an optional list of channels created during setup with a purpose, header and banner
+	Channels []SetupChannelConfig `json:"channels,omitempty"`
+
+	// Webhooks is an optional map of logical names to incoming webhooks created during setup
+	Webhooks map[string]WebhookConfig `json:"webhooks,omitempty"`
+
+	// SAML contains the SAML identity provider configuration
+	SAML SAMLConfig `json:"saml,omitempty"`
 }
 
 // ChannelConfig represents the configuration for a Mattermost channel
@@ -105,6 +117,12 @@ type ChannelConfig struct {
 
 	// Category is an optional category to add the channel to
 	Category string `json:"category,omitempty"`
+
+	// Groups is a list of LDAP group names linked to this channel for automatic membership
+	Groups []string `json:"groups,omitempty"`
+
+	// DefaultRole is the role members are given after they join: "channel_user" or "channel_admin"
+	DefaultRole string `json:"default_role,omitempty"`
 }
 
 // TeamConfig represents the configuration for a Mattermost team
@@ -125,6 +143,69 @@ type TeamConfig struct {
 	Channels []ChannelConfig `json:"channels,omitempty"`
 }
 
+// SetupChannelConfig represents a channel from the top-level channels list, created after the bulk import
+type SetupChannelConfig struct {
+	// Name is the channel name (no spaces, lowercase)
+	Name string `json:"name"`
+
+	// DisplayName is the human-readable channel name
+	DisplayName string `json:"display_name"`
+
+	// Team is the team the channel belongs to (defaults to default_team)
+	Team string `json:"team,omitempty"`
+
+	// Purpose is an optional channel purpose description
+	Purpose string `json:"purpose,omitempty"`
+
+	// Header is an optional channel header text
+	Header string `json:"header,omitempty"`
+
+	// BannerText is optional text shown in a banner at the top of the channel
+	BannerText string `json:"banner_text,omitempty"`
+
+	// BannerColor is the banner background color (defaults to #0066CC)
+	BannerColor string `json:"banner_color,omitempty"`
+
+	// Bookmarks is an optional list of links added to the channel's bookmarks bar
+	Bookmarks []BookmarkConfig `json:"bookmarks,omitempty"`
+}
+
+// BookmarkConfig represents a link bookmark to add to a channel during setup
+type BookmarkConfig struct {
+	// DisplayName is the bookmark label shown in the bookmarks bar
+	DisplayName string `json:"display_name"`
+
+	// Link is the URL the bookmark opens
+	Link string `json:"link"`
+
+	// Emoji is an optional emoji name shown next to the label (e.g., "chart_with_upwards_trend")
+	Emoji string `json:"emoji,omitempty"`
+}
+
+// WebhookConfig represents an incoming webhook to create during setup
+type WebhookConfig struct {
+	// Team is the team that owns the channel (defaults to default_team)
+	Team string `json:"team,omitempty"`
+
+	// Channel is the name of the channel the webhook posts to
+	Channel string `json:"channel"`
+
+	// DisplayName is the webhook name shown in the integrations list
+	DisplayName string `json:"display_name"`
+
+	// IconURL is an optional profile picture for posts made through the webhook
+	IconURL string `json:"icon_url,omitempty"`
+}
+
+// SAMLConfig represents the SAML identity provider used by setup --saml
+type SAMLConfig struct {
+	// IdpMetadataURL is the URL of the identity provider's SAML metadata XML
+	IdpMetadataURL string `json:"idp_metadata_url,omitempty"`
+
+	// SPCertPath is an optional path to the service provider public certificate to upload
+	SPCertPath string `json:"sp_cert_path,omitempty"`
+}
+
 // PluginConfig represents the configuration for a plugin to download from GitHub
 type PluginConfig struct {
 	// Name is the human-readable plugin name
@@ -135,6 +216,9 @@ type PluginConfig struct {
 
 	// PluginID is the prefix of the tar file (e.g., "mattermost-plugin-playbooks")
 	PluginID string `json:"plugin-id"`
+
+	// Version is the release tag to download (e.g., "v2.1.0"); empty downloads the latest release
+	Version string `json:"version,omitempty"`
 }
 
 // LoadConfig loads the configuration from the specified file path
@@ -184,54 +268,94 @@ func LoadConfig(path string) (*Config, error) {
 	return &config, nil
 }
 
-// validateConfig performs basic validation on the configuration
+// ValidateConfig checks the client's configuration and returns every problem found, so they can
+// all be fixed at once instead of surfacing one by one as API errors during setup
+func (c *Client) ValidateConfig() []error {
+	if c.Config == nil {
+		return nil
+	}
+	return configProblems(c.Config)
+}
+
+// validateConfig performs basic validation on the configuration, combining every problem into one error
 func validateConfig(config *Config) error {
+	return errors.Join(configProblems(config)...)
+}
+
+// configProblems returns every problem found in the configuration
+func configProblems(config *Config) []error {
+	var problems []error
+
 	// Check required admin fields
 	if config.Server == "" {
-		return fmt.Errorf("server URL is required in config")
+		problems = append(problems, fmt.Errorf("server URL is required in config"))
 	}
 	if config.AdminUsername == "" {
-		return fmt.Errorf("admin_username is required in config")
+		problems = append(problems, fmt.Errorf("admin_username is required in config"))
 	}
 	if config.AdminPassword == "" {
-		return fmt.Errorf("admin_password is required in config")
+		problems = append(problems, fmt.Errorf("admin_password is required in config"))
 	}
 
 	// Validate each user has required fields (if users are defined)
 	for i, user := range config.Users {
 		if user.Username == "" {
-			return fmt.Errorf("user at index %d is missing username", i)
+			problems = append(problems, fmt.Errorf("user at index %d is missing username", i))
 		}
 		if user.Email == "" {
-			return fmt.Errorf("user '%s' is missing email", user.Username)
+			problems = append(problems, fmt.Errorf("user '%s' is missing email", user.Username))
 		}
 		if user.Password == "" {
-			return fmt.Errorf("user '%s' is missing password", user.Username)
+			problems = append(problems, fmt.Errorf("user '%s' is missing password", user.Username))
+		}
+		if user.Email != "" {
+			if _, err := mail.ParseAddress(user.Email); err != nil {
+				problems = append(problems, fmt.Errorf("user '%s' has invalid email '%s'", user.Username, user.Email))
+			}
+		}
+
+		// Teams are only checked when the config defines them; otherwise they come from the bulk import
+		if len(config.Teams) == 0 {
+			continue
+		}
+		for _, teamName := range user.Teams {
+			if _, exists := config.Teams[teamName]; !exists && teamName != config.DefaultTeam {
+				problems = append(problems, fmt.Errorf("user '%s' references team '%s' which is not defined in teams", user.Username, teamName))
+			}
 		}
 	}
 
 	// Validate each team in the teams map has required fields
 	for name, team := range config.Teams {
 		if team.Name == "" {
-			return fmt.Errorf("team '%s' is missing name field", name)
+			problems = append(problems, fmt.Errorf("team '%s' is missing name field", name))
 		}
 		if team.DisplayName == "" {
-			return fmt.Errorf("team '%s' is missing displayName", name)
+			problems = append(problems, fmt.Errorf("team '%s' is missing displayName", name))
+		}
+		if team.Type != "" && team.Type != model.TeamOpen && team.Type != model.TeamInvite {
+			problems = append(problems, fmt.Errorf("team '%s' has invalid type '%s', must be 'O' for open or 'I' for invite only", name, team.Type))
 		}
 
 		// Validate channels
 		for i, channel := range team.Channels {
 			if channel.Name == "" {
-				return fmt.Errorf("channel at index %d for team '%s' is missing name", i, name)
+				problems = append(problems, fmt.Errorf("channel at index %d for team '%s' is missing name", i, name))
 			}
 			if channel.DisplayName == "" {
-				return fmt.Errorf("channel '%s' for team '%s' is missing displayName", channel.Name, name)
+				problems = append(problems, fmt.Errorf("channel '%s' for team '%s' is missing displayName", channel.Name, name))
 			}
 
 			// Validate channel type if provided
 			if channel.Type != "" && channel.Type != "O" && channel.Type != "P" {
-				return fmt.Errorf("channel '%s' for team '%s' has invalid type '%s', must be 'O' for public or 'P' for private",
-					channel.Name, name, channel.Type)
+				problems = append(problems, fmt.Errorf("channel '%s' for team '%s' has invalid type '%s', must be 'O' for public or 'P' for private",
+					channel.Name, name, channel.Type))
+			}
+
+			// Validate default role if provided
+			if channel.DefaultRole != "" && channel.DefaultRole != model.ChannelUserRoleId && channel.DefaultRole != model.ChannelAdminRoleId {
+				problems = append(problems, fmt.Errorf("channel '%s' for team '%s' has invalid default_role '%s', must be '%s' or '%s'",
+					channel.Name, name, channel.DefaultRole, model.ChannelUserRoleId, model.ChannelAdminRoleId))
 			}
 
 			// Validate members exist in users
@@ -257,21 +381,21 @@ func validateConfig(config *Config) error {
 			// Validate commands
 			for i, command := range channel.Commands {
 				if command == "" {
-					return fmt.Errorf("command at index %d for channel '%s' in team '%s' is empty",
-						i, channel.Name, name)
+					problems = append(problems, fmt.Errorf("command at index %d for channel '%s' in team '%s' is empty",
+						i, channel.Name, name))
 				}
 
 				// Verify the command starts with a slash
 				if !strings.HasPrefix(command, "/") {
-					return fmt.Errorf("command '%s' for channel '%s' in team '%s' must start with /",
-						command, channel.Name, name)
+					problems = append(problems, fmt.Errorf("command '%s' for channel '%s' in team '%s' must start with /",
+						command, channel.Name, name))
 				}
 
 				// Extract the command name for validation
 				parts := strings.Fields(command)
 				if len(parts) == 0 {
-					return fmt.Errorf("command '%s' for channel '%s' in team '%s' is invalid",
-						command, channel.Name, name)
+					problems = append(problems, fmt.Errorf("command '%s' for channel '%s' in team '%s' is invalid",
+						command, channel.Name, name))
 				}
 
 				cmdName := strings.TrimPrefix(parts[0], "/")
@@ -300,7 +424,41 @@ func validateConfig(config *Config) error {
 
 	}
 
-	return nil
+	// Validate each top-level channel has a name, display name and a team to create it in
+	for i, channel := range config.Channels {
+		if channel.Name == "" {
+			problems = append(problems, fmt.Errorf("channel at index %d is missing name", i))
+		}
+		if channel.DisplayName == "" {
+			problems = append(problems, fmt.Errorf("channel '%s' is missing display_name", channel.Name))
+		}
+		if channel.Team == "" && config.DefaultTeam == "" {
+			problems = append(problems, fmt.Errorf("channel '%s' is missing team and no default_team is set", channel.Name))
+		}
+		for j, bookmark := range channel.Bookmarks {
+			if bookmark.DisplayName == "" {
+				problems = append(problems, fmt.Errorf("bookmark at index %d for channel '%s' is missing display_name", j, channel.Name))
+			}
+			if bookmark.Link == "" {
+				problems = append(problems, fmt.Errorf("bookmark '%s' for channel '%s' is missing link", bookmark.DisplayName, channel.Name))
+			}
+		}
+	}
+
+	// Validate each webhook names a channel and a team to find it in
+	for name, webhook := range config.Webhooks {
+		if webhook.Channel == "" {
+			problems = append(problems, fmt.Errorf("webhook '%s' is missing channel", name))
+		}
+		if webhook.DisplayName == "" {
+			problems = append(problems, fmt.Errorf("webhook '%s' is missing display_name", name))
+		}
+		if webhook.Team == "" && config.DefaultTeam == "" {
+			problems = append(problems, fmt.Errorf("webhook '%s' is missing team and no default_team is set", name))
+		}
+	}
+
+	return problems
 }
 
 // SaveConfig saves the configuration to the specified file path
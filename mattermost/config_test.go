@@ -0,0 +1,86 @@
+package mattermost
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+// TestValidateConfigReportsAllProblems verifies every config problem is returned, not just the first
+func TestValidateConfigReportsAllProblems(t *testing.T) {
+	client := &Client{Config: &Config{
+		Server:        "http://localhost:8065",
+		AdminUsername: "sysadmin",
+		AdminPassword: "password",
+		DefaultTeam:   "demo",
+		Users: []UserConfig{
+			{Username: "alice", Email: "alice@example.com", Password: "pw", Teams: []string{"ops", "demo"}},
+			{Username: "bob", Email: "not-an-email", Teams: []string{"missing-team"}},
+			{Email: "carol@example.com", Password: "pw"},
+		},
+		Teams: map[string]TeamConfig{
+			"ops": {Name: "ops", DisplayName: "Ops", Type: "X"},
+		},
+	}}
+
+	problems := client.ValidateConfig()
+
+	expected := []string{
+		"user 'bob' is missing password",
+		"user 'bob' has invalid email 'not-an-email'",
+		"user 'bob' references team 'missing-team'",
+		"user at index 2 is missing username",
+		"team 'ops' has invalid type 'X'",
+	}
+	if len(problems) != len(expected) {
+		t.Fatalf("Expected %d problems, got %d: %v", len(expected), len(problems), problems)
+	}
+	for _, want := range expected {
+		found := false
+		for _, problem := range problems {
+			if strings.Contains(problem.Error(), want) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Errorf("Expected a problem containing %q, got %v", want, problems)
+		}
+	}
+}
+
+// TestValidateConfigAcceptsValidConfig verifies a valid config, and users whose teams come from the bulk import, pass
+func TestValidateConfigAcceptsValidConfig(t *testing.T) {
+	client := &Client{Config: &Config{
+		Server:        "http://localhost:8065",
+		AdminUsername: "sysadmin",
+		AdminPassword: "password",
+		Users: []UserConfig{
+			{Username: "alice", Email: "Alice <alice@example.com>", Password: "pw", Teams: []string{"imported-team"}},
+		},
+	}}
+
+	if problems := client.ValidateConfig(); len(problems) != 0 {
+		t.Errorf("Expected no problems, got %v", problems)
+	}
+}
+
+// TestSetupAbortsOnInvalidConfig verifies setup stops before sending any request when the config is invalid
+func TestSetupAbortsOnInvalidConfig(t *testing.T) {
+	server := newMockServer()
+	client := setupMockClient(t, server)
+	client.Config = &Config{
+		Server:        client.ServerURL,
+		AdminUsername: "sysadmin",
+		AdminPassword: "password",
+		Teams:         map[string]TeamConfig{"ops": {Name: "ops", DisplayName: "Ops", Type: "P"}},
+	}
+
+	err := client.SetupWithForceAndUpdates(context.Background(), false, false, false, false)
+	if err == nil || !strings.Contains(err.Error(), "team 'ops' has invalid type 'P'") {
+		t.Fatalf("Expected an invalid config error, got %v", err)
+	}
+	if requests := server.requests(); len(requests) != 0 {
+		t.Errorf("Expected no requests to the server, got %v", requests)
+	}
+}
@@ -0,0 +1,213 @@
+package mattermost
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/sirupsen/logrus"
+)
+
+// directMessageInterval spaces out onboarding direct messages, 2 per second
+var directMessageInterval = 500 * time.Millisecond
+
+// directLineUsernames returns the usernames a direct_channel or direct_post line refers to:
+// the conversation members and, for posts, the author
+func directLineUsernames(data map[string]any) []string {
+	var usernames []string
+	add := func(values any) {
+		list, _ := values.([]any)
+		for _, value := range list {
+			switch v := value.(type) {
+			case string:
+				usernames = append(usernames, v)
+			case map[string]any:
+				if username, ok := v["username"].(string); ok {
+					usernames = append(usernames, username)
+				}
+			}
+		}
+	}
+
+	if channel, ok := data["direct_channel"].(map[string]any); ok {
+		add(channel["members"])
+		add(channel["participants"])
+	}
+	if post, ok := data["direct_post"].(map[string]any); ok {
+		add(post["channel_members"])
+		if user, ok := post["user"].(string); ok {
+			usernames = append(usernames, user)
+		}
+	}
+	return usernames
+}
+
+// filterDirectLines drops direct_channel and direct_post lines that name a user missing from the
+// server, since Mattermost fails the whole import job on an unknown participant
+func filterDirectLines(lines []string, userExists userExistsFunc) []string {
+	kept := make([]string, 0, len(lines))
+	for _, line := range lines {
+		var data map[string]any
+		if err := json.Unmarshal([]byte(line), &data); err != nil {
+			continue
+		}
+
+		var missing []string
+		for _, username := range directLineUsernames(data) {
+			if !userExists(username) && !slices.Contains(missing, username) {
+				missing = append(missing, username)
+			}
+		}
+
+		if len(missing) > 0 {
+			Log.WithFields(logrus.Fields{
+				"type":          data["type"],
+				"missing_users": missing,
+			}).Warn("⚠️ Skipping direct message line for users that were not imported")
+			continue
+		}
+		kept = append(kept, line)
+	}
+	return kept
+}
+
+// convertDirectChannelMembers rewrites the usernames in a direct_channel line's members list into
+// the participants list Mattermost's bulk import expects. Lines already using participants are
+// returned unchanged.
+func convertDirectChannelMembers(channelLine string) (string, error) {
+	var data map[string]any
+	if err := json.Unmarshal([]byte(channelLine), &data); err != nil {
+		return "", fmt.Errorf("failed to parse direct channel JSON: %w", err)
+	}
+
+	channel, ok := data["direct_channel"].(map[string]any)
+	if !ok {
+		return channelLine, nil
+	}
+	members, ok := channel["members"].([]any)
+	if !ok || channel["participants"] != nil {
+		return channelLine, nil
+	}
+
+	participants := make([]map[string]any, 0, len(members))
+	for _, member := range members {
+		if username, ok := member.(string); ok {
+			participants = append(participants, map[string]any{"username": username})
+		}
+	}
+	channel["participants"] = participants
+	delete(channel, "members")
+
+	convertedJSON, err := json.Marshal(data)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal direct channel JSON: %w", err)
+	}
+	return string(convertedJSON), nil
+}
+
+// processDirectMessages sends the direct-message entries from the import file, once users exist.
+// Sends are rate limited by directMessageInterval and a failed message is logged and skipped.
+func (c *Client) processDirectMessages(ctx context.Context, bulkImportPath string) error {
+	Log.WithFields(logrus.Fields{"file_path": bulkImportPath}).Info("✉️ Processing direct messages")
+
+	file, err := os.Open(bulkImportPath)
+	if err != nil {
+		return err
+	}
+	defer closeWithLog(file, "bulk import file")
+
+	var messages []DirectMessageImport
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		var message DirectMessageImport
+		if err := json.Unmarshal([]byte(line), &message); err != nil {
+			continue
+		}
+		if message.Type == "direct-message" {
+			messages = append(messages, message)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	if len(messages) == 0 {
+		Log.Info("✅ No direct messages to process")
+		return nil
+	}
+
+	ticker := time.NewTicker(directMessageInterval)
+	defer ticker.Stop()
+
+	sentCount := 0
+	errorCount := 0
+	for i, message := range messages {
+		if i > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-ticker.C:
+			}
+		}
+
+		if err := c.sendDirectMessage(ctx, message); err != nil {
+			Log.WithFields(logrus.Fields{
+				"from":  message.From,
+				"to":    message.To,
+				"error": err.Error(),
+			}).Warn("⚠️ Failed to send direct message")
+			errorCount++
+			continue
+		}
+		sentCount++
+	}
+
+	Log.WithFields(logrus.Fields{
+		"sent_count":  sentCount,
+		"error_count": errorCount,
+	}).Info("✅ Direct messages complete")
+	return nil
+}
+
+// sendDirectMessage opens the direct channel between two users and posts the message as the sender
+func (c *Client) sendDirectMessage(ctx context.Context, message DirectMessageImport) error {
+	from, resp, err := c.API.GetUserByUsername(ctx, message.From, "")
+	if err != nil {
+		return handleAPIError(fmt.Sprintf("failed to get sender '%s'", message.From), err, resp)
+	}
+	to, resp, err := c.API.GetUserByUsername(ctx, message.To, "")
+	if err != nil {
+		return handleAPIError(fmt.Sprintf("failed to get recipient '%s'", message.To), err, resp)
+	}
+
+	channel, resp, err := c.API.CreateDirectChannel(ctx, from.Id, to.Id)
+	if err != nil {
+		// In dry-run mode the create call is answered with its own user ID list rather than a channel
+		if !c.DryRun {
+			return handleAPIError(fmt.Sprintf("failed to create direct channel between '%s' and '%s'", message.From, message.To), err, resp)
+		}
+		channel = &model.Channel{Name: model.GetDMNameFromIds(from.Id, to.Id)}
+	}
+
+	post := &model.Post{
+		ChannelId: channel.Id,
+		UserId:    from.Id,
+		Message:   message.Text,
+	}
+	if _, resp, err := c.API.CreatePost(ctx, post); err != nil {
+		return handleAPIError(fmt.Sprintf("failed to post direct message from '%s' to '%s'", message.From, message.To), err, resp)
+	}
+	return nil
+}
@@ -0,0 +1,144 @@
+package mattermost
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+// TestImportDirectMessages verifies direct channels get participants, lines naming missing users
+// are dropped and direct post timestamps are adjusted
+func TestImportDirectMessages(t *testing.T) {
+	path := writeScanTestFile(t,
+		`{"type": "team", "team": {"name": "demo"}}`,
+		`{"type": "user", "user": {"username": "alice"}}`,
+		`{"type": "post", "post": {"team": "demo", "channel": "ops", "user": "alice", "message": "hi", "create_at": 1000}}`,
+		`{"type": "direct_channel", "direct_channel": {"members": ["alice", "bob"], "header": "Flight crew"}}`,
+		`{"type": "direct_channel", "direct_channel": {"members": ["alice", "carol"]}}`,
+		`{"type": "direct_post", "direct_post": {"channel_members": ["alice", "bob"], "user": "bob", "message": "ready", "create_at": 2000}}`,
+		`{"type": "direct_post", "direct_post": {"channel_members": ["alice", "bob"], "user": "carol", "message": "lost", "create_at": 1500}}`,
+	)
+
+	server := newMockServer()
+	server.handleUsersByUsername("alice", "bob")
+	client := setupMockClient(t, server)
+
+	referenceTime := time.Date(2025, time.March, 1, 12, 0, 0, 0, time.UTC)
+	timestampOffset, offsetCalculated = 0, false
+	now = func() time.Time { return referenceTime }
+	t.Cleanup(func() {
+		timestampOffset, offsetCalculated = 0, false
+		now = time.Now
+	})
+
+	var imported []string
+	err := client.processLines(context.Background(), path, []string{"direct_channel", "direct_post"}, func(_ context.Context, tempPath string) error {
+		data, err := os.ReadFile(tempPath)
+		if err != nil {
+			return err
+		}
+		imported = strings.Split(strings.TrimSpace(string(data)), "\n")
+		return os.Remove(tempPath)
+	})
+	if err != nil {
+		t.Fatalf("processLines returned error: %v", err)
+	}
+
+	// The version line, one direct channel and one direct post
+	if len(imported) != 3 {
+		t.Fatalf("Expected 3 lines, got %d: %v", len(imported), imported)
+	}
+
+	var channel map[string]any
+	if err := json.Unmarshal([]byte(imported[1]), &channel); err != nil {
+		t.Fatalf("Failed to parse direct channel line: %v", err)
+	}
+	directChannel := channel["direct_channel"].(map[string]any)
+	if _, ok := directChannel["members"]; ok {
+		t.Errorf("Expected members to be replaced by participants, got %v", directChannel)
+	}
+	participants, _ := json.Marshal(directChannel["participants"])
+	if string(participants) != `[{"username":"alice"},{"username":"bob"}]` {
+		t.Errorf("Unexpected participants: %s", participants)
+	}
+
+	var post map[string]any
+	if err := json.Unmarshal([]byte(imported[2]), &post); err != nil {
+		t.Fatalf("Failed to parse direct post line: %v", err)
+	}
+	directPost := post["direct_post"].(map[string]any)
+	if directPost["message"] != "ready" {
+		t.Errorf("Expected the post from an imported user, got %v", directPost)
+	}
+	// The latest timestamp in the file is the direct post, which is moved to five minutes ago
+	if createAt := int64(directPost["create_at"].(float64)); createAt != referenceTime.Add(-5*time.Minute).UnixMilli() {
+		t.Errorf("Expected the direct post timestamp to be adjusted, got %d (offset %d)", createAt, timestampOffset)
+	}
+}
+
+// TestProcessDirectMessages verifies direct-message entries open a direct channel and post the
+// text, skip unknown users and are spaced out by the rate limit
+func TestProcessDirectMessages(t *testing.T) {
+	path := writeScanTestFile(t,
+		`{"type": "user", "user": {"username": "alice"}}`,
+		`{"type": "direct-message", "from": "alice", "to": "bob", "text": "Welcome aboard"}`,
+		`{"type": "direct-message", "from": "alice", "to": "carol", "text": "Lost"}`,
+		`{"type": "direct-message", "from": "bob", "to": "alice", "text": "Thanks"}`,
+	)
+
+	var channels [][]string
+	var posts []*model.Post
+	var postedAt []time.Time
+
+	server := newMockServer()
+	server.handleUsersByUsername("alice", "bob")
+	server.handle("POST /api/v4/channels/direct", func(w http.ResponseWriter, r *http.Request) {
+		var ids []string
+		if !decodeJSON(w, r, &ids) {
+			return
+		}
+		channels = append(channels, ids)
+		writeJSON(w, http.StatusCreated, &model.Channel{Id: "dm-" + strings.Join(ids, "-"), Type: model.ChannelTypeDirect})
+	})
+	server.handle("POST /api/v4/posts", func(w http.ResponseWriter, r *http.Request) {
+		var post model.Post
+		if !decodeJSON(w, r, &post) {
+			return
+		}
+		posts = append(posts, &post)
+		postedAt = append(postedAt, time.Now())
+		writeJSON(w, http.StatusCreated, &post)
+	})
+	client := setupMockClient(t, server)
+
+	interval := directMessageInterval
+	directMessageInterval = 50 * time.Millisecond
+	t.Cleanup(func() { directMessageInterval = interval })
+
+	if err := client.processDirectMessages(context.Background(), path); err != nil {
+		t.Fatalf("processDirectMessages returned error: %v", err)
+	}
+
+	if len(channels) != 2 || strings.Join(channels[0], ",") != "user-alice,user-bob" {
+		t.Errorf("Expected direct channels for the two known pairs, got %v", channels)
+	}
+	if len(posts) != 2 {
+		t.Fatalf("Expected 2 posts, got %d", len(posts))
+	}
+	if posts[0].ChannelId != "dm-user-alice-user-bob" || posts[0].Message != "Welcome aboard" {
+		t.Errorf("Unexpected first post: %+v", posts[0])
+	}
+	if posts[1].UserId != "user-bob" || posts[1].Message != "Thanks" {
+		t.Errorf("Unexpected second post: %+v", posts[1])
+	}
+	// The skipped message still waits its turn, so two intervals pass between the posts
+	if gap := postedAt[1].Sub(postedAt[0]); gap < 2*directMessageInterval-10*time.Millisecond {
+		t.Errorf("Expected the posts to be rate limited, got %v between them", gap)
+	}
+}
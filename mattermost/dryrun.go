@@ -0,0 +1,118 @@
+package mattermost
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+)
+
+// dryRunPassthroughPaths lists non-GET endpoints that are still sent in dry-run mode
+// because they do not change server state (login only issues a session token)
+var dryRunPassthroughPaths = map[string]bool{
+	"/api/v4/users/login": true,
+}
+
+// DryRunCall is the structured record printed for each API call skipped in dry-run mode
+type DryRunCall struct {
+	DryRun   bool            `json:"dry_run"`
+	Method   string          `json:"method"`
+	Path     string          `json:"path"`
+	Query    string          `json:"query,omitempty"`
+	Body     json.RawMessage `json:"body,omitempty"`
+	BodySize int             `json:"body_size,omitempty"` // Set instead of Body for non-JSON payloads such as uploads
+}
+
+// dryRunTransport forwards read-only requests and records mutating requests instead of sending them
+type dryRunTransport struct {
+	base http.RoundTripper
+	out  io.Writer
+	mu   sync.Mutex
+}
+
+// EnableDryRun switches the client into dry-run mode. Read-only API calls still reach the
+// server so the plan reflects its current state, but every call that would change it is
+// written to out as a JSON line and answered locally with an empty success response.
+func (c *Client) EnableDryRun(out io.Writer) {
+	base := http.DefaultTransport
+	if c.API.HTTPClient != nil && c.API.HTTPClient.Transport != nil {
+		base = c.API.HTTPClient.Transport
+	}
+
+	c.DryRun = true
+	c.API.HTTPClient = &http.Client{Transport: &dryRunTransport{base: base, out: out}}
+}
+
+// httpClient returns the HTTP client used for API calls not covered by the SDK
+func (c *Client) httpClient() *http.Client {
+	if c.API.HTTPClient != nil {
+		return c.API.HTTPClient
+	}
+	return &http.Client{}
+}
+
+func (t *dryRunTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method == http.MethodGet || req.Method == http.MethodHead || dryRunPassthroughPaths[req.URL.Path] {
+		return t.base.RoundTrip(req)
+	}
+
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		_ = req.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read dry-run request body: %w", err)
+		}
+	}
+
+	call := DryRunCall{
+		DryRun: true,
+		Method: req.Method,
+		Path:   req.URL.Path,
+		Query:  req.URL.RawQuery,
+	}
+
+	// Echo JSON payloads back so callers decode the object they asked to create
+	responseBody := []byte("{}")
+	if len(body) > 0 {
+		var compacted bytes.Buffer
+		if json.Valid(body) && json.Compact(&compacted, body) == nil {
+			call.Body = compacted.Bytes()
+			responseBody = compacted.Bytes()
+		} else {
+			call.BodySize = len(body)
+		}
+	}
+
+	if err := t.record(call); err != nil {
+		return nil, err
+	}
+
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": []string{"application/json"}},
+		Body:          io.NopCloser(bytes.NewReader(responseBody)),
+		ContentLength: int64(len(responseBody)),
+		Request:       req,
+	}, nil
+}
+
+// record writes a dry-run call as a single JSON line so runs can be diffed
+func (t *dryRunTransport) record(call DryRunCall) error {
+	line, err := json.Marshal(call)
+	if err != nil {
+		return fmt.Errorf("failed to marshal dry-run call: %w", err)
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	_, err = fmt.Fprintf(t.out, "%s\n", line)
+	return err
+}
@@ -0,0 +1,162 @@
+package mattermost
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// dryRunTestImport is a minimal bulk import covering teams, channels, users, attributes and groups
+const dryRunTestImport = `{"type": "team", "team": {"name": "demo", "display_name": "Demo", "type": "O", "description": "Demo team"}}
+{"type": "channel", "channel": {"team": "demo", "name": "ops", "display_name": "Ops", "type": "O"}}
+{"type": "user", "user": {"username": "alice", "email": "alice@example.com", "password": "password", "first_name": "Alice", "last_name": "Smith", "teams": [{"name": "demo", "roles": "team_user", "channels": [{"name": "ops", "roles": "channel_user"}]}]}}
+{"type": "user-attribute", "attribute": {"name": "rank", "display_name": "Rank", "type": "text", "saml": "Rank"}}
+{"type": "user-groups", "group": {"name": "operators", "id": "ops_001", "allow_reference": true, "members": ["alice"]}}
+`
+
+// handleDryRunReads registers the login and the read-only endpoints used during setup on server
+func handleDryRunReads(server *mockServer) {
+	respond := func(pattern string, v any) {
+		server.handle(pattern, func(w http.ResponseWriter, r *http.Request) {
+			writeJSON(w, http.StatusOK, v)
+		})
+	}
+
+	server.handle("POST /api/v4/users/login", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Token", "test-token")
+		writeJSON(w, http.StatusOK, map[string]string{"id": "admin-id", "username": "sysadmin", "roles": "system_admin system_user"})
+	})
+	respond("GET /api/v4/system/ping", map[string]string{"status": "OK"})
+	respond("GET /api/v4/users/me", map[string]string{"id": "admin-id", "username": "sysadmin"})
+	respond("GET /api/v4/license/client", map[string]string{"IsLicensed": "true", "Id": "license-id"})
+	respond("GET /api/v4/config", map[string]any{"ServiceSettings": map[string]any{"EnableAPIUserDeletion": true, "EnableAPITeamDeletion": true}})
+	respond("GET /api/v4/plugins", map[string]any{"active": []any{}, "inactive": []any{}})
+	respond("GET /api/v4/custom_profile_attributes/fields", []any{})
+	server.handle("GET /api/v4/users/username/{username}", func(w http.ResponseWriter, r *http.Request) {
+		username := r.PathValue("username")
+		writeJSON(w, http.StatusOK, map[string]string{"id": "user-" + username, "username": username})
+	})
+	server.handleTeamsByName()
+}
+
+// dryRunMutations returns the mutating requests that reached server, leaving out the login
+func dryRunMutations(server *mockServer) []string {
+	var mutations []string
+	for _, request := range server.requests() {
+		if !strings.HasPrefix(request, "GET ") && request != "POST /api/v4/users/login" {
+			mutations = append(mutations, request)
+		}
+	}
+	return mutations
+}
+
+// setupDryRunClient creates a dry-run client against a mock server, working in a temp dir holding bulk_import.jsonl
+func setupDryRunClient(t *testing.T) (*Client, *mockServer, *bytes.Buffer) {
+	t.Helper()
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "bulk_import.jsonl"), []byte(dryRunTestImport), 0600); err != nil {
+		t.Fatalf("Failed to write bulk import file: %v", err)
+	}
+	t.Chdir(dir)
+
+	server := newMockServer()
+	handleDryRunReads(server)
+	client := setupMockClient(t, server)
+	client.Config = &Config{Environment: "test", Server: client.ServerURL, AdminUsername: "sysadmin", AdminPassword: "password"}
+
+	output := &bytes.Buffer{}
+	client.EnableDryRun(output)
+	return client, server, output
+}
+
+// dryRunCalls parses the dry-run output into "METHOD path" entries
+func dryRunCalls(t *testing.T, output *bytes.Buffer) []string {
+	t.Helper()
+
+	var calls []string
+	for _, line := range strings.Split(strings.TrimSpace(output.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var call DryRunCall
+		if err := json.Unmarshal([]byte(line), &call); err != nil {
+			t.Fatalf("Dry-run output line is not valid JSON: %q", line)
+		}
+		if !call.DryRun {
+			t.Errorf("Expected dry_run to be true in %q", line)
+		}
+		calls = append(calls, call.Method+" "+call.Path)
+	}
+	return calls
+}
+
+// TestDryRun verifies that setup, LDAP setup and reset never send mutating calls in dry-run mode
+func TestDryRun(t *testing.T) {
+	testCases := []struct {
+		name          string
+		run           func(c *Client) error
+		expectedCalls []string
+	}{
+		{
+			name: "Setup",
+			run:  func(c *Client) error { return c.Setup() },
+			expectedCalls: []string{
+				"POST /api/v4/uploads",
+				"POST /api/v4/jobs",
+				"POST /api/v4/custom_profile_attributes/fields",
+			},
+		},
+		{
+			name: "SetupLDAP",
+			run:  func(c *Client) error { return c.SetupLDAP(context.Background()) },
+			expectedCalls: []string{
+				"PUT /api/v4/users/user-alice/auth",
+				"POST /api/v4/ldap/groups/operators/link",
+				"POST /api/v4/ldap/sync",
+			},
+		},
+		{
+			name: "Reset",
+			run:  func(c *Client) error { return c.Reset() },
+			expectedCalls: []string{
+				"DELETE /api/v4/users/user-alice",
+				"DELETE /api/v4/teams/team-demo",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			client, server, output := setupDryRunClient(t)
+			client.BulkImportPath = "bulk_import.jsonl"
+
+			if err := tc.run(client); err != nil {
+				t.Fatalf("%s returned error in dry-run mode: %v", tc.name, err)
+			}
+
+			if mutations := dryRunMutations(server); len(mutations) > 0 {
+				t.Errorf("Expected no mutating requests to reach the server, got %v", mutations)
+			}
+
+			calls := dryRunCalls(t, output)
+			for _, expected := range tc.expectedCalls {
+				found := false
+				for _, call := range calls {
+					if call == expected {
+						found = true
+						break
+					}
+				}
+				if !found {
+					t.Errorf("Expected dry-run output to include %q, got %v", expected, calls)
+				}
+			}
+		})
+	}
+}
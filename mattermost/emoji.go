@@ -0,0 +1,119 @@
+package mattermost
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/sirupsen/logrus"
+)
+
+// processEmoji uploads the emoji entries from the import file ("custom-emoji" is accepted as an alias).
+// Emoji that already exist are skipped, and a missing image or failed upload is logged and skipped.
+// Relative image paths are resolved from the directory of the import file.
+func (c *Client) processEmoji(bulkImportPath string) error {
+	Log.WithFields(logrus.Fields{"file_path": bulkImportPath}).Info("😀 Processing custom emoji")
+
+	file, err := os.Open(bulkImportPath)
+	if err != nil {
+		return err
+	}
+	defer closeWithLog(file, "bulk import file")
+
+	var emojis []EmojiImport
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		var emojiImport EmojiImport
+		if err := json.Unmarshal([]byte(line), &emojiImport); err != nil {
+			continue
+		}
+		if emojiImport.Type == "emoji" || emojiImport.Type == "custom-emoji" {
+			emojis = append(emojis, emojiImport)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	if len(emojis) == 0 {
+		Log.Info("✅ No custom emoji to process")
+		return nil
+	}
+
+	errorCount := 0
+	for _, emojiImport := range emojis {
+		// Mattermost only creates emoji for the session user
+		if emojiImport.Emoji.Creator != "" && emojiImport.Emoji.Creator != c.AdminUser {
+			Log.WithFields(logrus.Fields{
+				"emoji_name": emojiImport.Emoji.Name,
+				"creator":    emojiImport.Emoji.Creator,
+			}).Warn("⚠️ Custom emoji are created by the admin user, ignoring creator")
+		}
+
+		imagePath := emojiImport.Emoji.Image
+		if !filepath.IsAbs(imagePath) {
+			imagePath = filepath.Join(filepath.Dir(bulkImportPath), imagePath)
+		}
+
+		if err := c.EnsureCustomEmoji(emojiImport.Emoji.Name, imagePath); err != nil {
+			Log.WithFields(logrus.Fields{
+				"emoji_name": emojiImport.Emoji.Name,
+				"image":      emojiImport.Emoji.Image,
+				"error":      err.Error(),
+			}).Warn("⚠️ Failed to create custom emoji")
+			errorCount++
+		}
+	}
+
+	Log.WithFields(logrus.Fields{
+		"emoji_count": len(emojis),
+		"error_count": errorCount,
+	}).Info("✅ Custom emoji setup complete")
+	return nil
+}
+
+// EnsureCustomEmoji uploads the image as a custom emoji created by the admin user,
+// unless an emoji with the name already exists
+func (c *Client) EnsureCustomEmoji(name, imageFilePath string) error {
+	ctx := context.Background()
+
+	if _, resp, err := c.API.GetEmojiByName(ctx, name); err == nil {
+		Log.WithFields(logrus.Fields{"emoji_name": name}).Debug("⏭️ Custom emoji already exists")
+		return nil
+	} else if resp == nil || resp.StatusCode != http.StatusNotFound {
+		return handleAPIError(fmt.Sprintf("failed to check custom emoji '%s'", name), err, resp)
+	}
+
+	image, err := os.ReadFile(imageFilePath)
+	if err != nil {
+		return fmt.Errorf("failed to read emoji image: %w", err)
+	}
+
+	admin, resp, err := c.API.GetMe(ctx, "")
+	if err != nil {
+		return handleAPIError("failed to get current user", err, resp)
+	}
+
+	emoji := &model.Emoji{
+		CreatorId: admin.Id,
+		Name:      name,
+	}
+	if _, resp, err := c.API.CreateEmoji(ctx, emoji, image, filepath.Base(imageFilePath)); err != nil {
+		return handleAPIError(fmt.Sprintf("failed to create custom emoji '%s'", name), err, resp)
+	}
+
+	Log.WithFields(logrus.Fields{"emoji_name": name}).Info("✅ Created custom emoji")
+	return nil
+}
@@ -0,0 +1,96 @@
+package mattermost
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+// TestProcessEmoji verifies new emoji are uploaded by the admin, existing emoji are skipped
+// and a missing image does not stop the rest
+func TestProcessEmoji(t *testing.T) {
+	fixture, err := filepath.Abs(filepath.Join("testdata", "emoji.png"))
+	if err != nil {
+		t.Fatalf("Failed to resolve emoji fixture: %v", err)
+	}
+	image, err := os.ReadFile(fixture)
+	if err != nil {
+		t.Fatalf("Failed to read emoji fixture: %v", err)
+	}
+
+	path := writeScanTestFile(t,
+		`{"type": "emoji", "emoji": {"name": "usaf", "image": "`+fixture+`"}}`,
+		`{"type": "emoji", "emoji": {"name": "missing", "image": "emoji/missing.png"}}`,
+		`{"type": "emoji", "emoji": {"name": "existing", "image": "`+fixture+`"}}`,
+		`{"type": "custom-emoji", "emoji": {"name": "wings", "creator": "alice", "image": "emoji/wings.png"}}`,
+	)
+	emojiDir := filepath.Join(filepath.Dir(path), "emoji")
+	if err := os.Mkdir(emojiDir, 0755); err != nil {
+		t.Fatalf("Failed to create emoji dir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(emojiDir, "wings.png"), image, 0644); err != nil {
+		t.Fatalf("Failed to write emoji image: %v", err)
+	}
+
+	var created []string
+	var images [][]byte
+
+	server := newMockServer()
+	server.handle("GET /api/v4/users/me", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, &model.User{Id: "admin-id", Username: "sysadmin"})
+	})
+	server.handle("GET /api/v4/emoji/name/{name}", func(w http.ResponseWriter, r *http.Request) {
+		name := r.PathValue("name")
+		if name != "existing" {
+			writeAppError(w, http.StatusNotFound, "app.emoji.get_by_name.no_result")
+			return
+		}
+		writeJSON(w, http.StatusOK, &model.Emoji{Id: "emoji-" + name, Name: name})
+	})
+	server.handle("POST /api/v4/emoji", func(w http.ResponseWriter, r *http.Request) {
+		image, _, err := r.FormFile("image")
+		if err != nil {
+			writeAppError(w, http.StatusBadRequest, "api.emoji.create.no_image.app_error")
+			return
+		}
+		data, _ := io.ReadAll(image)
+		images = append(images, data)
+		emoji := r.FormValue("emoji")
+		if !strings.Contains(emoji, `"creator_id":"admin-id"`) {
+			writeAppError(w, http.StatusBadRequest, "api.emoji.create.other_user.app_error")
+			return
+		}
+		created = append(created, emoji)
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(emoji))
+	})
+	client := setupMockClient(t, server)
+
+	if err := client.processEmoji(path); err != nil {
+		t.Fatalf("processEmoji returned error: %v", err)
+	}
+
+	var names []string
+	for _, emoji := range created {
+		for _, name := range []string{"usaf", "missing", "existing", "wings"} {
+			if strings.Contains(emoji, `"name":"`+name+`"`) {
+				names = append(names, name)
+			}
+		}
+	}
+	if !slices.Equal(names, []string{"usaf", "wings"}) {
+		t.Errorf("Expected usaf and wings to be created, got %v", created)
+	}
+	for i, uploaded := range images {
+		if !bytes.Equal(uploaded, image) {
+			t.Errorf("Expected upload %d to be the fixture image, got %d bytes", i, len(uploaded))
+		}
+	}
+}
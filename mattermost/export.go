@@ -0,0 +1,502 @@
+package mattermost
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/sirupsen/logrus"
+)
+
+// exportPageSize is the page size used when listing teams, users and members for an export
+const exportPageSize = 200
+
+// exportLine is a line of the exported import file for the types Mattermost imports itself
+type exportLine struct {
+	Type    string         `json:"type"`
+	Version int            `json:"version,omitempty"`
+	Team    *BulkTeam      `json:"team,omitempty"`
+	Channel *exportChannel `json:"channel,omitempty"`
+	User    *exportUser    `json:"user,omitempty"`
+	Post    *exportPost    `json:"post,omitempty"`
+}
+
+// exportChannel is an exported team channel
+type exportChannel struct {
+	Team        string `json:"team"`
+	Name        string `json:"name"`
+	DisplayName string `json:"display_name"`
+	Type        string `json:"type"`
+	Header      string `json:"header,omitempty"`
+	Purpose     string `json:"purpose,omitempty"`
+}
+
+// exportUser is an exported user with the teams and channels it is a member of
+type exportUser struct {
+	Username    string           `json:"username"`
+	Email       string           `json:"email"`
+	AuthService string           `json:"auth_service,omitempty"`
+	AuthData    *string          `json:"auth_data,omitempty"`
+	Nickname    string           `json:"nickname,omitempty"`
+	FirstName   string           `json:"first_name,omitempty"`
+	LastName    string           `json:"last_name,omitempty"`
+	Position    string           `json:"position,omitempty"`
+	Roles       string           `json:"roles,omitempty"`
+	Teams       []exportUserTeam `json:"teams,omitempty"`
+}
+
+// exportUserTeam is a team membership of an exported user
+type exportUserTeam struct {
+	Name     string              `json:"name"`
+	Roles    string              `json:"roles,omitempty"`
+	Channels []exportUserChannel `json:"channels,omitempty"`
+}
+
+// exportUserChannel is a channel membership of an exported user
+type exportUserChannel struct {
+	Name  string `json:"name"`
+	Roles string `json:"roles,omitempty"`
+}
+
+// exportPost is an exported root post with its replies
+type exportPost struct {
+	Team      string           `json:"team"`
+	Channel   string           `json:"channel"`
+	User      string           `json:"user"`
+	Message   string           `json:"message"`
+	CreateAt  int64            `json:"create_at"`
+	Replies   []exportReply    `json:"replies,omitempty"`
+	Reactions []exportReaction `json:"reactions,omitempty"`
+}
+
+// exportReply is an exported reply to a root post
+type exportReply struct {
+	User      string           `json:"user"`
+	Message   string           `json:"message"`
+	CreateAt  int64            `json:"create_at"`
+	Reactions []exportReaction `json:"reactions,omitempty"`
+}
+
+// exportReaction is an emoji and the usernames reacting with it, in the form expandPostReactions reads
+type exportReaction struct {
+	EmojiName string   `json:"emoji_name"`
+	Users     []string `json:"users"`
+}
+
+// Export writes the teams, channels, users and recent posts on the server to path as a JSONL file
+// that setup can import. Channel sidebar categories set through Playbooks and channel banners are
+// written as channel-category and channel-banner entries. Only the ExportPostsPerChannel most recent
+// posts of each channel are written, so replies whose root post is older are left out, as are system
+// messages, file attachments, bots and deactivated users. Passwords cannot be exported, so imported
+// users without one get a random password.
+func (c *Client) Export(path string) error {
+	Log.WithFields(logrus.Fields{"file_path": path}).Info("📤 Exporting server data")
+
+	if c.API.AuthToken == "" {
+		if err := c.Login(); err != nil {
+			return err
+		}
+	}
+
+	ctx := context.Background()
+	teams, err := c.listExportTeams(ctx)
+	if err != nil {
+		return err
+	}
+
+	users, err := c.listExportUsers(ctx)
+	if err != nil {
+		return err
+	}
+	usernames := make(map[string]string, len(users))
+	for _, user := range users {
+		usernames[user.Id] = user.Username
+	}
+
+	var channelLines, categoryLines, bannerLines, postLines []any
+	memberships := make(map[string][]exportUserTeam)
+	skippedPosts := 0
+
+	for _, team := range teams {
+		teamRoles, err := c.listExportTeamMembers(ctx, team.Id)
+		if err != nil {
+			return err
+		}
+		for userID, roles := range teamRoles {
+			if _, ok := usernames[userID]; ok {
+				memberships[userID] = append(memberships[userID], exportUserTeam{Name: team.Name, Roles: roles})
+			}
+		}
+
+		channels, err := c.ListTeamChannels(team.Name)
+		if err != nil {
+			return err
+		}
+		channels = slices.DeleteFunc(channels, func(channel ChannelSummary) bool { return channel.DeleteAt != 0 })
+		slices.SortFunc(channels, func(a, b ChannelSummary) int { return strings.Compare(a.Name, b.Name) })
+
+		categories := make(map[string][]string)
+		for _, channel := range channels {
+			channelLines = append(channelLines, exportLine{Type: "channel", Channel: &exportChannel{
+				Team:        team.Name,
+				Name:        channel.Name,
+				DisplayName: channel.DisplayName,
+				Type:        string(channel.Type),
+				Header:      channel.Header,
+				Purpose:     channel.Purpose,
+			}})
+
+			if banner := exportChannelBanner(team.Name, channel.Channel); banner != nil {
+				bannerLines = append(bannerLines, banner)
+			}
+
+			if category := c.channelCategory(channel.Id); category != "" {
+				categories[category] = append(categories[category], channel.Name)
+			}
+
+			channelRoles, err := c.listExportChannelMembers(ctx, channel.Id)
+			if err != nil {
+				return err
+			}
+			for userID, roles := range channelRoles {
+				userTeams := memberships[userID]
+				if i := slices.IndexFunc(userTeams, func(t exportUserTeam) bool { return t.Name == team.Name }); i >= 0 {
+					userTeams[i].Channels = append(userTeams[i].Channels, exportUserChannel{Name: channel.Name, Roles: roles})
+				}
+			}
+
+			posts, skipped, err := c.exportChannelPosts(ctx, team.Name, channel.Channel, usernames)
+			if err != nil {
+				return err
+			}
+			for _, post := range posts {
+				postLines = append(postLines, exportLine{Type: "post", Post: post})
+			}
+			skippedPosts += skipped
+		}
+
+		for _, category := range slices.Sorted(maps.Keys(categories)) {
+			categoryLines = append(categoryLines, ChannelCategoryImport{
+				Type:     "channel-category",
+				Category: category,
+				Team:     team.Name,
+				Channels: categories[category],
+			})
+		}
+	}
+
+	// Import lines must be ordered by type, so teams and channels come before users and users before posts
+	lines := []any{exportLine{Type: "version", Version: 1}}
+	for _, team := range teams {
+		lines = append(lines, exportLine{Type: "team", Team: &BulkTeam{
+			Name:        team.Name,
+			DisplayName: team.DisplayName,
+			Type:        team.Type,
+			Description: team.Description,
+		}})
+	}
+	lines = append(lines, channelLines...)
+	lines = append(lines, categoryLines...)
+	lines = append(lines, bannerLines...)
+	for _, user := range users {
+		exported := &exportUser{
+			Username:  user.Username,
+			Email:     user.Email,
+			Nickname:  user.Nickname,
+			FirstName: user.FirstName,
+			LastName:  user.LastName,
+			Position:  user.Position,
+			Roles:     user.Roles,
+			Teams:     memberships[user.Id],
+		}
+		if user.AuthService != "" {
+			exported.AuthService = user.AuthService
+			exported.AuthData = user.AuthData
+		}
+		lines = append(lines, exportLine{Type: "user", User: exported})
+	}
+	lines = append(lines, postLines...)
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	// Keep markdown such as <https://...> links and & readable in the file
+	encoder.SetEscapeHTML(false)
+	for _, line := range lines {
+		if err := encoder.Encode(line); err != nil {
+			return fmt.Errorf("failed to marshal export line: %w", err)
+		}
+	}
+
+	if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to write export file: %w", err)
+	}
+
+	Log.WithFields(logrus.Fields{
+		"file_path":           path,
+		"teams_count":         len(teams),
+		"channels_count":      len(channelLines),
+		"users_count":         len(users),
+		"posts_count":         len(postLines),
+		"skipped_posts_count": skippedPosts,
+	}).Info("✅ Export complete")
+	return nil
+}
+
+// exportPostsPerChannel returns the number of posts exported per channel: ExportPostsPerChannel if set,
+// else DefaultExportPostsPerChannel
+func (c *Client) exportPostsPerChannel() int {
+	if c.ExportPostsPerChannel > 0 {
+		return c.ExportPostsPerChannel
+	}
+	return DefaultExportPostsPerChannel
+}
+
+// listExportTeams returns the teams that are not deleted, sorted by name
+func (c *Client) listExportTeams(ctx context.Context) ([]*model.Team, error) {
+	var teams []*model.Team
+	for page := 0; ; page++ {
+		pageTeams, resp, err := c.API.GetAllTeams(ctx, "", page, exportPageSize)
+		if err != nil {
+			return nil, handleAPIError("failed to list teams", err, resp)
+		}
+		for _, team := range pageTeams {
+			if team.DeleteAt == 0 {
+				teams = append(teams, team)
+			}
+		}
+		if len(pageTeams) < exportPageSize {
+			break
+		}
+	}
+
+	slices.SortFunc(teams, func(a, b *model.Team) int { return strings.Compare(a.Name, b.Name) })
+	return teams, nil
+}
+
+// listExportUsers returns the active users that are not bots, sorted by username
+func (c *Client) listExportUsers(ctx context.Context) ([]*model.User, error) {
+	var users []*model.User
+	for page := 0; ; page++ {
+		pageUsers, resp, err := c.API.GetUsers(ctx, page, exportPageSize, "")
+		if err != nil {
+			return nil, handleAPIError("failed to list users", err, resp)
+		}
+		for _, user := range pageUsers {
+			if !user.IsBot && user.DeleteAt == 0 {
+				users = append(users, user)
+			}
+		}
+		if len(pageUsers) < exportPageSize {
+			break
+		}
+	}
+
+	slices.SortFunc(users, func(a, b *model.User) int { return strings.Compare(a.Username, b.Username) })
+	return users, nil
+}
+
+// listExportTeamMembers returns the roles of each member of a team, by user ID
+func (c *Client) listExportTeamMembers(ctx context.Context, teamID string) (map[string]string, error) {
+	roles := make(map[string]string)
+	for page := 0; ; page++ {
+		members, resp, err := c.API.GetTeamMembers(ctx, teamID, page, exportPageSize, "")
+		if err != nil {
+			return nil, handleAPIError(fmt.Sprintf("failed to list members of team '%s'", teamID), err, resp)
+		}
+		for _, member := range members {
+			if member.DeleteAt == 0 {
+				roles[member.UserId] = member.Roles
+			}
+		}
+		if len(members) < exportPageSize {
+			break
+		}
+	}
+	return roles, nil
+}
+
+// listExportChannelMembers returns the roles of each member of a channel, by user ID
+func (c *Client) listExportChannelMembers(ctx context.Context, channelID string) (map[string]string, error) {
+	roles := make(map[string]string)
+	for page := 0; ; page++ {
+		members, resp, err := c.API.GetChannelMembers(ctx, channelID, page, channelMembersPerPage, "")
+		if err != nil {
+			return nil, handleAPIError(fmt.Sprintf("failed to list members of channel '%s'", channelID), err, resp)
+		}
+		for _, member := range members {
+			roles[member.UserId] = member.Roles
+		}
+		if len(members) < channelMembersPerPage {
+			break
+		}
+	}
+	return roles, nil
+}
+
+// exportChannelBanner returns the channel-banner entry for a channel, or nil if it has no banner text
+func exportChannelBanner(teamName string, channel *model.Channel) *ChannelBannerImport {
+	info := channel.BannerInfo
+	if info == nil || info.Text == nil || *info.Text == "" {
+		return nil
+	}
+
+	banner := &ChannelBannerImport{Type: "channel-banner"}
+	banner.Banner.Team = teamName
+	banner.Banner.Channel = channel.Name
+	banner.Banner.Text = *info.Text
+	if info.BackgroundColor != nil {
+		banner.Banner.BackgroundColor = *info.BackgroundColor
+	}
+	if info.Enabled != nil {
+		banner.Banner.Enabled = *info.Enabled
+	}
+	return banner
+}
+
+// channelCategory returns the sidebar category a channel is put in by its Playbooks categorize
+// action, or "" if it has none or Playbooks is not installed
+func (c *Client) channelCategory(channelID string) string {
+	url := fmt.Sprintf("%s/plugins/playbooks/api/v0/actions/channels/%s", c.ServerURL, channelID)
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return ""
+	}
+	req.Header.Set("Authorization", "Bearer "+c.API.AuthToken)
+
+	resp, err := c.httpClient().Do(req)
+	if err != nil {
+		Log.WithFields(logrus.Fields{"channel_id": channelID, "error": err.Error()}).Debug("Failed to get channel actions")
+		return ""
+	}
+	defer closeWithLog(resp.Body, "channel actions response")
+
+	if resp.StatusCode != http.StatusOK {
+		return ""
+	}
+
+	var actions []struct {
+		ActionType string `json:"action_type"`
+		Enabled    bool   `json:"enabled"`
+		Payload    struct {
+			CategoryName string `json:"category_name"`
+		} `json:"payload"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&actions); err != nil {
+		return ""
+	}
+
+	for _, action := range actions {
+		if action.ActionType == "categorize_channel" && action.Enabled {
+			return action.Payload.CategoryName
+		}
+	}
+	return ""
+}
+
+// exportChannelPosts returns the most recent root posts of a channel with their replies, oldest first,
+// and the number of posts left out because their author is not exported
+func (c *Client) exportChannelPosts(ctx context.Context, teamName string, channel *model.Channel, usernames map[string]string) ([]*exportPost, int, error) {
+	limit := c.exportPostsPerChannel()
+	perPage := min(limit, exportPageSize)
+
+	var posts []*model.Post
+	for page := 0; len(posts) < limit; page++ {
+		list, resp, err := c.API.GetPostsForChannel(ctx, channel.Id, page, perPage, "", false, false)
+		if err != nil {
+			return nil, 0, handleAPIError(fmt.Sprintf("failed to get posts for channel '%s'", channel.Name), err, resp)
+		}
+		for _, id := range list.Order {
+			if post, ok := list.Posts[id]; ok && len(posts) < limit {
+				posts = append(posts, post)
+			}
+		}
+		if len(list.Order) < perPage {
+			break
+		}
+	}
+
+	var roots []*model.Post
+	replies := make(map[string][]*model.Post)
+	for _, post := range posts {
+		if post.IsSystemMessage() || post.DeleteAt != 0 {
+			continue
+		}
+		if post.RootId == "" {
+			roots = append(roots, post)
+		} else {
+			replies[post.RootId] = append(replies[post.RootId], post)
+		}
+	}
+	byCreateAt := func(a, b *model.Post) int { return cmp.Compare(a.CreateAt, b.CreateAt) }
+	slices.SortFunc(roots, byCreateAt)
+
+	skipped := 0
+	exported := make([]*exportPost, 0, len(roots))
+	for _, root := range roots {
+		username, ok := usernames[root.UserId]
+		if !ok {
+			skipped += 1 + len(replies[root.Id])
+			continue
+		}
+
+		post := &exportPost{
+			Team:      teamName,
+			Channel:   channel.Name,
+			User:      username,
+			Message:   root.Message,
+			CreateAt:  root.CreateAt,
+			Reactions: exportPostReactions(root, usernames),
+		}
+
+		rootReplies := replies[root.Id]
+		slices.SortFunc(rootReplies, byCreateAt)
+		for _, reply := range rootReplies {
+			replyUsername, ok := usernames[reply.UserId]
+			if !ok {
+				skipped++
+				continue
+			}
+			post.Replies = append(post.Replies, exportReply{
+				User:      replyUsername,
+				Message:   reply.Message,
+				CreateAt:  reply.CreateAt,
+				Reactions: exportPostReactions(reply, usernames),
+			})
+		}
+
+		exported = append(exported, post)
+	}
+
+	return exported, skipped, nil
+}
+
+// exportPostReactions groups the reactions of a post by emoji, in the order each emoji was first
+// used, leaving out users that are not exported
+func exportPostReactions(post *model.Post, usernames map[string]string) []exportReaction {
+	if post.Metadata == nil {
+		return nil
+	}
+
+	var reactions []exportReaction
+	for _, reaction := range post.Metadata.Reactions {
+		username, ok := usernames[reaction.UserId]
+		if !ok {
+			continue
+		}
+		i := slices.IndexFunc(reactions, func(r exportReaction) bool { return r.EmojiName == reaction.EmojiName })
+		if i < 0 {
+			reactions = append(reactions, exportReaction{EmojiName: reaction.EmojiName})
+			i = len(reactions) - 1
+		}
+		reactions[i].Users = append(reactions[i].Users, username)
+	}
+	return reactions
+}
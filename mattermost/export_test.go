@@ -0,0 +1,124 @@
+package mattermost
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+// newExportServer answers the listing calls Export makes for one team with two channels
+func newExportServer() *mockServer {
+	server := newMockServer()
+	// Every list fits in the first page
+	respond := func(path string, v any) {
+		server.handle("GET "+path, func(w http.ResponseWriter, r *http.Request) {
+			if page := r.URL.Query().Get("page"); page != "" && page != "0" {
+				writeJSON(w, http.StatusOK, []any{})
+				return
+			}
+			writeJSON(w, http.StatusOK, v)
+		})
+	}
+
+	bannerText, bannerColor, bannerEnabled := "CLASSIFIED", "#FF0000", true
+	respond("/api/v4/teams", []*model.Team{
+		{Id: "team1", Name: "demo", DisplayName: "Demo", Type: model.TeamOpen},
+		{Id: "team2", Name: "gone", DisplayName: "Gone", Type: model.TeamOpen, DeleteAt: 1},
+	})
+	respond("/api/v4/teams/name/demo", model.Team{Id: "team1", Name: "demo"})
+	respond("/api/v4/users", []*model.User{
+		{Id: "u1", Username: "alice", Email: "alice@example.com", Roles: "system_user"},
+		{Id: "u2", Username: "bob", Email: "bob@example.com", Roles: "system_user"},
+		{Id: "b1", Username: "weather-bot", IsBot: true},
+	})
+	respond("/api/v4/teams/team1/members", []*model.TeamMember{
+		{TeamId: "team1", UserId: "u1", Roles: "team_user team_admin"},
+		{TeamId: "team1", UserId: "u2", Roles: "team_user"},
+	})
+	respond("/api/v4/teams/team1/channels", []*model.Channel{
+		{Id: "ch-ops", TeamId: "team1", Name: "ops", DisplayName: "Ops", Type: model.ChannelTypeOpen, Purpose: "Operations",
+			BannerInfo: &model.ChannelBannerInfo{Text: &bannerText, BackgroundColor: &bannerColor, Enabled: &bannerEnabled}},
+		{Id: "ch-old", TeamId: "team1", Name: "old", Type: model.ChannelTypeOpen, DeleteAt: 1},
+	})
+	respond("/api/v4/teams/team1/channels/private", []*model.Channel{{Id: "ch-intel", TeamId: "team1", Name: "intel", DisplayName: "Intel", Type: model.ChannelTypePrivate}})
+	respond("/plugins/playbooks/api/v0/actions/channels/ch-ops", []map[string]any{
+		{"action_type": "categorize_channel", "enabled": true, "payload": map[string]string{"category_name": "Operations"}},
+	})
+	respond("/api/v4/channels/ch-ops/members", []model.ChannelMember{{ChannelId: "ch-ops", UserId: "u1", Roles: "channel_user channel_admin"}, {ChannelId: "ch-ops", UserId: "u2", Roles: "channel_user"}})
+	respond("/api/v4/channels/ch-intel/members", []model.ChannelMember{{ChannelId: "ch-intel", UserId: "u1", Roles: "channel_user"}})
+
+	posts := model.NewPostList()
+	for _, post := range []*model.Post{
+		{Id: "p4", UserId: "b1", Message: "Forecast", CreateAt: 4000},
+		{Id: "p3", UserId: "u2", RootId: "p2", Message: "Copy", CreateAt: 3000},
+		{Id: "p2", UserId: "u1", Message: "Wheels up", CreateAt: 2000, Metadata: &model.PostMetadata{Reactions: []*model.Reaction{
+			{UserId: "u2", EmojiName: "rocket"}, {UserId: "b1", EmojiName: "rocket"},
+		}}},
+		{Id: "p1", UserId: "u1", Type: model.PostTypeJoinChannel, Message: "alice joined", CreateAt: 1000},
+	} {
+		posts.AddPost(post)
+		posts.AddOrder(post.Id)
+	}
+	respond("/api/v4/channels/ch-ops/posts", posts)
+	respond("/api/v4/channels/ch-intel/posts", model.NewPostList())
+	return server
+}
+
+// TestExport verifies the exported file lists the live teams, channels, categories, banners, users
+// with their memberships and posts by exported users, in import order
+func TestExport(t *testing.T) {
+	client := setupMockClient(t, newExportServer())
+
+	path := filepath.Join(t.TempDir(), "export.jsonl")
+	if err := client.Export(path); err != nil {
+		t.Fatalf("Export returned error: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read export file: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+
+	expected := []string{
+		`{"type":"version","version":1}`,
+		`{"type":"team","team":{"name":"demo","display_name":"Demo","type":"O","description":""}}`,
+		`{"type":"channel","channel":{"team":"demo","name":"intel","display_name":"Intel","type":"P"}}`,
+		`{"type":"channel","channel":{"team":"demo","name":"ops","display_name":"Ops","type":"O","purpose":"Operations"}}`,
+		`{"type":"channel-category","category":"Operations","team":"demo","channels":["ops"]}`,
+		`{"type":"channel-banner","banner":{"team":"demo","channel":"ops","text":"CLASSIFIED","background_color":"#FF0000","enabled":true}}`,
+		`{"type":"user","user":{"username":"alice","email":"alice@example.com","roles":"system_user","teams":[{"name":"demo","roles":"team_user team_admin","channels":[{"name":"intel","roles":"channel_user"},{"name":"ops","roles":"channel_user channel_admin"}]}]}}`,
+		`{"type":"user","user":{"username":"bob","email":"bob@example.com","roles":"system_user","teams":[{"name":"demo","roles":"team_user","channels":[{"name":"ops","roles":"channel_user"}]}]}}`,
+		`{"type":"post","post":{"team":"demo","channel":"ops","user":"alice","message":"Wheels up","create_at":2000,"replies":[{"user":"bob","message":"Copy","create_at":3000}],"reactions":[{"emoji_name":"rocket","users":["bob"]}]}}`,
+	}
+	if len(lines) != len(expected) {
+		t.Fatalf("Expected %d lines, got %d:\n%s", len(expected), len(lines), data)
+	}
+	for i, line := range lines {
+		if line != expected[i] {
+			t.Errorf("Line %d:\nexpected %s\ngot      %s", i+1, expected[i], line)
+		}
+	}
+}
+
+// TestExportPostsPerChannel verifies only the most recent posts are exported, dropping replies
+// whose root post falls outside the limit
+func TestExportPostsPerChannel(t *testing.T) {
+	client := setupMockClient(t, newExportServer())
+	client.ExportPostsPerChannel = 2
+
+	usernames := map[string]string{"u1": "alice", "u2": "bob"}
+	posts, skipped, err := client.exportChannelPosts(t.Context(), "demo", &model.Channel{Id: "ch-ops", Name: "ops"}, usernames)
+	if err != nil {
+		t.Fatalf("exportChannelPosts returned error: %v", err)
+	}
+
+	// The two most recent posts are the bot's post, which is skipped, and a reply whose root is older
+	if len(posts) != 0 || skipped != 1 {
+		t.Errorf("Expected no posts and 1 skipped, got %d posts and %d skipped", len(posts), skipped)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"bufio"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -16,12 +17,15 @@ import (
 	"github.com/sirupsen/logrus"
 )
 
-// Global storage for channel memberships during import processing
-// Simple map: username -> list of channel names
-var globalChannelMemberships = make(map[string][]string)
+// channelMembership is a channel a user belongs to, with the team the channel is in
+type channelMembership struct {
+	Team    string
+	Channel string
+}
 
-// Global storage for teams during import processing
-var globalImportedTeams = make([]string, 0)
+// Global storage for channel memberships during import processing
+// Map: username -> list of team and channel pairs
+var globalChannelMemberships = make(map[string][]channelMembership)
 
 // Global storage for current import file path
 var globalCurrentImportPath string
@@ -36,6 +40,9 @@ var (
 	offsetCalculated bool  = false
 )
 
+// now returns the reference time that post timestamps are made recent to; tests replace it
+var now = time.Now
+
 // JSON helper functions for clean, readable code
 
 // getNestedString safely gets a string value from nested JSON data
@@ -59,9 +66,9 @@ func getNestedString(data map[string]any, keys ...string) string {
 	return ""
 }
 
-// extractAllChannelNames gets all channel names from the user data
-func extractAllChannelNames(data map[string]any) []string {
-	var channels []string
+// extractAllChannelNames gets all channel names from the user data, with the team each is listed under
+func extractAllChannelNames(data map[string]any) []channelMembership {
+	var channels []channelMembership
 
 	user, ok := data["user"].(map[string]any)
 	if !ok {
@@ -75,11 +82,12 @@ func extractAllChannelNames(data map[string]any) []string {
 
 	for _, teamIntf := range teams {
 		if team, ok := teamIntf.(map[string]any); ok {
+			teamName, _ := team["name"].(string)
 			if channelsIntf, ok := team["channels"].([]any); ok {
 				for _, chIntf := range channelsIntf {
 					if ch, ok := chIntf.(map[string]any); ok {
 						if name, ok := ch["name"].(string); ok {
-							channels = append(channels, name)
+							channels = append(channels, channelMembership{Team: teamName, Channel: name})
 						}
 					}
 				}
@@ -153,11 +161,11 @@ func extractChannelMemberships(userLine string) (string, error) {
 	return string(cleanedJSON), nil
 }
 
-// adjustPostTimestamps adjusts post timestamps to be recent while preserving relative order
-func adjustPostTimestamps(postLine string) (string, error) {
+// adjustPostTimestampsAt adjusts post timestamps to be recent relative to now while preserving relative order
+func adjustPostTimestampsAt(postLine string, now time.Time) (string, error) {
 	// Calculate offset once on first post
 	if !offsetCalculated {
-		if err := calculateTimestampOffset(); err != nil {
+		if err := calculateTimestampOffset(now); err != nil {
 			Log.WithFields(logrus.Fields{"error": err.Error()}).Warn("⚠️ Failed to calculate timestamp offset")
 			return postLine, nil
 		}
@@ -182,21 +190,32 @@ func adjustPostTimestamps(postLine string) (string, error) {
 	return string(adjustedJSON), nil
 }
 
+// postData returns the post object of a post or direct_post line
+func postData(data map[string]any) (map[string]any, bool) {
+	if post, ok := data["post"].(map[string]any); ok {
+		return post, true
+	}
+	post, ok := data["direct_post"].(map[string]any)
+	return post, ok
+}
+
 // adjustAllTimestamps applies offset to all timestamp fields in post data
 func adjustAllTimestamps(data map[string]any) {
-	post, ok := data["post"].(map[string]any)
+	post, ok := postData(data)
 	if !ok {
 		return
 	}
 
 	// Main post timestamp
 	adjustTimestampField(post, "create_at")
+	adjustReactionTimestamps(post)
 
 	// Reply timestamps
 	if replies, ok := post["replies"].([]any); ok {
 		for _, replyIntf := range replies {
 			if reply, ok := replyIntf.(map[string]any); ok {
 				adjustTimestampField(reply, "create_at")
+				adjustReactionTimestamps(reply)
 			}
 		}
 	}
@@ -208,6 +227,17 @@ func adjustAllTimestamps(data map[string]any) {
 	}
 }
 
+// adjustReactionTimestamps keeps the reactions of a post or reply after it once it has moved
+func adjustReactionTimestamps(post map[string]any) {
+	if reactions, ok := post["reactions"].([]any); ok {
+		for _, reactionIntf := range reactions {
+			if reaction, ok := reactionIntf.(map[string]any); ok {
+				adjustTimestampField(reaction, "create_at")
+			}
+		}
+	}
+}
+
 // adjustTimestampField adds offset to a single timestamp field
 func adjustTimestampField(obj map[string]any, field string) {
 	if timestamp, ok := obj[field].(float64); ok {
@@ -215,16 +245,15 @@ func adjustTimestampField(obj map[string]any, field string) {
 	}
 }
 
-// calculateTimestampOffset calculates how much to shift timestamps to make posts recent
-func calculateTimestampOffset() error {
+// calculateTimestampOffset calculates how much to shift timestamps to make posts recent as of now
+func calculateTimestampOffset(now time.Time) error {
 	maxTimestamp, err := findLatestTimestamp()
 	if err != nil {
 		return err
 	}
 
 	// Make newest post ~5 minutes ago
-	now := time.Now().Unix() * 1000
-	fiveMinutesAgo := now - (5 * 60 * 1000)
+	fiveMinutesAgo := now.Unix()*1000 - (5 * 60 * 1000)
 	timestampOffset = fiveMinutesAgo - maxTimestamp
 
 	return nil
@@ -253,7 +282,7 @@ func findLatestTimestamp() (int64, error) {
 
 	for scanner.Scan() {
 		line := strings.TrimSpace(scanner.Text())
-		if !strings.Contains(line, `"type": "post"`) {
+		if !strings.Contains(line, `"type": "post"`) && !strings.Contains(line, `"type": "direct_post"`) {
 			continue
 		}
 
@@ -282,7 +311,7 @@ func findLatestTimestamp() (int64, error) {
 func extractAllTimestampsFromPost(data map[string]any) []int64 {
 	var timestamps []int64
 
-	post, ok := data["post"].(map[string]any)
+	post, ok := postData(data)
 	if !ok {
 		return timestamps
 	}
@@ -364,7 +393,7 @@ func CreateZipFile(jsonlPath, zipPath string) error {
 }
 
 // ImportBulkData handles the complete bulk import process
-func (c *Client) ImportBulkData(filePath string) error {
+func (c *Client) ImportBulkData(ctx context.Context, filePath string) error {
 	zipPath := filePath + ".zip"
 
 	// Clean up temp files when done
@@ -382,14 +411,14 @@ func (c *Client) ImportBulkData(filePath string) error {
 	}
 
 	// Upload file using upload session
-	importFileName, err := c.uploadImportFile(zipPath)
+	importFileName, err := c.uploadImportFile(ctx, zipPath)
 	if err != nil {
 		return fmt.Errorf("failed to upload import file: %w", err)
 	}
 
 	// Start import job
 	Log.WithFields(logrus.Fields{"import_file": importFileName}).Info("🚀 Creating import job for file")
-	job, resp, err := c.API.CreateJob(context.Background(), &model.Job{
+	job, resp, err := c.API.CreateJob(ctx, &model.Job{
 		Type: model.JobTypeImportProcess,
 		Data: map[string]string{
 			"import_file": importFileName,
@@ -400,12 +429,17 @@ func (c *Client) ImportBulkData(filePath string) error {
 	}
 	Log.WithFields(logrus.Fields{"job_id": job.Id}).Info("✅ Import job created")
 
+	// No job was created in dry-run mode, so there is nothing to wait for
+	if c.DryRun {
+		return nil
+	}
+
 	// Wait for completion
-	return c.waitForJobCompletion(job)
+	return c.waitForJobCompletion(ctx, job)
 }
 
 // uploadImportFile uploads a file using the upload session mechanism
-func (c *Client) uploadImportFile(zipPath string) (string, error) {
+func (c *Client) uploadImportFile(ctx context.Context, zipPath string) (string, error) {
 	file, err := os.Open(zipPath)
 	if err != nil {
 		return "", fmt.Errorf("failed to open zip file: %w", err)
@@ -425,13 +459,13 @@ func (c *Client) uploadImportFile(zipPath string) (string, error) {
 	}
 
 	// Get current user
-	user, _, err := c.API.GetMe(context.Background(), "")
+	user, _, err := c.API.GetMe(ctx, "")
 	if err != nil {
 		return "", fmt.Errorf("failed to get current user: %w", err)
 	}
 
 	// Create upload session
-	uploadSession, resp, err := c.API.CreateUpload(context.Background(), &model.UploadSession{
+	uploadSession, resp, err := c.API.CreateUpload(ctx, &model.UploadSession{
 		Filename: fileInfo.Name(),
 		FileSize: fileInfo.Size(),
 		Type:     model.UploadTypeImport,
@@ -442,7 +476,7 @@ func (c *Client) uploadImportFile(zipPath string) (string, error) {
 	}
 
 	// Upload the file data
-	_, resp, err = c.API.UploadData(context.Background(), uploadSession.Id, file)
+	_, resp, err = c.API.UploadData(ctx, uploadSession.Id, file)
 	if err != nil {
 		return "", handleAPIError("failed to upload file data: %w", err, resp)
 	}
@@ -457,10 +491,16 @@ func (c *Client) uploadImportFile(zipPath string) (string, error) {
 	return actualFileName, nil
 }
 
-// waitForJobCompletion waits for a job to complete
-func (c *Client) waitForJobCompletion(job *model.Job) error {
+// waitForJobCompletion waits for a job to complete or ctx to end
+func (c *Client) waitForJobCompletion(ctx context.Context, job *model.Job) error {
 	for {
-		currentJob, resp, err := c.API.GetJob(context.Background(), job.Id)
+		var currentJob *model.Job
+		var resp *model.Response
+		err := c.withRetry(ctx, "get import job status", func() (*model.Response, error) {
+			var err error
+			currentJob, resp, err = c.API.GetJob(ctx, job.Id)
+			return resp, err
+		})
 		if err != nil {
 			return handleAPIError("failed to get job status", err, resp)
 		}
@@ -473,7 +513,11 @@ func (c *Client) waitForJobCompletion(job *model.Job) error {
 		case model.JobStatusCanceled:
 			return fmt.Errorf("import job was canceled")
 		case model.JobStatusPending, model.JobStatusInProgress:
-			time.Sleep(2 * time.Second)
+			select {
+			case <-ctx.Done():
+				return fmt.Errorf("stopped waiting for import job %s: %w", job.Id, ctx.Err())
+			case <-time.After(2 * time.Second):
+			}
 			continue
 		default:
 			return fmt.Errorf("unknown job status: %s", currentJob.Status)
@@ -494,11 +538,13 @@ func findBulkImportPath() (string, error) {
 
 // SetupWithSplitImport performs setup using two-phase bulk import
 func (c *Client) SetupWithSplitImport() error {
-	return c.SetupWithSplitImportAndForce(false, false)
+	return c.SetupWithSplitImportAndForce(context.Background(), false, false)
 }
 
-// SetupWithSplitImportAndForce performs setup using two-phase bulk import with force options
-func (c *Client) SetupWithSplitImportAndForce(forcePlugins, forceGitHubPlugins bool) error {
+// SetupWithSplitImportAndForce performs setup using two-phase bulk import with force options.
+// When ResetOnFailure is set, a failure in any phase rolls back the users and teams in the import file.
+// When ctx ends, the error names the phase that was active.
+func (c *Client) SetupWithSplitImportAndForce(ctx context.Context, forcePlugins, forceGitHubPlugins bool) (err error) {
 	// Use the client's BulkImportPath if set, otherwise find the default
 	bulkImportPath := c.BulkImportPath
 	if bulkImportPath == "" {
@@ -509,81 +555,224 @@ func (c *Client) SetupWithSplitImportAndForce(forcePlugins, forceGitHubPlugins b
 		bulkImportPath = path
 	}
 
+	if c.VerifyBeforeImport {
+		if err := c.VerifyBulkImport(bulkImportPath); err != nil {
+			return fmt.Errorf("bulk import file failed verification: %w", err)
+		}
+	}
+
+	// Load the rollback targets before any phase runs so a failure can be undone
+	if c.ResetOnFailure {
+		bulkData, loadErr := c.LoadBulkImportData()
+		if loadErr != nil {
+			return fmt.Errorf("failed to load bulk import data for rollback: %w", loadErr)
+		}
+		defer func() {
+			if err != nil {
+				c.rollbackImport(bulkData, err)
+			}
+		}()
+	}
+
 	Log.WithFields(logrus.Fields{
 		"file": bulkImportPath,
 	}).Info("🚀 Starting two-phase bulk import")
 
-	if err := c.importInfrastructure(bulkImportPath); err != nil {
+	phase := "infrastructure import"
+	defer func() {
+		err = setupPhaseError(ctx, phase, err)
+	}()
+
+	if err := c.importInfrastructure(ctx, bulkImportPath); err != nil {
 		return fmt.Errorf("failed to import infrastructure: %w", err)
 	}
 
-	if err := c.processPlugins(bulkImportPath, forcePlugins, forceGitHubPlugins); err != nil {
+	phase = "config channel setup"
+	if err := c.setupConfigChannels(); err != nil {
+		return fmt.Errorf("failed to set up config channels: %w", err)
+	}
+
+	phase = "webhook setup"
+	if err := c.setupWebhooks(); err != nil {
+		return fmt.Errorf("failed to set up webhooks: %w", err)
+	}
+
+	phase = "incoming webhooks"
+	if err := c.processIncomingWebhooks(bulkImportPath); err != nil {
+		return fmt.Errorf("failed to process incoming webhooks: %w", err)
+	}
+
+	phase = "slash commands"
+	if err := c.processSlashCommands(bulkImportPath); err != nil {
+		return fmt.Errorf("failed to process slash commands: %w", err)
+	}
+
+	phase = "plugin setup"
+	if err := c.processPlugins(ctx, bulkImportPath, forcePlugins, forceGitHubPlugins); err != nil {
 		return fmt.Errorf("failed to process plugins: %w", err)
 	}
 
+	phase = "channel categories"
 	if err := c.processChannelCategories(bulkImportPath); err != nil {
 		return fmt.Errorf("failed to process channel categories: %w", err)
 	}
 
+	phase = "channel banners"
 	if err := c.processChannelBanners(bulkImportPath); err != nil {
 		return fmt.Errorf("failed to process channel banners: %w", err)
 	}
 
+	phase = "channel commands"
 	if err := c.processCommands(bulkImportPath); err != nil {
 		return fmt.Errorf("failed to process commands: %w", err)
 	}
 
-	if err := c.importUsers(bulkImportPath); err != nil {
+	phase = "user import"
+	if err := c.importUsers(ctx, bulkImportPath); err != nil {
 		return fmt.Errorf("failed to import users: %w", err)
 	}
 
+	phase = "channel memberships"
 	if err := c.processChannelMemberships(); err != nil {
 		return fmt.Errorf("failed to process channel memberships: %w", err)
 	}
 
+	phase = "channel member roles"
+	if err := c.applyChannelDefaultRoles(); err != nil {
+		return fmt.Errorf("failed to set channel member roles: %w", err)
+	}
+
+	phase = "user sidebar categories"
 	if err := c.createUserSidebarCategories(); err != nil {
 		return fmt.Errorf("failed to create user sidebar categories: %w", err)
 	}
 
+	phase = "user attributes"
 	if err := c.processUserAttributes(bulkImportPath); err != nil {
 		return fmt.Errorf("failed to process user attributes: %w", err)
 	}
 
-	if err := c.importPosts(bulkImportPath); err != nil {
+	phase = "user profiles"
+	if err := c.processUserProfiles(bulkImportPath); err != nil {
+		return fmt.Errorf("failed to process user profiles: %w", err)
+	}
+
+	phase = "bot accounts"
+	if err := c.processBots(bulkImportPath); err != nil {
+		return fmt.Errorf("failed to process bot accounts: %w", err)
+	}
+
+	phase = "custom emoji"
+	if err := c.processEmoji(bulkImportPath); err != nil {
+		return fmt.Errorf("failed to process custom emoji: %w", err)
+	}
+
+	if !c.SkipDirectMessages {
+		phase = "direct messages"
+		if err := c.processDirectMessages(ctx, bulkImportPath); err != nil {
+			return fmt.Errorf("failed to process direct messages: %w", err)
+		}
+	}
+
+	phase = "post import"
+	if err := c.importPosts(ctx, bulkImportPath); err != nil {
 		return fmt.Errorf("failed to import posts: %w", err)
 	}
 
+	phase = "direct message import"
+	if err := c.importDirectMessages(ctx, bulkImportPath); err != nil {
+		return fmt.Errorf("failed to import direct messages: %w", err)
+	}
+
 	return nil
 }
 
+// rollbackImport deletes the users and then the teams from the import file after a failed setup.
+// Rollback errors are logged rather than returned so the original setup error is preserved.
+func (c *Client) rollbackImport(bulkData *BulkImportData, setupErr error) {
+	Log.WithFields(logrus.Fields{
+		"error":       setupErr.Error(),
+		"users_count": len(bulkData.Users),
+		"teams_count": len(bulkData.Teams),
+	}).Warn("⏪ Setup failed, rolling back imported users and teams")
+
+	if err := c.CheckDeletionSettings(); err != nil {
+		Log.WithFields(logrus.Fields{"error": err.Error()}).Error("❌ Rollback aborted, deletion APIs are not enabled")
+		return
+	}
+
+	// Users are deleted before teams, matching the order used by Reset
+	Log.Info("⏪ Rollback step 1/2: deleting imported users")
+	if err := c.DeleteBulkUsers(bulkData.Users); err != nil {
+		Log.WithFields(logrus.Fields{"error": err.Error()}).Error("❌ Rollback failed to delete users")
+	}
+
+	Log.Info("⏪ Rollback step 2/2: deleting imported teams")
+	if err := c.DeleteBulkTeams(bulkData.Teams); err != nil {
+		Log.WithFields(logrus.Fields{"error": err.Error()}).Error("❌ Rollback failed to delete teams")
+		return
+	}
+
+	Log.Info("✅ Rollback completed")
+}
+
 // importInfrastructure imports teams and channels
-func (c *Client) importInfrastructure(bulkImportPath string) error {
+func (c *Client) importInfrastructure(ctx context.Context, bulkImportPath string) error {
 	Log.WithFields(logrus.Fields{"import_type": "infrastructure", "file_path": bulkImportPath}).Info("📋 Processing infrastructure import")
-	return c.processLines(bulkImportPath, []string{"team", "channel"}, c.ImportBulkData)
+	return c.processLines(ctx, bulkImportPath, []string{"team", "channel"}, c.ImportBulkData)
 }
 
 // importUsers imports only users first
-func (c *Client) importUsers(bulkImportPath string) error {
+func (c *Client) importUsers(ctx context.Context, bulkImportPath string) error {
 	Log.WithFields(logrus.Fields{"import_type": "users", "file_path": bulkImportPath}).Info("👥 Processing users import")
-	return c.processLines(bulkImportPath, []string{"user"}, c.ImportBulkData)
+	return c.processLines(ctx, bulkImportPath, []string{"user"}, c.ImportBulkData)
 }
 
 // importPosts imports posts after users are created
-func (c *Client) importPosts(bulkImportPath string) error {
+func (c *Client) importPosts(ctx context.Context, bulkImportPath string) error {
 	Log.WithFields(logrus.Fields{"import_type": "posts", "file_path": bulkImportPath}).Info("💬 Processing posts import")
-	return c.processLines(bulkImportPath, []string{"post"}, c.ImportBulkData)
+	return c.processLines(ctx, bulkImportPath, []string{"post"}, c.ImportBulkData)
+}
+
+// importDirectMessages imports direct and group message channels and their posts after posts
+func (c *Client) importDirectMessages(ctx context.Context, bulkImportPath string) error {
+	Log.WithFields(logrus.Fields{"import_type": "direct messages", "file_path": bulkImportPath}).Info("✉️ Processing direct messages import")
+	return c.processLines(ctx, bulkImportPath, []string{"direct_channel", "direct_post"}, c.ImportBulkData)
 }
 
-// processLines processes specific line types from bulk import file
-func (c *Client) processLines(bulkImportPath string, lineTypes []string, processor func(string) error) error {
+// processLines processes specific line types from bulk import file. Each phase starts where
+// the previous one stopped, using the offsets cached beside the import file.
+func (c *Client) processLines(ctx context.Context, bulkImportPath string, lineTypes []string, processor func(context.Context, string) error) error {
 	// Store the current import path globally for timestamp processing
 	globalCurrentImportPath = bulkImportPath
 
-	file, err := os.Open(bulkImportPath)
+	offsets, err := loadImportOffsetCache(bulkImportPath)
 	if err != nil {
-		return fmt.Errorf("failed to open bulk import file: %w", err)
+		return err
+	}
+
+	start := offsets.startOffset(lineTypes)
+	lines, end, err := scanPhase(bulkImportPath, lineTypes, start)
+	if err != nil {
+		return err
+	}
+	Log.WithFields(logrus.Fields{
+		"line_types":   lineTypes,
+		"start_offset": start,
+		"end_offset":   end,
+	}).Debug("📋 Scanned bulk import file")
+
+	userExists := c.newUserExistsCache(ctx)
+
+	// Direct messages fail the whole import job if a participant does not exist
+	if slices.Contains(lineTypes, "direct_channel") || slices.Contains(lineTypes, "direct_post") {
+		lines = filterDirectLines(lines, userExists)
+	}
+
+	offsets.record(lineTypes, start, end)
+	if err := offsets.save(bulkImportPath); err != nil {
+		Log.WithFields(logrus.Fields{"error": err.Error()}).Warn("⚠️ Failed to save import offsets")
 	}
-	defer closeWithLog(file, "bulk import file")
 
 	tempFile, err := os.CreateTemp("", "import_*.jsonl")
 	if err != nil {
@@ -596,102 +785,77 @@ func (c *Client) processLines(bulkImportPath string, lineTypes []string, process
 		return fmt.Errorf("failed to write version line: %w", err)
 	}
 
-	// Define custom types that should be skipped during bulk import
-	customTypes := map[string]bool{
-		"channel-category": true,
-		"channel-banner":   true,
-		"command":          true,
-		"plugin":           true,
-		"user-attribute":   true,
-		"user-profile":     true,
-		"user-groups":      true,
-	}
+	// Every post in the phase is made recent relative to the same moment
+	referenceTime := now()
 
-	scanner := bufio.NewScanner(file)
 	count := 0
-	for scanner.Scan() {
-		line := strings.TrimSpace(scanner.Text())
-		if line == "" {
-			continue
-		}
-
-		// First, extract just the type field to check if it's a custom type
-		var typeCheck struct {
-			Type string `json:"type"`
-		}
-		if err := json.Unmarshal([]byte(line), &typeCheck); err != nil {
-			// If we can't even parse the type, skip with warning
-			Log.WithFields(logrus.Fields{"line": line}).Warn("⚠️ Failed to parse type from line, skipping")
-			continue
-		}
-
-		// Skip custom types silently (no warning)
-		if customTypes[typeCheck.Type] {
-			continue
-		}
-
-		// For standard types, try to unmarshal as BulkImportLine
+	for _, line := range lines {
+		// scanPhase only returns lines that parsed as a BulkImportLine
 		var importLine BulkImportLine
 		if err := json.Unmarshal([]byte(line), &importLine); err != nil {
-			// Only warn for non-custom types that fail to parse
-			Log.WithFields(logrus.Fields{"line": line}).Warn("⚠️ Failed to parse standard import line, skipping")
 			continue
 		}
+		lineToWrite := line
 
-		if slices.Contains(lineTypes, importLine.Type) {
-			lineToWrite := line
-
-			// Special handling for team entries - store team names
-			if importLine.Type == "team" {
-				var teamData map[string]any
-				if err := json.Unmarshal([]byte(line), &teamData); err == nil {
-					if teamName := getNestedString(teamData, "team", "name"); teamName != "" {
-						if !slices.Contains(globalImportedTeams, teamName) {
-							globalImportedTeams = append(globalImportedTeams, teamName)
-							Log.WithFields(logrus.Fields{
-								"team_name": teamName,
-							}).Debug("📋 Stored team name for channel membership processing")
-						}
-					}
-				}
+		// Special handling for user entries - extract channel memberships
+		if importLine.Type == "user" {
+			cleanedLine, err := extractChannelMemberships(line)
+			if err != nil {
+				Log.WithFields(logrus.Fields{
+					"username":      "unknown",
+					"error":         err.Error(),
+					"original_line": line,
+				}).Warn("⚠️ Failed to extract channel memberships from user, using original line")
+			} else {
+				lineToWrite = cleanedLine
+				Log.WithFields(logrus.Fields{
+					"total_users_stored": len(globalChannelMemberships),
+					"original_line":      line,
+					"cleaned_line":       cleanedLine,
+				}).Debug("📋 Extracted channel memberships from user")
 			}
+		}
 
-			// Special handling for user entries - extract channel memberships
-			if importLine.Type == "user" {
-				cleanedLine, err := extractChannelMemberships(line)
-				if err != nil {
-					Log.WithFields(logrus.Fields{
-						"username":      "unknown",
-						"error":         err.Error(),
-						"original_line": line,
-					}).Warn("⚠️ Failed to extract channel memberships from user, using original line")
-				} else {
-					lineToWrite = cleanedLine
-					Log.WithFields(logrus.Fields{
-						"total_users_stored": len(globalChannelMemberships),
-						"original_line":      line,
-						"cleaned_line":       cleanedLine,
-					}).Debug("📋 Extracted channel memberships from user")
-				}
+		// Special handling for direct channels - turn member usernames into participants
+		if importLine.Type == "direct_channel" {
+			convertedLine, err := convertDirectChannelMembers(line)
+			if err != nil {
+				Log.WithFields(logrus.Fields{
+					"error": err.Error(),
+				}).Warn("⚠️ Failed to convert direct channel members, using original")
+			} else {
+				lineToWrite = convertedLine
 			}
+		}
 
-			// Special handling for posts - adjust timestamps to be recent
-			if importLine.Type == "post" {
-				adjustedLine, err := adjustPostTimestamps(line)
-				if err != nil {
-					Log.WithFields(logrus.Fields{
-						"error": err.Error(),
-					}).Warn("⚠️ Failed to adjust post timestamps, using original")
-				} else {
-					lineToWrite = adjustedLine
-				}
+		// Special handling for post reactions - one bulk import reaction per reacting user
+		if importLine.Type == "post" {
+			expandedLine, err := expandPostReactions(lineToWrite, userExists)
+			if err != nil {
+				Log.WithFields(logrus.Fields{
+					"error": err.Error(),
+				}).Warn("⚠️ Failed to expand post reactions, using original")
+			} else {
+				lineToWrite = expandedLine
 			}
+		}
 
-			if _, err := tempFile.WriteString(lineToWrite + "\n"); err != nil {
-				return fmt.Errorf("failed to write line: %w", err)
+		// Special handling for posts - adjust timestamps to be recent
+		if importLine.Type == "post" || importLine.Type == "direct_post" {
+			adjustedLine, err := adjustPostTimestampsAt(lineToWrite, referenceTime)
+			if err != nil {
+				Log.WithFields(logrus.Fields{
+					"error": err.Error(),
+				}).Warn("⚠️ Failed to adjust post timestamps, using original")
+			} else {
+				lineToWrite = adjustedLine
 			}
-			count++
 		}
+
+		if _, err := tempFile.WriteString(lineToWrite + "\n"); err != nil {
+			return fmt.Errorf("failed to write line: %w", err)
+		}
+		count++
 	}
 
 	if err := tempFile.Close(); err != nil {
@@ -704,49 +868,63 @@ func (c *Client) processLines(bulkImportPath string, lineTypes []string, process
 	}
 
 	Log.WithFields(logrus.Fields{"line_types": lineTypes, "count": count}).Info("📤 Processing items for bulk import")
-	return processor(tempFile.Name())
+	return processor(ctx, tempFile.Name())
 }
 
-// processChannelMemberships joins users to channels via API to trigger hooks
+// processChannelMemberships joins users to channels via API to trigger hooks. Each channel is looked
+// up in the team the user's import entry lists it under.
 func (c *Client) processChannelMemberships() error {
 	if len(globalChannelMemberships) == 0 {
 		Log.Info("ℹ️ No channel memberships to process")
 		return nil
 	}
 
-	if len(globalImportedTeams) == 0 {
-		Log.Warn("⚠️ No teams found during import, skipping channel memberships")
-		return nil
+	// Look up every team named by a membership
+	var teamNames []string
+	for _, memberships := range globalChannelMemberships {
+		for _, membership := range memberships {
+			if !slices.Contains(teamNames, membership.Team) {
+				teamNames = append(teamNames, membership.Team)
+			}
+		}
 	}
+	slices.Sort(teamNames)
 
 	Log.WithFields(logrus.Fields{
 		"total_users": len(globalChannelMemberships),
-		"teams":       globalImportedTeams,
+		"teams":       teamNames,
 	}).Info("👥 Processing channel memberships via API")
 
-	joinedCount := 0
-	errorCount := 0
-
-	// Get all imported teams
 	teams := make(map[string]*model.Team)
-	for _, teamName := range globalImportedTeams {
-		team, _, err := c.API.GetTeamByName(context.Background(), teamName, "")
+	var teamErrors []error
+	for _, teamName := range teamNames {
+		team, resp, err := c.API.GetTeamByName(context.Background(), teamName, "")
 		if err != nil {
+			err = handleAPIError(fmt.Sprintf("failed to find team '%s'", teamName), err, resp)
 			Log.WithFields(logrus.Fields{
 				"team_name": teamName,
 				"error":     err.Error(),
 			}).Warn("⚠️ Failed to find team for channel membership")
+			teamErrors = append(teamErrors, err)
 			continue
 		}
 		teams[teamName] = team
 	}
 
 	if len(teams) == 0 {
-		return fmt.Errorf("no teams found for channel membership processing")
+		if c.DryRun {
+			Log.Info("ℹ️ Dry run: imported teams do not exist yet, skipping channel memberships")
+			return nil
+		}
+		return fmt.Errorf("no teams found for channel membership processing: %w", errors.Join(teamErrors...))
 	}
 
+	joinedCount := 0
+	errorCount := 0
+	errorsByTeam := make(map[string]int)
+
 	// Process each user's channels
-	for username, channels := range globalChannelMemberships {
+	for username, memberships := range globalChannelMemberships {
 		// Get user by username
 		user, _, err := c.API.GetUserByUsername(context.Background(), username, "")
 		if err != nil {
@@ -759,69 +937,70 @@ func (c *Client) processChannelMemberships() error {
 		}
 
 		// Join user to ALL channels (no filtering - let API handle duplicates)
-		for _, channelName := range channels {
-			channelFound := false
-
-			// Try to find the channel in any of the teams
-			for teamName, team := range teams {
-				channel, _, err := c.API.GetChannelByName(context.Background(), channelName, team.Id, "")
-				if err != nil {
-					continue // Try next team
-				}
+		for _, membership := range memberships {
+			team, ok := teams[membership.Team]
+			if !ok {
+				// The team lookup already failed and was logged
+				errorsByTeam[membership.Team]++
+				errorCount++
+				continue
+			}
 
-				channelFound = true
+			channel, _, err := c.API.GetChannelByName(context.Background(), membership.Channel, team.Id, "")
+			if err != nil {
+				Log.WithFields(logrus.Fields{
+					"channel_name": membership.Channel,
+					"username":     username,
+					"team":         membership.Team,
+				}).Warn("⚠️ Failed to find channel in team")
+				errorsByTeam[membership.Team]++
+				errorCount++
+				continue
+			}
 
-				// Add user to channel via API (triggers hooks)
-				_, _, err = c.API.AddChannelMember(context.Background(), channel.Id, user.Id)
-				if err != nil {
-					// Check if user is already a member (not an error)
-					if strings.Contains(err.Error(), "already") || strings.Contains(err.Error(), "member") {
-						Log.WithFields(logrus.Fields{
-							"username":     username,
-							"channel_name": channelName,
-							"team":         teamName,
-						}).Debug("👤 User already member of channel")
-					} else {
-						Log.WithFields(logrus.Fields{
-							"username":     username,
-							"channel_name": channelName,
-							"team":         teamName,
-							"error":        err.Error(),
-						}).Warn("⚠️ Failed to add user to channel")
-						errorCount++
-						continue
-					}
+			// Add user to channel via API (triggers hooks)
+			_, _, err = c.API.AddChannelMember(context.Background(), channel.Id, user.Id)
+			if err != nil {
+				// Check if user is already a member (not an error)
+				if strings.Contains(err.Error(), "already") || strings.Contains(err.Error(), "member") {
+					Log.WithFields(logrus.Fields{
+						"username":     username,
+						"channel_name": membership.Channel,
+						"team":         membership.Team,
+					}).Debug("👤 User already member of channel")
 				} else {
-					joinedCount++
 					Log.WithFields(logrus.Fields{
 						"username":     username,
-						"channel_name": channelName,
-						"team":         teamName,
-					}).Debug("✅ Added user to channel via API")
+						"channel_name": membership.Channel,
+						"team":         membership.Team,
+						"error":        err.Error(),
+					}).Warn("⚠️ Failed to add user to channel")
+					errorsByTeam[membership.Team]++
+					errorCount++
 				}
-
-				break // Channel found and processed, no need to check other teams
+				continue
 			}
 
-			if !channelFound {
-				Log.WithFields(logrus.Fields{
-					"channel_name": channelName,
-					"username":     username,
-					"teams":        globalImportedTeams,
-				}).Warn("⚠️ Failed to find channel in any team")
-				errorCount++
-			}
+			joinedCount++
+			Log.WithFields(logrus.Fields{
+				"username":     username,
+				"channel_name": membership.Channel,
+				"team":         membership.Team,
+			}).Debug("✅ Added user to channel via API")
 		}
 	}
 
-	Log.WithFields(logrus.Fields{
+	fields := logrus.Fields{
 		"joined_count": joinedCount,
 		"error_count":  errorCount,
-	}).Info("✅ Channel membership processing complete")
+	}
+	if len(errorsByTeam) > 0 {
+		fields["errors_by_team"] = errorsByTeam
+	}
+	Log.WithFields(fields).Info("✅ Channel membership processing complete")
 
 	// Clear global data after processing
-	globalChannelMemberships = make(map[string][]string)
-	globalImportedTeams = make([]string, 0)
+	globalChannelMemberships = make(map[string][]channelMembership)
 
 	return nil
 }
@@ -833,9 +1012,11 @@ func (c *Client) createUserSidebarCategories() error {
 		return nil
 	}
 
-	Log.Info("⏳ Waiting 10 seconds before creating user sidebar categories to allow API to settle...")
-	time.Sleep(10 * time.Second)
-	
+	if !c.DryRun {
+		Log.Info("⏳ Waiting 10 seconds before creating user sidebar categories to allow API to settle...")
+		time.Sleep(10 * time.Second)
+	}
+
 	Log.Info("📂 Creating user sidebar categories")
 
 	// First, fetch all channels for all teams to build a lookup map
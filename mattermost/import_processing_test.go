@@ -0,0 +1,228 @@
+package mattermost
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/sirupsen/logrus"
+)
+
+// TestSetupResetOnFailure verifies that a failed import phase rolls back users and teams only when requested
+func TestSetupResetOnFailure(t *testing.T) {
+	testCases := []struct {
+		name            string
+		resetOnFailure  bool
+		expectedDeletes []string
+	}{
+		{
+			name:           "Rolls back users then teams",
+			resetOnFailure: true,
+			expectedDeletes: []string{
+				"/api/v4/users/user-alice",
+				"/api/v4/teams/team-demo",
+			},
+		},
+		{
+			name:           "Leaves server untouched without the flag",
+			resetOnFailure: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			importPath := filepath.Join(dir, "custom_import.jsonl")
+			if err := os.WriteFile(importPath, []byte(dryRunTestImport), 0600); err != nil {
+				t.Fatalf("Failed to write bulk import file: %v", err)
+			}
+			t.Chdir(dir)
+
+			// The import upload fails and the delete calls made by a rollback are recorded
+			var deletes []string
+			server := newMockServer()
+			handleDryRunReads(server)
+			server.handle("POST /api/v4/uploads", func(w http.ResponseWriter, r *http.Request) {
+				writeAppError(w, http.StatusInternalServerError, "api.upload.create.app_error")
+			})
+			recordDelete := func(w http.ResponseWriter, r *http.Request) {
+				deletes = append(deletes, r.URL.Path)
+				writeJSON(w, http.StatusOK, map[string]string{"status": "OK"})
+			}
+			server.handle("DELETE /api/v4/users/{user}", recordDelete)
+			server.handle("DELETE /api/v4/teams/{team}", recordDelete)
+			client := setupMockClient(t, server)
+			client.BulkImportPath = importPath
+			client.ResetOnFailure = tc.resetOnFailure
+
+			if err := client.SetupWithSplitImport(); err == nil {
+				t.Fatal("Expected setup to fail when the import upload fails")
+			}
+
+			if !slices.Equal(deletes, tc.expectedDeletes) {
+				t.Errorf("Expected rollback deletes %v, got %v", tc.expectedDeletes, deletes)
+			}
+		})
+	}
+}
+
+// TestSetupTimeoutNamesActivePhase verifies that a setup deadline stops a stuck import job and reports the phase
+func TestSetupTimeoutNamesActivePhase(t *testing.T) {
+	dir := t.TempDir()
+	importPath := filepath.Join(dir, "custom_import.jsonl")
+	if err := os.WriteFile(importPath, []byte(dryRunTestImport), 0600); err != nil {
+		t.Fatalf("Failed to write bulk import file: %v", err)
+	}
+
+	// The import upload is accepted but the import job never finishes
+	server := newMockServer()
+	handleDryRunReads(server)
+	server.handle("POST /api/v4/uploads", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusCreated, &model.UploadSession{Id: "upload1"})
+	})
+	server.handle("POST /api/v4/uploads/upload1", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusCreated, &model.FileInfo{Id: "file1"})
+	})
+	server.handle("POST /api/v4/jobs", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusCreated, &model.Job{Id: "job1", Status: model.JobStatusPending})
+	})
+	server.handle("GET /api/v4/jobs/job1", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, &model.Job{Id: "job1", Status: model.JobStatusInProgress})
+	})
+	client := setupMockClient(t, server)
+	client.BulkImportPath = importPath
+
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+
+	err := client.SetupWithSplitImportAndForce(ctx, false, false)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected a deadline exceeded error, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "infrastructure import") {
+		t.Errorf("Expected the error to name the active phase, got %q", err.Error())
+	}
+	if !strings.Contains(err.Error(), "failed to import infrastructure") {
+		t.Errorf("Expected the error to keep the phase's own error, got %q", err.Error())
+	}
+}
+
+// TestAdjustPostTimestampsAt verifies posts are shifted so the newest timestamp in the import file
+// lands five minutes before the reference time, keeping the others in the same relative order
+func TestAdjustPostTimestampsAt(t *testing.T) {
+	InitLogger(&LogConfig{Level: logrus.ErrorLevel})
+
+	globalCurrentImportPath = writeScanTestFile(t,
+		`{"type": "post", "post": {"message": "first", "create_at": 1000000}}`,
+		`{"type": "post", "post": {"message": "latest", "create_at": 4000000, "replies": [{"message": "reply", "create_at": 4600000}]}}`,
+	)
+	t.Cleanup(func() {
+		globalCurrentImportPath = ""
+		timestampOffset, offsetCalculated = 0, false
+	})
+
+	referenceTime := time.Date(2025, time.March, 1, 12, 0, 0, 0, time.UTC)
+	fiveMinutesAgo := referenceTime.Add(-5 * time.Minute).UnixMilli()
+
+	testCases := []struct {
+		name     string
+		line     string
+		expected int64
+	}{
+		{
+			name:     "newest timestamp is five minutes before the reference time",
+			line:     `{"type": "post", "post": {"message": "latest reply", "create_at": 4600000}}`,
+			expected: fiveMinutesAgo,
+		},
+		{
+			name:     "older post keeps its distance from the newest",
+			line:     `{"type": "post", "post": {"message": "first", "create_at": 1000000}}`,
+			expected: fiveMinutesAgo - 3600000,
+		},
+		{
+			name:     "direct post is shifted by the same offset",
+			line:     `{"type": "direct_post", "direct_post": {"message": "dm", "create_at": 4000000}}`,
+			expected: fiveMinutesAgo - 600000,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			timestampOffset, offsetCalculated = 0, false
+
+			adjusted, err := adjustPostTimestampsAt(tc.line, referenceTime)
+			if err != nil {
+				t.Fatalf("adjustPostTimestampsAt returned error: %v", err)
+			}
+
+			var data map[string]any
+			if err := json.Unmarshal([]byte(adjusted), &data); err != nil {
+				t.Fatalf("Failed to parse adjusted line: %v", err)
+			}
+			post, _ := postData(data)
+			createAt := int64(post["create_at"].(float64))
+			if createAt != tc.expected {
+				t.Errorf("Expected create_at %d, got %d", tc.expected, createAt)
+			}
+			if createAt > fiveMinutesAgo || createAt < fiveMinutesAgo-time.Hour.Milliseconds() {
+				t.Errorf("Expected create_at within the hour before %d, got %d", fiveMinutesAgo, createAt)
+			}
+		})
+	}
+}
+
+// TestProcessChannelMembershipsAcrossTeams verifies each channel is joined in the team the user
+// entry lists it under, for memberships spanning two teams
+func TestProcessChannelMembershipsAcrossTeams(t *testing.T) {
+	var added []string
+	server := newMockServer()
+	server.handleTeamsByName()
+	server.handleUsersByUsername("alice")
+	server.handle("GET /api/v4/teams/{team}/channels/name/{channel}", func(w http.ResponseWriter, r *http.Request) {
+		// Each team has only the channels named after it
+		team, channel := r.PathValue("team"), r.PathValue("channel")
+		if !strings.HasPrefix(channel, strings.TrimPrefix(team, "team-")) {
+			writeAppError(w, http.StatusNotFound, "app.channel.get_by_name.missing.app_error")
+			return
+		}
+		writeJSON(w, http.StatusOK, &model.Channel{Id: team + "-" + channel, Name: channel, TeamId: team})
+	})
+	server.handle("POST /api/v4/channels/{channel}/members", func(w http.ResponseWriter, r *http.Request) {
+		var member map[string]string
+		if !decodeJSON(w, r, &member) {
+			return
+		}
+		added = append(added, member["user_id"]+"@"+r.PathValue("channel"))
+		writeJSON(w, http.StatusCreated, &model.ChannelMember{ChannelId: r.PathValue("channel"), UserId: member["user_id"]})
+	})
+	client := setupMockClient(t, server)
+	t.Cleanup(func() { globalChannelMemberships = make(map[string][]channelMembership) })
+
+	line := `{"type": "user", "user": {"username": "alice", "teams": [` +
+		`{"name": "alpha", "channels": [{"name": "alpha-ops"}]},` +
+		`{"name": "bravo", "channels": [{"name": "bravo-ops"}, {"name": "bravo-intel"}]}]}}`
+	if _, err := extractChannelMemberships(line); err != nil {
+		t.Fatalf("extractChannelMemberships returned error: %v", err)
+	}
+
+	if err := client.processChannelMemberships(); err != nil {
+		t.Fatalf("processChannelMemberships returned error: %v", err)
+	}
+
+	slices.Sort(added)
+	expected := []string{"user-alice@team-alpha-alpha-ops", "user-alice@team-bravo-bravo-intel", "user-alice@team-bravo-bravo-ops"}
+	if !slices.Equal(added, expected) {
+		t.Errorf("Expected members %v, got %v", expected, added)
+	}
+	if len(globalChannelMemberships) != 0 {
+		t.Errorf("Expected memberships to be cleared, got %v", globalChannelMemberships)
+	}
+}
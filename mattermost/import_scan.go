@@ -0,0 +1,184 @@
+package mattermost
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+// importLineOrder ranks the standard line types in the order Mattermost requires them in a
+// bulk import file. A phase can stop reading once it reaches a line ranked after all of its
+// own types, and the next phase can start there instead of at the top of the file.
+var importLineOrder = map[string]int{
+	"version":        0,
+	"scheme":         1,
+	"emoji":          2,
+	"team":           3,
+	"channel":        4,
+	"user":           5,
+	"post":           6,
+	"direct_channel": 7,
+	"direct_post":    8,
+}
+
+// customImportTypes are demo-kit line types that are handled outside the bulk import
+var customImportTypes = map[string]bool{
+	"channel-category": true,
+	"channel-banner":   true,
+	"command":          true,
+	"plugin":           true,
+	"user-attribute":   true,
+	"user-profile":     true,
+	"user-groups":      true,
+	"direct-message":   true,
+	"custom-emoji":     true,
+	"bot":              true,
+	"slash-command":    true,
+	"incoming-webhook": true,
+}
+
+// scanPhase reads the bulk import file from offset and returns the lines matching lineTypes.
+// endOffset is the position of the first standard line ranked after every requested type,
+// or the end of the file, and is where the next phase can resume.
+//
+// The file must follow importLineOrder. Reading stops at the first line of a later type, so
+// a line placed after it, such as a user after the first post, is silently not imported.
+// VerifyBulkImport reports such lines.
+func scanPhase(path string, lineTypes []string, offset int64) (matchedLines []string, endOffset int64, err error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to open bulk import file: %w", err)
+	}
+	defer closeWithLog(file, "bulk import file")
+
+	if _, err := file.Seek(offset, io.SeekStart); err != nil {
+		return nil, 0, fmt.Errorf("failed to seek to offset %d: %w", offset, err)
+	}
+
+	lastRank := -1
+	for _, lineType := range lineTypes {
+		if rank, ok := importLineOrder[lineType]; ok && rank > lastRank {
+			lastRank = rank
+		}
+	}
+
+	reader := bufio.NewReader(file)
+	position := offset
+	for {
+		raw, readErr := reader.ReadString('\n')
+		if readErr != nil && !errors.Is(readErr, io.EOF) {
+			return nil, 0, fmt.Errorf("failed to read bulk import file: %w", readErr)
+		}
+		lineStart := position
+		position += int64(len(raw))
+
+		if line := strings.TrimSpace(raw); line != "" {
+			// First, extract just the type field to check if it's a custom type
+			var typeCheck struct {
+				Type string `json:"type"`
+			}
+			if err := json.Unmarshal([]byte(line), &typeCheck); err != nil {
+				Log.WithFields(logrus.Fields{"line": line}).Warn("⚠️ Failed to parse type from line, skipping")
+			} else if !customImportTypes[typeCheck.Type] {
+				// Lines are ordered by type, so nothing this phase needs comes after this one
+				if rank, ok := importLineOrder[typeCheck.Type]; ok && lastRank >= 0 && rank > lastRank {
+					return matchedLines, lineStart, nil
+				}
+
+				var importLine BulkImportLine
+				if err := json.Unmarshal([]byte(line), &importLine); err != nil {
+					Log.WithFields(logrus.Fields{"line": line}).Warn("⚠️ Failed to parse standard import line, skipping")
+				} else if slices.Contains(lineTypes, importLine.Type) {
+					matchedLines = append(matchedLines, line)
+				}
+			}
+		}
+
+		if errors.Is(readErr, io.EOF) {
+			return matchedLines, position, nil
+		}
+	}
+}
+
+// importOffsetCache records where each import phase starts in a bulk import file, so a re-run
+// against the same file does not re-read the lines earlier phases already covered
+type importOffsetCache struct {
+	ModTime int64            `json:"mod_time"`
+	Size    int64            `json:"size"`
+	Offsets map[string]int64 `json:"offsets"`
+	Next    int64            `json:"next"`
+}
+
+// importOffsetCachePath returns the cache file kept beside the import file,
+// e.g. bulk_import.offsets.json for bulk_import.jsonl
+func importOffsetCachePath(bulkImportPath string) string {
+	base := strings.TrimSuffix(filepath.Base(bulkImportPath), filepath.Ext(bulkImportPath))
+	return filepath.Join(filepath.Dir(bulkImportPath), base+".offsets.json")
+}
+
+// loadImportOffsetCache reads the offset cache for the import file. The offsets are reset
+// when there is no cache yet or the import file has changed since it was written.
+func loadImportOffsetCache(bulkImportPath string) (*importOffsetCache, error) {
+	info, err := os.Stat(bulkImportPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat bulk import file: %w", err)
+	}
+
+	fresh := &importOffsetCache{
+		ModTime: info.ModTime().UnixNano(),
+		Size:    info.Size(),
+		Offsets: make(map[string]int64),
+	}
+
+	data, err := os.ReadFile(importOffsetCachePath(bulkImportPath))
+	if err != nil {
+		return fresh, nil
+	}
+
+	var cache importOffsetCache
+	if err := json.Unmarshal(data, &cache); err != nil {
+		Log.WithFields(logrus.Fields{"error": err.Error()}).Warn("⚠️ Ignoring unreadable import offset cache")
+		return fresh, nil
+	}
+	if cache.ModTime != fresh.ModTime || cache.Size != fresh.Size || cache.Offsets == nil {
+		Log.Debug("🔄 Bulk import file changed, resetting import offsets")
+		return fresh, nil
+	}
+
+	return &cache, nil
+}
+
+// save writes the offset cache beside the import file
+func (cache *importOffsetCache) save(bulkImportPath string) error {
+	data, err := json.MarshalIndent(cache, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal import offsets: %w", err)
+	}
+	if err := os.WriteFile(importOffsetCachePath(bulkImportPath), data, 0644); err != nil {
+		return fmt.Errorf("failed to write import offsets: %w", err)
+	}
+	return nil
+}
+
+// startOffset returns where the phase for lineTypes should start reading: its cached
+// offset from an earlier run, or where the previous phase stopped
+func (cache *importOffsetCache) startOffset(lineTypes []string) int64 {
+	if offset, ok := cache.Offsets[strings.Join(lineTypes, ",")]; ok {
+		return offset
+	}
+	return cache.Next
+}
+
+// record stores the start of the phase for lineTypes and where the next phase resumes
+func (cache *importOffsetCache) record(lineTypes []string, start, end int64) {
+	cache.Offsets[strings.Join(lineTypes, ",")] = start
+	cache.Next = end
+}
@@ -0,0 +1,161 @@
+package mattermost
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writeScanTestFile(t testing.TB, lines ...string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "bulk_import.jsonl")
+	if err := os.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), 0644); err != nil {
+		t.Fatalf("Failed to write import file: %v", err)
+	}
+	return path
+}
+
+func TestScanPhaseResumesFromPreviousPhase(t *testing.T) {
+	path := writeScanTestFile(t,
+		`{"type":"version","version":1}`,
+		`{"type":"team","team":{"name":"t1"}}`,
+		`{"type":"plugin","plugin":{"name":"p1"}}`,
+		`{"type":"channel","channel":{"name":"c1"}}`,
+		`{"type":"user","user":{"username":"u1"}}`,
+		`{"type":"user-groups","group":{"name":"g1"}}`,
+		`{"type":"user","user":{"username":"u2"}}`,
+		`{"type":"post","post":{"message":"hello"}}`,
+	)
+
+	infrastructure, end, err := scanPhase(path, []string{"team", "channel"}, 0)
+	if err != nil {
+		t.Fatalf("scanPhase returned error: %v", err)
+	}
+	if len(infrastructure) != 2 {
+		t.Errorf("Expected 2 infrastructure lines, got %v", infrastructure)
+	}
+
+	users, userEnd, err := scanPhase(path, []string{"user"}, end)
+	if err != nil {
+		t.Fatalf("scanPhase returned error: %v", err)
+	}
+	if len(users) != 2 || !strings.Contains(users[0], `"u1"`) {
+		t.Errorf("Expected the two users after the channels, got %v", users)
+	}
+
+	posts, postEnd, err := scanPhase(path, []string{"post"}, userEnd)
+	if err != nil {
+		t.Fatalf("scanPhase returned error: %v", err)
+	}
+	if len(posts) != 1 {
+		t.Errorf("Expected 1 post, got %v", posts)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("Failed to stat import file: %v", err)
+	}
+	if postEnd != info.Size() {
+		t.Errorf("Expected the last phase to end at %d, got %d", info.Size(), postEnd)
+	}
+}
+
+func TestImportOffsetCacheResetsWhenFileChanges(t *testing.T) {
+	path := writeScanTestFile(t, `{"type":"team","team":{"name":"t1"}}`, `{"type":"user","user":{"username":"u1"}}`)
+
+	cache, err := loadImportOffsetCache(path)
+	if err != nil {
+		t.Fatalf("loadImportOffsetCache returned error: %v", err)
+	}
+	if cache.startOffset([]string{"user"}) != 0 {
+		t.Fatal("Expected a first run to start at the top of the file")
+	}
+	cache.record([]string{"team", "channel"}, 0, 38)
+	if err := cache.save(path); err != nil {
+		t.Fatalf("Failed to save offsets: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(filepath.Dir(path), "bulk_import.offsets.json")); err != nil {
+		t.Fatalf("Expected the cache beside the import file: %v", err)
+	}
+
+	cache, err = loadImportOffsetCache(path)
+	if err != nil {
+		t.Fatalf("loadImportOffsetCache returned error: %v", err)
+	}
+	if cache.startOffset([]string{"team", "channel"}) != 0 || cache.startOffset([]string{"user"}) != 38 {
+		t.Errorf("Expected cached offsets to be reused, got %+v", cache)
+	}
+
+	later := time.Now().Add(time.Minute)
+	if err := os.Chtimes(path, later, later); err != nil {
+		t.Fatalf("Failed to touch import file: %v", err)
+	}
+	cache, err = loadImportOffsetCache(path)
+	if err != nil {
+		t.Fatalf("loadImportOffsetCache returned error: %v", err)
+	}
+	if cache.startOffset([]string{"user"}) != 0 || len(cache.Offsets) != 0 {
+		t.Errorf("Expected offsets to reset after the file changed, got %+v", cache)
+	}
+}
+
+// BenchmarkScanPhase compares reading a ~50 MB import file from the top for every phase
+// against resuming each phase where the previous one stopped
+func BenchmarkScanPhase(b *testing.B) {
+	path := filepath.Join(b.TempDir(), "bulk_import.jsonl")
+	file, err := os.Create(path)
+	if err != nil {
+		b.Fatalf("Failed to create import file: %v", err)
+	}
+	writer := bufio.NewWriter(file)
+	padding := strings.Repeat("x", 150)
+	var written int
+	for i := 0; written < 50<<20; i++ {
+		var line string
+		switch {
+		case i < 1000:
+			line = fmt.Sprintf(`{"type":"channel","channel":{"team":"t1","name":"c%d","display_name":"%s"}}`, i, padding)
+		case i < 20000:
+			line = fmt.Sprintf(`{"type":"user","user":{"username":"u%d","nickname":"%s"}}`, i, padding)
+		default:
+			line = fmt.Sprintf(`{"type":"post","post":{"team":"t1","channel":"c1","user":"u1","message":"%s"}}`, padding)
+		}
+		n, _ := writer.WriteString(line + "\n")
+		written += n
+	}
+	if err := writer.Flush(); err != nil {
+		b.Fatalf("Failed to write import file: %v", err)
+	}
+	if err := file.Close(); err != nil {
+		b.Fatalf("Failed to close import file: %v", err)
+	}
+
+	phases := [][]string{{"team", "channel"}, {"user"}, {"post"}}
+
+	b.Run("full", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for _, lineTypes := range phases {
+				if _, _, err := scanPhase(path, lineTypes, 0); err != nil {
+					b.Fatal(err)
+				}
+			}
+		}
+	})
+
+	b.Run("incremental", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			var offset int64
+			for _, lineTypes := range phases {
+				_, end, err := scanPhase(path, lineTypes, offset)
+				if err != nil {
+					b.Fatal(err)
+				}
+				offset = end
+			}
+		}
+	})
+}
@@ -49,6 +49,7 @@ type PluginImport struct {
 	Plugin struct {
 		Source       string `json:"source"`        // "github" or "local"
 		GithubRepo   string `json:"github_repo"`   // For GitHub plugins: "owner/repo"
+		Version      string `json:"version"`       // For GitHub plugins: release tag to install, latest if empty
 		Path         string `json:"path"`          // For local plugins: "../apps/plugin-name"
 		PluginID     string `json:"plugin_id"`     // Plugin ID
 		Name         string `json:"name"`          // Human readable name
@@ -88,6 +89,64 @@ type UserProfileImport struct {
 	Attributes map[string]string `json:"attributes"` // Map of attribute name to value
 }
 
+// EmojiImport represents a custom emoji import entry
+type EmojiImport struct {
+	Type  string `json:"type"`
+	Emoji struct {
+		Name    string `json:"name"`
+		Creator string `json:"creator"` // Username; emoji are always created by the admin user
+		Image   string `json:"image"`   // Image path, relative to the import file
+	} `json:"emoji"`
+}
+
+// BotImport represents a bot account import entry
+type BotImport struct {
+	Type string `json:"type"`
+	Bot  struct {
+		Username    string `json:"username"`
+		DisplayName string `json:"display_name"`
+		Description string `json:"description"`
+		Owner       string `json:"owner"`        // Username of the owner, the admin user if empty
+		CreateToken bool   `json:"create_token"` // Whether to create an access token for the bot
+	} `json:"bot"`
+}
+
+// SlashCommandImport represents a custom slash command import entry
+type SlashCommandImport struct {
+	Type         string `json:"type"`
+	SlashCommand struct {
+		Team             string `json:"team"`
+		Trigger          string `json:"trigger"`
+		DisplayName      string `json:"display_name"`
+		Description      string `json:"description"`
+		URL              string `json:"url"`    // Request URL the command is sent to
+		Method           string `json:"method"` // "POST" (default) or "GET"
+		AutoComplete     bool   `json:"auto_complete"`
+		AutoCompleteDesc string `json:"auto_complete_desc"`
+		AutoCompleteHint string `json:"auto_complete_hint"`
+	} `json:"slash_command"`
+}
+
+// IncomingWebhookImport represents an incoming webhook import entry
+type IncomingWebhookImport struct {
+	Type            string `json:"type"`
+	IncomingWebhook struct {
+		Team        string `json:"team"`
+		Channel     string `json:"channel"`
+		DisplayName string `json:"display_name"`
+		Description string `json:"description"`
+		IconURL     string `json:"icon_url"`
+	} `json:"incoming_webhook"`
+}
+
+// DirectMessageImport represents an onboarding direct message sent after users are created
+type DirectMessageImport struct {
+	Type string `json:"type"`
+	From string `json:"from"` // Sender username
+	To   string `json:"to"`   // Recipient username
+	Text string `json:"text"`
+}
+
 // GroupConfig represents group configuration from import data
 type GroupConfig struct {
 	Name           string   `json:"name"`            // Group name
@@ -147,11 +206,18 @@ type ResetImportLine struct {
 
 // CustomProfileField represents a custom profile field definition
 type CustomProfileField struct {
-	ID          string   `json:"id"`
-	Name        string   `json:"name"`
-	DisplayName string   `json:"display_name"`
-	Type        string   `json:"type"`
-	Options     []string `json:"options,omitempty"`
+	ID          string                  `json:"id"`
+	Name        string                  `json:"name"`
+	DisplayName string                  `json:"display_name"`
+	Type        string                  `json:"type"`
+	Options     []string                `json:"options,omitempty"`
+	Attrs       CustomProfileFieldAttrs `json:"attrs,omitempty"`
+}
+
+// CustomProfileFieldAttrs represents the extended attributes of a custom profile field
+type CustomProfileFieldAttrs struct {
+	LDAPAttribute string `json:"ldap,omitempty"` // LDAP attribute name mapping
+	SAMLAttribute string `json:"saml,omitempty"` // SAML attribute mapping
 }
 
 // UserCustomProfileFields represents a user's custom profile field values
@@ -5,12 +5,14 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"os"
 	"strings"
 
+	"github.com/mattermost/mattermost/server/public/model"
 	"github.com/sirupsen/logrus"
 
 	ldapPkg "github.com/coltoneshaw/demokit/mattermost/ldap"
@@ -20,14 +22,14 @@ import (
 type LDAPConfig = ldapPkg.LDAPConfig
 
 // SetupLDAP extracts users from JSONL and imports them directly into LDAP using default configuration
-func (c *Client) SetupLDAP() error {
+func (c *Client) SetupLDAP(ctx context.Context) error {
 	defaultConfig := &LDAPConfig{
 		URL:          "ldap://localhost:10389",
 		BindDN:       "cn=admin,dc=planetexpress,dc=com",
 		BindPassword: "GoodNewsEveryone",
 		BaseDN:       "dc=planetexpress,dc=com",
 	}
-	return c.SetupLDAPWithConfig(defaultConfig)
+	return c.SetupLDAPWithConfig(ctx, defaultConfig)
 }
 
 // ShowLDAPSchemaExtensions displays the LDAP schema extensions that would be applied
@@ -47,14 +49,21 @@ func (c *Client) ShowLDAPSchemaExtensions() error {
 	return ldapClient.ShowSchemaExtensions(attributeFields, schemaConfig)
 }
 
-// SetupLDAPWithConfig extracts users from JSONL and imports them directly into LDAP with custom configuration
-func (c *Client) SetupLDAPWithConfig(config *LDAPConfig) error {
+// SetupLDAPWithConfig extracts users from JSONL and imports them directly into LDAP with custom configuration.
+// When ctx ends, the error names the LDAP step that was active.
+func (c *Client) SetupLDAPWithConfig(ctx context.Context, config *LDAPConfig) (err error) {
 	Log.WithFields(logrus.Fields{
 		"ldap_url": config.URL,
 		"bind_dn":  config.BindDN,
 		"base_dn":  config.BaseDN,
+		"tls_mode": config.TLSMode,
 	}).Info("🔐 Starting LDAP setup")
 
+	phase := "LDAP user import"
+	defer func() {
+		err = setupPhaseError(ctx, phase, err)
+	}()
+
 	// Extract users from JSONL
 	users, err := c.ExtractUsersFromJSONL(c.BulkImportPath)
 	if err != nil {
@@ -62,17 +71,19 @@ func (c *Client) SetupLDAPWithConfig(config *LDAPConfig) error {
 	}
 
 	// Import users directly into LDAP
-	if err := c.importUsersToLDAPWithConfig(users, config); err != nil {
+	if err := c.importUsersToLDAPWithConfig(ctx, users, config); err != nil {
 		return fmt.Errorf("failed to import users to LDAP: %w", err)
 	}
 
 	// Setup LDAP groups
-	if err := c.setupLDAPGroups(config); err != nil {
+	phase = "LDAP group setup"
+	if err := c.setupLDAPGroups(ctx, config); err != nil {
 		return fmt.Errorf("failed to setup LDAP groups: %w", err)
 	}
 
 	// Migrate existing Mattermost users from email auth to LDAP auth
-	if err := c.migrateUsersToLDAPAuth(users); err != nil {
+	phase = "LDAP auth migration"
+	if err := c.migrateUsersToLDAPAuth(ctx, users); err != nil {
 		return fmt.Errorf("failed to migrate users to LDAP auth: %w", err)
 	}
 
@@ -84,9 +95,26 @@ func (c *Client) SetupLDAPWithConfig(config *LDAPConfig) error {
 		// Don't fail the entire setup if API linking fails
 	}
 
+	// Link groups to the config channels that list them, so the sync adds their members
+	if c.Config != nil {
+		for key, team := range c.Config.Teams {
+			teamName := team.Name
+			if teamName == "" {
+				teamName = key
+			}
+			if err := c.SyncGroupsToChannels(teamName); err != nil {
+				Log.WithFields(logrus.Fields{
+					"team_name": teamName,
+					"error":     err.Error(),
+				}).Warn("Failed to link some LDAP groups to channels")
+			}
+		}
+	}
+
 	// Trigger LDAP sync to ensure Mattermost picks up all LDAP attributes and groups
 	Log.Info("🔄 Triggering LDAP sync to update user attributes and groups")
-	if err := c.syncLDAP(); err != nil {
+	phase = "LDAP sync"
+	if err := c.syncLDAP(ctx); err != nil {
 		return fmt.Errorf("failed to sync LDAP: %w", err)
 	}
 
@@ -95,12 +123,12 @@ func (c *Client) SetupLDAPWithConfig(config *LDAPConfig) error {
 }
 
 // syncLDAP triggers an LDAP sync to ensure Mattermost picks up all LDAP attributes
-func (c *Client) syncLDAP() error {
+func (c *Client) syncLDAP(ctx context.Context) error {
 	Log.Info("🔄 Starting LDAP sync...")
 
 	// SyncLdap requires a boolean parameter for includeRemovedMembers
 	includeRemovedMembers := false
-	resp, err := c.API.SyncLdap(context.Background(), &includeRemovedMembers)
+	resp, err := c.API.SyncLdap(ctx, &includeRemovedMembers)
 	if err != nil {
 		return handleAPIError("failed to trigger LDAP sync", err, resp)
 	}
@@ -346,7 +374,7 @@ func (c *Client) extractUserGroups(jsonlPath string) ([]ldapPkg.LDAPGroup, error
 }
 
 // setupLDAPGroups creates and configures LDAP groups from JSONL data
-func (c *Client) setupLDAPGroups(config *LDAPConfig) error {
+func (c *Client) setupLDAPGroups(ctx context.Context, config *LDAPConfig) error {
 	// Extract groups from JSONL
 	groups, err := c.extractUserGroups(c.BulkImportPath)
 	if err != nil {
@@ -359,9 +387,14 @@ func (c *Client) setupLDAPGroups(config *LDAPConfig) error {
 		return fmt.Errorf("failed to extract custom attribute definitions: %w", err)
 	}
 	
+	if c.DryRun {
+		Log.WithFields(logrus.Fields{"group_count": len(groups)}).Info("ℹ️ Dry run: skipping LDAP group creation")
+		return nil
+	}
+
 	// Delegate to LDAP package
 	ldapClient := ldapPkg.NewClient(config)
-	return ldapClient.SetupLDAPGroups(groups, attributeFields, config)
+	return ldapClient.SetupLDAPGroups(ctx, groups, attributeFields, config)
 }
 
 // linkLDAPGroups links LDAP groups to Mattermost via API
@@ -431,6 +464,105 @@ func (c *Client) linkSingleLDAPGroup(group ldapPkg.LDAPGroup) error {
 	return nil
 }
 
+// SyncGroupsToChannels links the LDAP groups listed on each of the team's config channels to that
+// channel with auto-add on, so the next LDAP sync adds the group members. Groups already linked are
+// skipped. A failure for one channel or group does not stop the rest; all failures are returned together.
+func (c *Client) SyncGroupsToChannels(teamName string) error {
+	if c.Config == nil {
+		return nil
+	}
+
+	var channels []ChannelConfig
+	for key, team := range c.Config.Teams {
+		if team.Name == teamName || (team.Name == "" && key == teamName) {
+			for _, channel := range team.Channels {
+				if len(channel.Groups) > 0 {
+					channels = append(channels, channel)
+				}
+			}
+			break
+		}
+	}
+	if len(channels) == 0 {
+		return nil
+	}
+
+	ctx := context.Background()
+	var errs []error
+	groupIDs := make(map[string]string)
+	linkedCount, skippedCount := 0, 0
+
+	team, resp, err := c.API.GetTeamByName(ctx, teamName, "")
+	if err != nil {
+		return handleAPIError(fmt.Sprintf("failed to get team '%s'", teamName), err, resp)
+	}
+
+	for _, channelConfig := range channels {
+		channel, resp, err := c.API.GetChannelByName(ctx, channelConfig.Name, team.Id, "")
+		if err != nil {
+			errs = append(errs, handleAPIError(fmt.Sprintf("failed to get channel '%s'", channelConfig.Name), err, resp))
+			continue
+		}
+
+		linkedGroups, _, resp, err := c.API.GetGroupsByChannel(ctx, channel.Id, model.GroupSearchOpts{PageOpts: &model.PageOpts{Page: 0, PerPage: 200}})
+		if err != nil {
+			errs = append(errs, handleAPIError(fmt.Sprintf("failed to list groups for channel '%s'", channelConfig.Name), err, resp))
+			continue
+		}
+		alreadyLinked := make(map[string]bool, len(linkedGroups))
+		for _, linked := range linkedGroups {
+			alreadyLinked[linked.Id] = true
+		}
+
+		for _, groupName := range channelConfig.Groups {
+			groupID, ok := groupIDs[groupName]
+			if !ok {
+				groupID, err = c.findLDAPGroupID(ctx, groupName)
+				if err != nil {
+					errs = append(errs, err)
+					continue
+				}
+				groupIDs[groupName] = groupID
+			}
+
+			if alreadyLinked[groupID] {
+				skippedCount++
+				continue
+			}
+
+			autoAdd := true
+			if _, resp, err := c.API.LinkGroupSyncable(ctx, groupID, channel.Id, model.GroupSyncableTypeChannel, &model.GroupSyncablePatch{AutoAdd: &autoAdd}); err != nil {
+				errs = append(errs, handleAPIError(fmt.Sprintf("failed to link group '%s' to channel '%s'", groupName, channelConfig.Name), err, resp))
+				continue
+			}
+			linkedCount++
+		}
+	}
+
+	Log.WithFields(logrus.Fields{
+		"team_name":     teamName,
+		"linked_count":  linkedCount,
+		"skipped_count": skippedCount,
+		"failed_count":  len(errs),
+	}).Info("✅ Linked LDAP groups to channels")
+
+	return errors.Join(errs...)
+}
+
+// findLDAPGroupID returns the ID of the linked LDAP group with the given name
+func (c *Client) findLDAPGroupID(ctx context.Context, groupName string) (string, error) {
+	groups, resp, err := c.API.GetGroups(ctx, model.GroupSearchOpts{Q: groupName, Source: model.GroupSourceLdap, PageOpts: &model.PageOpts{Page: 0, PerPage: 100}})
+	if err != nil {
+		return "", handleAPIError(fmt.Sprintf("failed to search for group '%s'", groupName), err, resp)
+	}
+	for _, group := range groups {
+		if strings.EqualFold(group.DisplayName, groupName) || (group.Name != nil && strings.EqualFold(*group.Name, groupName)) {
+			return group.Id, nil
+		}
+	}
+	return "", fmt.Errorf("group '%s' is not linked to Mattermost", groupName)
+}
+
 
 
 
@@ -438,31 +570,39 @@ func (c *Client) linkSingleLDAPGroup(group ldapPkg.LDAPGroup) error {
 
 
 // importUsersToLDAPWithConfig connects directly to LDAP and creates users with custom configuration
-func (c *Client) importUsersToLDAPWithConfig(users []LDAPUser, config *LDAPConfig) error {
+func (c *Client) importUsersToLDAPWithConfig(ctx context.Context, users []LDAPUser, config *LDAPConfig) error {
 	// Extract custom attribute definitions
 	attributeFields, err := c.extractCustomAttributeDefinitions(c.BulkImportPath)
 	if err != nil {
 		return fmt.Errorf("failed to extract custom attribute definitions: %w", err)
 	}
 	
+	if c.DryRun {
+		Log.WithFields(logrus.Fields{"user_count": len(users)}).Info("ℹ️ Dry run: skipping LDAP user import")
+		return nil
+	}
+
 	// Delegate to LDAP package
 	ldapClient := ldapPkg.NewClient(config)
-	return ldapClient.ImportUsersToLDAP(users, attributeFields, config)
+	return ldapClient.ImportUsersToLDAP(ctx, users, attributeFields, config)
 }
 
 
 
 // migrateUsersToLDAPAuth migrates existing Mattermost users from email authentication to LDAP authentication
-func (c *Client) migrateUsersToLDAPAuth(users []LDAPUser) error {
+func (c *Client) migrateUsersToLDAPAuth(ctx context.Context, users []LDAPUser) error {
 	Log.WithFields(logrus.Fields{"user_count": len(users)}).Info("🔄 Migrating users from email auth to LDAP auth")
 
 	successCount := 0
 	errorCount := 0
 
 	for _, user := range users {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
 		Log.WithFields(logrus.Fields{"username": user.Username}).Debug("Attempting to migrate user from JSONL to LDAP auth")
 
-		if err := c.migrateUserToLDAP(user.Username); err != nil {
+		if err := c.migrateUserToLDAP(ctx, user.Username); err != nil {
 			Log.WithFields(logrus.Fields{
 				"username": user.Username,
 				"error":    err.Error(),
@@ -486,9 +626,9 @@ func (c *Client) migrateUsersToLDAPAuth(users []LDAPUser) error {
 }
 
 // migrateUserToLDAP migrates a single user from email authentication to LDAP authentication
-func (c *Client) migrateUserToLDAP(username string) error {
+func (c *Client) migrateUserToLDAP(ctx context.Context, username string) error {
 	// First, find the user by username to get their user ID
-	user, resp, err := c.API.GetUserByUsername(context.Background(), username, "")
+	user, resp, err := c.API.GetUserByUsername(ctx, username, "")
 	if err != nil {
 		if resp != nil && resp.StatusCode == 404 {
 			Log.WithFields(logrus.Fields{"username": username}).Debug("User not found in Mattermost, skipping migration")
@@ -516,14 +656,14 @@ func (c *Client) migrateUserToLDAP(username string) error {
 		"service":  "ldap",
 	}).Debug("Updating user authentication method to LDAP")
 
-	req, err := http.NewRequest("PUT", url, bytes.NewBuffer(jsonPayload))
+	req, err := http.NewRequestWithContext(ctx, "PUT", url, bytes.NewBuffer(jsonPayload))
 	if err != nil {
 		return fmt.Errorf("failed to create auth update request: %w", err)
 	}
 	req.Header.Set("Authorization", "Bearer "+c.API.AuthToken)
 	req.Header.Set("Content-Type", "application/json")
 
-	client := &http.Client{}
+	client := c.httpClient()
 	httpResp, err := client.Do(req)
 	if err != nil {
 		return fmt.Errorf("failed to execute auth update request: %w", err)
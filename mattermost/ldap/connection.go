@@ -1,7 +1,9 @@
 package ldap
 
 import (
+	"crypto/tls"
 	"fmt"
+	"net/url"
 
 	"github.com/go-ldap/ldap/v3"
 	"github.com/sirupsen/logrus"
@@ -10,9 +12,9 @@ import (
 // ConnectToSchema creates connection using schema admin credentials
 func (c *Client) ConnectToSchema(ldapConfig *LDAPConfig) (*ldap.Conn, error) {
 	// Connect to LDAP server
-	conn, err := ldap.DialURL(ldapConfig.URL)
+	conn, err := Dial(ldapConfig)
 	if err != nil {
-		return nil, fmt.Errorf("failed to connect to LDAP server: %w", err)
+		return nil, err
 	}
 
 	// Bind as schema admin
@@ -35,9 +37,9 @@ func (c *Client) ConnectToSchema(ldapConfig *LDAPConfig) (*ldap.Conn, error) {
 // Connect creates a standard LDAP connection using directory admin credentials
 func (c *Client) Connect() (*ldap.Conn, error) {
 	// Connect to LDAP server
-	conn, err := ldap.DialURL(c.config.URL)
+	conn, err := Dial(c.config)
 	if err != nil {
-		return nil, fmt.Errorf("failed to connect to LDAP server: %w", err)
+		return nil, err
 	}
 
 	// Bind as directory admin
@@ -55,4 +57,53 @@ func (c *Client) Connect() (*ldap.Conn, error) {
 	}).Debug("Connected to LDAP as directory admin")
 
 	return conn, nil
-}
\ No newline at end of file
+}
+
+// Dial opens an unbound connection to the LDAP server using the configured TLS mode.
+// With starttls the connection is dialed in plain text and upgraded before it is returned,
+// so callers can bind without sending credentials unencrypted.
+func Dial(config *LDAPConfig) (*ldap.Conn, error) {
+	serverURL, err := url.Parse(config.URL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid LDAP URL %q: %w", config.URL, err)
+	}
+
+	tlsConfig := &tls.Config{
+		ServerName:         serverURL.Hostname(),
+		InsecureSkipVerify: config.InsecureSkipVerify,
+	}
+
+	switch config.TLSMode {
+	case "", TLSModeNone:
+	case TLSModeTLS:
+		if serverURL.Scheme != "ldaps" {
+			return nil, fmt.Errorf("TLS mode %q requires an ldaps:// URL, got %s", config.TLSMode, config.URL)
+		}
+	case TLSModeStartTLS:
+		if serverURL.Scheme != "ldap" {
+			return nil, fmt.Errorf("TLS mode %q requires an ldap:// URL, got %s", config.TLSMode, config.URL)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported LDAP TLS mode %q (valid: %s, %s, %s)", config.TLSMode, TLSModeNone, TLSModeTLS, TLSModeStartTLS)
+	}
+
+	conn, err := ldap.DialURL(config.URL, ldap.DialWithTLSConfig(tlsConfig))
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to LDAP server: %w", err)
+	}
+
+	if config.TLSMode == TLSModeStartTLS {
+		if err := conn.StartTLS(tlsConfig); err != nil {
+			if closeErr := conn.Close(); closeErr != nil {
+				Log.WithError(closeErr).Warn("Failed to close LDAP connection during error handling")
+			}
+			return nil, fmt.Errorf("failed to start TLS on LDAP connection: %w", err)
+		}
+
+		Log.WithFields(logrus.Fields{
+			"url": config.URL,
+		}).Debug("Upgraded LDAP connection with StartTLS")
+	}
+
+	return conn, nil
+}
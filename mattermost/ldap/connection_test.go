@@ -0,0 +1,185 @@
+package ldap
+
+import (
+	"crypto/tls"
+	"io"
+	"net"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	ber "github.com/go-asn1-ber/asn1-ber"
+	"github.com/go-ldap/ldap/v3"
+	"github.com/sirupsen/logrus"
+)
+
+// startTLSOID is the extended operation name of an LDAP StartTLS request
+const startTLSOID = "1.3.6.1.4.1.1466.20037"
+
+// startTLSServer is a mock LDAP server that answers a StartTLS request and completes the TLS handshake
+type startTLSServer struct {
+	listener net.Listener
+	cert     tls.Certificate
+	result   chan startTLSResult
+}
+
+// startTLSResult records what the mock server saw on its first connection
+type startTLSResult struct {
+	requestedStartTLS bool
+	handshakeErr      error
+}
+
+// newStartTLSServer starts a mock LDAP server on a local port using a self-signed certificate
+func newStartTLSServer(t *testing.T) *startTLSServer {
+	t.Helper()
+
+	// Borrow the self-signed certificate generated for httptest TLS servers
+	certServer := httptest.NewTLSServer(nil)
+	cert := certServer.TLS.Certificates[0]
+	certServer.Close()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	t.Cleanup(func() { _ = listener.Close() })
+
+	server := &startTLSServer{listener: listener, cert: cert, result: make(chan startTLSResult, 1)}
+	go server.serve()
+	return server
+}
+
+func (s *startTLSServer) url() string {
+	return "ldap://" + s.listener.Addr().String()
+}
+
+func (s *startTLSServer) serve() {
+	conn, err := s.listener.Accept()
+	if err != nil {
+		return
+	}
+	defer func() { _ = conn.Close() }()
+
+	var result startTLSResult
+	defer func() { s.result <- result }()
+
+	packet, err := ber.ReadPacket(conn)
+	if err != nil || len(packet.Children) < 2 {
+		return
+	}
+	messageID := packet.Children[0].Value
+	request := packet.Children[1]
+	if request.Tag != ldap.ApplicationExtendedRequest || len(request.Children) == 0 ||
+		string(request.Children[0].Data.Bytes()) != startTLSOID {
+		return
+	}
+	result.requestedStartTLS = true
+
+	// Reply with a successful extended response, then upgrade the connection
+	response := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "LDAP Response")
+	response.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagInteger, messageID, "MessageID"))
+	extended := ber.Encode(ber.ClassApplication, ber.TypeConstructed, ldap.ApplicationExtendedResponse, nil, "Extended Response")
+	extended.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagEnumerated, int64(ldap.LDAPResultSuccess), "Result Code"))
+	extended.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", "Matched DN"))
+	extended.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", "Diagnostic Message"))
+	response.AppendChild(extended)
+	if _, err := conn.Write(response.Bytes()); err != nil {
+		return
+	}
+
+	tlsConn := tls.Server(conn, &tls.Config{Certificates: []tls.Certificate{s.cert}})
+	result.handshakeErr = tlsConn.Handshake()
+	if result.handshakeErr == nil {
+		// Hold the connection open until the client closes it
+		_, _ = io.Copy(io.Discard, tlsConn)
+	}
+}
+
+// TestDialStartTLS verifies the StartTLS handshake is attempted before the connection is returned
+func TestDialStartTLS(t *testing.T) {
+	SetLogger(logrus.New())
+	Log.SetLevel(logrus.ErrorLevel)
+
+	testCases := []struct {
+		name               string
+		tlsMode            string
+		insecureSkipVerify bool
+		expectStartTLS     bool
+		expectedError      string
+	}{
+		{
+			name:               "StartTLS upgrades the connection",
+			tlsMode:            TLSModeStartTLS,
+			insecureSkipVerify: true,
+			expectStartTLS:     true,
+		},
+		{
+			name:           "StartTLS verifies the server certificate by default",
+			tlsMode:        TLSModeStartTLS,
+			expectStartTLS: true,
+			expectedError:  "failed to start TLS",
+		},
+		{
+			name:    "No TLS leaves the connection plain",
+			tlsMode: TLSModeNone,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := newStartTLSServer(t)
+
+			conn, err := Dial(&LDAPConfig{
+				URL:                server.url(),
+				TLSMode:            tc.tlsMode,
+				InsecureSkipVerify: tc.insecureSkipVerify,
+			})
+
+			if tc.expectedError != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.expectedError) {
+					t.Errorf("Expected error containing %q, got %v", tc.expectedError, err)
+				}
+			} else if err != nil {
+				t.Fatalf("Dial returned error: %v", err)
+			}
+
+			if conn != nil {
+				if _, isTLS := conn.TLSConnectionState(); isTLS != (tc.expectStartTLS && tc.expectedError == "") {
+					t.Errorf("Expected TLS connection state %v, got %v", tc.expectStartTLS, isTLS)
+				}
+				_ = conn.Close()
+			}
+
+			result := <-server.result
+			if result.requestedStartTLS != tc.expectStartTLS {
+				t.Errorf("Expected StartTLS requested=%v, got %v", tc.expectStartTLS, result.requestedStartTLS)
+			}
+			if tc.expectStartTLS && tc.expectedError == "" && result.handshakeErr != nil {
+				t.Errorf("Expected TLS handshake to succeed, got %v", result.handshakeErr)
+			}
+		})
+	}
+}
+
+// TestDialTLSModeValidation tests that mismatched TLS modes and URL schemes are rejected before dialing
+func TestDialTLSModeValidation(t *testing.T) {
+	testCases := []struct {
+		name          string
+		url           string
+		tlsMode       string
+		expectedError string
+	}{
+		{name: "tls requires ldaps", url: "ldap://localhost:10389", tlsMode: TLSModeTLS, expectedError: "requires an ldaps:// URL"},
+		{name: "starttls requires ldap", url: "ldaps://localhost:10636", tlsMode: TLSModeStartTLS, expectedError: "requires an ldap:// URL"},
+		{name: "unknown mode", url: "ldap://localhost:10389", tlsMode: "ssl", expectedError: "unsupported LDAP TLS mode"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := Dial(&LDAPConfig{URL: tc.url, TLSMode: tc.tlsMode})
+			if err == nil || !strings.Contains(err.Error(), tc.expectedError) {
+				t.Errorf("Expected error containing %q, got %v", tc.expectedError, err)
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package ldap
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
@@ -324,8 +325,8 @@ func ParseGroupEntry(entry map[string]any) (LDAPGroup, error) {
 	}, nil
 }
 
-// SetupLDAPGroups creates and configures LDAP groups
-func (c *Client) SetupLDAPGroups(groups []LDAPGroup, attributeFields []UserAttributeField, config *LDAPConfig) error {
+// SetupLDAPGroups creates and configures LDAP groups. It stops before the next group once ctx ends.
+func (c *Client) SetupLDAPGroups(ctx context.Context, groups []LDAPGroup, attributeFields []UserAttributeField, config *LDAPConfig) error {
 	Log.Info("👥 Setting up LDAP groups")
 
 	if len(groups) == 0 {
@@ -334,9 +335,9 @@ func (c *Client) SetupLDAPGroups(groups []LDAPGroup, attributeFields []UserAttri
 	}
 
 	// Connect to LDAP server
-	ldapConn, err := ldap.DialURL(config.URL)
+	ldapConn, err := Dial(config)
 	if err != nil {
-		return fmt.Errorf("failed to connect to LDAP server: %w", err)
+		return err
 	}
 	defer func() {
 		if err := ldapConn.Close(); err != nil {
@@ -356,6 +357,9 @@ func (c *Client) SetupLDAPGroups(groups []LDAPGroup, attributeFields []UserAttri
 
 	// Create each group
 	for _, group := range groups {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
 		if err := c.CreateGroup(ldapConn, group, config); err != nil {
 			Log.WithFields(logrus.Fields{
 				"group_name": group.Name,
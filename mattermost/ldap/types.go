@@ -8,8 +8,18 @@ type LDAPConfig struct {
 	BaseDN         string `json:"base_dn"`         // Base DN (e.g., dc=planetexpress,dc=com)
 	SchemaBindDN   string `json:"schema_bind_dn"`  // Schema admin bind DN (e.g., cn=admin,cn=config)
 	SchemaPassword string `json:"schema_password"` // Schema admin password
+	TLSMode        string `json:"tls_mode,omitempty"` // Connection security: none (default), tls (ldaps://) or starttls
+	// InsecureSkipVerify disables server certificate verification for TLS and StartTLS (test environments only)
+	InsecureSkipVerify bool `json:"insecure_skip_verify,omitempty"`
 }
 
+// Supported values for LDAPConfig.TLSMode
+const (
+	TLSModeNone     = "none"
+	TLSModeTLS      = "tls"
+	TLSModeStartTLS = "starttls"
+)
+
 // SchemaConfig represents configuration for LDAP schema extensions
 type SchemaConfig struct {
 	BaseOID            string // Base OID for custom attributes (e.g., "1.3.6.1.4.1.99999")
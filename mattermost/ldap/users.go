@@ -1,6 +1,7 @@
 package ldap
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
@@ -173,14 +174,15 @@ func FormatLDAPAttributesForDebug(addRequest *ldap.AddRequest) map[string]any {
 	return attributes
 }
 
-// ImportUsersToLDAP connects directly to LDAP and creates users with custom configuration
-func (c *Client) ImportUsersToLDAP(users []LDAPUser, attributeFields []UserAttributeField, config *LDAPConfig) error {
+// ImportUsersToLDAP connects directly to LDAP and creates users with custom configuration.
+// It stops before the next user once ctx ends.
+func (c *Client) ImportUsersToLDAP(ctx context.Context, users []LDAPUser, attributeFields []UserAttributeField, config *LDAPConfig) error {
 	Log.WithFields(logrus.Fields{"user_count": len(users)}).Info("📥 Importing users directly to LDAP")
 
 	// Connect to LDAP server
-	ldapConn, err := ldap.DialURL(config.URL)
+	ldapConn, err := Dial(config)
 	if err != nil {
-		return fmt.Errorf("failed to connect to LDAP server: %w", err)
+		return err
 	}
 	defer func() {
 		if err := ldapConn.Close(); err != nil {
@@ -211,6 +213,9 @@ func (c *Client) ImportUsersToLDAP(users []LDAPUser, attributeFields []UserAttri
 	errorCount := 0
 
 	for _, user := range users {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
 		if err := c.CreateLDAPUser(ldapConn, user, config); err != nil {
 			Log.WithFields(logrus.Fields{
 				"username": user.Username,
@@ -0,0 +1,74 @@
+package mattermost
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+// TestSyncGroupsToChannels verifies groups are linked to their channels, already linked groups are
+// skipped and failures for one channel or group do not stop the others
+func TestSyncGroupsToChannels(t *testing.T) {
+	linked := map[string][]string{"channel-ops": {"group-pilots"}} // channel ID -> linked group IDs
+	var links []string
+
+	server := newMockServer()
+	server.handleTeamsByName()
+	server.handle("GET /api/v4/teams/team-demo/channels/name/{channel}", func(w http.ResponseWriter, r *http.Request) {
+		name := r.PathValue("channel")
+		if name == "missing" {
+			writeAppError(w, http.StatusNotFound, "app.channel.get_by_name.missing.app_error")
+			return
+		}
+		writeJSON(w, http.StatusOK, &model.Channel{Id: "channel-" + name, Name: name})
+	})
+	server.handle("GET /api/v4/channels/{channel}/groups", func(w http.ResponseWriter, r *http.Request) {
+		groups := []*model.GroupWithSchemeAdmin{}
+		for _, id := range linked[r.PathValue("channel")] {
+			groups = append(groups, &model.GroupWithSchemeAdmin{Group: model.Group{Id: id}})
+		}
+		writeJSON(w, http.StatusOK, map[string]any{"groups": groups, "total_group_count": len(groups)})
+	})
+	server.handle("GET /api/v4/groups", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query().Get("q")
+		if q == "ghosts" {
+			writeJSON(w, http.StatusOK, []*model.Group{})
+			return
+		}
+		writeJSON(w, http.StatusOK, []*model.Group{{Id: "group-" + q, DisplayName: q, Source: model.GroupSourceLdap}})
+	})
+	server.handle("POST /api/v4/groups/{group}/channels/{channel}/link", func(w http.ResponseWriter, r *http.Request) {
+		links = append(links, r.PathValue("group")+"/channels/"+r.PathValue("channel"))
+		writeJSON(w, http.StatusOK, map[string]bool{"auto_add": true})
+	})
+	client := setupMockClient(t, server)
+	client.Config = &Config{Teams: map[string]TeamConfig{
+		"demo": {Name: "demo", Channels: []ChannelConfig{
+			{Name: "ops", Groups: []string{"pilots", "operators"}},
+			{Name: "missing", Groups: []string{"operators"}},
+			{Name: "briefing", Groups: []string{"ghosts", "pilots"}},
+			{Name: "town-square"},
+		}},
+	}}
+
+	err := client.SyncGroupsToChannels("demo")
+	if err == nil {
+		t.Fatal("Expected the missing channel and group to be reported")
+	}
+	for _, expected := range []string{"channel 'missing'", "group 'ghosts'"} {
+		if !strings.Contains(err.Error(), expected) {
+			t.Errorf("Expected the error to mention %s, got %v", expected, err)
+		}
+	}
+
+	expectedLinks := []string{
+		"group-operators/channels/channel-ops",
+		"group-pilots/channels/channel-briefing",
+	}
+	if !slices.Equal(links, expectedLinks) {
+		t.Errorf("Expected links %v, got %v", expectedLinks, links)
+	}
+}
@@ -2,14 +2,35 @@
 package mattermost
 
 import (
+	"os"
+	"time"
+
 	// Third party imports
 	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/sirupsen/logrus"
 )
 
 // Constants for configuration and behaviors
 const (
-	// MaxWaitSeconds is the maximum time to wait for server startup
+	// MaxWaitSeconds is the default maximum time to wait for server startup
 	MaxWaitSeconds = 120
+
+	// DefaultWaitInterval is the default time between pings while waiting for server startup
+	DefaultWaitInterval = time.Second
+
+	// WaitTimeoutEnv names the environment variable that overrides how long to wait for server
+	// startup, as a duration such as "5m"
+	WaitTimeoutEnv = "MM_WAIT_TIMEOUT"
+
+	// WaitIntervalEnv names the environment variable that overrides the time between pings while
+	// waiting for server startup, as a duration such as "5s"
+	WaitIntervalEnv = "MM_WAIT_INTERVAL"
+
+	// DefaultSetupTimeout is the default deadline for the whole setup command
+	DefaultSetupTimeout = 30 * time.Minute
+
+	// DefaultExportPostsPerChannel is the default number of most recent posts Export writes per channel
+	DefaultExportPostsPerChannel = 100
 )
 
 // Client represents a Mattermost API client with configuration for managing
@@ -41,6 +62,45 @@ type Client struct {
 
 	// BulkImportPath is the path to the bulk import JSONL file
 	BulkImportPath string
+
+	// DryRun prints mutating API calls instead of sending them (see EnableDryRun)
+	DryRun bool
+
+	// PluginConcurrency is the number of GitHub plugins downloaded at once (0 uses DefaultPluginConcurrency)
+	PluginConcurrency int
+
+	// PluginBuildConcurrency is the number of local plugins built at once (0 uses GOMAXPROCS)
+	PluginBuildConcurrency int
+
+	// WaitTimeout is how long WaitForStart waits for the server (0 uses WaitTimeoutEnv or MaxWaitSeconds)
+	WaitTimeout time.Duration
+
+	// WaitInterval is the time between WaitForStart pings (0 uses WaitIntervalEnv or DefaultWaitInterval)
+	WaitInterval time.Duration
+
+	// PluginUpdates holds the IDs of GitHub plugins with a newer release, which setup reinstalls
+	PluginUpdates map[string]bool
+
+	// VerifyBeforeImport runs VerifyBulkImport on the import file and aborts setup if it fails
+	VerifyBeforeImport bool
+
+	// ResetOnFailure deletes the users and teams in the import file if any setup phase fails
+	ResetOnFailure bool
+
+	// SkipDirectMessages skips sending the direct-message entries from the import file
+	SkipDirectMessages bool
+
+	// BotTokens holds the access tokens created for imported bots during setup, by bot username
+	BotTokens map[string]string
+
+	// WebhookURLs holds the URLs of the incoming webhooks set up during setup, by webhook name
+	WebhookURLs map[string]string
+
+	// ExportPostsPerChannel is the number of most recent posts Export writes per channel (0 uses DefaultExportPostsPerChannel)
+	ExportPostsPerChannel int
+
+	// RetryPolicy controls retries of API calls that fail with 5xx or connection errors (zero uses DefaultRetryPolicy)
+	RetryPolicy RetryPolicy
 }
 
 
@@ -66,8 +126,29 @@ func NewClient(serverURL, adminUser, adminPass, teamName string, configPath stri
 		BulkImportPath: "bulk_import.jsonl",
 	}
 
+	// Trust an internal CA for the server when one is configured
+	if httpClient := caBundleHTTPClient(os.Getenv(CABundlePathEnv)); httpClient != nil {
+		client.API.HTTPClient = httpClient
+	}
+
 	// Initialize plugin manager
 	client.PluginManager = NewPluginManager(client)
 
 	return client
 }
+
+// durationFromEnv returns the duration in the environment variable, falling back to the default
+// when it is unset or not a positive duration. name describes the setting in the warning.
+func durationFromEnv(env string, fallback time.Duration, name string) time.Duration {
+	value := os.Getenv(env)
+	if value == "" {
+		return fallback
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration <= 0 {
+		Log.WithFields(logrus.Fields{"env": env, "value": value}).Warn("⚠️ Invalid " + name + ", using the default")
+		return fallback
+	}
+	return duration
+}
@@ -123,7 +123,7 @@ func TestWaitForStart(t *testing.T) {
 	)
 
 	// Test just the WaitForStart function
-	err = client.WaitForStart()
+	err = client.WaitForStart(context.Background())
 	if err != nil {
 		t.Errorf("Expected no error from WaitForStart, but got: %v", err)
 	}
@@ -0,0 +1,116 @@
+package mattermost
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/sirupsen/logrus"
+)
+
+// mockServer is a mock Mattermost server answering from a table of routes. Tests register only the
+// routes they need with handle, using http.ServeMux patterns such as "GET /api/v4/teams/name/{team}";
+// every other request gets a 404 app error. Handlers run one at a time under the server's lock, so
+// they can record into the test's variables directly, and every request is logged for requests.
+type mockServer struct {
+	mu  sync.Mutex
+	mux *http.ServeMux
+	log []string
+}
+
+func newMockServer() *mockServer {
+	s := &mockServer{mux: http.NewServeMux()}
+	s.mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		writeAppError(w, http.StatusNotFound, "api.context.404.app_error")
+	})
+	return s
+}
+
+// handle registers handler for requests matching pattern
+func (s *mockServer) handle(pattern string, handler http.HandlerFunc) {
+	s.mux.HandleFunc(pattern, handler)
+}
+
+// requests returns every request received so far as "METHOD path"
+func (s *mockServer) requests() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.log)
+}
+
+func (s *mockServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.log = append(s.log, r.Method+" "+r.URL.Path)
+	w.Header().Set("Content-Type", "application/json")
+	s.mux.ServeHTTP(w, r)
+}
+
+// handleTeamsByName answers team lookups by name with a team whose ID is "team-" plus the name
+func (s *mockServer) handleTeamsByName() {
+	s.handle("GET /api/v4/teams/name/{team}", func(w http.ResponseWriter, r *http.Request) {
+		name := r.PathValue("team")
+		writeJSON(w, http.StatusOK, &model.Team{Id: "team-" + name, Name: name})
+	})
+}
+
+// handleChannelsByTeamName answers channel lookups by team and channel name with a channel whose
+// ID is "channel-" plus the name
+func (s *mockServer) handleChannelsByTeamName() {
+	s.handle("GET /api/v4/teams/name/{team}/channels/name/{channel}", func(w http.ResponseWriter, r *http.Request) {
+		name := r.PathValue("channel")
+		writeJSON(w, http.StatusOK, &model.Channel{Id: "channel-" + name, Name: name, TeamId: "team-" + r.PathValue("team")})
+	})
+}
+
+// handleUsersByUsername answers username lookups for the given users with a user whose ID is
+// "user-" plus the username, and 404s any other username
+func (s *mockServer) handleUsersByUsername(usernames ...string) {
+	s.handle("GET /api/v4/users/username/{username}", func(w http.ResponseWriter, r *http.Request) {
+		username := r.PathValue("username")
+		if !slices.Contains(usernames, username) {
+			writeAppError(w, http.StatusNotFound, "app.user.missing_account.const")
+			return
+		}
+		writeJSON(w, http.StatusOK, &model.User{Id: "user-" + username, Username: username})
+	})
+}
+
+// writeJSON writes v as the response body with the given status
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// writeAppError writes a Mattermost API error with the given status and error ID
+func writeAppError(w http.ResponseWriter, status int, id string) {
+	writeJSON(w, status, model.NewAppError("mockServer", id, nil, "", status))
+}
+
+// decodeJSON decodes the request body into v, answering 400 when it is not valid JSON
+func decodeJSON(w http.ResponseWriter, r *http.Request, v any) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		writeAppError(w, http.StatusBadRequest, "api.context.invalid_body_param.app_error")
+		return false
+	}
+	return true
+}
+
+// setupMockClient creates a client pointed at the given mock server
+func setupMockClient(t *testing.T, handler http.Handler) *Client {
+	t.Helper()
+
+	InitLogger(&LogConfig{Level: logrus.ErrorLevel})
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	client := NewClient(server.URL, "sysadmin", "password", "test-team", "")
+	client.API.AuthToken = "test-token"
+	return client
+}
@@ -0,0 +1,156 @@
+package mattermost
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"golang.org/x/mod/semver"
+)
+
+// PluginUpdateInfo describes an installed GitHub plugin and the latest release of its repository
+type PluginUpdateInfo struct {
+	PluginID         string
+	InstalledVersion string // Empty if the plugin is not installed
+	LatestVersion    string
+	NeedsUpdate      bool
+}
+
+// CheckForUpdates compares the installed version of each GitHub plugin in the import file with the
+// latest release of its repository. Plugins pinned to a version are left out, since they are not
+// meant to follow the latest release. A plugin whose release cannot be looked up is left out and its
+// error returned with the others, alongside the plugins that were checked.
+func (pm *PluginManager) CheckForUpdates() ([]PluginUpdateInfo, error) {
+	bulkImportPath := pm.client.BulkImportPath
+	if bulkImportPath == "" {
+		path, err := findBulkImportPath()
+		if err != nil {
+			return nil, err
+		}
+		bulkImportPath = path
+	}
+
+	plugins, err := readGitHubPlugins(bulkImportPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var updates []PluginUpdateInfo
+	var errs []error
+	for _, plugin := range plugins {
+		if plugin.Plugin.Version != "" {
+			continue
+		}
+
+		latest, err := latestReleaseTag(plugin.Plugin.GithubRepo)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to check %s: %w", plugin.Plugin.PluginID, err))
+			continue
+		}
+
+		info := PluginUpdateInfo{PluginID: plugin.Plugin.PluginID, LatestVersion: latest}
+		if installed, ok := pm.installedVersion(plugin.Plugin.PluginID); ok {
+			info.InstalledVersion = installed
+			info.NeedsUpdate = isNewerVersion(latest, installed)
+		}
+		updates = append(updates, info)
+
+		Log.WithFields(logrus.Fields{
+			"plugin_id":         info.PluginID,
+			"installed_version": info.InstalledVersion,
+			"latest_version":    info.LatestVersion,
+			"needs_update":      info.NeedsUpdate,
+		}).Debug("Checked plugin for updates")
+	}
+
+	return updates, errors.Join(errs...)
+}
+
+// readGitHubPlugins returns the plugin entries with source "github" from the import file
+func readGitHubPlugins(bulkImportPath string) ([]PluginImport, error) {
+	file, err := os.Open(bulkImportPath)
+	if err != nil {
+		return nil, err
+	}
+	defer closeWithLog(file, "bulk import file")
+
+	var plugins []PluginImport
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		var pluginImport PluginImport
+		if err := json.Unmarshal([]byte(line), &pluginImport); err != nil {
+			continue
+		}
+		if pluginImport.Type == "plugin" && pluginImport.Plugin.Source == "github" {
+			plugins = append(plugins, pluginImport)
+		}
+	}
+	return plugins, scanner.Err()
+}
+
+// latestReleaseTag returns the tag of the latest release of a GitHub repository
+func latestReleaseTag(repo string) (string, error) {
+	resp, err := http.Get(fmt.Sprintf("%s/repos/%s/releases/latest", githubAPIURL, repo))
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to get release info for %s: %s", repo, resp.Status)
+	}
+
+	var release struct {
+		TagName string `json:"tag_name"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&release); err != nil {
+		return "", err
+	}
+	return release.TagName, nil
+}
+
+// isNewerVersion reports whether the latest version is a newer semantic version than the installed one.
+// Versions may be written with or without a leading "v"; versions that do not parse are never newer.
+func isNewerVersion(latest, installed string) bool {
+	latest = "v" + strings.TrimPrefix(latest, "v")
+	installed = "v" + strings.TrimPrefix(installed, "v")
+	if !semver.IsValid(latest) || !semver.IsValid(installed) {
+		return false
+	}
+	return semver.Compare(latest, installed) > 0
+}
+
+// checkPluginUpdates records in PluginUpdates the GitHub plugins that have a newer release, so
+// processPlugins reinstalls them. A failed check is logged and does not stop setup.
+func (c *Client) checkPluginUpdates() {
+	Log.Info("🔍 Checking GitHub plugins for updates")
+
+	updates, err := c.PluginManager.CheckForUpdates()
+	if err != nil {
+		Log.WithFields(logrus.Fields{"error": err.Error()}).Warn("⚠️ Failed to check some plugins for updates")
+	}
+
+	c.PluginUpdates = make(map[string]bool)
+	for _, update := range updates {
+		if !update.NeedsUpdate {
+			continue
+		}
+		c.PluginUpdates[update.PluginID] = true
+		Log.WithFields(logrus.Fields{
+			"plugin_id":         update.PluginID,
+			"installed_version": update.InstalledVersion,
+			"latest_version":    update.LatestVersion,
+		}).Info("🔄 Newer plugin version available")
+	}
+}
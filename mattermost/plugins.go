@@ -4,17 +4,39 @@ import (
 	"bufio"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"runtime"
 	"strings"
+	"sync"
+	"time"
 
+	"github.com/mattermost/mattermost/server/public/model"
 	"github.com/sirupsen/logrus"
 )
 
+// DefaultPluginConcurrency is the number of GitHub plugins downloaded at once unless overridden
+const DefaultPluginConcurrency = 3
+
+// DefaultPluginActivationTimeout is how long to wait for an uploaded plugin to become active
+// unless overridden with the PluginActivationTimeoutEnv environment variable
+const DefaultPluginActivationTimeout = 30 * time.Second
+
+// PluginActivationTimeoutEnv names the environment variable that overrides the plugin activation
+// timeout, as a duration such as "90s" or "2m"
+const PluginActivationTimeoutEnv = "PLUGIN_ACTIVATION_TIMEOUT"
+
+// pluginActivePollInterval is how often WaitForPluginActive checks the plugin list
+var pluginActivePollInterval = time.Second
+
+// githubAPIURL is the GitHub API that plugin releases are looked up from
+var githubAPIURL = "https://api.github.com"
+
 // PluginManager handles all plugin-related operations
 type PluginManager struct {
 	client *Client
@@ -29,38 +51,55 @@ func NewPluginManager(client *Client) *PluginManager {
 
 // isInstalledByID checks if a plugin is installed by its exact plugin ID
 func (pm *PluginManager) isInstalledByID(pluginID string) bool {
+	_, installed := pm.installedVersion(pluginID)
+	return installed
+}
+
+// installedVersion returns the version of the installed plugin with the exact plugin ID
+func (pm *PluginManager) installedVersion(pluginID string) (string, bool) {
 	plugins, _, err := pm.client.API.GetPlugins(context.Background())
 	if err != nil {
 		Log.WithFields(logrus.Fields{"plugin_id": pluginID, "error": err.Error()}).Debug("Failed to get plugins list, assuming not installed")
-		return false // Assume not installed if we can't check
+		return "", false // Assume not installed if we can't check
 	}
 
 	allPlugins := append(plugins.Active, plugins.Inactive...)
 	for _, plugin := range allPlugins {
 		if plugin.Id == pluginID {
 			Log.WithFields(logrus.Fields{"plugin_id": pluginID, "plugin_version": plugin.Version}).Debug("Found installed plugin by ID")
-			return true
+			return plugin.Version, true
 		}
 	}
 
 	Log.WithFields(logrus.Fields{"plugin_id": pluginID}).Debug("Plugin not found by ID")
-	return false
+	return "", false
 }
 
-// downloadPlugin downloads a plugin from GitHub
-func (pm *PluginManager) downloadPlugin(plugin PluginConfig) error {
+// downloadPlugin downloads a plugin from GitHub, either the release tagged plugin.Version or the
+// latest release, and returns the path of the downloaded bundle
+func (pm *PluginManager) downloadPlugin(ctx context.Context, plugin PluginConfig) (string, error) {
 
-	// Get latest release
-	url := fmt.Sprintf("https://api.github.com/repos/%s/releases/latest", plugin.Repo)
-	Log.WithFields(logrus.Fields{"plugin_name": plugin.Name, "github_url": plugin.Repo, "plugin_id": plugin.PluginID, "api_url": url}).Debug("Getting latest release info")
+	// Get the pinned or latest release
+	url := fmt.Sprintf("%s/repos/%s/releases/latest", githubAPIURL, plugin.Repo)
+	if plugin.Version != "" {
+		url = fmt.Sprintf("%s/repos/%s/releases/tags/%s", githubAPIURL, plugin.Repo, plugin.Version)
+	}
+	Log.WithFields(logrus.Fields{"plugin_name": plugin.Name, "github_url": plugin.Repo, "plugin_id": plugin.PluginID, "version": plugin.Version, "api_url": url}).Debug("Getting release info")
 
-	resp, err := http.Get(url)
+	resp, err := httpGet(ctx, url)
 	if err != nil {
 		Log.WithFields(logrus.Fields{"plugin_name": plugin.Name, "github_url": plugin.Repo, "api_url": url, "error": err.Error()}).Debug("Failed to get release info")
-		return err
+		return "", err
 	}
 	defer func() { _ = resp.Body.Close() }()
 
+	if resp.StatusCode == http.StatusNotFound && plugin.Version != "" {
+		return "", fmt.Errorf("release %s not found in %s", plugin.Version, plugin.Repo)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to get release info for %s: %s", plugin.Repo, resp.Status)
+	}
+
 	var release struct {
 		TagName string `json:"tag_name"`
 		Assets  []struct {
@@ -71,7 +110,7 @@ func (pm *PluginManager) downloadPlugin(plugin PluginConfig) error {
 
 	if err := json.NewDecoder(resp.Body).Decode(&release); err != nil {
 		Log.WithFields(logrus.Fields{"plugin_name": plugin.Name, "github_url": plugin.Repo, "error": err.Error()}).Debug("Failed to decode release response")
-		return err
+		return "", err
 	}
 
 	Log.WithFields(logrus.Fields{"plugin_name": plugin.Name, "github_url": plugin.Repo, "release_tag": release.TagName, "asset_count": len(release.Assets)}).Debug("Got release info, looking for .tar.gz asset")
@@ -90,18 +129,18 @@ func (pm *PluginManager) downloadPlugin(plugin PluginConfig) error {
 
 	if downloadURL == "" {
 		Log.WithFields(logrus.Fields{"plugin_name": plugin.Name, "github_url": plugin.Repo, "release_tag": release.TagName, "asset_count": len(release.Assets)}).Debug("No suitable .tar.gz found in assets")
-		return fmt.Errorf("no suitable .tar.gz found")
+		return "", fmt.Errorf("no suitable .tar.gz found")
 	}
 
 	filename := plugin.PluginID + "-" + release.TagName + ".tar.gz"
 	Log.WithFields(logrus.Fields{"plugin_name": plugin.Name, "github_url": plugin.Repo, "release_tag": release.TagName, "asset_name": assetName, "download_url": downloadURL, "filename": filename}).Debug("Found suitable asset, downloading")
 
 	// Download to plugins directory
-	return pm.downloadFile(downloadURL, filename)
+	return pm.downloadFile(ctx, downloadURL, filename)
 }
 
-// downloadFile downloads a file to the plugins directory
-func (pm *PluginManager) downloadFile(url, filename string) error {
+// downloadFile downloads a file to the plugins directory and returns its path
+func (pm *PluginManager) downloadFile(ctx context.Context, url, filename string) (string, error) {
 
 	pluginsDir := "../files/mattermost/plugins"
 	if _, err := os.Stat("files/mattermost/plugins"); err == nil {
@@ -113,31 +152,40 @@ func (pm *PluginManager) downloadFile(url, filename string) error {
 
 	Log.WithFields(logrus.Fields{"download_url": url, "filename": filename, "plugins_dir": pluginsDir, "file_path": filePath}).Debug("Starting file download")
 
-	resp, err := http.Get(url)
+	resp, err := httpGet(ctx, url)
 	if err != nil {
 		Log.WithFields(logrus.Fields{"download_url": url, "filename": filename, "error": err.Error()}).Debug("Failed to download file")
-		return err
+		return "", err
 	}
 	defer func() { _ = resp.Body.Close() }()
 
 	file, err := os.Create(filePath)
 	if err != nil {
 		Log.WithFields(logrus.Fields{"download_url": url, "filename": filename, "file_path": filePath, "error": err.Error()}).Debug("Failed to create file")
-		return err
+		return "", err
 	}
 	defer func() { _ = file.Close() }()
 
 	_, err = io.Copy(file, resp.Body)
 	if err != nil {
 		Log.WithFields(logrus.Fields{"download_url": url, "filename": filename, "file_path": filePath, "error": err.Error()}).Debug("Failed to copy file contents")
-	} else {
-		Log.WithFields(logrus.Fields{"download_url": url, "filename": filename, "file_path": filePath}).Debug("Successfully downloaded file")
+		return "", err
 	}
-	return err
+	Log.WithFields(logrus.Fields{"download_url": url, "filename": filename, "file_path": filePath}).Debug("Successfully downloaded file")
+	return filePath, nil
+}
+
+// httpGet sends a GET request for url that is cancelled when ctx ends
+func httpGet(ctx context.Context, url string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	return http.DefaultClient.Do(req)
 }
 
 // cleanPlugin cleans a plugin build directory
-func (pm *PluginManager) cleanPlugin(pluginPath string) error {
+func (pm *PluginManager) cleanPlugin(ctx context.Context, pluginPath string) error {
 
 	if _, err := os.Stat(pluginPath); os.IsNotExist(err) {
 		Log.WithFields(logrus.Fields{"plugin_path": pluginPath, "error": err.Error()}).Debug("Plugin directory not found for cleaning")
@@ -145,7 +193,7 @@ func (pm *PluginManager) cleanPlugin(pluginPath string) error {
 	}
 
 	Log.WithFields(logrus.Fields{"plugin_path": pluginPath}).Debug("Running make clean")
-	cmd := exec.Command("make", "clean")
+	cmd := exec.CommandContext(ctx, "make", "clean")
 	cmd.Dir = pluginPath
 	err := cmd.Run()
 	if err != nil {
@@ -157,7 +205,7 @@ func (pm *PluginManager) cleanPlugin(pluginPath string) error {
 }
 
 // buildPlugin builds a plugin from source
-func (pm *PluginManager) buildPlugin(pluginPath string) error {
+func (pm *PluginManager) buildPlugin(ctx context.Context, pluginPath string) error {
 
 	if _, err := os.Stat(pluginPath); os.IsNotExist(err) {
 		Log.WithFields(logrus.Fields{"plugin_path": pluginPath, "error": err.Error()}).Debug("Plugin directory not found for building")
@@ -165,7 +213,7 @@ func (pm *PluginManager) buildPlugin(pluginPath string) error {
 	}
 
 	Log.WithFields(logrus.Fields{"plugin_path": pluginPath}).Debug("Running make dist")
-	cmd := exec.Command("make", "dist")
+	cmd := exec.CommandContext(ctx, "make", "dist")
 	cmd.Dir = pluginPath
 	err := cmd.Run()
 	if err != nil {
@@ -177,7 +225,7 @@ func (pm *PluginManager) buildPlugin(pluginPath string) error {
 }
 
 // uploadPlugin uploads and enables a plugin
-func (pm *PluginManager) uploadPlugin(bundlePath string) error {
+func (pm *PluginManager) uploadPlugin(ctx context.Context, bundlePath string) error {
 
 	file, err := os.Open(bundlePath)
 	if err != nil {
@@ -187,14 +235,24 @@ func (pm *PluginManager) uploadPlugin(bundlePath string) error {
 	defer func() { _ = file.Close() }()
 
 	Log.WithFields(logrus.Fields{"bundle_path": bundlePath}).Debug("Uploading plugin bundle")
-	manifest, _, err := pm.client.API.UploadPluginForced(context.Background(), file)
+	var manifest *model.Manifest
+	err = pm.client.withRetry(ctx, "upload plugin", func() (*model.Response, error) {
+		// Each attempt sends the bundle from the start
+		if _, err := file.Seek(0, io.SeekStart); err != nil {
+			return nil, err
+		}
+		var resp *model.Response
+		var err error
+		manifest, resp, err = pm.client.API.UploadPluginForced(ctx, file)
+		return resp, err
+	})
 	if err != nil {
 		Log.WithFields(logrus.Fields{"bundle_path": bundlePath, "error": err.Error()}).Debug("Failed to upload plugin")
 		return err
 	}
 
 	Log.WithFields(logrus.Fields{"bundle_path": bundlePath, "plugin_id": manifest.Id, "plugin_version": manifest.Version}).Debug("Plugin uploaded, enabling")
-	_, err = pm.client.API.EnablePlugin(context.Background(), manifest.Id)
+	_, err = pm.client.API.EnablePlugin(ctx, manifest.Id)
 	if err != nil {
 		Log.WithFields(logrus.Fields{"bundle_path": bundlePath, "plugin_id": manifest.Id, "error": err.Error()}).Debug("Failed to enable plugin")
 	} else {
@@ -267,7 +325,7 @@ func (c *Client) IsPluginBuilt(pluginPath string) bool {
 }
 
 // BuildPlugin builds a plugin from its source directory using make
-func (c *Client) BuildPlugin(pluginPath string) error {
+func (c *Client) BuildPlugin(ctx context.Context, pluginPath string) error {
 	// Check if the plugin directory exists
 	if _, err := os.Stat(pluginPath); os.IsNotExist(err) {
 		return fmt.Errorf("plugin directory does not exist: %s", pluginPath)
@@ -280,7 +338,7 @@ func (c *Client) BuildPlugin(pluginPath string) error {
 	}
 
 	// Run make dist to build the plugin
-	cmd := exec.Command("make", "dist")
+	cmd := exec.CommandContext(ctx, "make", "dist")
 	cmd.Dir = pluginPath
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
@@ -316,7 +374,7 @@ func (c *Client) FindPluginBundle(pluginPath string) (string, error) {
 }
 
 // UploadPlugin uploads and installs a plugin to the Mattermost server
-func (c *Client) UploadPlugin(bundlePath string) error {
+func (c *Client) UploadPlugin(ctx context.Context, bundlePath string) error {
 	Log.WithFields(logrus.Fields{
 		"bundle_path": bundlePath,
 	}).Info("📤 Uploading plugin bundle")
@@ -340,7 +398,7 @@ func (c *Client) UploadPlugin(bundlePath string) error {
 	if _, seekErr := file.Seek(0, 0); seekErr != nil {
 		return fmt.Errorf("❌ failed to reset file position: %w", seekErr)
 	}
-	manifest, resp, err := c.API.UploadPluginForced(context.Background(), file)
+	manifest, resp, err := c.API.UploadPluginForced(ctx, file)
 	if err != nil {
 		return handleAPIError(fmt.Sprintf("failed to upload plugin bundle '%s': %v", bundlePath, err), err, resp)
 	}
@@ -350,82 +408,135 @@ func (c *Client) UploadPlugin(bundlePath string) error {
 	}).Info("✅ Plugin uploaded successfully (forced)")
 
 	// Enable the plugin
-	enableResp, enableErr := c.API.EnablePlugin(context.Background(), manifest.Id)
+	enableResp, enableErr := c.API.EnablePlugin(ctx, manifest.Id)
 	if enableErr != nil {
 		return handleAPIError("failed to enable plugin", enableErr, enableResp)
 	}
 
-	return nil
+	return c.WaitForPluginActive(ctx, manifest.Id, pluginActivationTimeout())
+}
+
+// WaitForPluginActive polls the server's plugin list every second until the plugin is active,
+// returning an error if it is still not active after timeout or ctx ends
+func (c *Client) WaitForPluginActive(ctx context.Context, pluginID string, timeout time.Duration) error {
+	Log.WithFields(logrus.Fields{"plugin_id": pluginID, "timeout": timeout.String()}).Debug("⏳ Waiting for plugin to become active")
+
+	deadline := time.Now().Add(timeout)
+	for {
+		plugins, resp, err := c.API.GetPlugins(ctx)
+		if err != nil {
+			return handleAPIError(fmt.Sprintf("failed to get plugins while waiting for '%s' to activate", pluginID), err, resp)
+		}
+
+		for _, plugin := range plugins.Active {
+			if plugin.Id == pluginID {
+				Log.WithFields(logrus.Fields{"plugin_id": pluginID}).Debug("✅ Plugin is active")
+				return nil
+			}
+		}
+
+		if !time.Now().Add(pluginActivePollInterval).Before(deadline) {
+			return fmt.Errorf("plugin '%s' did not become active within %s", pluginID, timeout)
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("stopped waiting for plugin '%s' to become active: %w", pluginID, ctx.Err())
+		case <-time.After(pluginActivePollInterval):
+		}
+	}
+}
+
+// pluginActivationTimeout returns the plugin activation timeout from PluginActivationTimeoutEnv,
+// falling back to DefaultPluginActivationTimeout when it is unset or invalid
+func pluginActivationTimeout() time.Duration {
+	return durationFromEnv(PluginActivationTimeoutEnv, DefaultPluginActivationTimeout, "plugin activation timeout")
 }
 
-// processGitHubPlugin downloads and installs a GitHub plugin
-func (c *Client) processGitHubPlugin(pluginImport PluginImport) error {
+// githubPluginNeedsInstall reports whether a GitHub plugin has to be downloaded and installed.
+// Plugins that are already installed at the pinned version (unless forced) and all plugins in
+// dry-run mode are skipped.
+func (c *Client) githubPluginNeedsInstall(pluginImport PluginImport) bool {
 	Log.WithFields(logrus.Fields{
 		"plugin_name":   pluginImport.Plugin.Name,
 		"github_repo":   pluginImport.Plugin.GithubRepo,
 		"plugin_id":     pluginImport.Plugin.PluginID,
+		"version":       pluginImport.Plugin.Version,
 		"force_install": pluginImport.Plugin.ForceInstall,
 	}).Info("📦 Processing plugin " + pluginImport.Plugin.Name)
 
-	// Check if already installed unless forced
+	// Check if already installed unless forced. A pinned plugin installed at another version is replaced.
 	pm := NewPluginManager(c)
-	if !pluginImport.Plugin.ForceInstall && pm.isInstalledByID(pluginImport.Plugin.PluginID) {
+	if !pluginImport.Plugin.ForceInstall {
+		if version, installed := pm.installedVersion(pluginImport.Plugin.PluginID); installed {
+			if pluginImport.Plugin.Version == "" || version == strings.TrimPrefix(pluginImport.Plugin.Version, "v") {
+				Log.WithFields(logrus.Fields{
+					"plugin_name": pluginImport.Plugin.Name,
+					"plugin_id":   pluginImport.Plugin.PluginID,
+				}).Info("⏭️ Skipping " + pluginImport.Plugin.Name + ": already installed")
+				return false
+			}
+			Log.WithFields(logrus.Fields{
+				"plugin_name":       pluginImport.Plugin.Name,
+				"installed_version": version,
+				"pinned_version":    pluginImport.Plugin.Version,
+			}).Info("🔄 Replacing " + pluginImport.Plugin.Name + " with the pinned version")
+		}
+	}
+
+	if c.DryRun {
 		Log.WithFields(logrus.Fields{
 			"plugin_name": pluginImport.Plugin.Name,
-			"plugin_id":   pluginImport.Plugin.PluginID,
-		}).Info("⏭️ Skipping " + pluginImport.Plugin.Name + ": already installed")
-		return nil
+			"github_repo": pluginImport.Plugin.GithubRepo,
+		}).Info("ℹ️ Dry run: skipping plugin download and upload")
+		return false
 	}
 
+	return true
+}
+
+// downloadGitHubPlugin downloads a GitHub plugin's release bundle and returns its path
+func (c *Client) downloadGitHubPlugin(ctx context.Context, pluginImport PluginImport) (string, error) {
 	// Create PluginConfig for compatibility with existing plugin manager
 	pluginConfig := PluginConfig{
 		Name:     pluginImport.Plugin.Name,
 		Repo:     pluginImport.Plugin.GithubRepo,
 		PluginID: pluginImport.Plugin.PluginID,
+		Version:  pluginImport.Plugin.Version,
 	}
 
-	// Download the plugin
 	Log.WithFields(logrus.Fields{
 		"plugin_name": pluginImport.Plugin.Name,
 		"github_repo": pluginImport.Plugin.GithubRepo,
 		"plugin_id":   pluginImport.Plugin.PluginID,
 	}).Info("📥 Downloading plugin from GitHub...")
 
-	if err := pm.downloadPlugin(pluginConfig); err != nil {
-		return fmt.Errorf("failed to download GitHub plugin: %w", err)
-	}
-
-	// Install from plugins directory
-	pluginsDir := "../files/mattermost/plugins"
-	if _, err := os.Stat("files/mattermost/plugins"); err == nil {
-		pluginsDir = "files/mattermost/plugins"
-	}
-
-	// Find the downloaded .tar.gz file
-	files, err := os.ReadDir(pluginsDir)
+	pluginPath, err := NewPluginManager(c).downloadPlugin(ctx, pluginConfig)
 	if err != nil {
-		return fmt.Errorf("failed to read plugins directory: %w", err)
+		return "", fmt.Errorf("failed to download GitHub plugin: %w", err)
 	}
+	return pluginPath, nil
+}
 
-	for _, file := range files {
-		if strings.HasSuffix(file.Name(), ".tar.gz") && strings.Contains(file.Name(), pluginImport.Plugin.PluginID) {
-			pluginPath := filepath.Join(pluginsDir, file.Name())
-			if err := pm.uploadPlugin(pluginPath); err != nil {
-				return fmt.Errorf("failed to install GitHub plugin: %w", err)
-			}
-			Log.WithFields(logrus.Fields{
-				"plugin_name": pluginImport.Plugin.Name,
-				"plugin_id":   pluginImport.Plugin.PluginID,
-			}).Info("✅ Successfully installed " + pluginImport.Plugin.Name)
-			return nil
-		}
+// installGitHubPlugin uploads the bundle downloaded by downloadGitHubPlugin and waits for it to activate.
+// The bundle just downloaded is installed, not an older release left in the plugins directory.
+func (c *Client) installGitHubPlugin(ctx context.Context, pluginImport PluginImport, pluginPath string) error {
+	pm := NewPluginManager(c)
+	if err := pm.uploadPlugin(ctx, pluginPath); err != nil {
+		return fmt.Errorf("failed to install GitHub plugin: %w", err)
 	}
-
-	return fmt.Errorf("downloaded plugin file not found for %s", pluginImport.Plugin.Name)
+	if err := c.WaitForPluginActive(ctx, pluginImport.Plugin.PluginID, pluginActivationTimeout()); err != nil {
+		return fmt.Errorf("failed to activate GitHub plugin: %w", err)
+	}
+	Log.WithFields(logrus.Fields{
+		"plugin_name": pluginImport.Plugin.Name,
+		"plugin_id":   pluginImport.Plugin.PluginID,
+	}).Info("✅ Successfully installed " + pluginImport.Plugin.Name)
+	return nil
 }
 
-// processLocalPlugin builds and installs a local plugin
-func (c *Client) processLocalPlugin(pluginImport PluginImport) error {
+// localPluginNeedsInstall reports whether a local plugin has to be built and installed.
+// Plugins that are already installed (unless forced) and all plugins in dry-run mode are skipped.
+func (c *Client) localPluginNeedsInstall(pluginImport PluginImport) (bool, error) {
 	Log.WithFields(logrus.Fields{
 		"plugin_name":   pluginImport.Plugin.Name,
 		"plugin_path":   pluginImport.Plugin.Path,
@@ -440,20 +551,35 @@ func (c *Client) processLocalPlugin(pluginImport PluginImport) error {
 			"plugin_name": pluginImport.Plugin.Name,
 			"plugin_id":   pluginImport.Plugin.PluginID,
 		}).Info("⏭️ Skipping " + pluginImport.Plugin.Name + ": already installed")
-		return nil
+		return false, nil
 	}
 
 	// Check if plugin directory exists
 	if _, err := os.Stat(pluginImport.Plugin.Path); os.IsNotExist(err) {
-		return fmt.Errorf("plugin directory not found: %s", pluginImport.Plugin.Path)
+		return false, fmt.Errorf("plugin directory not found: %s", pluginImport.Plugin.Path)
 	}
 
+	if c.DryRun {
+		Log.WithFields(logrus.Fields{
+			"plugin_name": pluginImport.Plugin.Name,
+			"plugin_path": pluginImport.Plugin.Path,
+		}).Info("ℹ️ Dry run: skipping plugin build and upload")
+		return false, nil
+	}
+
+	return true, nil
+}
+
+// buildLocalPlugin builds a local plugin with make, cleaning it first when the install is forced
+func (c *Client) buildLocalPlugin(ctx context.Context, pluginImport PluginImport) error {
+	pm := NewPluginManager(c)
+
 	// Clean if forced install
 	if pluginImport.Plugin.ForceInstall {
 		Log.WithFields(logrus.Fields{
 			"plugin_path": pluginImport.Plugin.Path,
 		}).Debug("🧹 Cleaning plugin before rebuild")
-		if err := pm.cleanPlugin(pluginImport.Plugin.Path); err != nil {
+		if err := pm.cleanPlugin(ctx, pluginImport.Plugin.Path); err != nil {
 			Log.WithFields(logrus.Fields{
 				"plugin_path": pluginImport.Plugin.Path,
 				"error":       err.Error(),
@@ -466,11 +592,19 @@ func (c *Client) processLocalPlugin(pluginImport PluginImport) error {
 		"plugin_path": pluginImport.Plugin.Path,
 	}).Info("🔨 Building plugin...")
 
-	if err := pm.buildPlugin(pluginImport.Plugin.Path); err != nil {
+	if err := pm.buildPlugin(ctx, pluginImport.Plugin.Path); err != nil {
 		return fmt.Errorf("failed to build local plugin: %w", err)
 	}
 
-	Log.Info("✅ Plugin build completed, looking for built file...")
+	Log.WithFields(logrus.Fields{
+		"plugin_name": pluginImport.Plugin.Name,
+	}).Info("✅ Plugin build completed")
+	return nil
+}
+
+// installLocalPlugin uploads the bundle built by buildLocalPlugin and waits for it to activate
+func (c *Client) installLocalPlugin(ctx context.Context, pluginImport PluginImport) error {
+	pm := NewPluginManager(c)
 
 	// Find the built .tar.gz file in the plugin's dist directory
 	distDir := filepath.Join(pluginImport.Plugin.Path, "dist")
@@ -509,9 +643,12 @@ func (c *Client) processLocalPlugin(pluginImport PluginImport) error {
 				"full_path":  pluginPath,
 			}).Info("📦 Found plugin file in dist directory, installing...")
 
-			if err := pm.uploadPlugin(pluginPath); err != nil {
+			if err := pm.uploadPlugin(ctx, pluginPath); err != nil {
 				return fmt.Errorf("failed to install local plugin: %w", err)
 			}
+			if err := c.WaitForPluginActive(ctx, pluginImport.Plugin.PluginID, pluginActivationTimeout()); err != nil {
+				return fmt.Errorf("failed to activate local plugin: %w", err)
+			}
 			Log.WithFields(logrus.Fields{
 				"plugin_name": pluginImport.Plugin.Name,
 				"plugin_id":   pluginImport.Plugin.PluginID,
@@ -523,8 +660,9 @@ func (c *Client) processLocalPlugin(pluginImport PluginImport) error {
 	return fmt.Errorf("built plugin file not found for %s (no .tar.gz files found in dist directory '%s')", pluginImport.Plugin.Name, distDir)
 }
 
-// processPlugins processes plugin entries from bulk import file
-func (c *Client) processPlugins(bulkImportPath string, forcePlugins, forceGitHubPlugins bool) error {
+// processPlugins processes plugin entries from bulk import file. Builds, downloads, and uploads
+// stop when ctx ends.
+func (c *Client) processPlugins(ctx context.Context, bulkImportPath string, forcePlugins, forceGitHubPlugins bool) error {
 	Log.Info("📦 Processing plugins from JSONL")
 
 	file, err := os.Open(bulkImportPath)
@@ -562,24 +700,48 @@ func (c *Client) processPlugins(bulkImportPath string, forcePlugins, forceGitHub
 	}).Info("📦 Found plugins in JSONL")
 
 	// Process plugins in order: GitHub first, then local
+	var githubPlugins []PluginImport
 	for _, plugin := range plugins {
 		if plugin.Plugin.Source == "github" {
-			// Apply force flags: forceGitHubPlugins forces all plugins
+			// Apply force flags: forceGitHubPlugins forces all plugins, and plugins with a newer release are reinstalled
 			pluginCopy := plugin
-			if forceGitHubPlugins {
+			if forceGitHubPlugins || c.PluginUpdates[plugin.Plugin.PluginID] {
 				pluginCopy.Plugin.ForceInstall = true
 			}
-
-			if err := c.processGitHubPlugin(pluginCopy); err != nil {
-				Log.WithFields(logrus.Fields{
-					"plugin_name": plugin.Plugin.Name,
-					"error":       err.Error(),
-				}).Error("❌ Failed to process GitHub plugin")
-				return fmt.Errorf("failed to process GitHub plugin '%s': %w", plugin.Plugin.Name, err)
+			if c.githubPluginNeedsInstall(pluginCopy) {
+				githubPlugins = append(githubPlugins, pluginCopy)
 			}
 		}
 	}
 
+	// GitHub bundles are downloaded in parallel, then installed one at a time in file order so the uploads don't race
+	var mu sync.Mutex
+	bundlePaths := make(map[string]string, len(githubPlugins))
+	err = processPluginsConcurrently(ctx, githubPlugins, c.pluginConcurrency(), "GitHub plugin", func(ctx context.Context, plugin PluginImport) error {
+		pluginPath, err := c.downloadGitHubPlugin(ctx, plugin)
+		if err != nil {
+			return err
+		}
+		mu.Lock()
+		bundlePaths[plugin.Plugin.Name] = pluginPath
+		mu.Unlock()
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, plugin := range githubPlugins {
+		if err := c.installGitHubPlugin(ctx, plugin, bundlePaths[plugin.Plugin.Name]); err != nil {
+			Log.WithFields(logrus.Fields{
+				"plugin_name": plugin.Plugin.Name,
+				"error":       err.Error(),
+			}).Error("❌ Failed to process GitHub plugin")
+			return fmt.Errorf("failed to process GitHub plugin '%s': %w", plugin.Plugin.Name, err)
+		}
+	}
+
+	var localPlugins []PluginImport
 	for _, plugin := range plugins {
 		if plugin.Plugin.Source == "local" {
 			// Apply force flags: forceGitHubPlugins forces all plugins, forcePlugins forces local plugins
@@ -588,15 +750,93 @@ func (c *Client) processPlugins(bulkImportPath string, forcePlugins, forceGitHub
 				pluginCopy.Plugin.ForceInstall = true
 			}
 
-			if err := c.processLocalPlugin(pluginCopy); err != nil {
-				Log.WithFields(logrus.Fields{
-					"plugin_name": plugin.Plugin.Name,
-					"error":       err.Error(),
-				}).Error("❌ Failed to process local plugin")
+			needsInstall, err := c.localPluginNeedsInstall(pluginCopy)
+			if err != nil {
 				return fmt.Errorf("failed to process local plugin '%s': %w", plugin.Plugin.Name, err)
 			}
+			if needsInstall {
+				localPlugins = append(localPlugins, pluginCopy)
+			}
+		}
+	}
+
+	// Local plugins are built in parallel, since each make runs in its own directory,
+	// then installed one at a time in file order so the uploads don't race
+	if err := processPluginsConcurrently(ctx, localPlugins, c.pluginBuildConcurrency(), "local plugin", c.buildLocalPlugin); err != nil {
+		return err
+	}
+
+	for _, plugin := range localPlugins {
+		if err := c.installLocalPlugin(ctx, plugin); err != nil {
+			Log.WithFields(logrus.Fields{
+				"plugin_name": plugin.Plugin.Name,
+				"error":       err.Error(),
+			}).Error("❌ Failed to process local plugin")
+			return fmt.Errorf("failed to process local plugin '%s': %w", plugin.Plugin.Name, err)
 		}
 	}
 
 	return scanner.Err()
 }
+
+// pluginConcurrency returns the number of GitHub plugins to download at once
+func (c *Client) pluginConcurrency() int {
+	if c.PluginConcurrency > 0 {
+		return c.PluginConcurrency
+	}
+	return DefaultPluginConcurrency
+}
+
+// pluginBuildConcurrency returns the number of local plugins to build at once
+func (c *Client) pluginBuildConcurrency() int {
+	if c.PluginBuildConcurrency > 0 {
+		return c.PluginBuildConcurrency
+	}
+	return runtime.GOMAXPROCS(0)
+}
+
+// processPluginsConcurrently runs process for each plugin using a bounded pool of workers.
+// Every plugin is attempted; failures are combined into a single error once all workers finish.
+// kind describes the plugins in log messages and errors, e.g. "GitHub plugin".
+func processPluginsConcurrently(ctx context.Context, plugins []PluginImport, workers int, kind string, process func(context.Context, PluginImport) error) error {
+	if len(plugins) == 0 {
+		return nil
+	}
+	if workers > len(plugins) {
+		workers = len(plugins)
+	}
+
+	jobs := make(chan PluginImport)
+	errs := make(chan error, len(plugins))
+
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for plugin := range jobs {
+				if err := process(ctx, plugin); err != nil {
+					Log.WithFields(logrus.Fields{
+						"plugin_name": plugin.Plugin.Name,
+						"error":       err.Error(),
+					}).Error("❌ Failed to process " + kind)
+					errs <- fmt.Errorf("failed to process %s '%s': %w", kind, plugin.Plugin.Name, err)
+				}
+			}
+		}()
+	}
+
+	for _, plugin := range plugins {
+		jobs <- plugin
+	}
+	close(jobs)
+
+	wg.Wait()
+	close(errs)
+
+	var combined []error
+	for err := range errs {
+		combined = append(combined, err)
+	}
+	return errors.Join(combined...)
+}
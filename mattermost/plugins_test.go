@@ -0,0 +1,472 @@
+package mattermost
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/sirupsen/logrus"
+)
+
+// TestProcessPluginsConcurrently tests the bounded worker pool used for GitHub downloads and local builds
+func TestProcessPluginsConcurrently(t *testing.T) {
+	InitLogger(&LogConfig{Level: logrus.ErrorLevel})
+
+	testCases := []struct {
+		name          string
+		pluginCount   int
+		workers       int
+		failing       map[string]bool
+		expectedError []string
+	}{
+		{
+			name:        "All plugins succeed",
+			pluginCount: 7,
+			workers:     3,
+		},
+		{
+			name:          "Errors from every failing plugin are combined",
+			pluginCount:   6,
+			workers:       3,
+			failing:       map[string]bool{"plugin-1": true, "plugin-4": true},
+			expectedError: []string{"plugin-1", "plugin-4"},
+		},
+		{
+			name:        "More workers than plugins",
+			pluginCount: 2,
+			workers:     5,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var plugins []PluginImport
+			for i := range tc.pluginCount {
+				var plugin PluginImport
+				plugin.Plugin.Name = fmt.Sprintf("plugin-%d", i)
+				plugins = append(plugins, plugin)
+			}
+
+			var running, maxRunning atomic.Int32
+			var mu sync.Mutex
+			processed := make(map[string]bool)
+
+			err := processPluginsConcurrently(context.Background(), plugins, tc.workers, "GitHub plugin", func(_ context.Context, plugin PluginImport) error {
+				current := running.Add(1)
+				defer running.Add(-1)
+				for {
+					seen := maxRunning.Load()
+					if current <= seen || maxRunning.CompareAndSwap(seen, current) {
+						break
+					}
+				}
+				time.Sleep(10 * time.Millisecond)
+
+				mu.Lock()
+				processed[plugin.Plugin.Name] = true
+				mu.Unlock()
+
+				if tc.failing[plugin.Plugin.Name] {
+					return errors.New("install failed")
+				}
+				return nil
+			})
+
+			if len(processed) != tc.pluginCount {
+				t.Errorf("Expected all %d plugins to be processed, got %d", tc.pluginCount, len(processed))
+			}
+
+			limit := min(tc.workers, tc.pluginCount)
+			if got := int(maxRunning.Load()); got > limit {
+				t.Errorf("Expected at most %d plugins processed at once, got %d", limit, got)
+			}
+
+			if len(tc.expectedError) == 0 {
+				if err != nil {
+					t.Errorf("Expected no error, got %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("Expected combined error, got nil")
+			}
+			for _, name := range tc.expectedError {
+				if !strings.Contains(err.Error(), name) {
+					t.Errorf("Expected error to mention %s, got %v", name, err)
+				}
+			}
+		})
+	}
+}
+
+// TestBuildLocalPluginsConcurrently builds fake plugin directories in parallel and checks failures are combined
+func TestBuildLocalPluginsConcurrently(t *testing.T) {
+	InitLogger(&LogConfig{Level: logrus.ErrorLevel})
+
+	client := NewClient("http://localhost:8065", "sysadmin", "password", "test-team", "")
+	makefiles := map[string]string{
+		"alpha":   "dist:\n\tmkdir -p dist && touch dist/alpha.tar.gz\n",
+		"bravo":   "dist:\n\tmkdir -p dist && touch dist/bravo.tar.gz\n",
+		"charlie": "dist:\n\texit 1\n",
+		"delta":   "dist:\n\tmkdir -p dist && touch dist/delta.tar.gz\n",
+		"echo":    "dist:\n\texit 1\n",
+	}
+
+	root := t.TempDir()
+	var plugins []PluginImport
+	for name, makefile := range makefiles {
+		dir := filepath.Join(root, name)
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatalf("Failed to create plugin directory: %v", err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, "Makefile"), []byte(makefile), 0644); err != nil {
+			t.Fatalf("Failed to write Makefile: %v", err)
+		}
+
+		var plugin PluginImport
+		plugin.Plugin.Name = name
+		plugin.Plugin.Path = dir
+		plugins = append(plugins, plugin)
+	}
+
+	err := processPluginsConcurrently(context.Background(), plugins, 3, "local plugin", client.buildLocalPlugin)
+	if err == nil {
+		t.Fatal("Expected the failing builds to be reported")
+	}
+	for _, name := range []string{"charlie", "echo"} {
+		if !strings.Contains(err.Error(), fmt.Sprintf("local plugin '%s'", name)) {
+			t.Errorf("Expected error to mention %s, got %v", name, err)
+		}
+	}
+
+	for _, name := range []string{"alpha", "bravo", "delta"} {
+		if _, err := os.Stat(filepath.Join(root, name, "dist", name+".tar.gz")); err != nil {
+			t.Errorf("Expected %s to be built: %v", name, err)
+		}
+	}
+}
+
+// newPluginStatusServer reports the plugin as inactive until it has been asked activeAfter times,
+// counting the requests in calls
+func newPluginStatusServer(pluginID string, activeAfter int, calls *int) *mockServer {
+	server := newMockServer()
+	server.handle("GET /api/v4/plugins", func(w http.ResponseWriter, r *http.Request) {
+		*calls++
+		manifest := model.PluginInfo{Manifest: model.Manifest{Id: pluginID}}
+		plugins := &model.PluginsResponse{Inactive: []*model.PluginInfo{&manifest}}
+		if *calls > activeAfter {
+			plugins = &model.PluginsResponse{Active: []*model.PluginInfo{&manifest}}
+		}
+		writeJSON(w, http.StatusOK, plugins)
+	})
+	return server
+}
+
+// TestWaitForPluginActive tests polling the plugin list until the plugin activates or the timeout passes
+func TestWaitForPluginActive(t *testing.T) {
+	originalInterval := pluginActivePollInterval
+	pluginActivePollInterval = 10 * time.Millisecond
+	t.Cleanup(func() { pluginActivePollInterval = originalInterval })
+
+	testCases := []struct {
+		name        string
+		activeAfter int
+		timeout     time.Duration
+		expectError bool
+	}{
+		{name: "Already active", activeAfter: 0, timeout: time.Second},
+		{name: "Activates after a few polls", activeAfter: 3, timeout: time.Second},
+		{name: "Never activates in time", activeAfter: 1000, timeout: 50 * time.Millisecond, expectError: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var calls int
+			client := setupMockClient(t, newPluginStatusServer("com.example.plugin", tc.activeAfter, &calls))
+
+			err := client.WaitForPluginActive(context.Background(), "com.example.plugin", tc.timeout)
+			if tc.expectError {
+				if err == nil || !strings.Contains(err.Error(), "did not become active") {
+					t.Fatalf("Expected a timeout error, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("WaitForPluginActive returned error: %v", err)
+			}
+			if calls != tc.activeAfter+1 {
+				t.Errorf("Expected %d polls, got %d", tc.activeAfter+1, calls)
+			}
+		})
+	}
+}
+
+// TestWaitForPluginActiveStopsWhenContextEnds verifies the wait ends with the context instead of the timeout
+func TestWaitForPluginActiveStopsWhenContextEnds(t *testing.T) {
+	originalInterval := pluginActivePollInterval
+	pluginActivePollInterval = 10 * time.Millisecond
+	t.Cleanup(func() { pluginActivePollInterval = originalInterval })
+
+	var calls int
+	client := setupMockClient(t, newPluginStatusServer("com.example.plugin", 1000, &calls))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err := client.WaitForPluginActive(ctx, "com.example.plugin", time.Minute)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected a deadline exceeded error, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Expected the wait to stop with the context, took %s", elapsed)
+	}
+}
+
+// TestPluginActivationTimeout tests reading the activation timeout from the environment
+func TestPluginActivationTimeout(t *testing.T) {
+	InitLogger(&LogConfig{Level: logrus.ErrorLevel})
+
+	testCases := map[string]time.Duration{
+		"":     DefaultPluginActivationTimeout,
+		"90s":  90 * time.Second,
+		"2m":   2 * time.Minute,
+		"soon": DefaultPluginActivationTimeout,
+		"-10s": DefaultPluginActivationTimeout,
+	}
+
+	for value, expected := range testCases {
+		t.Setenv(PluginActivationTimeoutEnv, value)
+		if timeout := pluginActivationTimeout(); timeout != expected {
+			t.Errorf("Expected %q to give %s, got %s", value, expected, timeout)
+		}
+	}
+}
+
+// TestDownloadPluginPinnedVersion tests downloading a GitHub plugin pinned to a release tag
+func TestDownloadPluginPinnedVersion(t *testing.T) {
+	InitLogger(&LogConfig{Level: logrus.ErrorLevel})
+
+	// A single tagged release and its bundle; every other tag 404s
+	repo, tag := "mattermost/mattermost-plugin-demo", "v1.2.0"
+	releases := newMockServer()
+	releases.handle("GET /repos/"+repo+"/releases/tags/"+tag, func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]any{
+			"tag_name": tag,
+			"assets": []map[string]string{
+				{"name": "plugin-linux-amd64.tar.gz", "browser_download_url": "http://" + r.Host + "/download/linux.tar.gz"},
+				{"name": "plugin.tar.gz", "browser_download_url": "http://" + r.Host + "/download/plugin.tar.gz"},
+			},
+		})
+	})
+	releases.handle("GET /download/plugin.tar.gz", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/octet-stream")
+		_, _ = w.Write([]byte("bundle " + tag))
+	})
+	server := httptest.NewServer(releases)
+	defer server.Close()
+
+	originalURL := githubAPIURL
+	githubAPIURL = server.URL
+	defer func() { githubAPIURL = originalURL }()
+
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "files", "mattermost", "plugins"), 0755); err != nil {
+		t.Fatalf("Failed to create plugins directory: %v", err)
+	}
+	t.Chdir(dir)
+
+	pm := NewPluginManager(&Client{})
+
+	path, err := pm.downloadPlugin(context.Background(), PluginConfig{Name: "Demo", Repo: repo, PluginID: "com.mattermost.demo", Version: "v1.2.0"})
+	if err != nil {
+		t.Fatalf("downloadPlugin returned error: %v", err)
+	}
+	if path != filepath.Join("files", "mattermost", "plugins", "com.mattermost.demo-v1.2.0.tar.gz") {
+		t.Errorf("Unexpected bundle path %q", path)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read downloaded bundle: %v", err)
+	}
+	if string(data) != "bundle v1.2.0" {
+		t.Errorf("Expected the pinned release bundle, got %q", data)
+	}
+
+	_, err = pm.downloadPlugin(context.Background(), PluginConfig{Name: "Demo", Repo: repo, PluginID: "com.mattermost.demo", Version: "v9.9.9"})
+	if err == nil {
+		t.Fatal("Expected an error for a release tag that does not exist")
+	}
+	if !strings.Contains(err.Error(), "release v9.9.9 not found in "+repo) {
+		t.Errorf("Expected the error to name the missing tag, got %v", err)
+	}
+}
+
+// TestProcessPluginsInstallsInFileOrder verifies GitHub bundles are uploaded one at a time in file
+// order, each after the one before it is active, even though they are downloaded in parallel
+func TestProcessPluginsInstallsInFileOrder(t *testing.T) {
+	originalInterval := pluginActivePollInterval
+	pluginActivePollInterval = 10 * time.Millisecond
+	t.Cleanup(func() { pluginActivePollInterval = originalInterval })
+
+	ids := []string{"com.example.alpha", "com.example.bravo", "com.example.charlie", "com.example.delta"}
+
+	// Each plugin turns active on the second status check after its upload
+	var uploaded []string
+	polls := make(map[string]int)
+	server := newMockServer()
+	server.handle("GET /repos/example/{repo}/releases/latest", func(w http.ResponseWriter, r *http.Request) {
+		repo := r.PathValue("repo")
+		writeJSON(w, http.StatusOK, map[string]any{
+			"tag_name": "v1.0.0",
+			"assets":   []map[string]string{{"name": repo + ".tar.gz", "browser_download_url": "http://" + r.Host + "/download/" + repo}},
+		})
+	})
+	server.handle("GET /download/{repo}", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/octet-stream")
+		_, _ = w.Write([]byte("com.example." + r.PathValue("repo")))
+	})
+	server.handle("POST /api/v4/plugins", func(w http.ResponseWriter, r *http.Request) {
+		file, _, err := r.FormFile("plugin")
+		if err != nil {
+			writeAppError(w, http.StatusBadRequest, "api.plugin.upload.file.app_error")
+			return
+		}
+		defer func() { _ = file.Close() }()
+		bundle, _ := io.ReadAll(file)
+		uploaded = append(uploaded, string(bundle))
+		writeJSON(w, http.StatusCreated, &model.Manifest{Id: string(bundle)})
+	})
+	server.handle("POST /api/v4/plugins/{id}/enable", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]string{"status": "OK"})
+	})
+	server.handle("GET /api/v4/plugins", func(w http.ResponseWriter, r *http.Request) {
+		plugins := &model.PluginsResponse{}
+		for _, id := range uploaded {
+			polls[id]++
+			info := &model.PluginInfo{Manifest: model.Manifest{Id: id}}
+			if polls[id] > 1 {
+				plugins.Active = append(plugins.Active, info)
+			} else {
+				plugins.Inactive = append(plugins.Inactive, info)
+			}
+		}
+		writeJSON(w, http.StatusOK, plugins)
+	})
+	client := setupMockClient(t, server)
+	client.PluginConcurrency = len(ids)
+
+	originalURL := githubAPIURL
+	githubAPIURL = client.ServerURL
+	t.Cleanup(func() { githubAPIURL = originalURL })
+
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "files", "mattermost", "plugins"), 0755); err != nil {
+		t.Fatalf("Failed to create plugins directory: %v", err)
+	}
+	t.Chdir(dir)
+
+	var lines []string
+	for _, id := range ids {
+		repo := strings.TrimPrefix(id, "com.example.")
+		lines = append(lines, fmt.Sprintf(`{"type":"plugin","plugin":{"source":"github","github_repo":"example/%s","plugin_id":"%s","name":"%s"}}`, repo, id, repo))
+	}
+	importPath := filepath.Join(dir, "plugins.jsonl")
+	if err := os.WriteFile(importPath, []byte(strings.Join(lines, "\n")), 0644); err != nil {
+		t.Fatalf("Failed to write import file: %v", err)
+	}
+
+	if err := client.processPlugins(context.Background(), importPath, false, false); err != nil {
+		t.Fatalf("processPlugins returned error: %v", err)
+	}
+	if !slices.Equal(uploaded, ids) {
+		t.Errorf("Expected uploads in file order %v, got %v", ids, uploaded)
+	}
+}
+
+// TestCheckForUpdates verifies installed GitHub plugins are compared with their latest release,
+// pinned and local plugins are skipped and a failed lookup is reported without losing the rest
+func TestCheckForUpdates(t *testing.T) {
+	server := newMockServer()
+	server.handle("GET /api/v4/plugins", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, &model.PluginsResponse{
+			Active: []*model.PluginInfo{
+				{Manifest: model.Manifest{Id: "playbooks", Version: "2.1.0"}},
+				{Manifest: model.Manifest{Id: "ai", Version: "1.3.0"}},
+			},
+		})
+	})
+	for repo, tag := range map[string]string{
+		"mattermost/mattermost-plugin-playbooks": "v2.2.0",
+		"mattermost/mattermost-plugin-ai":        "v1.3.0",
+		"mattermost/mattermost-plugin-calls":     "v1.0.0",
+	} {
+		server.handle("GET /repos/"+repo+"/releases/latest", func(w http.ResponseWriter, r *http.Request) {
+			writeJSON(w, http.StatusOK, map[string]string{"tag_name": tag})
+		})
+	}
+	client := setupMockClient(t, server)
+
+	originalURL := githubAPIURL
+	githubAPIURL = client.ServerURL
+	t.Cleanup(func() { githubAPIURL = originalURL })
+	client.BulkImportPath = writeScanTestFile(t,
+		`{"type": "plugin", "plugin": {"source": "github", "github_repo": "mattermost/mattermost-plugin-playbooks", "plugin_id": "playbooks"}}`,
+		`{"type": "plugin", "plugin": {"source": "github", "github_repo": "mattermost/mattermost-plugin-ai", "plugin_id": "ai"}}`,
+		`{"type": "plugin", "plugin": {"source": "github", "github_repo": "mattermost/mattermost-plugin-calls", "plugin_id": "calls"}}`,
+		`{"type": "plugin", "plugin": {"source": "github", "github_repo": "mattermost/mattermost-plugin-jira", "plugin_id": "jira", "version": "v4.0.0"}}`,
+		`{"type": "plugin", "plugin": {"source": "github", "github_repo": "mattermost/missing", "plugin_id": "missing"}}`,
+		`{"type": "plugin", "plugin": {"source": "local", "path": "../apps/weather-plugin", "plugin_id": "weather"}}`,
+	)
+
+	updates, err := client.PluginManager.CheckForUpdates()
+	if err == nil || !strings.Contains(err.Error(), "missing") {
+		t.Errorf("Expected an error for the missing repository, got %v", err)
+	}
+
+	expected := []PluginUpdateInfo{
+		{PluginID: "playbooks", InstalledVersion: "2.1.0", LatestVersion: "v2.2.0", NeedsUpdate: true},
+		{PluginID: "ai", InstalledVersion: "1.3.0", LatestVersion: "v1.3.0"},
+		{PluginID: "calls", LatestVersion: "v1.0.0"},
+	}
+	if len(updates) != len(expected) {
+		t.Fatalf("Expected %d results, got %+v", len(expected), updates)
+	}
+	for i, want := range expected {
+		if updates[i] != want {
+			t.Errorf("Expected %+v, got %+v", want, updates[i])
+		}
+	}
+}
+
+// TestIsNewerVersion tests semantic version comparison with and without a leading v
+func TestIsNewerVersion(t *testing.T) {
+	testCases := []struct {
+		latest, installed string
+		expected          bool
+	}{
+		{"v1.10.0", "1.9.3", true},
+		{"2.0.0", "v2.0.0", false},
+		{"v1.2.0", "1.3.0", false},
+		{"v1.2.0", "1.2.0-rc1", true},
+		{"nightly", "1.0.0", false},
+	}
+
+	for _, tc := range testCases {
+		if got := isNewerVersion(tc.latest, tc.installed); got != tc.expected {
+			t.Errorf("isNewerVersion(%q, %q) = %v, expected %v", tc.latest, tc.installed, got, tc.expected)
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package mattermost
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Reactions in the import file list an emoji and the usernames reacting with it:
+//
+//	"reactions": [{"emoji_name": "thumbsup", "users": ["alice", "bob"]}]
+//
+// The reactions API only saves reactions for the session user, so they cannot be added for other
+// users after the posts are imported. Instead each reaction is expanded into the per-user entries
+// the bulk import accepts inside a post, which attaches them to the post as it is created and so
+// needs no matching of imported posts. Entries already in that form ("user" set) are kept.
+
+// userExistsFunc reports whether a username exists on the server
+type userExistsFunc func(username string) bool
+
+// newUserExistsCache returns a userExistsFunc that looks each username up once. In dry-run mode
+// the users have not been imported, so every username is treated as existing.
+func (c *Client) newUserExistsCache(ctx context.Context) userExistsFunc {
+	exists := make(map[string]bool)
+	return func(username string) bool {
+		if c.DryRun {
+			return true
+		}
+		found, checked := exists[username]
+		if !checked {
+			_, _, err := c.API.GetUserByUsername(ctx, username, "")
+			found = err == nil
+			exists[username] = found
+		}
+		return found
+	}
+}
+
+// expandPostReactions rewrites the reactions of a post line and its replies into bulk import
+// reaction entries. Users that do not exist are logged and left out.
+func expandPostReactions(postLine string, userExists userExistsFunc) (string, error) {
+	var data map[string]any
+	if err := json.Unmarshal([]byte(postLine), &data); err != nil {
+		return "", fmt.Errorf("failed to parse post JSON: %w", err)
+	}
+
+	post, ok := data["post"].(map[string]any)
+	if !ok {
+		return postLine, nil
+	}
+
+	changed := expandReactions(post, userExists)
+	if replies, ok := post["replies"].([]any); ok {
+		for _, replyIntf := range replies {
+			if reply, ok := replyIntf.(map[string]any); ok {
+				changed = expandReactions(reply, userExists) || changed
+			}
+		}
+	}
+	if !changed {
+		return postLine, nil
+	}
+
+	expandedJSON, err := json.Marshal(data)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal post JSON: %w", err)
+	}
+	return string(expandedJSON), nil
+}
+
+// expandReactions replaces the reactions of a post or reply with one entry per existing user,
+// timestamped just after the post so the import accepts them. It reports whether anything changed.
+func expandReactions(post map[string]any, userExists userExistsFunc) bool {
+	reactions, ok := post["reactions"].([]any)
+	if !ok || len(reactions) == 0 {
+		return false
+	}
+
+	createAt := getTimestamp(post, "create_at")
+	expanded := make([]any, 0, len(reactions))
+	for _, reactionIntf := range reactions {
+		reaction, ok := reactionIntf.(map[string]any)
+		if !ok {
+			continue
+		}
+		if _, ok := reaction["user"]; ok {
+			expanded = append(expanded, reaction)
+			continue
+		}
+
+		emojiName, _ := reaction["emoji_name"].(string)
+		users, _ := reaction["users"].([]any)
+		for _, userIntf := range users {
+			username, ok := userIntf.(string)
+			if !ok {
+				continue
+			}
+			if !userExists(username) {
+				Log.WithFields(logrus.Fields{
+					"username":   username,
+					"emoji_name": emojiName,
+				}).Warn("⚠️ Skipping reaction from a user that was not imported")
+				continue
+			}
+			createAt++
+			expanded = append(expanded, map[string]any{
+				"user":       username,
+				"emoji_name": emojiName,
+				"create_at":  createAt,
+			})
+		}
+	}
+
+	post["reactions"] = expanded
+	return true
+}
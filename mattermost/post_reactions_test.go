@@ -0,0 +1,75 @@
+package mattermost
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+)
+
+// TestImportPostReactions verifies reactions are expanded into one bulk import entry per existing
+// user, for posts and replies, and keep their place after the post when timestamps are adjusted
+func TestImportPostReactions(t *testing.T) {
+	path := writeScanTestFile(t,
+		`{"type": "post", "post": {"team": "demo", "channel": "ops", "user": "alice", "message": "Wheels up", "create_at": 1000, `+
+			`"reactions": [{"emoji_name": "rocket", "users": ["bob", "carol"]}, {"emoji_name": "+1", "users": ["alice"]}], `+
+			`"replies": [{"user": "bob", "message": "Copy", "create_at": 2000, "reactions": [{"user": "alice", "emoji_name": "eyes", "create_at": 2500}]}]}}`,
+	)
+
+	server := newMockServer()
+	server.handleUsersByUsername("alice", "bob")
+	client := setupMockClient(t, server)
+
+	timestampOffset, offsetCalculated = 0, false
+	t.Cleanup(func() { timestampOffset, offsetCalculated = 0, false })
+
+	var imported []string
+	err := client.processLines(context.Background(), path, []string{"post"}, func(_ context.Context, tempPath string) error {
+		data, err := os.ReadFile(tempPath)
+		if err != nil {
+			return err
+		}
+		imported = strings.Split(strings.TrimSpace(string(data)), "\n")
+		return os.Remove(tempPath)
+	})
+	if err != nil {
+		t.Fatalf("processLines returned error: %v", err)
+	}
+	if len(imported) != 2 {
+		t.Fatalf("Expected the version line and one post, got %v", imported)
+	}
+
+	var line struct {
+		Post struct {
+			CreateAt  int64 `json:"create_at"`
+			Reactions []struct {
+				User      string `json:"user"`
+				EmojiName string `json:"emoji_name"`
+				CreateAt  int64  `json:"create_at"`
+			} `json:"reactions"`
+			Replies []struct {
+				Reactions []struct {
+					User     string `json:"user"`
+					CreateAt int64  `json:"create_at"`
+				} `json:"reactions"`
+			} `json:"replies"`
+		} `json:"post"`
+	}
+	if err := json.Unmarshal([]byte(imported[1]), &line); err != nil {
+		t.Fatalf("Failed to parse post line: %v", err)
+	}
+
+	reactions := line.Post.Reactions
+	if len(reactions) != 2 || reactions[0].User != "bob" || reactions[0].EmojiName != "rocket" || reactions[1].User != "alice" || reactions[1].EmojiName != "+1" {
+		t.Fatalf("Expected reactions from bob and alice only, got %+v", reactions)
+	}
+	if reactions[0].CreateAt != line.Post.CreateAt+1 || reactions[1].CreateAt != line.Post.CreateAt+2 {
+		t.Errorf("Expected reactions just after the post at %d, got %+v", line.Post.CreateAt, reactions)
+	}
+
+	replyReactions := line.Post.Replies[0].Reactions
+	if len(replyReactions) != 1 || replyReactions[0].User != "alice" || replyReactions[0].CreateAt != 2500+timestampOffset {
+		t.Errorf("Expected the reply reaction to be kept and shifted by %d, got %+v", timestampOffset, replyReactions)
+	}
+}
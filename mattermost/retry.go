@@ -0,0 +1,86 @@
+package mattermost
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/sirupsen/logrus"
+)
+
+// DefaultRetryPolicy retries transient API failures up to three times, waiting 1s and then 2s
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts:    3,
+	InitialBackoff: time.Second,
+	MaxBackoff:     10 * time.Second,
+}
+
+// RetryPolicy controls how API calls are retried when the server is still warming up.
+// Zero fields use the value from DefaultRetryPolicy.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first
+	MaxAttempts int
+
+	// InitialBackoff is the wait before the second attempt; it doubles after each retry
+	InitialBackoff time.Duration
+
+	// MaxBackoff caps the wait between attempts
+	MaxBackoff time.Duration
+}
+
+// retryPolicy returns the client's retry policy with defaults filled in
+func (c *Client) retryPolicy() RetryPolicy {
+	policy := c.RetryPolicy
+	if policy.MaxAttempts <= 0 {
+		policy.MaxAttempts = DefaultRetryPolicy.MaxAttempts
+	}
+	if policy.InitialBackoff <= 0 {
+		policy.InitialBackoff = DefaultRetryPolicy.InitialBackoff
+	}
+	if policy.MaxBackoff <= 0 {
+		policy.MaxBackoff = DefaultRetryPolicy.MaxBackoff
+	}
+	return policy
+}
+
+// isRetryableAPIError reports whether a failed API call may succeed if sent again: the server
+// answered with a 5xx status, or the request never got a response because the connection failed
+func isRetryableAPIError(resp *model.Response, err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	if resp == nil || resp.StatusCode == 0 {
+		return true
+	}
+	return resp.StatusCode >= http.StatusInternalServerError
+}
+
+// withRetry runs call, retrying it with exponential backoff on 5xx responses and connection
+// errors. The last error is returned once the attempts run out or ctx ends.
+func (c *Client) withRetry(ctx context.Context, operation string, call func() (*model.Response, error)) error {
+	policy := c.retryPolicy()
+	backoff := policy.InitialBackoff
+
+	for attempt := 1; ; attempt++ {
+		resp, err := call()
+		if !isRetryableAPIError(resp, err) || attempt >= policy.MaxAttempts {
+			return err
+		}
+
+		fields := logrus.Fields{"operation": operation, "attempt": attempt, "max_attempts": policy.MaxAttempts, "backoff": backoff.String(), "error": err.Error()}
+		if resp != nil {
+			fields["status_code"] = resp.StatusCode
+		}
+		Log.WithFields(fields).Warn("🔁 API call failed, retrying")
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%w (gave up retrying: %w)", err, ctx.Err())
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, policy.MaxBackoff)
+	}
+}
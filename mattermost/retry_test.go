@@ -0,0 +1,140 @@
+package mattermost
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+// flakyRequests records the requests received by the flaky mock server
+type flakyRequests struct {
+	jobCalls int
+	bundles  []string
+}
+
+// newFlakyServer fails the first failures requests to the job and plugin upload endpoints with
+// status, then answers normally
+func newFlakyServer(failures, status int, sent *flakyRequests) *mockServer {
+	server := newMockServer()
+	server.handle("GET /api/v4/jobs/job1", func(w http.ResponseWriter, r *http.Request) {
+		sent.jobCalls++
+		if sent.jobCalls <= failures {
+			writeAppError(w, status, "api.job.get.app_error")
+			return
+		}
+		writeJSON(w, http.StatusOK, &model.Job{Id: "job1", Status: model.JobStatusSuccess})
+	})
+	server.handle("POST /api/v4/plugins", func(w http.ResponseWriter, r *http.Request) {
+		file, _, err := r.FormFile("plugin")
+		if err != nil {
+			writeAppError(w, http.StatusBadRequest, "api.plugin.upload.file.app_error")
+			return
+		}
+		data, _ := io.ReadAll(file)
+		sent.bundles = append(sent.bundles, string(data))
+		if len(sent.bundles) <= failures {
+			writeAppError(w, status, "api.plugin.upload.app_error")
+			return
+		}
+		writeJSON(w, http.StatusCreated, &model.Manifest{Id: "com.example.demo", Version: "1.0.0"})
+	})
+	server.handle("POST /api/v4/plugins/com.example.demo/enable", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]string{"status": "OK"})
+	})
+	return server
+}
+
+// setupRetryClient creates a mock client that retries with a short backoff
+func setupRetryClient(t *testing.T, server *mockServer) *Client {
+	t.Helper()
+	client := setupMockClient(t, server)
+	client.RetryPolicy = RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: 5 * time.Millisecond}
+	return client
+}
+
+// TestWaitForJobCompletionRetries verifies job polling survives transient 5xx responses and stops retrying after MaxAttempts
+func TestWaitForJobCompletionRetries(t *testing.T) {
+	testCases := []struct {
+		name          string
+		failures      int
+		status        int
+		expectError   bool
+		expectedCalls int
+	}{
+		{name: "recovers from two 503s", failures: 2, status: http.StatusServiceUnavailable, expectedCalls: 3},
+		{name: "gives up after three 502s", failures: 3, status: http.StatusBadGateway, expectError: true, expectedCalls: 3},
+		{name: "does not retry a 404", failures: 1, status: http.StatusNotFound, expectError: true, expectedCalls: 1},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var sent flakyRequests
+			client := setupRetryClient(t, newFlakyServer(tc.failures, tc.status, &sent))
+
+			err := client.waitForJobCompletion(context.Background(), &model.Job{Id: "job1"})
+			if tc.expectError && err == nil {
+				t.Fatal("Expected an error")
+			}
+			if !tc.expectError && err != nil {
+				t.Fatalf("waitForJobCompletion returned error: %v", err)
+			}
+			if sent.jobCalls != tc.expectedCalls {
+				t.Errorf("Expected %d job status requests, got %d", tc.expectedCalls, sent.jobCalls)
+			}
+		})
+	}
+}
+
+// TestUploadPluginRetries verifies a failed upload is retried with the whole bundle
+func TestUploadPluginRetries(t *testing.T) {
+	var sent flakyRequests
+	client := setupRetryClient(t, newFlakyServer(1, http.StatusServiceUnavailable, &sent))
+
+	bundlePath := filepath.Join(t.TempDir(), "demo.tar.gz")
+	if err := os.WriteFile(bundlePath, []byte("bundle contents"), 0644); err != nil {
+		t.Fatalf("Failed to write plugin bundle: %v", err)
+	}
+
+	if err := client.PluginManager.uploadPlugin(context.Background(), bundlePath); err != nil {
+		t.Fatalf("uploadPlugin returned error: %v", err)
+	}
+	if len(sent.bundles) != 2 {
+		t.Fatalf("Expected 2 upload attempts, got %d", len(sent.bundles))
+	}
+	for i, bundle := range sent.bundles {
+		if bundle != "bundle contents" {
+			t.Errorf("Expected attempt %d to send the whole bundle, got %q", i+1, bundle)
+		}
+	}
+}
+
+// TestIsRetryableAPIError verifies only 5xx responses and connection errors are retried
+func TestIsRetryableAPIError(t *testing.T) {
+	err := io.ErrUnexpectedEOF
+	testCases := []struct {
+		name     string
+		resp     *model.Response
+		err      error
+		expected bool
+	}{
+		{name: "success", resp: &model.Response{StatusCode: http.StatusOK}},
+		{name: "connection error", err: err, expected: true},
+		{name: "503", resp: &model.Response{StatusCode: http.StatusServiceUnavailable}, err: err, expected: true},
+		{name: "400", resp: &model.Response{StatusCode: http.StatusBadRequest}, err: err},
+		{name: "deadline", err: context.DeadlineExceeded},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := isRetryableAPIError(tc.resp, tc.err); got != tc.expected {
+				t.Errorf("Expected %v, got %v", tc.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,249 @@
+package mattermost
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/pem"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/sirupsen/logrus"
+)
+
+// File names the server stores uploaded SAML certificates under
+const (
+	samlIdpCertificateName    = "saml-idp.crt"
+	samlPublicCertificateName = "saml-public.crt"
+)
+
+// SAML binding preferred for the IdP login URL, as used by the Mattermost SAML flow
+const samlRedirectBinding = "urn:oasis:names:tc:SAML:2.0:bindings:HTTP-Redirect"
+
+// samlEntityDescriptor is the part of the IdP metadata XML needed to configure Mattermost
+type samlEntityDescriptor struct {
+	EntityID         string `xml:"entityID,attr"`
+	IDPSSODescriptor struct {
+		KeyDescriptors []struct {
+			Use         string `xml:"use,attr"`
+			Certificate string `xml:"KeyInfo>X509Data>X509Certificate"`
+		} `xml:"KeyDescriptor"`
+		SingleSignOnServices []struct {
+			Binding  string `xml:"Binding,attr"`
+			Location string `xml:"Location,attr"`
+		} `xml:"SingleSignOnService"`
+	} `xml:"IDPSSODescriptor"`
+}
+
+// SAMLMetadata is the IdP information extracted from its metadata
+type SAMLMetadata struct {
+	EntityID       string
+	SSOURL         string
+	CertificatePEM []byte
+}
+
+// SetupSAML configures SAML authentication from the IdP metadata at idpMetadataURL,
+// using the rest of the saml settings from config.json
+func (c *Client) SetupSAML(idpMetadataURL string) error {
+	var config SAMLConfig
+	if c.Config != nil {
+		config = c.Config.SAML
+	}
+	config.IdpMetadataURL = idpMetadataURL
+	return c.SetupSAMLWithConfig(&config)
+}
+
+// SetupSAMLWithConfig configures SAML authentication from the IdP metadata at config.IdpMetadataURL.
+// The IdP certificate is uploaded to the server, and so is the service provider certificate when
+// config.SPCertPath is set. Custom profile fields with a saml attribute in the import file are then
+// mapped to those SAML attributes, so they sync when users log in.
+func (c *Client) SetupSAMLWithConfig(config *SAMLConfig) error {
+	idpMetadataURL := config.IdpMetadataURL
+	Log.WithFields(logrus.Fields{
+		"idp_metadata_url": idpMetadataURL,
+	}).Info("🔐 Starting SAML setup")
+
+	metadata, err := c.fetchSAMLMetadata(idpMetadataURL)
+	if err != nil {
+		return fmt.Errorf("failed to load IdP metadata: %w", err)
+	}
+
+	Log.WithFields(logrus.Fields{
+		"entity_id": metadata.EntityID,
+		"sso_url":   metadata.SSOURL,
+	}).Info("📄 Loaded IdP metadata")
+
+	resp, err := c.API.UploadSamlIdpCertificate(context.Background(), metadata.CertificatePEM, samlIdpCertificateName)
+	if err != nil {
+		return handleAPIError("failed to upload SAML IdP certificate", err, resp)
+	}
+
+	spCertPath := config.SPCertPath
+	if spCertPath != "" {
+		certData, err := os.ReadFile(spCertPath)
+		if err != nil {
+			return fmt.Errorf("failed to read SAML service provider certificate: %w", err)
+		}
+		resp, err := c.API.UploadSamlPublicCertificate(context.Background(), certData, samlPublicCertificateName)
+		if err != nil {
+			return handleAPIError("failed to upload SAML service provider certificate", err, resp)
+		}
+	}
+
+	serverConfig, resp, err := c.API.GetConfig(context.Background())
+	if err != nil {
+		return handleAPIError("failed to get server config", err, resp)
+	}
+
+	applySAMLSettings(&serverConfig.SamlSettings, metadata, idpMetadataURL, strings.TrimSuffix(c.ServerURL, "/"), spCertPath != "")
+
+	if _, resp, err := c.API.UpdateConfig(context.Background(), serverConfig); err != nil {
+		return handleAPIError("failed to update SAML settings", err, resp)
+	}
+
+	if err := c.mapSAMLAttributes(); err != nil {
+		return fmt.Errorf("failed to map custom profile fields to SAML attributes: %w", err)
+	}
+
+	Log.Info("✅ SAML setup completed successfully")
+	return nil
+}
+
+// mapSAMLAttributes makes sure every custom profile field with a saml attribute in the import file
+// exists on the server with that SAML mapping, creating missing fields and patching changed ones
+func (c *Client) mapSAMLAttributes() error {
+	attributeFields, err := c.extractCustomAttributeDefinitions(c.BulkImportPath)
+	if errors.Is(err, os.ErrNotExist) {
+		Log.WithFields(logrus.Fields{"file_path": c.BulkImportPath}).Info("📋 No import file, skipping SAML attribute mapping")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to extract custom attribute definitions: %w", err)
+	}
+
+	existingFields, err := c.ListCustomProfileFields()
+	if err != nil {
+		return fmt.Errorf("failed to list existing custom fields: %w", err)
+	}
+	existingByName := make(map[string]CustomProfileField, len(existingFields))
+	for _, field := range existingFields {
+		existingByName[field.Name] = field
+	}
+
+	mapped := 0
+	for _, field := range attributeFields {
+		if field.SAMLAttribute == "" {
+			continue
+		}
+
+		existing, exists := existingByName[field.Name]
+		switch {
+		case !exists:
+			if _, err := c.CreateCustomProfileFieldExtended(field); err != nil {
+				return fmt.Errorf("failed to create custom field '%s': %w", field.Name, err)
+			}
+		case existing.Attrs.SAMLAttribute != field.SAMLAttribute:
+			if err := c.PatchCustomProfileFieldAttrs(existing.ID, field); err != nil {
+				return fmt.Errorf("failed to update custom field '%s': %w", field.Name, err)
+			}
+		default:
+			continue
+		}
+
+		mapped++
+		Log.WithFields(logrus.Fields{
+			"field_name":     field.Name,
+			"saml_attribute": field.SAMLAttribute,
+		}).Info("🔗 Mapped custom profile field to SAML attribute")
+	}
+
+	Log.WithFields(logrus.Fields{"mapped_count": mapped}).Info("✅ SAML attribute mapping complete")
+	return nil
+}
+
+// applySAMLSettings enables SAML with the IdP details, keeping any attribute mappings already configured
+func applySAMLSettings(settings *model.SamlSettings, metadata *SAMLMetadata, idpMetadataURL, serverURL string, hasSPCertificate bool) {
+	settings.Enable = model.NewPointer(true)
+	settings.Verify = model.NewPointer(true)
+	settings.IdpMetadataURL = model.NewPointer(idpMetadataURL)
+	settings.IdpURL = model.NewPointer(metadata.SSOURL)
+	settings.IdpDescriptorURL = model.NewPointer(metadata.EntityID)
+	settings.IdpCertificateFile = model.NewPointer(samlIdpCertificateName)
+	settings.ServiceProviderIdentifier = model.NewPointer(serverURL)
+	settings.AssertionConsumerServiceURL = model.NewPointer(serverURL + "/login/sso/saml")
+	if hasSPCertificate {
+		settings.PublicCertificateFile = model.NewPointer(samlPublicCertificateName)
+	}
+
+	// The server refuses to enable SAML without these mappings
+	if settings.EmailAttribute == nil || *settings.EmailAttribute == "" {
+		settings.EmailAttribute = model.NewPointer("Email")
+	}
+	if settings.UsernameAttribute == nil || *settings.UsernameAttribute == "" {
+		settings.UsernameAttribute = model.NewPointer("Username")
+	}
+}
+
+// fetchSAMLMetadata downloads and parses the IdP metadata XML
+func (c *Client) fetchSAMLMetadata(idpMetadataURL string) (*SAMLMetadata, error) {
+	resp, err := c.httpClient().Get(idpMetadataURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %s: %w", idpMetadataURL, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetching %s returned status %d", idpMetadataURL, resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", idpMetadataURL, err)
+	}
+
+	return parseSAMLMetadata(data)
+}
+
+// parseSAMLMetadata extracts the entity ID, single sign-on URL and signing certificate from IdP metadata XML
+func parseSAMLMetadata(data []byte) (*SAMLMetadata, error) {
+	var descriptor samlEntityDescriptor
+	if err := xml.Unmarshal(data, &descriptor); err != nil {
+		return nil, fmt.Errorf("failed to parse metadata XML: %w", err)
+	}
+
+	idp := descriptor.IDPSSODescriptor
+	metadata := &SAMLMetadata{EntityID: descriptor.EntityID}
+
+	for _, service := range idp.SingleSignOnServices {
+		if metadata.SSOURL == "" || service.Binding == samlRedirectBinding {
+			metadata.SSOURL = service.Location
+		}
+	}
+	if metadata.SSOURL == "" {
+		return nil, fmt.Errorf("metadata has no SingleSignOnService URL")
+	}
+
+	// Prefer the signing key; a KeyDescriptor without a use attribute covers both signing and encryption
+	var certificate string
+	for _, key := range idp.KeyDescriptors {
+		if key.Use == "signing" || (key.Use == "" && certificate == "") {
+			certificate = key.Certificate
+		}
+	}
+	if certificate = strings.Join(strings.Fields(certificate), ""); certificate == "" {
+		return nil, fmt.Errorf("metadata has no signing certificate")
+	}
+
+	der, err := base64.StdEncoding.DecodeString(certificate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode IdP certificate: %w", err)
+	}
+	metadata.CertificatePEM = pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+
+	return metadata, nil
+}
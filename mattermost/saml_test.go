@@ -0,0 +1,194 @@
+package mattermost
+
+import (
+	"encoding/base64"
+	"encoding/pem"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+var testSAMLCertificate = []byte("test IdP certificate")
+
+// testSAMLMetadata describes an IdP with a POST and a redirect login URL and separate signing and encryption keys
+var testSAMLMetadata = `<?xml version="1.0"?>
+<md:EntityDescriptor xmlns:md="urn:oasis:names:tc:SAML:2.0:metadata" xmlns:ds="http://www.w3.org/2000/09/xmldsig#" entityID="https://idp.example.com/realms/demo">
+  <md:IDPSSODescriptor protocolSupportEnumeration="urn:oasis:names:tc:SAML:2.0:protocol">
+    <md:KeyDescriptor use="encryption">
+      <ds:KeyInfo><ds:X509Data><ds:X509Certificate>` + base64.StdEncoding.EncodeToString([]byte("encryption key")) + `</ds:X509Certificate></ds:X509Data></ds:KeyInfo>
+    </md:KeyDescriptor>
+    <md:KeyDescriptor use="signing">
+      <ds:KeyInfo><ds:X509Data><ds:X509Certificate>
+        ` + base64.StdEncoding.EncodeToString(testSAMLCertificate) + `
+      </ds:X509Certificate></ds:X509Data></ds:KeyInfo>
+    </md:KeyDescriptor>
+    <md:SingleSignOnService Binding="urn:oasis:names:tc:SAML:2.0:bindings:HTTP-POST" Location="https://idp.example.com/sso/post"/>
+    <md:SingleSignOnService Binding="urn:oasis:names:tc:SAML:2.0:bindings:HTTP-Redirect" Location="https://idp.example.com/sso/redirect"/>
+  </md:IDPSSODescriptor>
+</md:EntityDescriptor>`
+
+// samlRequests records what was uploaded and saved through the SAML mock server
+type samlRequests struct {
+	uploadedCert  []byte
+	savedConfig   *model.Config
+	createdFields []string
+	patchedAttrs  map[string]map[string]any
+}
+
+// newSAMLServer serves IdP metadata, the config and certificate endpoints and the given custom
+// profile fields, recording what was uploaded and saved in sent
+func newSAMLServer(fields []CustomProfileField, sent *samlRequests) *mockServer {
+	server := newMockServer()
+	server.handle("GET /metadata", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/xml")
+		_, _ = io.WriteString(w, testSAMLMetadata)
+	})
+	server.handle("POST /api/v4/saml/certificate/idp", func(w http.ResponseWriter, r *http.Request) {
+		file, _, err := r.FormFile("certificate")
+		if err != nil {
+			writeAppError(w, http.StatusBadRequest, "api.admin.add_certificate.no_file.app_error")
+			return
+		}
+		defer file.Close()
+		sent.uploadedCert, _ = io.ReadAll(file)
+		writeJSON(w, http.StatusOK, map[string]string{"status": "OK"})
+	})
+	server.handle("GET /api/v4/config", func(w http.ResponseWriter, r *http.Request) {
+		config := &model.Config{}
+		config.SetDefaults()
+		config.SamlSettings.EmailAttribute = model.NewPointer("mail")
+		writeJSON(w, http.StatusOK, config)
+	})
+	server.handle("PUT /api/v4/config", func(w http.ResponseWriter, r *http.Request) {
+		config := &model.Config{}
+		if !decodeJSON(w, r, config) {
+			return
+		}
+		sent.savedConfig = config
+		writeJSON(w, http.StatusOK, config)
+	})
+	server.handle("GET /api/v4/custom_profile_attributes/fields", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, fields)
+	})
+	server.handle("POST /api/v4/custom_profile_attributes/fields", func(w http.ResponseWriter, r *http.Request) {
+		var field CustomProfileField
+		if !decodeJSON(w, r, &field) {
+			return
+		}
+		sent.createdFields = append(sent.createdFields, field.Name+"="+field.Attrs.SAMLAttribute)
+		writeJSON(w, http.StatusCreated, &field)
+	})
+	server.handle("PATCH /api/v4/custom_profile_attributes/fields/{field}", func(w http.ResponseWriter, r *http.Request) {
+		var patch struct {
+			Attrs map[string]any `json:"attrs"`
+		}
+		if !decodeJSON(w, r, &patch) {
+			return
+		}
+		if sent.patchedAttrs == nil {
+			sent.patchedAttrs = make(map[string]map[string]any)
+		}
+		sent.patchedAttrs[r.PathValue("field")] = patch.Attrs
+		writeJSON(w, http.StatusOK, map[string]any{})
+	})
+	return server
+}
+
+// TestSetupSAML verifies the IdP certificate is uploaded and SAML settings are saved from the metadata
+func TestSetupSAML(t *testing.T) {
+	var sent samlRequests
+	client := setupMockClient(t, newSAMLServer(nil, &sent))
+	metadataURL := client.ServerURL + "/metadata"
+
+	if err := client.SetupSAML(metadataURL); err != nil {
+		t.Fatalf("SetupSAML returned error: %v", err)
+	}
+
+	block, _ := pem.Decode(sent.uploadedCert)
+	if block == nil || block.Type != "CERTIFICATE" || string(block.Bytes) != string(testSAMLCertificate) {
+		t.Errorf("Expected the signing certificate to be uploaded as PEM, got %q", sent.uploadedCert)
+	}
+
+	if sent.savedConfig == nil {
+		t.Fatal("Expected the server config to be updated")
+	}
+	settings := sent.savedConfig.SamlSettings
+	expected := map[string]*string{
+		"https://idp.example.com/sso/redirect": settings.IdpURL,
+		"https://idp.example.com/realms/demo":  settings.IdpDescriptorURL,
+		metadataURL:                            settings.IdpMetadataURL,
+		samlIdpCertificateName:                 settings.IdpCertificateFile,
+		client.ServerURL + "/login/sso/saml":   settings.AssertionConsumerServiceURL,
+		"mail":                                 settings.EmailAttribute,
+		"Username":                             settings.UsernameAttribute,
+	}
+	for want, got := range expected {
+		if got == nil || *got != want {
+			t.Errorf("Expected SAML setting %q, got %v", want, got)
+		}
+	}
+	if settings.Enable == nil || !*settings.Enable {
+		t.Error("Expected SAML to be enabled")
+	}
+}
+
+// TestSetupSAMLWithConfigMapsAttributes verifies custom profile fields from the import file get their SAML mapping
+func TestSetupSAMLWithConfigMapsAttributes(t *testing.T) {
+	importPath := filepath.Join(t.TempDir(), "bulk_import.jsonl")
+	lines := strings.Join([]string{
+		`{"type":"user-attribute","attribute":{"name":"rank","display_name":"Rank","type":"text","saml":"Rank"}}`,
+		`{"type":"user-attribute","attribute":{"name":"unit","display_name":"Unit","type":"select","options":["Alpha"],"saml":"UnitName","ldap":"unit"}}`,
+		`{"type":"user-attribute","attribute":{"name":"callsign","display_name":"Callsign","type":"text","saml":"Callsign"}}`,
+		`{"type":"user-attribute","attribute":{"name":"notes","display_name":"Notes","type":"text"}}`,
+	}, "\n")
+	if err := os.WriteFile(importPath, []byte(lines+"\n"), 0644); err != nil {
+		t.Fatalf("Failed to write import file: %v", err)
+	}
+
+	var sent samlRequests
+	client := setupMockClient(t, newSAMLServer([]CustomProfileField{
+		{ID: "field-unit", Name: "unit", Attrs: CustomProfileFieldAttrs{SAMLAttribute: "Unit"}},
+		{ID: "field-callsign", Name: "callsign", Attrs: CustomProfileFieldAttrs{SAMLAttribute: "Callsign"}},
+	}, &sent))
+	client.BulkImportPath = importPath
+
+	if err := client.SetupSAMLWithConfig(&SAMLConfig{IdpMetadataURL: client.ServerURL + "/metadata"}); err != nil {
+		t.Fatalf("SetupSAMLWithConfig returned error: %v", err)
+	}
+
+	if strings.Join(sent.createdFields, ",") != "rank=Rank" {
+		t.Errorf("Expected only the missing field to be created, got %v", sent.createdFields)
+	}
+	if len(sent.patchedAttrs) != 1 {
+		t.Fatalf("Expected only the field with a changed mapping to be patched, got %v", sent.patchedAttrs)
+	}
+	attrs := sent.patchedAttrs["field-unit"]
+	if attrs["saml"] != "UnitName" || attrs["ldap"] != "unit" {
+		t.Errorf("Expected the patch to keep the field's other attributes, got %v", attrs)
+	}
+}
+
+// TestParseSAMLMetadataErrors verifies incomplete metadata is rejected
+func TestParseSAMLMetadataErrors(t *testing.T) {
+	testCases := []struct {
+		name     string
+		metadata string
+	}{
+		{name: "not XML", metadata: "not xml"},
+		{name: "no login URL", metadata: `<EntityDescriptor entityID="idp"><IDPSSODescriptor><KeyDescriptor><KeyInfo><X509Data><X509Certificate>dGVzdA==</X509Certificate></X509Data></KeyInfo></KeyDescriptor></IDPSSODescriptor></EntityDescriptor>`},
+		{name: "no certificate", metadata: `<EntityDescriptor entityID="idp"><IDPSSODescriptor><SingleSignOnService Location="https://idp/sso"/></IDPSSODescriptor></EntityDescriptor>`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := parseSAMLMetadata([]byte(tc.metadata)); err == nil {
+				t.Error("Expected an error for incomplete metadata")
+			}
+		})
+	}
+}
@@ -0,0 +1,105 @@
+package mattermost
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/sirupsen/logrus"
+)
+
+// BackupServerConfig writes the server configuration to path as indented JSON. Secrets such as
+// passwords are masked by the server, and restoring the masked value keeps the current secret.
+func (c *Client) BackupServerConfig(path string) error {
+	config, resp, err := c.API.GetConfig(context.Background())
+	if err != nil {
+		return handleAPIError("failed to get server config", err, resp)
+	}
+
+	data, err := json.MarshalIndent(config, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal server config: %w", err)
+	}
+
+	if err := os.WriteFile(path, append(data, '\n'), 0600); err != nil {
+		return fmt.Errorf("failed to write server config backup: %w", err)
+	}
+
+	Log.WithFields(logrus.Fields{"path": path}).Info("✅ Server config backed up")
+	return nil
+}
+
+// RestoreServerConfig updates the server configuration from a file written by BackupServerConfig.
+// When keys are given, only those top-level settings (e.g. ServiceSettings) are restored and the
+// rest of the current server configuration is kept.
+func (c *Client) RestoreServerConfig(path string, keys ...string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read server config backup: %w", err)
+	}
+
+	config := &model.Config{}
+	if len(keys) == 0 {
+		if err := json.Unmarshal(data, config); err != nil {
+			return fmt.Errorf("failed to parse server config backup %s: %w", path, err)
+		}
+	} else {
+		if config, err = c.mergeServerConfigKeys(data, keys); err != nil {
+			return fmt.Errorf("failed to restore %s: %w", path, err)
+		}
+	}
+
+	if _, resp, err := c.API.UpdateConfig(context.Background(), config); err != nil {
+		return handleAPIError("failed to update server config", err, resp)
+	}
+
+	fields := logrus.Fields{"path": path}
+	if len(keys) > 0 {
+		fields["keys"] = keys
+	}
+	Log.WithFields(fields).Info("✅ Server config restored")
+	return nil
+}
+
+// mergeServerConfigKeys returns the current server config with the given top-level keys replaced by
+// their values in the backup
+func (c *Client) mergeServerConfigKeys(backup []byte, keys []string) (*model.Config, error) {
+	var backupSections map[string]json.RawMessage
+	if err := json.Unmarshal(backup, &backupSections); err != nil {
+		return nil, fmt.Errorf("failed to parse server config backup: %w", err)
+	}
+
+	current, resp, err := c.API.GetConfig(context.Background())
+	if err != nil {
+		return nil, handleAPIError("failed to get server config", err, resp)
+	}
+
+	currentData, err := json.Marshal(current)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal server config: %w", err)
+	}
+	var sections map[string]json.RawMessage
+	if err := json.Unmarshal(currentData, &sections); err != nil {
+		return nil, fmt.Errorf("failed to parse server config: %w", err)
+	}
+
+	for _, key := range keys {
+		section, ok := backupSections[key]
+		if !ok {
+			return nil, fmt.Errorf("config key %s is not in the backup", key)
+		}
+		sections[key] = section
+	}
+
+	merged, err := json.Marshal(sections)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal merged server config: %w", err)
+	}
+	config := &model.Config{}
+	if err := json.Unmarshal(merged, config); err != nil {
+		return nil, fmt.Errorf("failed to parse merged server config: %w", err)
+	}
+	return config, nil
+}
@@ -0,0 +1,73 @@
+package mattermost
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func newTestServerConfig(siteName, ldapServer string) *model.Config {
+	config := &model.Config{}
+	config.SetDefaults()
+	config.TeamSettings.SiteName = model.NewPointer(siteName)
+	config.LdapSettings.LdapServer = model.NewPointer(ldapServer)
+	return config
+}
+
+// TestBackupAndRestoreServerConfig verifies a backup restores every setting, or only the requested keys
+func TestBackupAndRestoreServerConfig(t *testing.T) {
+	config := newTestServerConfig("Backed Up", "ldap.backup")
+	var savedConfig *model.Config
+
+	server := newMockServer()
+	server.handle("GET /api/v4/config", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, config)
+	})
+	server.handle("PUT /api/v4/config", func(w http.ResponseWriter, r *http.Request) {
+		saved := &model.Config{}
+		if !decodeJSON(w, r, saved) {
+			return
+		}
+		savedConfig = saved
+		writeJSON(w, http.StatusOK, saved)
+	})
+	client := setupMockClient(t, server)
+	path := filepath.Join(t.TempDir(), "config-backup.json")
+
+	if err := client.BackupServerConfig(path); err != nil {
+		t.Fatalf("BackupServerConfig returned error: %v", err)
+	}
+	if info, err := os.Stat(path); err != nil || info.Mode().Perm() != 0600 {
+		t.Fatalf("Expected a backup readable only by its owner, got %v (error %v)", info, err)
+	}
+
+	// The server has moved on since the backup
+	config = newTestServerConfig("Changed", "ldap.changed")
+
+	if err := client.RestoreServerConfig(path); err != nil {
+		t.Fatalf("RestoreServerConfig returned error: %v", err)
+	}
+	if *savedConfig.TeamSettings.SiteName != "Backed Up" || *savedConfig.LdapSettings.LdapServer != "ldap.backup" {
+		t.Errorf("Expected every setting to be restored, got site name %q and LDAP server %q",
+			*savedConfig.TeamSettings.SiteName, *savedConfig.LdapSettings.LdapServer)
+	}
+
+	if err := client.RestoreServerConfig(path, "LdapSettings"); err != nil {
+		t.Fatalf("RestoreServerConfig with keys returned error: %v", err)
+	}
+	if *savedConfig.TeamSettings.SiteName != "Changed" || *savedConfig.LdapSettings.LdapServer != "ldap.backup" {
+		t.Errorf("Expected only LdapSettings to be restored, got site name %q and LDAP server %q",
+			*savedConfig.TeamSettings.SiteName, *savedConfig.LdapSettings.LdapServer)
+	}
+
+	savedConfig = nil
+	if err := client.RestoreServerConfig(path, "NoSuchSettings"); err == nil {
+		t.Error("Expected an error restoring a key that is not in the backup")
+	}
+	if savedConfig != nil {
+		t.Error("Expected nothing to be saved when a key is missing from the backup")
+	}
+}
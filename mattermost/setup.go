@@ -2,7 +2,10 @@ package mattermost
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"maps"
+	"slices"
 	"strings"
 	"time"
 
@@ -13,24 +16,29 @@ import (
 // WaitForStart polls the Mattermost server until it responds or times out.
 // It sends periodic ping requests to check if the server is ready to accept connections.
 //
-// This method will wait up to MaxWaitSeconds (default: 120) before timing out.
-// During the wait, it prints a dot every second to indicate progress.
+// This method will wait up to WaitTimeout (default: MaxWaitSeconds, or WaitTimeoutEnv if set),
+// pinging every WaitInterval (default: one second, or WaitIntervalEnv if set).
+// During the wait, it shows a spinner to indicate progress.
 //
-// Returns nil if the server starts successfully, or an error if the timeout is reached.
-func (c *Client) WaitForStart() error {
-	Log.Info("🚀 Waiting for Mattermost server to start...")
+// Returns nil if the server starts successfully, or an error if the timeout is reached
+// or ctx ends first.
+func (c *Client) WaitForStart(ctx context.Context) error {
+	timeout, interval := c.waitTimeout(), c.waitInterval()
+	Log.WithFields(logrus.Fields{"timeout": timeout.String(), "interval": interval.String()}).Info("🚀 Waiting for Mattermost server to start...")
 
 	// Progress indicators
 	progressChars := []string{"-", "\\", "|", "/"}
 
-	for i := range MaxWaitSeconds {
+	start := time.Now()
+	deadline := start.Add(timeout)
+	for i := 0; ; i++ {
 		// Show a spinning progress indicator
 		progressChar := progressChars[i%len(progressChars)]
 		fmt.Printf("\r[%s] Checking Mattermost API status... (%d/%d seconds)",
-			progressChar, i+1, MaxWaitSeconds)
+			progressChar, int(time.Since(start).Seconds())+1, int(timeout.Seconds()))
 
 		// Send a ping request
-		_, resp, err := c.API.GetPing(context.Background())
+		_, resp, err := c.API.GetPing(ctx)
 		if err == nil && resp != nil && resp.StatusCode == 200 {
 			// Clear the progress line
 			fmt.Print("\r                                                           \r")
@@ -38,13 +46,39 @@ func (c *Client) WaitForStart() error {
 			return nil
 		}
 
-		time.Sleep(1 * time.Second)
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			fmt.Print("\r                                                           \r")
+			return ctx.Err()
+		case <-time.After(min(interval, remaining)):
+		}
 	}
 
 	// Clear the progress line
 	fmt.Print("\r                                                           \r")
-	Log.WithFields(logrus.Fields{"timeout_seconds": MaxWaitSeconds}).Error("❌ Server didn't start within timeout")
-	return fmt.Errorf("server didn't start in %d seconds", MaxWaitSeconds)
+	Log.WithFields(logrus.Fields{"timeout": timeout.String()}).Error("❌ Server didn't start within timeout")
+	return fmt.Errorf("server didn't start in %s", timeout)
+}
+
+// waitTimeout returns how long WaitForStart waits: WaitTimeout if set, else WaitTimeoutEnv or MaxWaitSeconds
+func (c *Client) waitTimeout() time.Duration {
+	if c.WaitTimeout > 0 {
+		return c.WaitTimeout
+	}
+	return durationFromEnv(WaitTimeoutEnv, MaxWaitSeconds*time.Second, "wait timeout")
+}
+
+// waitInterval returns the time between WaitForStart pings: WaitInterval if set, else WaitIntervalEnv or DefaultWaitInterval
+func (c *Client) waitInterval() time.Duration {
+	if c.WaitInterval > 0 {
+		return c.WaitInterval
+	}
+	return durationFromEnv(WaitIntervalEnv, DefaultWaitInterval, "wait interval")
 }
 
 // SetupChannelCommands executes specified slash commands in channels sequentially
@@ -169,18 +203,27 @@ func (c *Client) Setup() error {
 
 // SetupWithForce performs the main setup with force options
 func (c *Client) SetupWithForce(forcePlugins, forceGitHubPlugins, forceAll bool) error {
-	return c.SetupWithForceAndUpdates(forcePlugins, forceGitHubPlugins, forceAll, false)
+	return c.SetupWithForceAndUpdates(context.Background(), forcePlugins, forceGitHubPlugins, forceAll, false)
 }
 
-// SetupWithForceAndUpdates performs the main setup with force options and update checking
-func (c *Client) SetupWithForceAndUpdates(forcePlugins, forceGitHubPlugins, forceAll, checkUpdates bool) error {
+// SetupWithForceAndUpdates performs the main setup with force options and update checking.
+// Setup stops with an error naming the active phase once ctx ends.
+func (c *Client) SetupWithForceAndUpdates(ctx context.Context, forcePlugins, forceGitHubPlugins, forceAll, checkUpdates bool) error {
 	// Safety check - make sure the client and API are properly initialized
 	if c == nil || c.API == nil {
 		return fmt.Errorf("client not properly initialized")
 	}
 
-	if err := c.WaitForStart(); err != nil {
-		return err
+	// Report every config problem before anything is sent to the server
+	if problems := c.ValidateConfig(); len(problems) > 0 {
+		for _, problem := range problems {
+			Log.WithFields(logrus.Fields{"problem": problem.Error()}).Error("❌ Invalid config")
+		}
+		return fmt.Errorf("config has %d problem(s), fix them and run setup again:\n%w", len(problems), errors.Join(problems...))
+	}
+
+	if err := c.WaitForStart(ctx); err != nil {
+		return setupPhaseError(ctx, "waiting for the server to start", err)
 	}
 
 	if err := c.Login(); err != nil {
@@ -192,14 +235,31 @@ func (c *Client) SetupWithForceAndUpdates(forcePlugins, forceGitHubPlugins, forc
 		return err
 	}
 
+	// Find the GitHub plugins with a newer release so the plugin phase reinstalls them
+	if checkUpdates {
+		c.checkPluginUpdates()
+	}
+
 	// Use two-phase bulk import for plugins, users, teams, and channels
-	if err := c.SetupWithSplitImportAndForce(forcePlugins, forceGitHubPlugins); err != nil {
+	if err := c.SetupWithSplitImportAndForce(ctx, forcePlugins, forceGitHubPlugins); err != nil {
 		return err
 	}
 
 	return nil
 }
 
+// setupPhaseError explains an error from a setup phase that stopped because ctx ended,
+// naming the phase that was active and keeping the phase's own error. Other errors are returned unchanged.
+func setupPhaseError(ctx context.Context, phase string, err error) error {
+	if err == nil || ctx.Err() == nil {
+		return err
+	}
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("setup timed out during %s (%w): %w", phase, context.DeadlineExceeded, err)
+	}
+	return fmt.Errorf("setup was cancelled during %s (%w): %w", phase, ctx.Err(), err)
+}
+
 // EchoLogins prints login information - always shown regardless of test mode
 func (c *Client) EchoLogins() {
 	Log.Info("===========================================")
@@ -219,6 +279,23 @@ func (c *Client) EchoLogins() {
 		}
 	}
 
+	// Bot tokens are only known in the run that created them
+	if len(c.BotTokens) > 0 {
+		Log.Info("- Bot access tokens:")
+		for _, username := range slices.Sorted(maps.Keys(c.BotTokens)) {
+			Log.WithFields(logrus.Fields{"username": username}).Info("     - bot")
+			Log.WithFields(logrus.Fields{"token": c.BotTokens[username]}).Info("     - token")
+		}
+	}
+
+	if len(c.WebhookURLs) > 0 {
+		Log.Info("- Incoming webhooks:")
+		for _, name := range slices.Sorted(maps.Keys(c.WebhookURLs)) {
+			Log.WithFields(logrus.Fields{"name": name}).Info("     - webhook")
+			Log.WithFields(logrus.Fields{"url": c.WebhookURLs[name]}).Info("     - url")
+		}
+	}
+
 	Log.Info("- LDAP or SAML account:")
 	Log.Info("     - username: professor")
 	Log.Info("     - password: professor")
@@ -0,0 +1,84 @@
+package mattermost
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newPingServer fails the first pings before answering them, or fails them all when upAfter is 0,
+// counting the pings in pings
+func newPingServer(upAfter int, pings *int) *mockServer {
+	server := newMockServer()
+	server.handle("GET /api/v4/system/ping", func(w http.ResponseWriter, r *http.Request) {
+		*pings++
+		if upAfter == 0 || *pings < upAfter {
+			writeAppError(w, http.StatusServiceUnavailable, "api.system.ping.unavailable")
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]string{"status": "OK"})
+	})
+	return server
+}
+
+// TestWaitForStartPolling verifies a server that comes up after a few pings is detected and one that
+// never comes up returns the timeout error
+func TestWaitForStartPolling(t *testing.T) {
+	testCases := []struct {
+		name          string
+		upAfter       int
+		expectedPings int
+		expectedError string
+	}{
+		{name: "up after three pings", upAfter: 3, expectedPings: 3},
+		{name: "never up", expectedError: "server didn't start in 200ms"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var pings int
+			client := setupMockClient(t, newPingServer(tc.upAfter, &pings))
+			client.WaitTimeout = 200 * time.Millisecond
+			client.WaitInterval = 10 * time.Millisecond
+
+			err := client.WaitForStart(context.Background())
+			if tc.expectedError == "" {
+				if err != nil {
+					t.Fatalf("WaitForStart returned error: %v", err)
+				}
+				if pings != tc.expectedPings {
+					t.Errorf("Expected %d pings, got %d", tc.expectedPings, pings)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.expectedError) {
+				t.Errorf("Expected error containing %q, got %v", tc.expectedError, err)
+			}
+			if pings < 2 {
+				t.Errorf("Expected the server to be pinged until the timeout, got %d pings", pings)
+			}
+		})
+	}
+}
+
+// TestWaitSettingsFromEnv verifies the environment overrides the defaults and client fields override the environment
+func TestWaitSettingsFromEnv(t *testing.T) {
+	client := setupMockClient(t, newMockServer())
+
+	if timeout, interval := client.waitTimeout(), client.waitInterval(); timeout != MaxWaitSeconds*time.Second || interval != DefaultWaitInterval {
+		t.Errorf("Expected the defaults, got timeout %s and interval %s", timeout, interval)
+	}
+
+	t.Setenv(WaitTimeoutEnv, "10m")
+	t.Setenv(WaitIntervalEnv, "not-a-duration")
+	if timeout, interval := client.waitTimeout(), client.waitInterval(); timeout != 10*time.Minute || interval != DefaultWaitInterval {
+		t.Errorf("Expected the env timeout and default interval, got timeout %s and interval %s", timeout, interval)
+	}
+
+	client.WaitTimeout = time.Minute
+	if timeout := client.waitTimeout(); timeout != time.Minute {
+		t.Errorf("Expected the client timeout to win, got %s", timeout)
+	}
+}
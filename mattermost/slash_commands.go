@@ -0,0 +1,130 @@
+package mattermost
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/sirupsen/logrus"
+)
+
+// processSlashCommands registers the slash-command entries from the import file as custom commands.
+// Commands whose trigger already exists in the team are skipped, and a command that cannot be
+// created is logged and skipped.
+func (c *Client) processSlashCommands(bulkImportPath string) error {
+	Log.WithFields(logrus.Fields{"file_path": bulkImportPath}).Info("⚡ Processing slash commands")
+
+	file, err := os.Open(bulkImportPath)
+	if err != nil {
+		return err
+	}
+	defer closeWithLog(file, "bulk import file")
+
+	createdCount := 0
+	errorCount := 0
+
+	// Existing triggers are listed once per team
+	triggers := make(map[string]map[string]bool)
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		var commandImport SlashCommandImport
+		if err := json.Unmarshal([]byte(line), &commandImport); err != nil {
+			continue
+		}
+		if commandImport.Type != "slash-command" {
+			continue
+		}
+
+		created, err := c.ensureSlashCommand(commandImport, triggers)
+		if err != nil {
+			Log.WithFields(logrus.Fields{
+				"team_name": commandImport.SlashCommand.Team,
+				"trigger":   commandImport.SlashCommand.Trigger,
+				"error":     err.Error(),
+			}).Warn("⚠️ Failed to create slash command")
+			errorCount++
+			continue
+		}
+		if created {
+			createdCount++
+		}
+	}
+
+	if createdCount > 0 || errorCount > 0 {
+		Log.WithFields(logrus.Fields{
+			"created_count": createdCount,
+			"error_count":   errorCount,
+		}).Info("✅ Slash command setup complete")
+	} else {
+		Log.Info("✅ No slash commands to create")
+	}
+
+	return scanner.Err()
+}
+
+// ensureSlashCommand creates the command unless its trigger is already registered in the team.
+// It reports whether the command was created.
+func (c *Client) ensureSlashCommand(commandImport SlashCommandImport, triggers map[string]map[string]bool) (bool, error) {
+	ctx := context.Background()
+	command := commandImport.SlashCommand
+	trigger := strings.TrimPrefix(command.Trigger, "/")
+
+	team, resp, err := c.API.GetTeamByName(ctx, command.Team, "")
+	if err != nil {
+		return false, handleAPIError(fmt.Sprintf("failed to get team '%s'", command.Team), err, resp)
+	}
+
+	if triggers[team.Id] == nil {
+		commands, resp, err := c.API.ListCommands(ctx, team.Id, false)
+		if err != nil {
+			return false, handleAPIError(fmt.Sprintf("failed to list commands for team '%s'", command.Team), err, resp)
+		}
+		triggers[team.Id] = make(map[string]bool, len(commands))
+		for _, existing := range commands {
+			triggers[team.Id][existing.Trigger] = true
+		}
+	}
+	if triggers[team.Id][trigger] {
+		Log.WithFields(logrus.Fields{"team_name": command.Team, "trigger": trigger}).Debug("⏭️ Slash command already exists")
+		return false, nil
+	}
+
+	method := model.CommandMethodPost
+	switch strings.ToUpper(command.Method) {
+	case "", "POST", model.CommandMethodPost:
+	case "GET", model.CommandMethodGet:
+		method = model.CommandMethodGet
+	default:
+		return false, fmt.Errorf("invalid method '%s', must be POST or GET", command.Method)
+	}
+
+	_, resp, err = c.API.CreateCommand(ctx, &model.Command{
+		TeamId:           team.Id,
+		Trigger:          trigger,
+		DisplayName:      command.DisplayName,
+		Description:      command.Description,
+		URL:              command.URL,
+		Method:           method,
+		AutoComplete:     command.AutoComplete,
+		AutoCompleteDesc: command.AutoCompleteDesc,
+		AutoCompleteHint: command.AutoCompleteHint,
+	})
+	if err != nil {
+		return false, handleAPIError(fmt.Sprintf("failed to create slash command '/%s'", trigger), err, resp)
+	}
+
+	triggers[team.Id][trigger] = true
+	Log.WithFields(logrus.Fields{"team_name": command.Team, "trigger": trigger}).Info("✅ Created slash command")
+	return true, nil
+}
@@ -0,0 +1,53 @@
+package mattermost
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+// TestProcessSlashCommands verifies slash-command entries are created with their method, existing
+// triggers and duplicates are skipped and invalid methods are rejected
+func TestProcessSlashCommands(t *testing.T) {
+	path := writeScanTestFile(t,
+		`{"type": "slash-command", "slash_command": {"team": "demo", "trigger": "weather", "url": "https://example.com/weather"}}`,
+		`{"type": "slash-command", "slash_command": {"team": "demo", "trigger": "/flights", "display_name": "Flights", "url": "https://example.com/flights", "method": "GET", "auto_complete": true}}`,
+		`{"type": "slash-command", "slash_command": {"team": "demo", "trigger": "flights", "url": "https://example.com/other"}}`,
+		`{"type": "slash-command", "slash_command": {"team": "demo", "trigger": "bad", "url": "https://example.com/bad", "method": "PUT"}}`,
+		`{"type": "slash-command", "slash_command": {"team": "demo", "trigger": "status", "url": "https://example.com/status", "method": "post"}}`,
+	)
+
+	existing := []*model.Command{{Id: "command-weather", TeamId: "team-demo", Trigger: "weather"}}
+	var created []*model.Command
+
+	server := newMockServer()
+	server.handleTeamsByName()
+	server.handle("GET /api/v4/commands", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, existing)
+	})
+	server.handle("POST /api/v4/commands", func(w http.ResponseWriter, r *http.Request) {
+		var command model.Command
+		if !decodeJSON(w, r, &command) {
+			return
+		}
+		command.Id = "command-" + command.Trigger
+		created = append(created, &command)
+		writeJSON(w, http.StatusCreated, &command)
+	})
+	client := setupMockClient(t, server)
+
+	if err := client.processSlashCommands(path); err != nil {
+		t.Fatalf("processSlashCommands returned error: %v", err)
+	}
+
+	if len(created) != 2 {
+		t.Fatalf("Expected 2 commands to be created, got %d", len(created))
+	}
+	if command := created[0]; command.Trigger != "flights" || command.Method != model.CommandMethodGet || command.TeamId != "team-demo" || !command.AutoComplete {
+		t.Errorf("Unexpected first command: %+v", command)
+	}
+	if command := created[1]; command.Trigger != "status" || command.Method != model.CommandMethodPost {
+		t.Errorf("Unexpected second command: %+v", command)
+	}
+}
@@ -1,6 +1,7 @@
 package mattermost
 
 import (
+	"context"
 	"os"
 	"testing"
 )
@@ -42,7 +43,7 @@ func setupTestClient(t *testing.T) *Client {
 	}
 
 	// Ensure we can connect to the server
-	err = client.WaitForStart()
+	err = client.WaitForStart(context.Background())
 	if err != nil {
 		t.Fatalf("Failed to connect to Mattermost server at %s: %v", siteURL, err)
 	}
@@ -0,0 +1,49 @@
+package mattermost
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net/http"
+	"os"
+
+	"github.com/sirupsen/logrus"
+)
+
+// CABundlePathEnv names a PEM file of extra CA certificates to trust, for Mattermost servers
+// whose certificate is issued by an internal CA
+const CABundlePathEnv = "MATTERMOST_CA_BUNDLE_PATH"
+
+// caBundleHTTPClient returns an HTTP client that trusts the system CAs and the certificates in the
+// PEM file at path. It returns nil, so the default client and system CAs are used, when path is
+// empty or the file cannot be read or holds no certificates.
+func caBundleHTTPClient(path string) *http.Client {
+	if path == "" {
+		return nil
+	}
+
+	pem, err := os.ReadFile(path)
+	if err != nil {
+		Log.WithFields(logrus.Fields{
+			"path":  path,
+			"error": err.Error(),
+		}).Warn("⚠️ Could not read CA bundle, using the system certificate pool")
+		return nil
+	}
+
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(pem) {
+		Log.WithFields(logrus.Fields{
+			"path": path,
+		}).Warn("⚠️ CA bundle contains no PEM certificates, using the system certificate pool")
+		return nil
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}
+
+	Log.WithFields(logrus.Fields{"path": path}).Debug("🔒 Trusting CA bundle for Mattermost connections")
+	return &http.Client{Transport: transport}
+}
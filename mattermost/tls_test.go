@@ -0,0 +1,56 @@
+package mattermost
+
+import (
+	"context"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+)
+
+// TestCABundle verifies a server signed by a CA in MATTERMOST_CA_BUNDLE_PATH is trusted,
+// and that an unreadable bundle falls back to the system certificate pool
+func TestCABundle(t *testing.T) {
+	InitLogger(&LogConfig{Level: logrus.ErrorLevel})
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"status":"OK"}`))
+	}))
+	t.Cleanup(server.Close)
+
+	bundlePath := filepath.Join(t.TempDir(), "ca.pem")
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	if err := os.WriteFile(bundlePath, certPEM, 0600); err != nil {
+		t.Fatalf("Failed to write CA bundle: %v", err)
+	}
+
+	testCases := []struct {
+		name          string
+		bundlePath    string
+		expectSuccess bool
+	}{
+		{name: "server certificate in the bundle", bundlePath: bundlePath, expectSuccess: true},
+		{name: "unreadable bundle uses system pool", bundlePath: filepath.Join(t.TempDir(), "missing.pem")},
+		{name: "no bundle", bundlePath: ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv(CABundlePathEnv, tc.bundlePath)
+
+			client := NewClient(server.URL, "sysadmin", "password", "test-team", "")
+			_, _, err := client.API.GetPing(context.Background())
+
+			if tc.expectSuccess && err != nil {
+				t.Errorf("Expected the request to succeed, got %v", err)
+			}
+			if !tc.expectSuccess && err == nil {
+				t.Error("Expected the self-signed certificate to be rejected")
+			}
+		})
+	}
+}
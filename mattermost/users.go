@@ -7,8 +7,10 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"maps"
 	"net/http"
 	"os"
+	"slices"
 	"strings"
 
 	"github.com/mattermost/mattermost/server/public/model"
@@ -25,6 +27,11 @@ func (c *Client) LoadBulkImportData() (*BulkImportData, error) {
 		"../bulk_import.jsonl", // Parent directory (when run from mattermost/)
 	}
 
+	// Prefer the client's import file so custom imports are read instead of the default
+	if c.BulkImportPath != "" {
+		possiblePaths = append([]string{c.BulkImportPath}, possiblePaths...)
+	}
+
 	var bulkImportPath string
 	for _, path := range possiblePaths {
 		if _, err := os.Stat(path); err == nil {
@@ -194,7 +201,7 @@ func (c *Client) Reset() error {
 		return fmt.Errorf("client not properly initialized")
 	}
 
-	if err := c.WaitForStart(); err != nil {
+	if err := c.WaitForStart(context.Background()); err != nil {
 		return err
 	}
 
@@ -305,7 +312,7 @@ func (c *Client) ListCustomProfileFields() ([]CustomProfileField, error) {
 	req.Header.Set("Authorization", "Bearer "+c.API.AuthToken)
 	req.Header.Set("Content-Type", "application/json")
 
-	client := &http.Client{}
+	client := c.httpClient()
 	resp, err := client.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get custom fields: %w", err)
@@ -352,7 +359,21 @@ func (c *Client) CreateCustomProfileFieldExtended(field UserAttributeField) (*Cu
 		"type":         field.Type,
 	}
 
-	// Add extended attributes based on the new JSON structure
+	// Set attrs if we have any extended configuration
+	if attrs := customProfileFieldAttrs(field); len(attrs) > 0 {
+		payload["attrs"] = attrs
+	}
+
+	// Add basic options for backward compatibility
+	if len(field.Options) > 0 {
+		payload["options"] = field.Options
+	}
+
+	return c.createCustomProfileFieldWithPayload(url, payload)
+}
+
+// customProfileFieldAttrs builds the extended attributes of a custom profile field from its definition
+func customProfileFieldAttrs(field UserAttributeField) map[string]any {
 	attrs := map[string]any{}
 
 	if field.LDAPAttribute != "" {
@@ -373,18 +394,39 @@ func (c *Client) CreateCustomProfileFieldExtended(field UserAttributeField) (*Cu
 	if field.Visibility != "" {
 		attrs["visibility"] = field.Visibility
 	}
+	return attrs
+}
 
-	// Set attrs if we have any extended configuration
-	if len(attrs) > 0 {
-		payload["attrs"] = attrs
+// PatchCustomProfileFieldAttrs replaces the extended attributes of an existing custom profile field
+// with the ones from its definition, e.g. to update its LDAP or SAML attribute mapping
+func (c *Client) PatchCustomProfileFieldAttrs(fieldID string, field UserAttributeField) error {
+	url := fmt.Sprintf("%s/api/v4/custom_profile_attributes/fields/%s", c.ServerURL, fieldID)
+
+	jsonPayload, err := json.Marshal(map[string]any{"attrs": customProfileFieldAttrs(field)})
+	if err != nil {
+		return fmt.Errorf("failed to marshal payload: %w", err)
 	}
 
-	// Add basic options for backward compatibility
-	if len(field.Options) > 0 {
-		payload["options"] = field.Options
+	req, err := http.NewRequest("PATCH", url, bytes.NewBuffer(jsonPayload))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
 	}
+	req.Header.Set("Authorization", "Bearer "+c.API.AuthToken)
+	req.Header.Set("Content-Type", "application/json")
 
-	return c.createCustomProfileFieldWithPayload(url, payload)
+	client := c.httpClient()
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to patch custom field: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("failed to patch custom field, status %d: %s", resp.StatusCode, string(body))
+	}
+
+	return nil
 }
 
 // createCustomProfileFieldWithPayload handles the actual API call
@@ -402,14 +444,14 @@ func (c *Client) createCustomProfileFieldWithPayload(url string, payload map[str
 	req.Header.Set("Authorization", "Bearer "+c.API.AuthToken)
 	req.Header.Set("Content-Type", "application/json")
 
-	client := &http.Client{}
+	client := c.httpClient()
 	resp, err := client.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create custom field: %w", err)
 	}
 	defer func() { _ = resp.Body.Close() }()
 
-	if resp.StatusCode != http.StatusCreated {
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
 		body, _ := io.ReadAll(resp.Body)
 		return nil, fmt.Errorf("failed to create custom field, status %d: %s", resp.StatusCode, string(body))
 	}
@@ -494,6 +536,14 @@ func (c *Client) ensureCustomFieldExists(field UserAttributeField) error {
 	// Check if field already exists
 	for _, existingField := range existingFields {
 		if existingField.Name == field.Name {
+			if existingField.Attrs.SAMLAttribute != field.SAMLAttribute {
+				Log.WithFields(logrus.Fields{
+					"field_name":              field.Name,
+					"existing_saml_attribute": existingField.Attrs.SAMLAttribute,
+					"saml_attribute":          field.SAMLAttribute,
+				}).Warn("⚠️ Custom field already exists with a different SAML attribute mapping")
+				return nil
+			}
 			Log.WithFields(logrus.Fields{
 				"field_name": field.Name,
 			}).Debug("🔍 Custom field already exists")
@@ -526,6 +576,116 @@ func (c *Client) ensureCustomFieldExists(field UserAttributeField) error {
 	return nil
 }
 
+// processUserProfiles applies attribute values from user-profile entries to existing users.
+// It runs after processUserAttributes so the custom fields the values refer to exist.
+func (c *Client) processUserProfiles(bulkImportPath string) error {
+	Log.WithFields(logrus.Fields{"file_path": bulkImportPath}).Info("🪪 Processing user profiles")
+
+	userProfiles, err := c.extractUserProfiles(bulkImportPath)
+	if err != nil {
+		return fmt.Errorf("failed to read user profiles: %w", err)
+	}
+
+	if len(userProfiles) == 0 {
+		Log.Info("🪪 No user profiles found, skipping")
+		return nil
+	}
+
+	fields, err := c.ListCustomProfileFields()
+	if err != nil {
+		return err
+	}
+	fieldIDs := make(map[string]string, len(fields))
+	for _, field := range fields {
+		fieldIDs[field.Name] = field.ID
+	}
+
+	updatedCount := 0
+	errorCount := 0
+
+	usernames := slices.Sorted(maps.Keys(userProfiles))
+	for _, username := range usernames {
+		values := make(map[string]string)
+		for name, value := range userProfiles[username] {
+			fieldID, ok := fieldIDs[name]
+			if !ok {
+				Log.WithFields(logrus.Fields{
+					"username":   username,
+					"field_name": name,
+				}).Warn("⚠️ User profile references an unknown attribute, skipping it")
+				continue
+			}
+			values[fieldID] = value
+		}
+		if len(values) == 0 {
+			continue
+		}
+
+		user, resp, err := c.API.GetUserByUsername(context.Background(), username, "")
+		if err != nil {
+			Log.WithFields(logrus.Fields{
+				"username": username,
+				"error":    handleAPIError("failed to get user", err, resp).Error(),
+			}).Warn("⚠️ Failed to find user for profile")
+			errorCount++
+			continue
+		}
+
+		if err := c.PatchUserCustomProfileAttributes(user.Id, values); err != nil {
+			Log.WithFields(logrus.Fields{
+				"username": username,
+				"error":    err.Error(),
+			}).Warn("⚠️ Failed to update user profile attributes")
+			errorCount++
+			continue
+		}
+
+		Log.WithFields(logrus.Fields{
+			"username":        username,
+			"attribute_count": len(values),
+		}).Debug("✅ Updated user profile attributes")
+		updatedCount++
+	}
+
+	Log.WithFields(logrus.Fields{
+		"updated_count": updatedCount,
+		"error_count":   errorCount,
+	}).Info("✅ User profiles processing complete")
+
+	return nil
+}
+
+// PatchUserCustomProfileAttributes sets a user's custom profile attribute values, keyed by field ID
+func (c *Client) PatchUserCustomProfileAttributes(userID string, values map[string]string) error {
+	url := fmt.Sprintf("%s/api/v4/users/%s/custom_profile_attributes", c.ServerURL, userID)
+
+	jsonPayload, err := json.Marshal(values)
+	if err != nil {
+		return fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	req, err := http.NewRequest("PATCH", url, bytes.NewBuffer(jsonPayload))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+c.API.AuthToken)
+	req.Header.Set("Content-Type", "application/json")
+
+	client := c.httpClient()
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to update custom profile attributes: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("failed to update custom profile attributes, status %d: %s", resp.StatusCode, string(body))
+	}
+
+	return nil
+}
+
 // configureGroupProperties configures group properties based on the group configuration
 func (c *Client) configureGroupProperties(groupID string, group ldapPkg.LDAPGroup) error {
 	// Create patch request with desired properties
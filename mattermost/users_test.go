@@ -0,0 +1,197 @@
+package mattermost
+
+import (
+	"maps"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+// handleCustomFields registers the custom profile attributes API on server, listing existing and
+// appending created fields to created
+func handleCustomFields(server *mockServer, existing []map[string]any, created *[]map[string]any) {
+	if existing == nil {
+		existing = []map[string]any{}
+	}
+	server.handle("GET /api/v4/custom_profile_attributes/fields", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, existing)
+	})
+	server.handle("POST /api/v4/custom_profile_attributes/fields", func(w http.ResponseWriter, r *http.Request) {
+		var payload map[string]any
+		if !decodeJSON(w, r, &payload) {
+			return
+		}
+		*created = append(*created, payload)
+		payload["id"] = "field-id"
+		writeJSON(w, http.StatusCreated, payload)
+	})
+}
+
+// TestProcessUserAttributesSAMLMapping verifies SAML attributes from the JSONL reach the API payload
+func TestProcessUserAttributesSAMLMapping(t *testing.T) {
+	var created []map[string]any
+	server := newMockServer()
+	handleCustomFields(server, nil, &created)
+	client := setupMockClient(t, server)
+
+	bulkImportPath := filepath.Join(t.TempDir(), "bulk_import.jsonl")
+	jsonl := `{"type":"user-attribute","attribute":{"name":"department","display_name":"Department","type":"text","ldap":"departmentNumber","saml":"Department"}}` + "\n"
+	if err := os.WriteFile(bulkImportPath, []byte(jsonl), 0600); err != nil {
+		t.Fatalf("Failed to write bulk import file: %v", err)
+	}
+
+	if err := client.processUserAttributes(bulkImportPath); err != nil {
+		t.Fatalf("processUserAttributes returned error: %v", err)
+	}
+
+	if len(created) != 1 {
+		t.Fatalf("Expected 1 created field, got %d", len(created))
+	}
+
+	attrs, ok := created[0]["attrs"].(map[string]any)
+	if !ok {
+		t.Fatalf("Expected attrs in payload, got %v", created[0])
+	}
+	if attrs["saml"] != "Department" {
+		t.Errorf("Expected saml attribute to be Department, got %v", attrs["saml"])
+	}
+	if attrs["ldap"] != "departmentNumber" {
+		t.Errorf("Expected ldap attribute to be departmentNumber, got %v", attrs["ldap"])
+	}
+}
+
+// TestEnsureCustomFieldExistsSAMLAttribute tests duplicate detection against existing SAML mappings
+func TestEnsureCustomFieldExistsSAMLAttribute(t *testing.T) {
+	testCases := []struct {
+		name          string
+		existing      []map[string]any
+		field         UserAttributeField
+		expectCreated bool
+	}{
+		{
+			name:          "Creates missing field",
+			field:         UserAttributeField{Name: "rank", Type: "text", SAMLAttribute: "Rank"},
+			expectCreated: true,
+		},
+		{
+			name: "Skips field with matching SAML attribute",
+			existing: []map[string]any{
+				{"id": "1", "name": "rank", "type": "text", "attrs": map[string]any{"saml": "Rank"}},
+			},
+			field:         UserAttributeField{Name: "rank", Type: "text", SAMLAttribute: "Rank"},
+			expectCreated: false,
+		},
+		{
+			name: "Does not recreate field with different SAML attribute",
+			existing: []map[string]any{
+				{"id": "1", "name": "rank", "type": "text", "attrs": map[string]any{"saml": "Grade"}},
+			},
+			field:         UserAttributeField{Name: "rank", Type: "text", SAMLAttribute: "Rank"},
+			expectCreated: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var created []map[string]any
+			server := newMockServer()
+			handleCustomFields(server, tc.existing, &created)
+			client := setupMockClient(t, server)
+
+			if err := client.ensureCustomFieldExists(tc.field); err != nil {
+				t.Fatalf("ensureCustomFieldExists returned error: %v", err)
+			}
+
+			if created := len(created) > 0; created != tc.expectCreated {
+				t.Errorf("Expected created=%v, got %v", tc.expectCreated, created)
+			}
+		})
+	}
+}
+
+// TestListCustomProfileFieldsSAMLAttribute verifies the SAML attribute round-trips from the API
+func TestListCustomProfileFieldsSAMLAttribute(t *testing.T) {
+	var created []map[string]any
+	server := newMockServer()
+	handleCustomFields(server, []map[string]any{
+		{"id": "1", "name": "rank", "type": "text", "attrs": map[string]any{"saml": "Rank", "ldap": "rank"}},
+	}, &created)
+	client := setupMockClient(t, server)
+
+	fields, err := client.ListCustomProfileFields()
+	if err != nil {
+		t.Fatalf("ListCustomProfileFields returned error: %v", err)
+	}
+
+	if len(fields) != 1 {
+		t.Fatalf("Expected 1 field, got %d", len(fields))
+	}
+	if fields[0].Attrs.SAMLAttribute != "Rank" {
+		t.Errorf("Expected SAML attribute Rank, got %q", fields[0].Attrs.SAMLAttribute)
+	}
+	if fields[0].Attrs.LDAPAttribute != "rank" {
+		t.Errorf("Expected LDAP attribute rank, got %q", fields[0].Attrs.LDAPAttribute)
+	}
+}
+
+// TestProcessUserProfiles verifies user-profile values are patched onto existing users by field ID
+func TestProcessUserProfiles(t *testing.T) {
+	fields := []map[string]any{
+		{"id": "field-department", "name": "department", "type": "text"},
+		{"id": "field-rank", "name": "rank", "type": "text"},
+	}
+	users := map[string]string{"john.smith": "user-john", "maria.rodriguez": "user-maria"}
+	patched := make(map[string]map[string]string)
+
+	server := newMockServer()
+	server.handle("GET /api/v4/custom_profile_attributes/fields", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, fields)
+	})
+	server.handle("GET /api/v4/users/username/{username}", func(w http.ResponseWriter, r *http.Request) {
+		username := r.PathValue("username")
+		userID, ok := users[username]
+		if !ok {
+			writeAppError(w, http.StatusNotFound, "app.user.missing_account.const")
+			return
+		}
+		writeJSON(w, http.StatusOK, &model.User{Id: userID, Username: username})
+	})
+	server.handle("PATCH /api/v4/users/{user}/custom_profile_attributes", func(w http.ResponseWriter, r *http.Request) {
+		var values map[string]string
+		if !decodeJSON(w, r, &values) {
+			return
+		}
+		patched[r.PathValue("user")] = values
+		writeJSON(w, http.StatusOK, values)
+	})
+	client := setupMockClient(t, server)
+
+	bulkImportPath := filepath.Join(t.TempDir(), "bulk_import.jsonl")
+	jsonl := `{"type":"user-profile","user":"john.smith","attributes":{"department":"Security Forces","rank":"Colonel"}}
+{"type":"user-profile","user":"maria.rodriguez","attributes":{"rank":"Major","callsign":"Viper"}}
+{"type":"user-profile","user":"missing.user","attributes":{"rank":"Captain"}}
+`
+	if err := os.WriteFile(bulkImportPath, []byte(jsonl), 0600); err != nil {
+		t.Fatalf("Failed to write bulk import file: %v", err)
+	}
+
+	if err := client.processUserProfiles(bulkImportPath); err != nil {
+		t.Fatalf("processUserProfiles returned error: %v", err)
+	}
+
+	expected := map[string]map[string]string{
+		"user-john":  {"field-department": "Security Forces", "field-rank": "Colonel"},
+		"user-maria": {"field-rank": "Major"},
+	}
+	if len(patched) != len(expected) {
+		t.Fatalf("Expected %d users to be patched, got %v", len(expected), patched)
+	}
+	for userID, values := range expected {
+		if !maps.Equal(patched[userID], values) {
+			t.Errorf("Expected %s to be patched with %v, got %v", userID, values, patched[userID])
+		}
+	}
+}
@@ -0,0 +1,245 @@
+package mattermost
+
+import (
+	"bufio"
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+// requiredImportFields lists the fields each import line type must set, as paths into the line's JSON.
+// Types with no entry are still recognised but have nothing to check.
+var requiredImportFields = map[string][][]string{
+	"version":          nil,
+	"scheme":           nil,
+	"emoji":            {{"emoji", "name"}, {"emoji", "image"}},
+	"direct_channel":   nil,
+	"direct_post":      nil,
+	"team":             {{"team", "name"}, {"team", "display_name"}, {"team", "type"}},
+	"channel":          {{"channel", "name"}, {"channel", "team"}, {"channel", "display_name"}, {"channel", "type"}},
+	"user":             {{"user", "username"}, {"user", "email"}},
+	"post":             {{"post", "team"}, {"post", "channel"}, {"post", "user"}, {"post", "message"}},
+	"channel-category": {{"category"}, {"team"}},
+	"channel-banner":   {{"banner", "team"}, {"banner", "channel"}},
+	"command":          {{"command", "team"}, {"command", "channel"}, {"command", "text"}},
+	"plugin":           {{"plugin", "name"}, {"plugin", "source"}},
+	"user-attribute":   {{"attribute", "name"}},
+	"user-profile":     {{"user"}},
+	"user-groups":      {{"group", "name"}},
+	"direct-message":   {{"from"}, {"to"}, {"text"}},
+	"custom-emoji":     {{"emoji", "name"}, {"emoji", "image"}},
+	"bot":              {{"bot", "username"}},
+	"slash-command":    {{"slash_command", "team"}, {"slash_command", "trigger"}, {"slash_command", "url"}},
+	"incoming-webhook": {{"incoming_webhook", "team"}, {"incoming_webhook", "channel"}, {"incoming_webhook", "display_name"}},
+}
+
+// defaultChannels are created in every team, so import lines may reference them without defining them
+var defaultChannels = []string{"town-square", "off-topic"}
+
+// importReference is a team, channel or user named by an import line, checked once the whole file is read
+type importReference struct {
+	line     int
+	lineType string
+	kind     string // "team", "channel" or "user"
+	name     string // team/channel for channels
+}
+
+// importReferences collects what an import file defines and what its lines reference
+type importReferences struct {
+	defined    map[string]map[string]bool
+	references []importReference
+}
+
+// VerifyBulkImport validates every line of a JSONL import file without contacting the server.
+// It checks that each line is valid JSON with a known type and that the required fields for
+// that type are set, that the standard types follow importLineOrder, and that the teams and
+// channels used by channels, users and posts, and the authors of posts, are defined in the file.
+// All problems are collected and returned together in line order, or nil if the file is valid.
+func (c *Client) VerifyBulkImport(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open bulk import file: %w", err)
+	}
+	defer closeWithLog(file, "bulk import file")
+
+	var errs []error
+	lineNumber := 0
+	count := 0
+	references := &importReferences{defined: map[string]map[string]bool{"team": {}, "channel": {}, "user": {}}}
+	order := &importLineOrderCheck{}
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		count++
+
+		if err := verifyImportLine(line); err != nil {
+			errs = append(errs, fmt.Errorf("line %d: %w", lineNumber, err))
+			continue
+		}
+		if err := order.check(line, lineNumber); err != nil {
+			errs = append(errs, fmt.Errorf("line %d: %w", lineNumber, err))
+		}
+		references.collect(line, lineNumber)
+	}
+	if err := scanner.Err(); err != nil {
+		errs = append(errs, fmt.Errorf("failed to read bulk import file: %w", err))
+	}
+	errs = append(errs, references.check()...)
+	slices.SortStableFunc(errs, func(a, b error) int { return cmp.Compare(errorLine(a), errorLine(b)) })
+
+	if len(errs) > 0 {
+		Log.WithFields(logrus.Fields{
+			"file":   path,
+			"lines":  count,
+			"errors": len(errs),
+		}).Error("❌ Bulk import file failed verification")
+		return errors.Join(errs...)
+	}
+
+	Log.WithFields(logrus.Fields{
+		"file":  path,
+		"lines": count,
+	}).Info("✅ Bulk import file verified")
+	return nil
+}
+
+// verifyImportLine checks a single import line for valid JSON, a known type and its required fields
+func verifyImportLine(line string) error {
+	var data map[string]any
+	if err := json.Unmarshal([]byte(line), &data); err != nil {
+		return fmt.Errorf("invalid JSON: %w", err)
+	}
+
+	lineType := getNestedString(data, "type")
+	if lineType == "" {
+		return fmt.Errorf("missing required field \"type\"")
+	}
+
+	fields, known := requiredImportFields[lineType]
+	if !known {
+		return fmt.Errorf("unknown type %q", lineType)
+	}
+
+	var missing []string
+	for _, field := range fields {
+		if getNestedString(data, field...) == "" {
+			missing = append(missing, strings.Join(field, "."))
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("%s is missing required fields: %s", lineType, strings.Join(missing, ", "))
+	}
+
+	return nil
+}
+
+// importLineOrderCheck tracks the latest standard line type seen so far in an import file
+type importLineOrderCheck struct {
+	lineType string
+	line     int
+}
+
+// check returns an error if a valid import line's type ranks before a type already seen.
+// The import stops reading each phase at the first line of a later type, so such a line
+// would not be imported.
+func (o *importLineOrderCheck) check(line string, lineNumber int) error {
+	var typeCheck struct {
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal([]byte(line), &typeCheck); err != nil {
+		return nil
+	}
+
+	rank, ok := importLineOrder[typeCheck.Type]
+	if !ok {
+		return nil
+	}
+	if o.lineType != "" {
+		latestRank := importLineOrder[o.lineType]
+		if rank < latestRank {
+			return fmt.Errorf("%s comes after the %s on line %d and would not be imported; %s lines must come before %s lines",
+				typeCheck.Type, o.lineType, o.line, typeCheck.Type, o.lineType)
+		}
+		if rank == latestRank {
+			return nil
+		}
+	}
+	o.lineType, o.line = typeCheck.Type, lineNumber
+	return nil
+}
+
+// collect records the teams, channels and users a valid import line defines and references
+func (r *importReferences) collect(line string, lineNumber int) {
+	var data map[string]any
+	if err := json.Unmarshal([]byte(line), &data); err != nil {
+		return
+	}
+
+	lineType := getNestedString(data, "type")
+	refer := func(kind string, names ...string) {
+		r.references = append(r.references, importReference{line: lineNumber, lineType: lineType, kind: kind, name: strings.Join(names, "/")})
+	}
+
+	switch lineType {
+	case "team":
+		teamName := getNestedString(data, "team", "name")
+		r.defined["team"][teamName] = true
+		for _, channelName := range defaultChannels {
+			r.defined["channel"][teamName+"/"+channelName] = true
+		}
+	case "channel":
+		teamName := getNestedString(data, "channel", "team")
+		r.defined["channel"][teamName+"/"+getNestedString(data, "channel", "name")] = true
+		refer("team", teamName)
+	case "user":
+		r.defined["user"][getNestedString(data, "user", "username")] = true
+		for _, membership := range extractAllChannelNames(data) {
+			refer("team", membership.Team)
+			refer("channel", membership.Team, membership.Channel)
+		}
+	case "post":
+		post, _ := data["post"].(map[string]any)
+		refer("channel", getNestedString(post, "team"), getNestedString(post, "channel"))
+		refer("user", getNestedString(post, "user"))
+		if replies, ok := post["replies"].([]any); ok {
+			for _, replyIntf := range replies {
+				if reply, ok := replyIntf.(map[string]any); ok {
+					refer("user", getNestedString(reply, "user"))
+				}
+			}
+		}
+	case "direct_post":
+		refer("user", getNestedString(data, "direct_post", "user"))
+	}
+}
+
+// check returns an error for each reference to a team, channel or user the file does not define
+func (r *importReferences) check() []error {
+	var errs []error
+	for _, ref := range r.references {
+		if ref.name == "" || r.defined[ref.kind][ref.name] {
+			continue
+		}
+		errs = append(errs, fmt.Errorf("line %d: %s references %s %q, which is not defined in the file", ref.line, ref.lineType, ref.kind, ref.name))
+	}
+	return errs
+}
+
+// errorLine returns the line number an error from VerifyBulkImport starts with, or 0 if it has none
+func errorLine(err error) int {
+	var line int
+	_, _ = fmt.Sscanf(err.Error(), "line %d:", &line)
+	return line
+}
@@ -0,0 +1,134 @@
+package mattermost
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+)
+
+// TestVerifyBulkImport tests JSONL validation before import
+func TestVerifyBulkImport(t *testing.T) {
+	InitLogger(&LogConfig{Level: logrus.ErrorLevel})
+
+	testCases := []struct {
+		name           string
+		content        string
+		expectedErrors []string
+	}{
+		{
+			name: "Valid file",
+			content: `{"type": "version", "version": 1}
+{"type": "team", "team": {"name": "demo", "display_name": "Demo", "type": "O"}}
+{"type": "channel", "channel": {"team": "demo", "name": "ops", "display_name": "Ops", "type": "O"}}
+
+{"type": "user", "user": {"username": "alice", "email": "alice@example.com"}}
+{"type": "channel-category", "category": "Ops", "team": "demo", "channels": ["ops"]}
+{"type": "user-profile", "user": "alice", "attributes": {"rank": "Captain"}}
+`,
+		},
+		{
+			name:           "Invalid JSON",
+			content:        `{"type": "user", "user": {"username": "alice"` + "\n",
+			expectedErrors: []string{"line 1: invalid JSON"},
+		},
+		{
+			name: "Missing and unknown types",
+			content: `{"user": {"username": "alice", "email": "alice@example.com"}}
+{"type": "widget", "widget": {}}
+`,
+			expectedErrors: []string{
+				`line 1: missing required field "type"`,
+				`line 2: unknown type "widget"`,
+			},
+		},
+		{
+			name: "All missing fields are collected",
+			content: `{"type": "user", "user": {"username": "alice"}}
+{"type": "channel", "channel": {"display_name": "Ops", "type": "O"}}
+{"type": "user", "user": {"username": "bob", "email": "bob@example.com"}}
+{"type": "post", "post": {"team": "demo", "channel": "ops", "user": "bob"}}
+`,
+			expectedErrors: []string{
+				"line 1: user is missing required fields: user.email",
+				"line 2: channel is missing required fields: channel.name, channel.team",
+				"line 4: post is missing required fields: post.message",
+			},
+		},
+		{
+			name: "Undefined teams, channels and users",
+			content: `{"type": "team", "team": {"name": "demo", "display_name": "Demo", "type": "O"}}
+{"type": "channel", "channel": {"team": "other", "name": "ops", "display_name": "Ops", "type": "O"}}
+{"type": "user", "user": {"username": "alice", "email": "alice@example.com", "teams": [{"name": "demo", "channels": [{"name": "town-square"}, {"name": "intel"}]}]}}
+{"type": "post", "post": {"team": "demo", "channel": "town-square", "user": "alice", "message": "hi", "replies": [{"user": "bob", "message": "hey"}]}}
+{"type": "post", "post": {"team": "other", "channel": "ops", "user": "carol", "message": "lost"}}
+{"type": "user", "user": {"username": "bob", "email": "bob@example.com"}}
+`,
+			expectedErrors: []string{
+				`line 2: channel references team "other"`,
+				`line 3: user references channel "demo/intel"`,
+				`line 5: post references user "carol"`,
+				`line 6: user comes after the post on line 4 and would not be imported`,
+			},
+		},
+		{
+			name: "Lines out of import order",
+			content: `{"type": "version", "version": 1}
+{"type": "team", "team": {"name": "demo", "display_name": "Demo", "type": "O"}}
+{"type": "user", "user": {"username": "alice", "email": "alice@example.com"}}
+{"type": "channel-category", "category": "Ops", "team": "demo", "channels": ["ops"]}
+{"type": "channel", "channel": {"team": "demo", "name": "ops", "display_name": "Ops", "type": "O"}}
+{"type": "post", "post": {"team": "demo", "channel": "ops", "user": "alice", "message": "hi"}}
+{"type": "team", "team": {"name": "late", "display_name": "Late", "type": "O"}}
+{"type": "post", "post": {"team": "demo", "channel": "ops", "user": "alice", "message": "again"}}
+`,
+			expectedErrors: []string{
+				`line 5: channel comes after the user on line 3 and would not be imported`,
+				`line 7: team comes after the post on line 6 and would not be imported`,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "bulk_import.jsonl")
+			if err := os.WriteFile(path, []byte(tc.content), 0600); err != nil {
+				t.Fatalf("Failed to write bulk import file: %v", err)
+			}
+
+			client := NewClient("", "", "", "", "")
+			err := client.VerifyBulkImport(path)
+
+			if len(tc.expectedErrors) == 0 {
+				if err != nil {
+					t.Errorf("Expected no error, got %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("Expected verification error, got nil")
+			}
+
+			lines := strings.Split(err.Error(), "\n")
+			if len(lines) != len(tc.expectedErrors) {
+				t.Errorf("Expected %d errors, got %d: %v", len(tc.expectedErrors), len(lines), err)
+			}
+			for i, expected := range tc.expectedErrors {
+				if i < len(lines) && !strings.Contains(lines[i], expected) {
+					t.Errorf("Expected error %d to contain %q, got %v", i+1, expected, err)
+				}
+			}
+		})
+	}
+
+	t.Run("Bundled import files are valid", func(t *testing.T) {
+		client := NewClient("", "", "", "", "")
+		for _, path := range []string{"../evac.jsonl", "../usaf.jsonl"} {
+			if err := client.VerifyBulkImport(path); err != nil {
+				t.Errorf("Expected %s to verify, got %v", path, err)
+			}
+		}
+	})
+}
@@ -0,0 +1,243 @@
+package mattermost
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/sirupsen/logrus"
+)
+
+// GeneratedWebhooksPath is where setup writes the URLs of the webhooks it created
+const GeneratedWebhooksPath = "generated_webhooks.json"
+
+// GeneratedWebhook is the entry written to generated_webhooks.json for each configured webhook
+type GeneratedWebhook struct {
+	URL     string `json:"url"`
+	Team    string `json:"team"`
+	Channel string `json:"channel"`
+}
+
+// webhooksPerPage is the page size used when listing a team's incoming webhooks
+const webhooksPerPage = 200
+
+// CreateWebhook creates the incoming webhook and returns its ID. In dry-run mode the webhook is
+// not created and the ID is empty.
+func (c *Client) CreateWebhook(hook *model.IncomingWebhook) (string, error) {
+	created, resp, err := c.API.CreateIncomingWebhook(context.Background(), hook)
+	if err != nil {
+		return "", handleAPIError(fmt.Sprintf("failed to create webhook '%s'", hook.DisplayName), err, resp)
+	}
+
+	return created.Id, nil
+}
+
+// webhookURL builds the public URL for an incoming webhook ID
+func (c *Client) webhookURL(hookID string) string {
+	return strings.TrimSuffix(c.ServerURL, "/") + "/hooks/" + hookID
+}
+
+// setupWebhooks creates the webhooks from the config, reusing any that already exist with the same
+// channel and display name, and writes their URLs to GeneratedWebhooksPath
+func (c *Client) setupWebhooks() error {
+	if c.Config == nil || len(c.Config.Webhooks) == 0 {
+		return nil
+	}
+
+	Log.WithFields(logrus.Fields{"webhook_count": len(c.Config.Webhooks)}).Info("🪝 Setting up incoming webhooks")
+
+	// Sort names so the output order is stable between runs
+	names := make([]string, 0, len(c.Config.Webhooks))
+	for name := range c.Config.Webhooks {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	generated := make(map[string]GeneratedWebhook, len(names))
+	for _, name := range names {
+		webhookConfig := c.Config.Webhooks[name]
+		teamName := webhookConfig.Team
+		if teamName == "" {
+			teamName = c.Config.DefaultTeam
+		}
+
+		channel, resp, err := c.API.GetChannelByNameForTeamName(context.Background(), webhookConfig.Channel, teamName, "")
+		if err != nil {
+			return handleAPIError(fmt.Sprintf("failed to find channel '%s' in team '%s' for webhook '%s'", webhookConfig.Channel, teamName, name), err, resp)
+		}
+
+		hookID, err := c.findIncomingWebhook(channel, webhookConfig.DisplayName)
+		if err != nil {
+			return err
+		}
+
+		if hookID != "" {
+			Log.WithFields(logrus.Fields{"webhook": name, "channel_name": channel.Name}).Info("⏭️ Webhook already exists, reusing it")
+		} else {
+			hookID, err = c.CreateWebhook(&model.IncomingWebhook{
+				ChannelId:   channel.Id,
+				DisplayName: webhookConfig.DisplayName,
+				IconURL:     webhookConfig.IconURL,
+			})
+			if err != nil {
+				return err
+			}
+			Log.WithFields(logrus.Fields{"webhook": name, "channel_name": channel.Name}).Info("✅ Created webhook")
+		}
+
+		// In dry-run mode the webhook was not created, so it has no URL
+		if hookID == "" {
+			continue
+		}
+		url := c.webhookURL(hookID)
+		generated[name] = GeneratedWebhook{URL: url, Team: teamName, Channel: channel.Name}
+		c.addWebhookURL(name, url)
+	}
+
+	if c.DryRun {
+		Log.Info("🔍 Dry run: not writing " + GeneratedWebhooksPath)
+		return nil
+	}
+
+	data, err := json.MarshalIndent(generated, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal generated webhooks: %w", err)
+	}
+	if err := os.WriteFile(GeneratedWebhooksPath, data, 0600); err != nil {
+		return fmt.Errorf("failed to write %s: %w", GeneratedWebhooksPath, err)
+	}
+
+	Log.WithFields(logrus.Fields{"file": GeneratedWebhooksPath, "webhook_count": len(generated)}).Info("✅ Wrote generated webhook URLs")
+	return nil
+}
+
+// findIncomingWebhook returns the ID of an existing webhook for the channel with the display name, or ""
+func (c *Client) findIncomingWebhook(channel *model.Channel, displayName string) (string, error) {
+	for page := 0; ; page++ {
+		hooks, resp, err := c.API.GetIncomingWebhooksForTeam(context.Background(), channel.TeamId, page, webhooksPerPage, "")
+		if err != nil {
+			return "", handleAPIError(fmt.Sprintf("failed to list webhooks for channel '%s'", channel.Name), err, resp)
+		}
+
+		for _, hook := range hooks {
+			if hook.ChannelId == channel.Id && hook.DisplayName == displayName {
+				return hook.Id, nil
+			}
+		}
+
+		if len(hooks) < webhooksPerPage {
+			return "", nil
+		}
+	}
+}
+
+// processIncomingWebhooks creates the incoming-webhook entries from the import file, reusing any that
+// already exist with the same channel and display name. A webhook that cannot be set up is logged
+// and skipped. The URLs are kept in WebhookURLs so EchoLogins can show them.
+func (c *Client) processIncomingWebhooks(bulkImportPath string) error {
+	Log.WithFields(logrus.Fields{"file_path": bulkImportPath}).Info("🪝 Processing incoming webhooks")
+
+	file, err := os.Open(bulkImportPath)
+	if err != nil {
+		return err
+	}
+	defer closeWithLog(file, "bulk import file")
+
+	createdCount := 0
+	errorCount := 0
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		var webhookImport IncomingWebhookImport
+		if err := json.Unmarshal([]byte(line), &webhookImport); err != nil {
+			continue
+		}
+		if webhookImport.Type != "incoming-webhook" {
+			continue
+		}
+
+		webhook := webhookImport.IncomingWebhook
+		created, err := c.ensureIncomingWebhook(webhookImport)
+		if err != nil {
+			Log.WithFields(logrus.Fields{
+				"team_name":    webhook.Team,
+				"channel_name": webhook.Channel,
+				"webhook":      webhook.DisplayName,
+				"error":        err.Error(),
+			}).Warn("⚠️ Failed to set up incoming webhook")
+			errorCount++
+			continue
+		}
+		if created {
+			createdCount++
+		}
+	}
+
+	if createdCount > 0 || errorCount > 0 {
+		Log.WithFields(logrus.Fields{
+			"created_count": createdCount,
+			"error_count":   errorCount,
+		}).Info("✅ Incoming webhook setup complete")
+	} else {
+		Log.Info("✅ No incoming webhooks to create")
+	}
+
+	return scanner.Err()
+}
+
+// ensureIncomingWebhook creates the webhook unless one with its display name already posts to the
+// channel, and records its URL. It reports whether the webhook was created.
+func (c *Client) ensureIncomingWebhook(webhookImport IncomingWebhookImport) (bool, error) {
+	webhook := webhookImport.IncomingWebhook
+
+	channel, resp, err := c.API.GetChannelByNameForTeamName(context.Background(), webhook.Channel, webhook.Team, "")
+	if err != nil {
+		return false, handleAPIError(fmt.Sprintf("failed to find channel '%s' in team '%s'", webhook.Channel, webhook.Team), err, resp)
+	}
+
+	hookID, err := c.findIncomingWebhook(channel, webhook.DisplayName)
+	if err != nil {
+		return false, err
+	}
+	if hookID != "" {
+		c.addWebhookURL(webhook.DisplayName, c.webhookURL(hookID))
+		Log.WithFields(logrus.Fields{"webhook": webhook.DisplayName, "channel_name": channel.Name}).Debug("⏭️ Webhook already exists")
+		return false, nil
+	}
+
+	hookID, err = c.CreateWebhook(&model.IncomingWebhook{
+		ChannelId:   channel.Id,
+		DisplayName: webhook.DisplayName,
+		Description: webhook.Description,
+		IconURL:     webhook.IconURL,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	// In dry-run mode the webhook was not created, so it has no URL
+	if hookID != "" {
+		c.addWebhookURL(webhook.DisplayName, c.webhookURL(hookID))
+	}
+	Log.WithFields(logrus.Fields{"webhook": webhook.DisplayName, "channel_name": channel.Name}).Info("✅ Created webhook")
+	return true, nil
+}
+
+// addWebhookURL records a webhook URL for EchoLogins
+func (c *Client) addWebhookURL(name, url string) {
+	if c.WebhookURLs == nil {
+		c.WebhookURLs = make(map[string]string)
+	}
+	c.WebhookURLs[name] = url
+}
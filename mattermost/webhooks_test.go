@@ -0,0 +1,231 @@
+package mattermost
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/sirupsen/logrus"
+)
+
+// handleIncomingWebhooks registers the incoming webhook list and create calls on server, listing
+// existing and appending created webhooks to created
+func handleIncomingWebhooks(server *mockServer, existing []*model.IncomingWebhook, created *[]*model.IncomingWebhook) {
+	server.handle("GET /api/v4/hooks/incoming", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, existing)
+	})
+	server.handle("POST /api/v4/hooks/incoming", func(w http.ResponseWriter, r *http.Request) {
+		var hook model.IncomingWebhook
+		if !decodeJSON(w, r, &hook) {
+			return
+		}
+		hook.Id = "hook-" + hook.ChannelId
+		*created = append(*created, &hook)
+		writeJSON(w, http.StatusCreated, &hook)
+	})
+}
+
+// TestSetupWebhooks verifies configured webhooks are created or reused and their URLs written to disk
+func TestSetupWebhooks(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	var created []*model.IncomingWebhook
+	server := newMockServer()
+	server.handleChannelsByTeamName()
+	handleIncomingWebhooks(server, []*model.IncomingWebhook{
+		{Id: "hook-existing", ChannelId: "channel-alerts", DisplayName: "Alerts"},
+	}, &created)
+	client := setupMockClient(t, server)
+	client.Config = &Config{
+		DefaultTeam: "demo",
+		Webhooks: map[string]WebhookConfig{
+			"alerts":  {Channel: "alerts", DisplayName: "Alerts"},
+			"weather": {Team: "ops", Channel: "weather", DisplayName: "Weather Feed", IconURL: "https://example.com/weather.png"},
+		},
+	}
+
+	if err := client.setupWebhooks(); err != nil {
+		t.Fatalf("setupWebhooks returned error: %v", err)
+	}
+
+	if len(created) != 1 {
+		t.Fatalf("Expected only the missing webhook to be created, got %d", len(created))
+	}
+	if hook := created[0]; hook.ChannelId != "channel-weather" || hook.DisplayName != "Weather Feed" || hook.IconURL != "https://example.com/weather.png" {
+		t.Errorf("Unexpected webhook payload: %+v", hook)
+	}
+
+	data, err := os.ReadFile(GeneratedWebhooksPath)
+	if err != nil {
+		t.Fatalf("Failed to read %s: %v", GeneratedWebhooksPath, err)
+	}
+	var generated map[string]GeneratedWebhook
+	if err := json.Unmarshal(data, &generated); err != nil {
+		t.Fatalf("Failed to parse %s: %v", GeneratedWebhooksPath, err)
+	}
+
+	expected := map[string]GeneratedWebhook{
+		"alerts":  {URL: client.ServerURL + "/hooks/hook-existing", Team: "demo", Channel: "alerts"},
+		"weather": {URL: client.ServerURL + "/hooks/hook-channel-weather", Team: "ops", Channel: "weather"},
+	}
+	for name, want := range expected {
+		if got := generated[name]; got != want {
+			t.Errorf("Expected %s to be %+v, got %+v", name, want, got)
+		}
+	}
+}
+
+// TestValidateConfigWebhooks verifies webhook entries must name a channel, display name and team
+func TestValidateConfigWebhooks(t *testing.T) {
+	InitLogger(&LogConfig{Level: logrus.ErrorLevel})
+
+	testCases := []struct {
+		name          string
+		defaultTeam   string
+		webhook       WebhookConfig
+		expectedError string
+	}{
+		{name: "valid with default team", defaultTeam: "demo", webhook: WebhookConfig{Channel: "alerts", DisplayName: "Alerts"}},
+		{name: "missing channel", defaultTeam: "demo", webhook: WebhookConfig{DisplayName: "Alerts"}, expectedError: "missing channel"},
+		{name: "missing display name", defaultTeam: "demo", webhook: WebhookConfig{Channel: "alerts"}, expectedError: "missing display_name"},
+		{name: "missing team", webhook: WebhookConfig{Channel: "alerts", DisplayName: "Alerts"}, expectedError: "no default_team"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			config := &Config{
+				Server:        "http://localhost:8065",
+				AdminUsername: "sysadmin",
+				AdminPassword: "password",
+				DefaultTeam:   tc.defaultTeam,
+				Webhooks:      map[string]WebhookConfig{"hook": tc.webhook},
+			}
+
+			err := validateConfig(config)
+			if tc.expectedError == "" {
+				if err != nil {
+					t.Errorf("Expected config to be valid, got %v", err)
+				}
+			} else if err == nil || !strings.Contains(err.Error(), tc.expectedError) {
+				t.Errorf("Expected error containing %q, got %v", tc.expectedError, err)
+			}
+		})
+	}
+}
+
+// TestProcessIncomingWebhooks verifies incoming-webhook entries are created or reused, a missing
+// channel is skipped and the URLs are kept for EchoLogins
+func TestProcessIncomingWebhooks(t *testing.T) {
+	path := writeScanTestFile(t,
+		`{"type": "incoming-webhook", "incoming_webhook": {"team": "demo", "channel": "alerts", "display_name": "Alerts"}}`,
+		`{"type": "incoming-webhook", "incoming_webhook": {"team": "demo", "channel": "weather", "display_name": "Weather Feed", "description": "Forecasts"}}`,
+		`{"type": "incoming-webhook", "incoming_webhook": {"team": "demo", "channel": "missing", "display_name": "Lost"}}`,
+	)
+
+	var created []*model.IncomingWebhook
+	server := newMockServer()
+	server.handleChannelsByTeamName()
+	server.handle("GET /api/v4/teams/name/{team}/channels/name/missing", func(w http.ResponseWriter, r *http.Request) {
+		writeAppError(w, http.StatusNotFound, "app.channel.get_by_name.missing.app_error")
+	})
+	handleIncomingWebhooks(server, []*model.IncomingWebhook{
+		{Id: "hook-existing", ChannelId: "channel-alerts", DisplayName: "Alerts"},
+	}, &created)
+	client := setupMockClient(t, server)
+
+	if err := client.processIncomingWebhooks(path); err != nil {
+		t.Fatalf("processIncomingWebhooks returned error: %v", err)
+	}
+
+	if len(created) != 1 {
+		t.Fatalf("Expected only the missing webhook to be created, got %d", len(created))
+	}
+	if hook := created[0]; hook.ChannelId != "channel-weather" || hook.Description != "Forecasts" {
+		t.Errorf("Unexpected webhook payload: %+v", hook)
+	}
+
+	expected := map[string]string{
+		"Alerts":       client.ServerURL + "/hooks/hook-existing",
+		"Weather Feed": client.ServerURL + "/hooks/hook-channel-weather",
+	}
+	if len(client.WebhookURLs) != len(expected) {
+		t.Errorf("Expected %d webhook URLs, got %v", len(expected), client.WebhookURLs)
+	}
+	for name, want := range expected {
+		if got := client.WebhookURLs[name]; got != want {
+			t.Errorf("Expected %s URL %q, got %q", name, want, got)
+		}
+	}
+}
+
+// TestFindIncomingWebhookPages verifies webhooks past the first page are found and the listing
+// stops at the first short page
+func TestFindIncomingWebhookPages(t *testing.T) {
+	var pages []string
+	server := newMockServer()
+	server.handle("GET /api/v4/hooks/incoming", func(w http.ResponseWriter, r *http.Request) {
+		page := r.URL.Query().Get("page")
+		pages = append(pages, page)
+
+		hooks := []*model.IncomingWebhook{}
+		switch page {
+		case "0":
+			for i := range webhooksPerPage {
+				hooks = append(hooks, &model.IncomingWebhook{Id: fmt.Sprintf("hook-%d", i), ChannelId: "channel-other", DisplayName: "Other"})
+			}
+		case "1":
+			hooks = append(hooks, &model.IncomingWebhook{Id: "hook-alerts", ChannelId: "channel-alerts", DisplayName: "Alerts"})
+		}
+		writeJSON(w, http.StatusOK, hooks)
+	})
+	client := setupMockClient(t, server)
+
+	channel := &model.Channel{Id: "channel-alerts", Name: "alerts", TeamId: "team-demo"}
+	hookID, err := client.findIncomingWebhook(channel, "Alerts")
+	if err != nil {
+		t.Fatalf("findIncomingWebhook returned error: %v", err)
+	}
+	if hookID != "hook-alerts" {
+		t.Errorf("Expected the webhook on the second page, got %q", hookID)
+	}
+
+	pages = nil
+	if hookID, err := client.findIncomingWebhook(channel, "Missing"); err != nil || hookID != "" {
+		t.Errorf("Expected no webhook, got %q (error %v)", hookID, err)
+	}
+	if !slices.Equal(pages, []string{"0", "1"}) {
+		t.Errorf("Expected the listing to stop at the short page, got pages %v", pages)
+	}
+}
+
+// TestSetupWebhooksDryRun verifies a webhook that dry-run did not create gets no URL
+func TestSetupWebhooksDryRun(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	var created []*model.IncomingWebhook
+	server := newMockServer()
+	server.handleChannelsByTeamName()
+	handleIncomingWebhooks(server, nil, &created)
+	client := setupMockClient(t, server)
+	client.EnableDryRun(&bytes.Buffer{})
+	client.Config = &Config{
+		DefaultTeam: "demo",
+		Webhooks:    map[string]WebhookConfig{"alerts": {Channel: "alerts", DisplayName: "Alerts"}},
+	}
+
+	if err := client.setupWebhooks(); err != nil {
+		t.Fatalf("setupWebhooks returned error: %v", err)
+	}
+	if len(created) != 0 {
+		t.Errorf("Expected no webhook to reach the server, got %d", len(created))
+	}
+	if len(client.WebhookURLs) != 0 {
+		t.Errorf("Expected no webhook URLs, got %v", client.WebhookURLs)
+	}
+}